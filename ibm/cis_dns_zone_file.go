@@ -0,0 +1,554 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	cisdnsbulkv1 "github.com/IBM/networking-go-sdk/dnsrecordbulkv1"
+	cisdnsrecordsv1 "github.com/IBM/networking-go-sdk/dnsrecordsv1"
+)
+
+// cisZoneFileRecord is a DNS record of a BIND zone file or of a zone, with
+// its name and content normalized to compare them: lower case names without
+// the trailing dot. The ID is only set for the records of a zone.
+type cisZoneFileRecord struct {
+	ID       string
+	Name     string
+	Type     string
+	Content  string
+	Priority int64
+	TTL      int64
+	Proxied  *bool
+}
+
+func (r cisZoneFileRecord) key() string {
+	return fmt.Sprintf("%s|%s|%s|%d", r.Type, r.Name, r.Content, r.Priority)
+}
+
+func (r cisZoneFileRecord) String() string {
+	if r.Type == cisDNSRecordTypeMX {
+		return fmt.Sprintf("%s %d %s %d %s", r.Name, r.TTL, r.Type, r.Priority, r.Content)
+	}
+	return fmt.Sprintf("%s %d %s %s", r.Name, r.TTL, r.Type, r.Content)
+}
+
+// cisDNSRecordsSyncPlan is the changes that make a zone match a zone file.
+// The updated records have the ID of the zone record they replace.
+type cisDNSRecordsSyncPlan struct {
+	Add    []cisZoneFileRecord
+	Update []cisZoneFileRecord
+	Delete []cisZoneFileRecord
+}
+
+func (p cisDNSRecordsSyncPlan) empty() bool {
+	return len(p.Add) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// cisDNSRecordsSyncTypes are the record types the sync manages, the records
+// of the other types of a zone are left as they are
+var cisDNSRecordsSyncTypes = map[string]bool{
+	cisDNSRecordTypeA:     true,
+	cisDNSRecordTypeAAAA:  true,
+	cisDNSRecordTypeCNAME: true,
+	cisDNSRecordTypeMX:    true,
+	cisDNSRecordTypeNS:    true,
+	cisDNSRecordTypePTR:   true,
+	cisDNSRecordTypeSPF:   true,
+	cisDNSRecordTypeTXT:   true,
+}
+
+// parseCISZoneFile returns the records of a BIND zone file of the zone
+// zoneName that the sync manages, see cisDNSRecordsSyncTypes. The SOA record
+// and the NS records of the zone apex are managed by CIS, they are skipped.
+// The proxied status of a record is read from the "cf_tags=cf-proxied:true"
+// comment of the CIS exports.
+func parseCISZoneFile(r io.Reader, zoneName string) ([]cisZoneFileRecord, error) {
+	zoneName = strings.ToLower(strings.TrimSuffix(zoneName, "."))
+	origin := zoneName
+	defaultTTL := int64(1)
+	owner := ""
+
+	var (
+		records    []cisZoneFileRecord
+		tokens     []string
+		comment    string
+		depth      int
+		lineNo     int
+		startLine  int
+		blankOwner bool
+	)
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if depth == 0 {
+			tokens, comment, startLine = nil, "", lineNo
+			blankOwner = strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		}
+		lineTokens, lineComment, lineDepth := splitCISZoneFileLine(line)
+		tokens = append(tokens, lineTokens...)
+		comment += lineComment
+		depth += lineDepth
+		if depth < 0 {
+			return nil, fmt.Errorf("[ERROR] Unbalanced parentheses on line %d of the zone file", lineNo)
+		}
+		if depth > 0 || len(tokens) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(tokens[0], "$") {
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("[ERROR] Incorrect $ORIGIN on line %d of the zone file", startLine)
+				}
+				origin = cisZoneFileName(tokens[1], origin)
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("[ERROR] Incorrect $TTL on line %d of the zone file", startLine)
+				}
+				ttl, err := strconv.ParseInt(tokens[1], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("[ERROR] Incorrect $TTL on line %d of the zone file, the TTL must be in seconds", startLine)
+				}
+				defaultTTL = ttl
+			default:
+				return nil, fmt.Errorf("[ERROR] Unsupported directive %s on line %d of the zone file", tokens[0], startLine)
+			}
+			continue
+		}
+
+		if !blankOwner {
+			owner = cisZoneFileName(tokens[0], origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("[ERROR] Missing record name on line %d of the zone file", startLine)
+		}
+		record := cisZoneFileRecord{Name: owner, TTL: defaultTTL}
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			if ttl, err := strconv.ParseInt(tokens[0], 10, 64); err == nil {
+				record.TTL = ttl
+			} else if class := strings.ToUpper(tokens[0]); class != "IN" && class != "CH" && class != "HS" {
+				break
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("[ERROR] Missing record type on line %d of the zone file", startLine)
+		}
+		record.Type = strings.ToUpper(tokens[0])
+		rdata := tokens[1:]
+
+		switch record.Type {
+		case "SOA":
+			continue
+		case cisDNSRecordTypeA, cisDNSRecordTypeAAAA:
+			var ip net.IP
+			if len(rdata) == 1 {
+				ip = net.ParseIP(rdata[0])
+			}
+			if ip == nil || (ip.To4() != nil) != (record.Type == cisDNSRecordTypeA) {
+				return nil, fmt.Errorf("[ERROR] Incorrect %s record address on line %d of the zone file", record.Type, startLine)
+			}
+			record.Content = ip.String()
+		case cisDNSRecordTypeCNAME, cisDNSRecordTypeNS, cisDNSRecordTypePTR:
+			if len(rdata) != 1 {
+				return nil, fmt.Errorf("[ERROR] Incorrect %s record on line %d of the zone file", record.Type, startLine)
+			}
+			if record.Type == cisDNSRecordTypeNS && record.Name == zoneName {
+				continue
+			}
+			record.Content = cisZoneFileName(rdata[0], origin)
+		case cisDNSRecordTypeMX:
+			if len(rdata) != 2 {
+				return nil, fmt.Errorf("[ERROR] Incorrect MX record on line %d of the zone file", startLine)
+			}
+			priority, err := strconv.ParseInt(rdata[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("[ERROR] Incorrect MX record priority on line %d of the zone file", startLine)
+			}
+			record.Priority = priority
+			record.Content = cisZoneFileName(rdata[1], origin)
+		case cisDNSRecordTypeTXT, cisDNSRecordTypeSPF:
+			if len(rdata) == 0 {
+				return nil, fmt.Errorf("[ERROR] Missing %s record text on line %d of the zone file", record.Type, startLine)
+			}
+			record.Content = cisZoneFileText(rdata)
+		default:
+			// an export of the zone has the records of every type, the
+			// other types are left as they are in the zone
+			log.Printf("[WARN] The %s record on line %d of the zone file is not managed by sync, it is skipped", record.Type, startLine)
+			continue
+		}
+
+		if strings.Contains(comment, "cf-proxied:true") {
+			record.Proxied = core.BoolPtr(true)
+		} else if strings.Contains(comment, "cf-proxied:false") {
+			record.Proxied = core.BoolPtr(false)
+		}
+		if !seen[record.key()] {
+			seen[record.key()] = true
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("[ERROR] Unbalanced parentheses on line %d of the zone file", startLine)
+	}
+	return records, nil
+}
+
+// splitCISZoneFileLine returns the fields of a zone file line, its comment,
+// and how many parentheses it opens. The quoted fields keep their quotes.
+func splitCISZoneFileLine(line string) ([]string, string, int) {
+	var (
+		tokens  []string
+		token   strings.Builder
+		depth   int
+		quoted  bool
+		escaped bool
+	)
+	flush := func() {
+		if token.Len() > 0 {
+			tokens = append(tokens, token.String())
+			token.Reset()
+		}
+	}
+	for i, c := range line {
+		switch {
+		case escaped:
+			escaped = false
+			token.WriteRune(c)
+		case c == '\\':
+			escaped = true
+			token.WriteRune(c)
+		case c == '"':
+			quoted = !quoted
+			token.WriteRune(c)
+		case quoted:
+			token.WriteRune(c)
+		case c == ';':
+			flush()
+			return tokens, line[i+1:], depth
+		case c == '(' || c == ')':
+			flush()
+			if c == '(' {
+				depth++
+			} else {
+				depth--
+			}
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			token.WriteRune(c)
+		}
+	}
+	flush()
+	return tokens, "", depth
+}
+
+// cisZoneFileName returns the fully qualified name of a zone file name
+func cisZoneFileName(name, origin string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	case origin == "":
+		return name
+	}
+	return name + "." + origin
+}
+
+// cisZoneFileText returns the text of a TXT record, its quoted strings are
+// concatenated
+func cisZoneFileText(rdata []string) string {
+	var text strings.Builder
+	previousQuoted := true
+	for i, part := range rdata {
+		quoted := len(part) >= 2 && strings.HasPrefix(part, "\"") && strings.HasSuffix(part, "\"")
+		if i > 0 && (!quoted || !previousQuoted) {
+			text.WriteString(" ")
+		}
+		if quoted {
+			part = part[1 : len(part)-1]
+			part = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(part)
+		}
+		text.WriteString(part)
+		previousQuoted = quoted
+	}
+	return text.String()
+}
+
+// cisZoneRecordFromDetails returns the record of a zone to compare it with
+// the zone file records, it returns false for the records the sync does not
+// manage
+func cisZoneRecordFromDetails(zoneName string, details cisdnsrecordsv1.DnsrecordDetails) (cisZoneFileRecord, bool) {
+	if details.ID == nil || details.Name == nil || details.Type == nil || !cisDNSRecordsSyncTypes[*details.Type] {
+		return cisZoneFileRecord{}, false
+	}
+	record := cisZoneFileRecord{
+		ID:      *details.ID,
+		Name:    strings.ToLower(strings.TrimSuffix(*details.Name, ".")),
+		Type:    *details.Type,
+		Proxied: details.Proxied,
+	}
+	if record.Type == cisDNSRecordTypeNS && record.Name == strings.ToLower(strings.TrimSuffix(zoneName, ".")) {
+		return cisZoneFileRecord{}, false
+	}
+	if details.Content != nil {
+		record.Content = *details.Content
+	}
+	if details.TTL != nil {
+		record.TTL = *details.TTL
+	}
+	switch record.Type {
+	case cisDNSRecordTypeA, cisDNSRecordTypeAAAA:
+		if ip := net.ParseIP(record.Content); ip != nil {
+			record.Content = ip.String()
+		}
+	case cisDNSRecordTypeCNAME, cisDNSRecordTypeNS, cisDNSRecordTypePTR, cisDNSRecordTypeMX:
+		record.Content = strings.ToLower(strings.TrimSuffix(record.Content, "."))
+	}
+	if record.Type == cisDNSRecordTypeMX && details.Priority != nil {
+		record.Priority = *details.Priority
+	}
+	return record, true
+}
+
+// planCISDNSRecordsSync returns the changes that make the records of a zone
+// match the desired records. The records with the same type, name and
+// content are kept, the TTL and proxied status are updated in place. The
+// other records of a type and name replace the zone records in place, then
+// the remaining records are added and the remaining zone records deleted.
+func planCISDNSRecordsSync(desired, live []cisZoneFileRecord) cisDNSRecordsSyncPlan {
+	desired = sortedCISZoneFileRecords(desired)
+	live = sortedCISZoneFileRecords(live)
+	matched := make([]bool, len(live))
+	var plan cisDNSRecordsSyncPlan
+
+	var unmatched []cisZoneFileRecord
+	for _, record := range desired {
+		i := findCISZoneFileRecord(live, matched, func(l cisZoneFileRecord) bool { return l.key() == record.key() })
+		if i < 0 {
+			unmatched = append(unmatched, record)
+			continue
+		}
+		matched[i] = true
+		if cisDNSRecordNeedsUpdate(record, live[i]) {
+			plan.Update = append(plan.Update, cisDNSRecordReplacing(record, live[i]))
+		}
+	}
+	for _, record := range unmatched {
+		i := findCISZoneFileRecord(live, matched, func(l cisZoneFileRecord) bool { return l.Type == record.Type && l.Name == record.Name })
+		if i < 0 {
+			plan.Add = append(plan.Add, record)
+			continue
+		}
+		matched[i] = true
+		plan.Update = append(plan.Update, cisDNSRecordReplacing(record, live[i]))
+	}
+	for i, record := range live {
+		if !matched[i] {
+			plan.Delete = append(plan.Delete, record)
+		}
+	}
+	return plan
+}
+
+func sortedCISZoneFileRecords(records []cisZoneFileRecord) []cisZoneFileRecord {
+	sorted := append([]cisZoneFileRecord(nil), records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].key() < sorted[j].key()
+	})
+	return sorted
+}
+
+func findCISZoneFileRecord(records []cisZoneFileRecord, matched []bool, match func(cisZoneFileRecord) bool) int {
+	for i, record := range records {
+		if !matched[i] && match(record) {
+			return i
+		}
+	}
+	return -1
+}
+
+// cisDNSRecordNeedsUpdate tells if the TTL or the proxied status of a zone
+// record differ from the desired record. The proxied records have an
+// automatic TTL, their TTL is ignored.
+func cisDNSRecordNeedsUpdate(desired, live cisZoneFileRecord) bool {
+	liveProxied := live.Proxied != nil && *live.Proxied
+	proxied := liveProxied
+	if desired.Proxied != nil {
+		proxied = *desired.Proxied
+	}
+	if proxied != liveProxied {
+		return true
+	}
+	return !proxied && desired.TTL != live.TTL
+}
+
+// cisDNSRecordReplacing returns the desired record with the ID of the zone
+// record it replaces, and its proxied status when the desired record does not
+// set it
+func cisDNSRecordReplacing(desired, live cisZoneFileRecord) cisZoneFileRecord {
+	desired.ID = live.ID
+	if desired.Proxied == nil {
+		desired.Proxied = live.Proxied
+	}
+	return desired
+}
+
+// listCISDNSRecords returns all the records of the zone of sess
+func listCISDNSRecords(sess *cisdnsrecordsv1.DnsRecordsV1) ([]cisdnsrecordsv1.DnsrecordDetails, error) {
+	var records []cisdnsrecordsv1.DnsrecordDetails
+	perPage := int64(1000)
+	for page := int64(1); ; page++ {
+		opt := sess.NewListAllDnsRecordsOptions()
+		opt.SetPage(page)
+		opt.SetPerPage(perPage)
+		result, response, err := sess.ListAllDnsRecords(opt)
+		if err != nil {
+			log.Printf("Error reading dns records: %s", response)
+			return nil, err
+		}
+		records = append(records, result.Result...)
+		if len(result.Result) == 0 || result.ResultInfo == nil || result.ResultInfo.TotalCount == nil ||
+			int64(len(records)) >= *result.ResultInfo.TotalCount {
+			return records, nil
+		}
+	}
+}
+
+// applyCISDNSRecordsSync applies a sync plan to the zone of sess and returns
+// the IDs of the added records. The records are added and updated before the
+// others are deleted, so a failed sync does not leave the names without
+// records. Only the deletions that clear the name of a CNAME record, which
+// cannot have the name of another record, are applied first.
+func applyCISDNSRecordsSync(sess *cisdnsrecordsv1.DnsRecordsV1, plan cisDNSRecordsSyncPlan) ([]string, error) {
+	early, late := splitCISDNSRecordsDeletes(plan)
+	if err := deleteCISDNSRecords(sess, early); err != nil {
+		return nil, err
+	}
+	for _, record := range plan.Update {
+		log.Printf("[INFO] Updating dns record %s", record)
+		if err := updateCISDNSRecord(sess, record); err != nil {
+			return nil, err
+		}
+	}
+	var added []string
+	for _, record := range plan.Add {
+		log.Printf("[INFO] Adding dns record %s", record)
+		opt := sess.NewCreateDnsRecordOptions()
+		opt.SetType(record.Type)
+		opt.SetName(record.Name)
+		opt.SetContent(record.Content)
+		opt.SetTTL(record.TTL)
+		if record.Type == cisDNSRecordTypeMX {
+			opt.SetPriority(record.Priority)
+		}
+		result, response, err := sess.CreateDnsRecord(opt)
+		if err != nil {
+			log.Printf("Error creating dns record: %s", response)
+			return added, fmt.Errorf("[ERROR] Error creating dns record %s: %s", record, err)
+		}
+		record.ID = *result.Result.ID
+		added = append(added, record.ID)
+		// The records are created without proxy
+		if record.Proxied != nil && *record.Proxied {
+			if err := updateCISDNSRecord(sess, record); err != nil {
+				return added, err
+			}
+		}
+	}
+	return added, deleteCISDNSRecords(sess, late)
+}
+
+// splitCISDNSRecordsDeletes returns the deletions of a sync plan that must be
+// applied before the records are added and updated, the zone records with the
+// name of an added or updated CNAME record and the CNAME records with the
+// name of an added or updated record, and the other deletions.
+func splitCISDNSRecordsDeletes(plan cisDNSRecordsSyncPlan) (early, late []cisZoneFileRecord) {
+	names := map[string]bool{}
+	cnameNames := map[string]bool{}
+	for _, records := range [][]cisZoneFileRecord{plan.Add, plan.Update} {
+		for _, record := range records {
+			names[record.Name] = true
+			if record.Type == cisDNSRecordTypeCNAME {
+				cnameNames[record.Name] = true
+			}
+		}
+	}
+	for _, record := range plan.Delete {
+		if cnameNames[record.Name] || (record.Type == cisDNSRecordTypeCNAME && names[record.Name]) {
+			early = append(early, record)
+		} else {
+			late = append(late, record)
+		}
+	}
+	return early, late
+}
+
+func deleteCISDNSRecords(sess *cisdnsrecordsv1.DnsRecordsV1, records []cisZoneFileRecord) error {
+	for _, record := range records {
+		log.Printf("[INFO] Deleting dns record %s", record)
+		_, response, err := sess.DeleteDnsRecord(sess.NewDeleteDnsRecordOptions(record.ID))
+		if err != nil {
+			log.Printf("Error deleting dns record: %s", response)
+			return fmt.Errorf("[ERROR] Error deleting dns record %s: %s", record, err)
+		}
+	}
+	return nil
+}
+
+func updateCISDNSRecord(sess *cisdnsrecordsv1.DnsRecordsV1, record cisZoneFileRecord) error {
+	opt := sess.NewUpdateDnsRecordOptions(record.ID)
+	opt.SetType(record.Type)
+	opt.SetName(record.Name)
+	opt.SetContent(record.Content)
+	opt.SetTTL(record.TTL)
+	if record.Type == cisDNSRecordTypeMX {
+		opt.SetPriority(record.Priority)
+	}
+	if record.Proxied != nil {
+		opt.SetProxied(*record.Proxied)
+	}
+	_, response, err := sess.UpdateDnsRecord(opt)
+	if err != nil {
+		log.Printf("Error updating dns record: %s", response)
+		return fmt.Errorf("[ERROR] Error updating dns record %s: %s", record, err)
+	}
+	return nil
+}
+
+// exportCISDNSRecords returns the records of the zone of sess as a BIND zone
+// file
+func exportCISDNSRecords(sess *cisdnsbulkv1.DnsRecordBulkV1) ([]byte, error) {
+	result, response, err := sess.GetDnsRecordsBulk(sess.NewGetDnsRecordsBulkOptions())
+	if err != nil {
+		log.Printf("Error exporting dns records: %s", response)
+		return nil, err
+	}
+	defer result.Close()
+	buf, err := ioutil.ReadAll(result)
+	if err != nil {
+		log.Printf("Error while reading io reader")
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,177 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	cisdnsrecordsv1 "github.com/IBM/networking-go-sdk/dnsrecordsv1"
+)
+
+const testCISZoneFile = `;;
+;; Domain:     example.com.
+;;
+$ORIGIN example.com.
+$TTL 3600
+@	3600	IN	SOA	ns1.example.com. admin.example.com. (
+			2021081000 ; serial
+			10000 2400 604800 3600 )
+
+;; NS Records
+example.com.	86400	IN	NS	ns1.cloudflare.com.
+sub	IN	NS	ns1.other.net.
+
+;; A Records
+www.example.com.	1	IN	A	192.168.178.5 ; cf_tags=cf-proxied:true
+	300	IN	A	192.168.178.6
+api	A	192.168.178.7 ; cf_tags=cf-proxied:false
+
+;; AAAA Records
+www.example.com.	1	IN	AAAA	2001:DB1:0::45
+
+;; CNAME Records
+Docs	IN	CNAME	WWW
+
+;; MX Records
+@	1	IN	MX	10 mail.example.com.
+
+;; TXT Records
+@	IN	TXT	"v=spf1 include:_spf.example.com" " -all"
+txt	IN	TXT	"a \"quoted\" text; with a semicolon"
+`
+
+func TestParseCISZoneFile(t *testing.T) {
+	records, err := parseCISZoneFile(strings.NewReader(testCISZoneFile), "Example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []cisZoneFileRecord{
+		{Name: "sub.example.com", Type: "NS", Content: "ns1.other.net", TTL: 3600},
+		{Name: "www.example.com", Type: "A", Content: "192.168.178.5", TTL: 1, Proxied: core.BoolPtr(true)},
+		{Name: "www.example.com", Type: "A", Content: "192.168.178.6", TTL: 300},
+		{Name: "api.example.com", Type: "A", Content: "192.168.178.7", TTL: 3600, Proxied: core.BoolPtr(false)},
+		{Name: "www.example.com", Type: "AAAA", Content: "2001:db1::45", TTL: 1},
+		{Name: "docs.example.com", Type: "CNAME", Content: "www.example.com", TTL: 3600},
+		{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 1},
+		{Name: "example.com", Type: "TXT", Content: "v=spf1 include:_spf.example.com -all", TTL: 3600},
+		{Name: "txt.example.com", Type: "TXT", Content: `a "quoted" text; with a semicolon`, TTL: 3600},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("Expected the records\n%v\ngot\n%v", want, records)
+	}
+
+	// the other types of an export are skipped
+	records, err = parseCISZoneFile(strings.NewReader("_sip._tcp IN SRV 1 1 443 target.example.com.\n\t300 IN CAA 0 issue \"ca.example.net\"\nwww IN A 192.168.178.5"), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []cisZoneFileRecord{{Name: "www.example.com", Type: "A", Content: "192.168.178.5", TTL: 1}}; !reflect.DeepEqual(records, want) {
+		t.Errorf("Expected the records\n%v\ngot\n%v", want, records)
+	}
+
+	for _, zoneFile := range []string{
+		"www IN A 2001:db1::45",
+		"www IN MX mail.example.com.",
+		"$INCLUDE other.zone",
+		"www IN TXT (\"unbalanced\"",
+		"\t1 IN A 192.168.178.5",
+	} {
+		if _, err := parseCISZoneFile(strings.NewReader(zoneFile), "example.com"); err == nil {
+			t.Errorf("Expected an error for %q", zoneFile)
+		}
+	}
+}
+
+func TestCISZoneRecordFromDetails(t *testing.T) {
+	record, ok := cisZoneRecordFromDetails("example.com", cisdnsrecordsv1.DnsrecordDetails{
+		ID:       core.StringPtr("record-1"),
+		Name:     core.StringPtr("example.com"),
+		Type:     core.StringPtr("MX"),
+		Content:  core.StringPtr("Mail.example.com"),
+		Priority: core.Int64Ptr(10),
+		TTL:      core.Int64Ptr(1),
+		Proxied:  core.BoolPtr(false),
+	})
+	want := cisZoneFileRecord{ID: "record-1", Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 1, Proxied: core.BoolPtr(false)}
+	if !ok || !reflect.DeepEqual(record, want) {
+		t.Errorf("Expected %v, got %v, %t", want, record, ok)
+	}
+	for _, details := range []cisdnsrecordsv1.DnsrecordDetails{
+		{ID: core.StringPtr("record-2"), Name: core.StringPtr("example.com"), Type: core.StringPtr("NS"), Content: core.StringPtr("ns1.cloudflare.com")},
+		{ID: core.StringPtr("record-3"), Name: core.StringPtr("_sip._tcp.example.com"), Type: core.StringPtr("SRV")},
+	} {
+		if record, ok := cisZoneRecordFromDetails("example.com", details); ok {
+			t.Errorf("Expected the record %s to be skipped, got %v", *details.ID, record)
+		}
+	}
+}
+
+func TestPlanCISDNSRecordsSync(t *testing.T) {
+	desired := []cisZoneFileRecord{
+		{Name: "www.example.com", Type: "A", Content: "192.168.178.5", TTL: 1},
+		{Name: "www.example.com", Type: "A", Content: "192.168.178.6", TTL: 300},
+		{Name: "api.example.com", Type: "A", Content: "192.168.178.8", TTL: 1},
+		{Name: "cdn.example.com", Type: "CNAME", Content: "www.example.com", TTL: 300},
+		{Name: "new.example.com", Type: "A", Content: "192.168.178.9", TTL: 1, Proxied: core.BoolPtr(true)},
+	}
+	live := []cisZoneFileRecord{
+		{ID: "www-5", Name: "www.example.com", Type: "A", Content: "192.168.178.5", TTL: 1, Proxied: core.BoolPtr(false)},
+		{ID: "www-6", Name: "www.example.com", Type: "A", Content: "192.168.178.6", TTL: 1, Proxied: core.BoolPtr(false)},
+		{ID: "api-7", Name: "api.example.com", Type: "A", Content: "192.168.178.7", TTL: 1, Proxied: core.BoolPtr(true)},
+		{ID: "cdn", Name: "cdn.example.com", Type: "CNAME", Content: "www.example.com", TTL: 1, Proxied: core.BoolPtr(true)},
+		{ID: "old", Name: "old.example.com", Type: "TXT", Content: "old", TTL: 1, Proxied: core.BoolPtr(false)},
+	}
+
+	plan := planCISDNSRecordsSync(desired, live)
+	want := cisDNSRecordsSyncPlan{
+		Add: []cisZoneFileRecord{
+			{Name: "new.example.com", Type: "A", Content: "192.168.178.9", TTL: 1, Proxied: core.BoolPtr(true)},
+		},
+		Update: []cisZoneFileRecord{
+			{ID: "www-6", Name: "www.example.com", Type: "A", Content: "192.168.178.6", TTL: 300, Proxied: core.BoolPtr(false)},
+			{ID: "api-7", Name: "api.example.com", Type: "A", Content: "192.168.178.8", TTL: 1, Proxied: core.BoolPtr(true)},
+		},
+		Delete: []cisZoneFileRecord{
+			{ID: "old", Name: "old.example.com", Type: "TXT", Content: "old", TTL: 1, Proxied: core.BoolPtr(false)},
+		},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("Expected the plan\n%v\ngot\n%v", want, plan)
+	}
+
+	if plan := planCISDNSRecordsSync(desired[:2], live[:2]); len(plan.Update) != 1 || plan.Update[0].ID != "www-6" {
+		t.Errorf("Expected the TTL update of www-6, got %v", plan)
+	}
+	if plan := planCISDNSRecordsSync(live, live); !plan.empty() {
+		t.Errorf("Expected an empty plan, got %v", plan)
+	}
+}
+
+func TestSplitCISDNSRecordsDeletes(t *testing.T) {
+	plan := cisDNSRecordsSyncPlan{
+		Add: []cisZoneFileRecord{
+			{Name: "www.example.com", Type: "CNAME", Content: "cdn.example.net"},
+			{Name: "api.example.com", Type: "A", Content: "192.168.178.7"},
+		},
+		Update: []cisZoneFileRecord{
+			{ID: "mail", Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10},
+		},
+		Delete: []cisZoneFileRecord{
+			{ID: "www-a", Name: "www.example.com", Type: "A", Content: "192.168.178.5"},
+			{ID: "api-cname", Name: "api.example.com", Type: "CNAME", Content: "www.example.com"},
+			{ID: "api-txt", Name: "api.example.com", Type: "TXT", Content: "old"},
+			{ID: "old", Name: "old.example.com", Type: "A", Content: "192.168.178.8"},
+		},
+	}
+	early, late := splitCISDNSRecordsDeletes(plan)
+	if want := []cisZoneFileRecord{plan.Delete[0], plan.Delete[1]}; !reflect.DeepEqual(early, want) {
+		t.Errorf("Expected the deletions before the changes\n%v\ngot\n%v", want, early)
+	}
+	if want := []cisZoneFileRecord{plan.Delete[2], plan.Delete[3]}; !reflect.DeepEqual(late, want) {
+		t.Errorf("Expected the deletions after the changes\n%v\ngot\n%v", want, late)
+	}
+}
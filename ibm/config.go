@@ -12,6 +12,7 @@ import (
 	gohttp "net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	// Added code for the Power Colo Offering
@@ -64,7 +65,7 @@ import (
 	"github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
 	vpc "github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/apache/openwhisk-client-go/whisk"
-	jwt "github.com/golang-jwt/jwt"
+	httptransport "github.com/go-openapi/runtime/client"
 	slsession "github.com/softlayer/softlayer-go/session"
 
 	bluemix "github.com/IBM-Cloud/bluemix-go"
@@ -88,6 +89,7 @@ import (
 	"github.com/IBM-Cloud/bluemix-go/api/usermanagement/usermanagementv2"
 	"github.com/IBM-Cloud/bluemix-go/authentication"
 	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+	"github.com/IBM-Cloud/bluemix-go/helpers"
 	"github.com/IBM-Cloud/bluemix-go/http"
 	"github.com/IBM-Cloud/bluemix-go/rest"
 	bxsession "github.com/IBM-Cloud/bluemix-go/session"
@@ -98,6 +100,9 @@ import (
 // RetryAPIDelay - retry api delay
 const RetryAPIDelay = 5 * time.Second
 
+// SoftlayerRestEndpoint - default classic infrastructure endpoint
+const SoftlayerRestEndpoint = "https://api.softlayer.com/rest/v3"
+
 //BluemixRegion ...
 var BluemixRegion string
 
@@ -145,6 +150,8 @@ type Config struct {
 	RetryCount int
 	//Constant Retry Delay for API calls
 	RetryDelay time.Duration
+	//Retry policy from the provider retry block, nil to derive it from RetryCount and RetryDelay
+	RetryPolicy *RetryPolicy
 
 	// FunctionNameSpace ...
 	FunctionNameSpace string
@@ -161,12 +168,51 @@ type Config struct {
 	//IAM Refresh Token
 	IAMRefreshToken string
 
+	//Trusted profile the compute resource token in CRTokenFile is exchanged for
+	IAMProfileID   string
+	IAMProfileName string
+	CRTokenFile    string
+	//File holding an IAM token, re-read when the token expires
+	IAMTokenFile string
+	tokenSource  *iamTokenSource
+
+	//Trusted profile assumed with the token of the credentials, nil to act as the credentials
+	Assume       *AssumeProfile
+	assumeSource *iamTokenSource
+
+	//Tags attached to every resource that supports tags
+	DefaultTags []string
+
+	//Account the IAM token must be for, empty for any account
+	AccountID string
+	//JSON Web Key Set the IAM token is verified with, the IAM keys when empty
+	IAMJWKSURL string
+
 	// PowerService Instance
 	PowerServiceInstance string
 
 	// Zone
 	Zone       string
 	Visibility string
+
+	//Path of the file holding the service endpoint overrides
+	EndpointsFile string
+	//Service endpoint overrides of the endpoints block, they take precedence over the file
+	Endpoints endpointsFile
+	endpoints endpointsFile
+
+	//Client side rate limits shared by every service client, nil for no limit
+	RateLimit *RateLimit
+	limiter   *rateLimiter
+
+	//Intervals of the waits for the state of the resources, nil for the defaults
+	Polling *PollingPolicy
+
+	//Log the API requests and responses as JSON, with credentials redacted
+	HTTPLogging bool
+
+	//Cassette the API requests are recorded to or replayed from, see transport_recorder.go
+	cassette *cassette
 }
 
 //Session stores the information required for communication with the SoftLayer and Bluemix API
@@ -185,6 +231,9 @@ type ClientSession interface {
 	BluemixAcccountAPI() (accountv2.AccountServiceAPI, error)
 	BluemixAcccountv1API() (accountv1.AccountServiceAPI, error)
 	BluemixUserDetails() (*UserConfig, error)
+	DefaultTags() []string
+	PollingPolicy() *PollingPolicy
+	Endpoint(key, defaultValue string) string
 	ContainerAPI() (containerv1.ContainerServiceAPI, error)
 	VpcContainerAPI() (containerv2.ContainerServiceAPI, error)
 	ContainerRegistryV1() (*containerregistryv1.ContainerRegistryV1, error)
@@ -255,435 +304,562 @@ type ClientSession interface {
 type clientSession struct {
 	session *Session
 
-	appidErr error
-	appidAPI *appid.AppIDManagementV4
+	config        *Config
+	authenticator core.Authenticator
+
+	cfAuthOnce sync.Once
+	cfAuthErr  error
+
+	appidOnce sync.Once
+	appidErr  error
+	appidAPI  *appid.AppIDManagementV4
 
-	apigatewayErr error
-	apigatewayAPI *apigateway.ApiGatewayControllerApiV1
+	apigatewayOnce sync.Once
+	apigatewayErr  error
+	apigatewayAPI  *apigateway.ApiGatewayControllerApiV1
 
+	accountOnce          sync.Once
 	accountConfigErr     error
 	bmxAccountServiceAPI accountv2.AccountServiceAPI
 
+	accountV1Once          sync.Once
 	accountV1ConfigErr     error
 	bmxAccountv1ServiceAPI accountv1.AccountServiceAPI
 
 	bmxUserDetails  *UserConfig
 	bmxUserFetchErr error
 
+	csOnce       sync.Once
 	csConfigErr  error
 	csServiceAPI containerv1.ContainerServiceAPI
 
+	csv2Once       sync.Once
 	csv2ConfigErr  error
 	csv2ServiceAPI containerv2.ContainerServiceAPI
 
+	containerRegistryOnce      sync.Once
 	containerRegistryClientErr error
 	containerRegistryClient    *containerregistryv1.ContainerRegistryV1
 
-	certManagementErr error
-	certManagementAPI certificatemanager.CertificateManagerServiceAPI
+	certManagementOnce sync.Once
+	certManagementErr  error
+	certManagementAPI  certificatemanager.CertificateManagerServiceAPI
 
+	cfOnce       sync.Once
 	cfConfigErr  error
 	cfServiceAPI mccpv2.MccpServiceAPI
 
+	cisOnce       sync.Once
 	cisConfigErr  error
 	cisServiceAPI cisv1.CisServiceAPI
 
+	functionOnce      sync.Once
 	functionConfigErr error
 	functionClient    *whisk.Client
 
+	globalSearchOnce       sync.Once
 	globalSearchConfigErr  error
 	globalSearchServiceAPI globalsearchv2.GlobalSearchServiceAPI
 
+	globalTaggingOnce       sync.Once
 	globalTaggingConfigErr  error
 	globalTaggingServiceAPI globaltaggingv3.GlobalTaggingServiceAPI
 
+	globalTaggingV1Once       sync.Once
 	globalTaggingConfigErrV1  error
 	globalTaggingServiceAPIV1 globaltaggingv1.GlobalTaggingV1
 
+	iamUUMV2Once       sync.Once
 	iamUUMConfigErrV2  error
 	iamUUMServiceAPIV2 iamuumv2.IAMUUMServiceAPIv2
 
-	userManagementErr error
-	userManagementAPI usermanagementv2.UserManagementAPI
+	userManagementOnce sync.Once
+	userManagementErr  error
+	userManagementAPI  usermanagementv2.UserManagementAPI
 
+	icdOnce       sync.Once
 	icdConfigErr  error
 	icdServiceAPI icdv4.ICDServiceAPI
 
+	resourceControllerV1Once     sync.Once
 	resourceControllerConfigErr  error
 	resourceControllerServiceAPI controller.ResourceControllerAPI
 
+	resourceControllerV2Once       sync.Once
 	resourceControllerConfigErrv2  error
 	resourceControllerServiceAPIv2 controllerv2.ResourceControllerAPIV2
 
+	resourceManagementV2Once       sync.Once
 	resourceManagementConfigErrv2  error
 	resourceManagementServiceAPIv2 managementv2.ResourceManagementAPIv2
 
+	resourceCatalogOnce       sync.Once
 	resourceCatalogConfigErr  error
 	resourceCatalogServiceAPI catalog.ResourceCatalogAPI
 
+	ibmpiOnce      sync.Once
 	powerConfigErr error
 	ibmpiConfigErr error
 	ibmpiSession   *ibmpisession.IBMPISession
 
-	kpErr error
-	kpAPI *kp.API
+	kpOnce sync.Once
+	kpErr  error
+	kpAPI  *kp.API
 
-	kmsErr error
-	kmsAPI *kp.API
+	kmsOnce sync.Once
+	kmsErr  error
+	kmsAPI  *kp.API
 
-	hpcsEndpointErr error
-	hpcsEndpointAPI hpcs.HPCSV2
+	hpcsEndpointOnce sync.Once
+	hpcsEndpointErr  error
+	hpcsEndpointAPI  hpcs.HPCSV2
 
 	pDNSClient *dns.DnsSvcsV1
+	pDNSOnce   sync.Once
 	pDNSErr    error
 
 	bluemixSessionErr error
 
 	pushServiceClient    *pushservicev1.PushServiceV1
+	pushServiceOnce      sync.Once
 	pushServiceClientErr error
 
 	appConfigurationClient    *appconfigurationv1.AppConfigurationV1
+	appConfigurationOnce      sync.Once
 	appConfigurationClientErr error
 
-	vpcErr error
-	vpcAPI *vpc.VpcV1
+	vpcOnce sync.Once
+	vpcErr  error
+	vpcAPI  *vpc.VpcV1
 
-	directlinkAPI *dl.DirectLinkV1
-	directlinkErr error
-	dlProviderAPI *dlProviderV2.DirectLinkProviderV2
-	dlProviderErr error
+	directlinkAPI  *dl.DirectLinkV1
+	directlinkOnce sync.Once
+	directlinkErr  error
+	dlProviderAPI  *dlProviderV2.DirectLinkProviderV2
+	dlProviderOnce sync.Once
+	dlProviderErr  error
 
-	cosConfigErr error
-	cosConfigAPI *cosconfig.ResourceConfigurationV1
+	cosConfigOnce sync.Once
+	cosConfigErr  error
+	cosConfigAPI  *cosconfig.ResourceConfigurationV1
 
-	transitgatewayAPI *tg.TransitGatewayApisV1
-	transitgatewayErr error
+	transitgatewayAPI  *tg.TransitGatewayApisV1
+	transitgatewayOnce sync.Once
+	transitgatewayErr  error
 
-	functionIAMNamespaceAPI functions.FunctionServiceAPI
-	functionIAMNamespaceErr error
+	functionIAMNamespaceAPI  functions.FunctionServiceAPI
+	functionIAMNamespaceOnce sync.Once
+	functionIAMNamespaceErr  error
 
 	// CIS Zones
+	cisZonesOnce     sync.Once
 	cisZonesErr      error
 	cisZonesV1Client *ciszonesv1.ZonesV1
 
 	// CIS dns service options
+	cisDNSOnce          sync.Once
 	cisDNSErr           error
 	cisDNSRecordsClient *cisdnsrecordsv1.DnsRecordsV1
 
 	// CIS dns bulk service options
+	cisDNSBulkOnce         sync.Once
 	cisDNSBulkErr          error
 	cisDNSRecordBulkClient *cisdnsbulkv1.DnsRecordBulkV1
 
 	// CIS Global Load Balancer Pool service options
+	cisGLBPoolOnce   sync.Once
 	cisGLBPoolErr    error
 	cisGLBPoolClient *cisglbpoolv0.GlobalLoadBalancerPoolsV0
 
 	// CIS GLB service options
+	cisGLBOnce   sync.Once
 	cisGLBErr    error
 	cisGLBClient *cisglbv1.GlobalLoadBalancerV1
 
 	// CIS GLB health check service options
+	cisGLBHealthCheckOnce   sync.Once
 	cisGLBHealthCheckErr    error
 	cisGLBHealthCheckClient *cisglbhealthcheckv1.GlobalLoadBalancerMonitorV1
 
 	// CIS IP service options
+	cisIPOnce   sync.Once
 	cisIPErr    error
 	cisIPClient *cisipv1.CisIpApiV1
 
 	// CIS Zone Rate Limits service options
+	cisRLOnce   sync.Once
 	cisRLErr    error
 	cisRLClient *cisratelimitv1.ZoneRateLimitsV1
 
 	// CIS Page Rules service options
+	cisPageRuleOnce   sync.Once
 	cisPageRuleErr    error
 	cisPageRuleClient *cispagerulev1.PageRuleApiV1
 
 	// CIS Edge Functions service options
+	cisEdgeFunctionOnce   sync.Once
 	cisEdgeFunctionErr    error
 	cisEdgeFunctionClient *cisedgefunctionv1.EdgeFunctionsApiV1
 
 	// CIS SSL certificate service options
+	cisSSLOnce   sync.Once
 	cisSSLErr    error
 	cisSSLClient *cissslv1.SslCertificateApiV1
 
 	// CIS WAF Package service options
+	cisWAFPackageOnce   sync.Once
 	cisWAFPackageErr    error
 	cisWAFPackageClient *ciswafpackagev1.WafRulePackagesApiV1
 
 	// CIS Zone Setting service options
+	cisDomainSettingsOnce   sync.Once
 	cisDomainSettingsErr    error
 	cisDomainSettingsClient *cisdomainsettingsv1.ZonesSettingsV1
 
 	// CIS Routing service options
+	cisRoutingOnce   sync.Once
 	cisRoutingErr    error
 	cisRoutingClient *cisroutingv1.RoutingV1
 
 	// CIS WAF Group service options
+	cisWAFGroupOnce   sync.Once
 	cisWAFGroupErr    error
 	cisWAFGroupClient *ciswafgroupv1.WafRuleGroupsApiV1
 
 	// CIS Caching service options
+	cisCacheOnce   sync.Once
 	cisCacheErr    error
 	cisCacheClient *ciscachev1.CachingApiV1
 
 	// CIS Custom Pages service options
+	cisCustomPageOnce   sync.Once
 	cisCustomPageErr    error
 	cisCustomPageClient *ciscustompagev1.CustomPagesV1
 
 	// CIS Firewall Access rule service option
+	cisAccessRuleOnce   sync.Once
 	cisAccessRuleErr    error
 	cisAccessRuleClient *cisaccessrulev1.ZoneFirewallAccessRulesV1
 
 	// CIS User Agent Blocking Rule service option
+	cisUARuleOnce   sync.Once
 	cisUARuleErr    error
 	cisUARuleClient *cisuarulev1.UserAgentBlockingRulesV1
 
 	// CIS Firewall Lockdwon Rule service option
+	cisLockdownOnce   sync.Once
 	cisLockdownErr    error
 	cisLockdownClient *cislockdownv1.ZoneLockdownV1
 
 	// CIS Range app service option
+	cisRangeAppOnce   sync.Once
 	cisRangeAppErr    error
 	cisRangeAppClient *cisrangeappv1.RangeApplicationsV1
 
 	// CIS WAF rule service options
+	cisWAFRuleOnce   sync.Once
 	cisWAFRuleErr    error
 	cisWAFRuleClient *ciswafrulev1.WafRulesApiV1
 	//IAM Identity Option
-	iamIdentityErr error
-	iamIdentityAPI *iamidentity.IamIdentityV1
+	iamIdentityOnce sync.Once
+	iamIdentityErr  error
+	iamIdentityAPI  *iamidentity.IamIdentityV1
 
 	//Resource Manager Option
-	resourceManagerErr error
-	resourceManagerAPI *resourcemanager.ResourceManagerV2
+	resourceManagerOnce sync.Once
+	resourceManagerErr  error
+	resourceManagerAPI  *resourcemanager.ResourceManagerV2
 
 	//Catalog Management Option
 	catalogManagementClient    *catalogmanagementv1.CatalogManagementV1
+	catalogManagementOnce      sync.Once
 	catalogManagementClientErr error
 
 	enterpriseManagementClient    *enterprisemanagementv1.EnterpriseManagementV1
+	enterpriseManagementOnce      sync.Once
 	enterpriseManagementClientErr error
 
 	//Resource Controller Option
+	resourceControllerOnce  sync.Once
 	resourceControllerErr   error
 	resourceControllerAPI   *resourcecontroller.ResourceControllerV2
 	secretsManagerClient    *secretsmanagerv1.SecretsManagerV1
+	secretsManagerOnce      sync.Once
 	secretsManagerClientErr error
 
 	// Schematics service options
 	schematicsClient    *schematicsv1.SchematicsV1
+	schematicsOnce      sync.Once
 	schematicsClientErr error
 
 	//Satellite service
 	satelliteClient    *kubernetesserviceapiv1.KubernetesServiceApiV1
+	satelliteOnce      sync.Once
 	satelliteClientErr error
 
 	//IAM Policy Management
-	iamPolicyManagementErr error
-	iamPolicyManagementAPI *iampolicymanagement.IamPolicyManagementV1
+	iamPolicyManagementOnce sync.Once
+	iamPolicyManagementErr  error
+	iamPolicyManagementAPI  *iampolicymanagement.IamPolicyManagementV1
 
 	// CIS Filters options
 	cisFiltersClient *cisfiltersv1.FiltersV1
+	cisFiltersOnce   sync.Once
 	cisFiltersErr    error
 
 	//Atracker
 	atrackerClient    *atrackerv1.AtrackerV1
+	atrackerOnce      sync.Once
 	atrackerClientErr error
 }
 
 // AppIDAPI provides AppID Service APIs ...
-func (session clientSession) AppIDAPI() (*appid.AppIDManagementV4, error) {
+func (session *clientSession) AppIDAPI() (*appid.AppIDManagementV4, error) {
+	session.lazy(&session.appidOnce, &session.appidErr, session.initAppID)
 	return session.appidAPI, session.appidErr
 }
 
-func (session clientSession) CatalogManagementV1() (*catalogmanagementv1.CatalogManagementV1, error) {
+func (session *clientSession) CatalogManagementV1() (*catalogmanagementv1.CatalogManagementV1, error) {
+	session.lazy(&session.catalogManagementOnce, &session.catalogManagementClientErr, session.initCatalogManagement)
 	return session.catalogManagementClient, session.catalogManagementClientErr
 }
 
 // BluemixAcccountAPI ...
-func (sess clientSession) BluemixAcccountAPI() (accountv2.AccountServiceAPI, error) {
+func (sess *clientSession) BluemixAcccountAPI() (accountv2.AccountServiceAPI, error) {
+	sess.lazy(&sess.accountOnce, &sess.accountConfigErr, sess.initAccount)
 	return sess.bmxAccountServiceAPI, sess.accountConfigErr
 }
 
 // BluemixAcccountAPI ...
-func (sess clientSession) BluemixAcccountv1API() (accountv1.AccountServiceAPI, error) {
+func (sess *clientSession) BluemixAcccountv1API() (accountv1.AccountServiceAPI, error) {
+	sess.lazy(&sess.accountV1Once, &sess.accountV1ConfigErr, sess.initAccountV1)
 	return sess.bmxAccountv1ServiceAPI, sess.accountV1ConfigErr
 }
 
 // BluemixSession to provide the Bluemix Session
-func (sess clientSession) BluemixSession() (*bxsession.Session, error) {
+func (sess *clientSession) BluemixSession() (*bxsession.Session, error) {
 	return sess.session.BluemixSession, sess.bluemixSessionErr
 }
 
 // BluemixUserDetails ...
-func (sess clientSession) BluemixUserDetails() (*UserConfig, error) {
+func (sess *clientSession) BluemixUserDetails() (*UserConfig, error) {
 	return sess.bmxUserDetails, sess.bmxUserFetchErr
 }
 
+// DefaultTags provides the tags attached to every taggable resource
+func (sess *clientSession) DefaultTags() []string {
+	return sess.config.DefaultTags
+}
+
+// PollingPolicy provides the intervals of the waits for the state of the resources
+func (sess *clientSession) PollingPolicy() *PollingPolicy {
+	return sess.config.Polling
+}
+
+// Endpoint provides the endpoint of the service identified by key, for the
+// clients built by the resources, ex: the clients of an instance
+func (sess *clientSession) Endpoint(key, defaultValue string) string {
+	return sess.config.endpointFor(key, defaultValue)
+}
+
 // ContainerAPI provides Container Service APIs ...
-func (sess clientSession) ContainerAPI() (containerv1.ContainerServiceAPI, error) {
+func (sess *clientSession) ContainerAPI() (containerv1.ContainerServiceAPI, error) {
+	sess.lazy(&sess.csOnce, &sess.csConfigErr, sess.initContainer)
 	return sess.csServiceAPI, sess.csConfigErr
 }
 
 // VpcContainerAPI provides v2Container Service APIs ...
-func (sess clientSession) VpcContainerAPI() (containerv2.ContainerServiceAPI, error) {
+func (sess *clientSession) VpcContainerAPI() (containerv2.ContainerServiceAPI, error) {
+	sess.lazy(&sess.csv2Once, &sess.csv2ConfigErr, sess.initVpcContainer)
 	return sess.csv2ServiceAPI, sess.csv2ConfigErr
 }
 
 // ContainerRegistryV1 provides Container Registry Service APIs ...
-func (session clientSession) ContainerRegistryV1() (*containerregistryv1.ContainerRegistryV1, error) {
+func (session *clientSession) ContainerRegistryV1() (*containerregistryv1.ContainerRegistryV1, error) {
+	session.lazy(&session.containerRegistryOnce, &session.containerRegistryClientErr, session.initContainerRegistry)
 	return session.containerRegistryClient, session.containerRegistryClientErr
 }
 
 // SchematicsAPI provides schematics Service APIs ...
-func (sess clientSession) SchematicsV1() (*schematicsv1.SchematicsV1, error) {
+func (sess *clientSession) SchematicsV1() (*schematicsv1.SchematicsV1, error) {
+	sess.lazy(&sess.schematicsOnce, &sess.schematicsClientErr, sess.initSchematics)
 	return sess.schematicsClient, sess.schematicsClientErr
 }
 
 // CisAPI provides Cloud Internet Services APIs ...
-func (sess clientSession) CisAPI() (cisv1.CisServiceAPI, error) {
+func (sess *clientSession) CisAPI() (cisv1.CisServiceAPI, error) {
+	sess.lazy(&sess.cisOnce, &sess.cisConfigErr, sess.initCis)
 	return sess.cisServiceAPI, sess.cisConfigErr
 }
 
 // FunctionClient ...
-func (sess clientSession) FunctionClient() (*whisk.Client, error) {
+func (sess *clientSession) FunctionClient() (*whisk.Client, error) {
+	sess.lazy(&sess.functionOnce, &sess.functionConfigErr, sess.initFunctionClient)
 	return sess.functionClient, sess.functionConfigErr
 }
 
 // GlobalSearchAPI provides Global Search  APIs ...
-func (sess clientSession) GlobalSearchAPI() (globalsearchv2.GlobalSearchServiceAPI, error) {
+func (sess *clientSession) GlobalSearchAPI() (globalsearchv2.GlobalSearchServiceAPI, error) {
+	sess.lazy(&sess.globalSearchOnce, &sess.globalSearchConfigErr, sess.initGlobalSearch)
 	return sess.globalSearchServiceAPI, sess.globalSearchConfigErr
 }
 
 // GlobalTaggingAPI provides Global Search  APIs ...
-func (sess clientSession) GlobalTaggingAPI() (globaltaggingv3.GlobalTaggingServiceAPI, error) {
+func (sess *clientSession) GlobalTaggingAPI() (globaltaggingv3.GlobalTaggingServiceAPI, error) {
+	sess.lazy(&sess.globalTaggingOnce, &sess.globalTaggingConfigErr, sess.initGlobalTagging)
 	return sess.globalTaggingServiceAPI, sess.globalTaggingConfigErr
 }
 
 // GlobalTaggingAPIV1 provides Platform-go Global Tagging  APIs ...
-func (sess clientSession) GlobalTaggingAPIv1() (globaltaggingv1.GlobalTaggingV1, error) {
+func (sess *clientSession) GlobalTaggingAPIv1() (globaltaggingv1.GlobalTaggingV1, error) {
+	sess.lazy(&sess.globalTaggingV1Once, &sess.globalTaggingConfigErrV1, sess.initGlobalTaggingV1)
 	return sess.globalTaggingServiceAPIV1, sess.globalTaggingConfigErrV1
 }
 
 // HpcsEndpointAPI provides Hpcs Endpoint generator APIs ...
-func (sess clientSession) HpcsEndpointAPI() (hpcs.HPCSV2, error) {
+func (sess *clientSession) HpcsEndpointAPI() (hpcs.HPCSV2, error) {
+	sess.lazy(&sess.hpcsEndpointOnce, &sess.hpcsEndpointErr, sess.initHpcsEndpoint)
 	return sess.hpcsEndpointAPI, sess.hpcsEndpointErr
 }
 
 // UserManagementAPI provides User management APIs ...
-func (sess clientSession) UserManagementAPI() (usermanagementv2.UserManagementAPI, error) {
+func (sess *clientSession) UserManagementAPI() (usermanagementv2.UserManagementAPI, error) {
+	sess.lazy(&sess.userManagementOnce, &sess.userManagementErr, sess.initUserManagement)
 	return sess.userManagementAPI, sess.userManagementErr
 }
 
 // IAM Policy Management
-func (sess clientSession) IAMPolicyManagementV1API() (*iampolicymanagement.IamPolicyManagementV1, error) {
+func (sess *clientSession) IAMPolicyManagementV1API() (*iampolicymanagement.IamPolicyManagementV1, error) {
+	sess.lazy(&sess.iamPolicyManagementOnce, &sess.iamPolicyManagementErr, sess.initIAMPolicyManagement)
 	return sess.iamPolicyManagementAPI, sess.iamPolicyManagementErr
 }
 
 // IAMUUMAPIV2 provides IAM UUM APIs ...
-func (sess clientSession) IAMUUMAPIV2() (iamuumv2.IAMUUMServiceAPIv2, error) {
+func (sess *clientSession) IAMUUMAPIV2() (iamuumv2.IAMUUMServiceAPIv2, error) {
+	sess.lazy(&sess.iamUUMV2Once, &sess.iamUUMConfigErrV2, sess.initIAMUUMV2)
 	return sess.iamUUMServiceAPIV2, sess.iamUUMConfigErrV2
 }
 
 // IcdAPI provides IBM Cloud Databases APIs ...
-func (sess clientSession) ICDAPI() (icdv4.ICDServiceAPI, error) {
+func (sess *clientSession) ICDAPI() (icdv4.ICDServiceAPI, error) {
+	sess.lazy(&sess.icdOnce, &sess.icdConfigErr, sess.initICD)
 	return sess.icdServiceAPI, sess.icdConfigErr
 }
 
 // MccpAPI provides Multi Cloud Controller Proxy APIs ...
-func (sess clientSession) MccpAPI() (mccpv2.MccpServiceAPI, error) {
+func (sess *clientSession) MccpAPI() (mccpv2.MccpServiceAPI, error) {
+	sess.lazy(&sess.cfOnce, &sess.cfConfigErr, sess.initMccp)
 	return sess.cfServiceAPI, sess.cfConfigErr
 }
 
 // ResourceCatalogAPI ...
-func (sess clientSession) ResourceCatalogAPI() (catalog.ResourceCatalogAPI, error) {
+func (sess *clientSession) ResourceCatalogAPI() (catalog.ResourceCatalogAPI, error) {
+	sess.lazy(&sess.resourceCatalogOnce, &sess.resourceCatalogConfigErr, sess.initResourceCatalog)
 	return sess.resourceCatalogServiceAPI, sess.resourceCatalogConfigErr
 }
 
 // ResourceManagementAPIv2 ...
-func (sess clientSession) ResourceManagementAPIv2() (managementv2.ResourceManagementAPIv2, error) {
+func (sess *clientSession) ResourceManagementAPIv2() (managementv2.ResourceManagementAPIv2, error) {
+	sess.lazy(&sess.resourceManagementV2Once, &sess.resourceManagementConfigErrv2, sess.initResourceManagementV2)
 	return sess.resourceManagementServiceAPIv2, sess.resourceManagementConfigErrv2
 }
 
 // ResourceControllerAPI ...
-func (sess clientSession) ResourceControllerAPI() (controller.ResourceControllerAPI, error) {
+func (sess *clientSession) ResourceControllerAPI() (controller.ResourceControllerAPI, error) {
+	sess.lazy(&sess.resourceControllerV1Once, &sess.resourceControllerConfigErr, sess.initResourceControllerV1)
 	return sess.resourceControllerServiceAPI, sess.resourceControllerConfigErr
 }
 
 // ResourceControllerAPIv2 ...
-func (sess clientSession) ResourceControllerAPIV2() (controllerv2.ResourceControllerAPIV2, error) {
+func (sess *clientSession) ResourceControllerAPIV2() (controllerv2.ResourceControllerAPIV2, error) {
+	sess.lazy(&sess.resourceControllerV2Once, &sess.resourceControllerConfigErrv2, sess.initResourceControllerV2)
 	return sess.resourceControllerServiceAPIv2, sess.resourceControllerConfigErrv2
 }
 
 // SoftLayerSession providers SoftLayer Session
-func (sess clientSession) SoftLayerSession() *slsession.Session {
+func (sess *clientSession) SoftLayerSession() *slsession.Session {
 	return sess.session.SoftLayerSession
 }
 
 // CertManagementAPI provides Certificate  management APIs ...
-func (sess clientSession) CertificateManagerAPI() (certificatemanager.CertificateManagerServiceAPI, error) {
+func (sess *clientSession) CertificateManagerAPI() (certificatemanager.CertificateManagerServiceAPI, error) {
+	sess.lazy(&sess.certManagementOnce, &sess.certManagementErr, sess.initCertManagement)
 	return sess.certManagementAPI, sess.certManagementErr
 }
 
 //apigatewayAPI provides API Gateway APIs
-func (sess clientSession) APIGateway() (*apigateway.ApiGatewayControllerApiV1, error) {
+func (sess *clientSession) APIGateway() (*apigateway.ApiGatewayControllerApiV1, error) {
+	sess.lazy(&sess.apigatewayOnce, &sess.apigatewayErr, sess.initAPIGateway)
 	return sess.apigatewayAPI, sess.apigatewayErr
 }
 
-func (session clientSession) PushServiceV1() (*pushservicev1.PushServiceV1, error) {
+func (session *clientSession) PushServiceV1() (*pushservicev1.PushServiceV1, error) {
+	session.lazy(&session.pushServiceOnce, &session.pushServiceClientErr, session.initPushService)
 	return session.pushServiceClient, session.pushServiceClientErr
 }
 
-func (session clientSession) AppConfigurationV1() (*appconfigurationv1.AppConfigurationV1, error) {
+func (session *clientSession) AppConfigurationV1() (*appconfigurationv1.AppConfigurationV1, error) {
+	session.lazy(&session.appConfigurationOnce, &session.appConfigurationClientErr, session.initAppConfiguration)
 	return session.appConfigurationClient, session.appConfigurationClientErr
 }
 
-func (sess clientSession) keyProtectAPI() (*kp.Client, error) {
+func (sess *clientSession) keyProtectAPI() (*kp.Client, error) {
+	sess.lazy(&sess.kpOnce, &sess.kpErr, sess.initKeyProtect)
 	return sess.kpAPI, sess.kpErr
 }
 
-func (sess clientSession) keyManagementAPI() (*kp.Client, error) {
+func (sess *clientSession) keyManagementAPI() (*kp.Client, error) {
+	sess.lazy(&sess.kmsOnce, &sess.kmsErr, sess.initKeyManagement)
 	return sess.kmsAPI, sess.kmsErr
 }
 
-func (sess clientSession) VpcV1API() (*vpc.VpcV1, error) {
+func (sess *clientSession) VpcV1API() (*vpc.VpcV1, error) {
+	sess.lazy(&sess.vpcOnce, &sess.vpcErr, sess.initVpc)
 	return sess.vpcAPI, sess.vpcErr
 }
 
-func (sess clientSession) DirectlinkV1API() (*dl.DirectLinkV1, error) {
+func (sess *clientSession) DirectlinkV1API() (*dl.DirectLinkV1, error) {
+	sess.lazy(&sess.directlinkOnce, &sess.directlinkErr, sess.initDirectlink)
 	return sess.directlinkAPI, sess.directlinkErr
 }
-func (sess clientSession) DirectlinkProviderV2API() (*dlProviderV2.DirectLinkProviderV2, error) {
+func (sess *clientSession) DirectlinkProviderV2API() (*dlProviderV2.DirectLinkProviderV2, error) {
+	sess.lazy(&sess.dlProviderOnce, &sess.dlProviderErr, sess.initDirectlinkProvider)
 	return sess.dlProviderAPI, sess.dlProviderErr
 }
-func (sess clientSession) CosConfigV1API() (*cosconfig.ResourceConfigurationV1, error) {
+func (sess *clientSession) CosConfigV1API() (*cosconfig.ResourceConfigurationV1, error) {
+	sess.lazy(&sess.cosConfigOnce, &sess.cosConfigErr, sess.initCosConfig)
 	return sess.cosConfigAPI, sess.cosConfigErr
 }
 
-func (sess clientSession) TransitGatewayV1API() (*tg.TransitGatewayApisV1, error) {
+func (sess *clientSession) TransitGatewayV1API() (*tg.TransitGatewayApisV1, error) {
+	sess.lazy(&sess.transitgatewayOnce, &sess.transitgatewayErr, sess.initTransitGateway)
 	return sess.transitgatewayAPI, sess.transitgatewayErr
 }
 
 // Session to the Power Colo Service
 
-func (sess clientSession) IBMPISession() (*ibmpisession.IBMPISession, error) {
+func (sess *clientSession) IBMPISession() (*ibmpisession.IBMPISession, error) {
+	sess.lazy(&sess.ibmpiOnce, &sess.powerConfigErr, sess.initIBMPI)
 	return sess.ibmpiSession, sess.powerConfigErr
 }
 
 // Private DNS Service
 
-func (sess clientSession) PrivateDNSClientSession() (*dns.DnsSvcsV1, error) {
+func (sess *clientSession) PrivateDNSClientSession() (*dns.DnsSvcsV1, error) {
+	sess.lazy(&sess.pDNSOnce, &sess.pDNSErr, sess.initPrivateDNS)
 	return sess.pDNSClient, sess.pDNSErr
 }
 
 // Session to the Namespace cloud function
 
-func (sess clientSession) FunctionIAMNamespaceAPI() (functions.FunctionServiceAPI, error) {
+func (sess *clientSession) FunctionIAMNamespaceAPI() (functions.FunctionServiceAPI, error) {
+	sess.lazy(&sess.functionIAMNamespaceOnce, &sess.functionIAMNamespaceErr, sess.initFunctionIAMNamespace)
 	return sess.functionIAMNamespaceAPI, sess.functionIAMNamespaceErr
 }
 
 // CIS Zones Service
-func (sess clientSession) CisZonesV1ClientSession() (*ciszonesv1.ZonesV1, error) {
+func (sess *clientSession) CisZonesV1ClientSession() (*ciszonesv1.ZonesV1, error) {
+	sess.lazy(&sess.cisZonesOnce, &sess.cisZonesErr, sess.initCisZones)
 	if sess.cisZonesErr != nil {
 		return sess.cisZonesV1Client, sess.cisZonesErr
 	}
@@ -691,7 +867,8 @@ func (sess clientSession) CisZonesV1ClientSession() (*ciszonesv1.ZonesV1, error)
 }
 
 // CIS DNS Service
-func (sess clientSession) CisDNSRecordClientSession() (*cisdnsrecordsv1.DnsRecordsV1, error) {
+func (sess *clientSession) CisDNSRecordClientSession() (*cisdnsrecordsv1.DnsRecordsV1, error) {
+	sess.lazy(&sess.cisDNSOnce, &sess.cisDNSErr, sess.initCisDNSRecords)
 	if sess.cisDNSErr != nil {
 		return sess.cisDNSRecordsClient, sess.cisDNSErr
 	}
@@ -699,7 +876,8 @@ func (sess clientSession) CisDNSRecordClientSession() (*cisdnsrecordsv1.DnsRecor
 }
 
 // CIS DNS Bulk Service
-func (sess clientSession) CisDNSRecordBulkClientSession() (*cisdnsbulkv1.DnsRecordBulkV1, error) {
+func (sess *clientSession) CisDNSRecordBulkClientSession() (*cisdnsbulkv1.DnsRecordBulkV1, error) {
+	sess.lazy(&sess.cisDNSBulkOnce, &sess.cisDNSBulkErr, sess.initCisDNSRecordBulk)
 	if sess.cisDNSBulkErr != nil {
 		return sess.cisDNSRecordBulkClient, sess.cisDNSBulkErr
 	}
@@ -707,7 +885,8 @@ func (sess clientSession) CisDNSRecordBulkClientSession() (*cisdnsbulkv1.DnsReco
 }
 
 // CIS GLB Pool
-func (sess clientSession) CisGLBPoolClientSession() (*cisglbpoolv0.GlobalLoadBalancerPoolsV0, error) {
+func (sess *clientSession) CisGLBPoolClientSession() (*cisglbpoolv0.GlobalLoadBalancerPoolsV0, error) {
+	sess.lazy(&sess.cisGLBPoolOnce, &sess.cisGLBPoolErr, sess.initCisGLBPool)
 	if sess.cisGLBPoolErr != nil {
 		return sess.cisGLBPoolClient, sess.cisGLBPoolErr
 	}
@@ -715,7 +894,8 @@ func (sess clientSession) CisGLBPoolClientSession() (*cisglbpoolv0.GlobalLoadBal
 }
 
 // CIS GLB
-func (sess clientSession) CisGLBClientSession() (*cisglbv1.GlobalLoadBalancerV1, error) {
+func (sess *clientSession) CisGLBClientSession() (*cisglbv1.GlobalLoadBalancerV1, error) {
+	sess.lazy(&sess.cisGLBOnce, &sess.cisGLBErr, sess.initCisGLB)
 	if sess.cisGLBErr != nil {
 		return sess.cisGLBClient, sess.cisGLBErr
 	}
@@ -723,7 +903,8 @@ func (sess clientSession) CisGLBClientSession() (*cisglbv1.GlobalLoadBalancerV1,
 }
 
 // CIS GLB Health Check/Monitor
-func (sess clientSession) CisGLBHealthCheckClientSession() (*cisglbhealthcheckv1.GlobalLoadBalancerMonitorV1, error) {
+func (sess *clientSession) CisGLBHealthCheckClientSession() (*cisglbhealthcheckv1.GlobalLoadBalancerMonitorV1, error) {
+	sess.lazy(&sess.cisGLBHealthCheckOnce, &sess.cisGLBHealthCheckErr, sess.initCisGLBHealthCheck)
 	if sess.cisGLBHealthCheckErr != nil {
 		return sess.cisGLBHealthCheckClient, sess.cisGLBHealthCheckErr
 	}
@@ -731,7 +912,8 @@ func (sess clientSession) CisGLBHealthCheckClientSession() (*cisglbhealthcheckv1
 }
 
 // CIS Zone Rate Limits
-func (sess clientSession) CisRLClientSession() (*cisratelimitv1.ZoneRateLimitsV1, error) {
+func (sess *clientSession) CisRLClientSession() (*cisratelimitv1.ZoneRateLimitsV1, error) {
+	sess.lazy(&sess.cisRLOnce, &sess.cisRLErr, sess.initCisRL)
 	if sess.cisRLErr != nil {
 		return sess.cisRLClient, sess.cisRLErr
 	}
@@ -739,7 +921,8 @@ func (sess clientSession) CisRLClientSession() (*cisratelimitv1.ZoneRateLimitsV1
 }
 
 // CIS IP
-func (sess clientSession) CisIPClientSession() (*cisipv1.CisIpApiV1, error) {
+func (sess *clientSession) CisIPClientSession() (*cisipv1.CisIpApiV1, error) {
+	sess.lazy(&sess.cisIPOnce, &sess.cisIPErr, sess.initCisIP)
 	if sess.cisIPErr != nil {
 		return sess.cisIPClient, sess.cisIPErr
 	}
@@ -747,7 +930,8 @@ func (sess clientSession) CisIPClientSession() (*cisipv1.CisIpApiV1, error) {
 }
 
 // CIS Page Rules
-func (sess clientSession) CisPageRuleClientSession() (*cispagerulev1.PageRuleApiV1, error) {
+func (sess *clientSession) CisPageRuleClientSession() (*cispagerulev1.PageRuleApiV1, error) {
+	sess.lazy(&sess.cisPageRuleOnce, &sess.cisPageRuleErr, sess.initCisPageRule)
 	if sess.cisPageRuleErr != nil {
 		return sess.cisPageRuleClient, sess.cisPageRuleErr
 	}
@@ -755,7 +939,8 @@ func (sess clientSession) CisPageRuleClientSession() (*cispagerulev1.PageRuleApi
 }
 
 // CIS Edge Function
-func (sess clientSession) CisEdgeFunctionClientSession() (*cisedgefunctionv1.EdgeFunctionsApiV1, error) {
+func (sess *clientSession) CisEdgeFunctionClientSession() (*cisedgefunctionv1.EdgeFunctionsApiV1, error) {
+	sess.lazy(&sess.cisEdgeFunctionOnce, &sess.cisEdgeFunctionErr, sess.initCisEdgeFunction)
 	if sess.cisEdgeFunctionErr != nil {
 		return sess.cisEdgeFunctionClient, sess.cisEdgeFunctionErr
 	}
@@ -763,7 +948,8 @@ func (sess clientSession) CisEdgeFunctionClientSession() (*cisedgefunctionv1.Edg
 }
 
 // CIS SSL certificate
-func (sess clientSession) CisSSLClientSession() (*cissslv1.SslCertificateApiV1, error) {
+func (sess *clientSession) CisSSLClientSession() (*cissslv1.SslCertificateApiV1, error) {
+	sess.lazy(&sess.cisSSLOnce, &sess.cisSSLErr, sess.initCisSSL)
 	if sess.cisSSLErr != nil {
 		return sess.cisSSLClient, sess.cisSSLErr
 	}
@@ -771,7 +957,8 @@ func (sess clientSession) CisSSLClientSession() (*cissslv1.SslCertificateApiV1,
 }
 
 // CIS WAF Packages
-func (sess clientSession) CisWAFPackageClientSession() (*ciswafpackagev1.WafRulePackagesApiV1, error) {
+func (sess *clientSession) CisWAFPackageClientSession() (*ciswafpackagev1.WafRulePackagesApiV1, error) {
+	sess.lazy(&sess.cisWAFPackageOnce, &sess.cisWAFPackageErr, sess.initCisWAFPackage)
 	if sess.cisWAFPackageErr != nil {
 		return sess.cisWAFPackageClient, sess.cisWAFPackageErr
 	}
@@ -779,7 +966,8 @@ func (sess clientSession) CisWAFPackageClientSession() (*ciswafpackagev1.WafRule
 }
 
 // CIS Zone Settings
-func (sess clientSession) CisDomainSettingsClientSession() (*cisdomainsettingsv1.ZonesSettingsV1, error) {
+func (sess *clientSession) CisDomainSettingsClientSession() (*cisdomainsettingsv1.ZonesSettingsV1, error) {
+	sess.lazy(&sess.cisDomainSettingsOnce, &sess.cisDomainSettingsErr, sess.initCisDomainSettings)
 	if sess.cisDomainSettingsErr != nil {
 		return sess.cisDomainSettingsClient, sess.cisDomainSettingsErr
 	}
@@ -787,7 +975,8 @@ func (sess clientSession) CisDomainSettingsClientSession() (*cisdomainsettingsv1
 }
 
 // CIS Routing
-func (sess clientSession) CisRoutingClientSession() (*cisroutingv1.RoutingV1, error) {
+func (sess *clientSession) CisRoutingClientSession() (*cisroutingv1.RoutingV1, error) {
+	sess.lazy(&sess.cisRoutingOnce, &sess.cisRoutingErr, sess.initCisRouting)
 	if sess.cisRoutingErr != nil {
 		return sess.cisRoutingClient, sess.cisRoutingErr
 	}
@@ -795,7 +984,8 @@ func (sess clientSession) CisRoutingClientSession() (*cisroutingv1.RoutingV1, er
 }
 
 // CIS WAF Group
-func (sess clientSession) CisWAFGroupClientSession() (*ciswafgroupv1.WafRuleGroupsApiV1, error) {
+func (sess *clientSession) CisWAFGroupClientSession() (*ciswafgroupv1.WafRuleGroupsApiV1, error) {
+	sess.lazy(&sess.cisWAFGroupOnce, &sess.cisWAFGroupErr, sess.initCisWAFGroup)
 	if sess.cisWAFGroupErr != nil {
 		return sess.cisWAFGroupClient, sess.cisWAFGroupErr
 	}
@@ -803,7 +993,8 @@ func (sess clientSession) CisWAFGroupClientSession() (*ciswafgroupv1.WafRuleGrou
 }
 
 // CIS Cache service
-func (sess clientSession) CisCacheClientSession() (*ciscachev1.CachingApiV1, error) {
+func (sess *clientSession) CisCacheClientSession() (*ciscachev1.CachingApiV1, error) {
+	sess.lazy(&sess.cisCacheOnce, &sess.cisCacheErr, sess.initCisCache)
 	if sess.cisCacheErr != nil {
 		return sess.cisCacheClient, sess.cisCacheErr
 	}
@@ -811,7 +1002,8 @@ func (sess clientSession) CisCacheClientSession() (*ciscachev1.CachingApiV1, err
 }
 
 // CIS Zone Settings
-func (sess clientSession) CisCustomPageClientSession() (*ciscustompagev1.CustomPagesV1, error) {
+func (sess *clientSession) CisCustomPageClientSession() (*ciscustompagev1.CustomPagesV1, error) {
+	sess.lazy(&sess.cisCustomPageOnce, &sess.cisCustomPageErr, sess.initCisCustomPage)
 	if sess.cisCustomPageErr != nil {
 		return sess.cisCustomPageClient, sess.cisCustomPageErr
 	}
@@ -819,7 +1011,8 @@ func (sess clientSession) CisCustomPageClientSession() (*ciscustompagev1.CustomP
 }
 
 // CIS Firewall access rule
-func (sess clientSession) CisAccessRuleClientSession() (*cisaccessrulev1.ZoneFirewallAccessRulesV1, error) {
+func (sess *clientSession) CisAccessRuleClientSession() (*cisaccessrulev1.ZoneFirewallAccessRulesV1, error) {
+	sess.lazy(&sess.cisAccessRuleOnce, &sess.cisAccessRuleErr, sess.initCisAccessRule)
 	if sess.cisAccessRuleErr != nil {
 		return sess.cisAccessRuleClient, sess.cisAccessRuleErr
 	}
@@ -827,7 +1020,8 @@ func (sess clientSession) CisAccessRuleClientSession() (*cisaccessrulev1.ZoneFir
 }
 
 // CIS User Agent Blocking rule
-func (sess clientSession) CisUARuleClientSession() (*cisuarulev1.UserAgentBlockingRulesV1, error) {
+func (sess *clientSession) CisUARuleClientSession() (*cisuarulev1.UserAgentBlockingRulesV1, error) {
+	sess.lazy(&sess.cisUARuleOnce, &sess.cisUARuleErr, sess.initCisUARule)
 	if sess.cisUARuleErr != nil {
 		return sess.cisUARuleClient, sess.cisUARuleErr
 	}
@@ -835,7 +1029,8 @@ func (sess clientSession) CisUARuleClientSession() (*cisuarulev1.UserAgentBlocki
 }
 
 // CIS Firewall Lockdown rule
-func (sess clientSession) CisLockdownClientSession() (*cislockdownv1.ZoneLockdownV1, error) {
+func (sess *clientSession) CisLockdownClientSession() (*cislockdownv1.ZoneLockdownV1, error) {
+	sess.lazy(&sess.cisLockdownOnce, &sess.cisLockdownErr, sess.initCisLockdown)
 	if sess.cisLockdownErr != nil {
 		return sess.cisLockdownClient, sess.cisLockdownErr
 	}
@@ -843,7 +1038,8 @@ func (sess clientSession) CisLockdownClientSession() (*cislockdownv1.ZoneLockdow
 }
 
 // CIS Range app rule
-func (sess clientSession) CisRangeAppClientSession() (*cisrangeappv1.RangeApplicationsV1, error) {
+func (sess *clientSession) CisRangeAppClientSession() (*cisrangeappv1.RangeApplicationsV1, error) {
+	sess.lazy(&sess.cisRangeAppOnce, &sess.cisRangeAppErr, sess.initCisRangeApp)
 	if sess.cisRangeAppErr != nil {
 		return sess.cisRangeAppClient, sess.cisRangeAppErr
 	}
@@ -851,7 +1047,8 @@ func (sess clientSession) CisRangeAppClientSession() (*cisrangeappv1.RangeApplic
 }
 
 // CIS WAF Rule
-func (sess clientSession) CisWAFRuleClientSession() (*ciswafrulev1.WafRulesApiV1, error) {
+func (sess *clientSession) CisWAFRuleClientSession() (*ciswafrulev1.WafRulesApiV1, error) {
+	sess.lazy(&sess.cisWAFRuleOnce, &sess.cisWAFRuleErr, sess.initCisWAFRule)
 	if sess.cisWAFRuleErr != nil {
 		return sess.cisWAFRuleClient, sess.cisWAFRuleErr
 	}
@@ -859,38 +1056,45 @@ func (sess clientSession) CisWAFRuleClientSession() (*ciswafrulev1.WafRulesApiV1
 }
 
 // IAM Identity Session
-func (sess clientSession) IAMIdentityV1API() (*iamidentity.IamIdentityV1, error) {
+func (sess *clientSession) IAMIdentityV1API() (*iamidentity.IamIdentityV1, error) {
+	sess.lazy(&sess.iamIdentityOnce, &sess.iamIdentityErr, sess.initIAMIdentity)
 	return sess.iamIdentityAPI, sess.iamIdentityErr
 }
 
 // ResourceMAanger Session
-func (sess clientSession) ResourceManagerV2API() (*resourcemanager.ResourceManagerV2, error) {
+func (sess *clientSession) ResourceManagerV2API() (*resourcemanager.ResourceManagerV2, error) {
+	sess.lazy(&sess.resourceManagerOnce, &sess.resourceManagerErr, sess.initResourceManager)
 	return sess.resourceManagerAPI, sess.resourceManagerErr
 }
 
-func (session clientSession) EnterpriseManagementV1() (*enterprisemanagementv1.EnterpriseManagementV1, error) {
+func (session *clientSession) EnterpriseManagementV1() (*enterprisemanagementv1.EnterpriseManagementV1, error) {
+	session.lazy(&session.enterpriseManagementOnce, &session.enterpriseManagementClientErr, session.initEnterpriseManagement)
 	return session.enterpriseManagementClient, session.enterpriseManagementClientErr
 }
 
 // ResourceController Session
-func (sess clientSession) ResourceControllerV2API() (*resourcecontroller.ResourceControllerV2, error) {
+func (sess *clientSession) ResourceControllerV2API() (*resourcecontroller.ResourceControllerV2, error) {
+	sess.lazy(&sess.resourceControllerOnce, &sess.resourceControllerErr, sess.initResourceController)
 	return sess.resourceControllerAPI, sess.resourceControllerErr
 }
 
 // SecretsManager Session
-func (session clientSession) SecretsManagerV1() (*secretsmanagerv1.SecretsManagerV1, error) {
+func (session *clientSession) SecretsManagerV1() (*secretsmanagerv1.SecretsManagerV1, error) {
+	session.lazy(&session.secretsManagerOnce, &session.secretsManagerClientErr, session.initSecretsManager)
 	return session.secretsManagerClient, session.secretsManagerClientErr
 }
 
 var cloudEndpoint = "cloud.ibm.com"
 
 // Session to the Satellite client
-func (sess clientSession) SatelliteClientSession() (*kubernetesserviceapiv1.KubernetesServiceApiV1, error) {
+func (sess *clientSession) SatelliteClientSession() (*kubernetesserviceapiv1.KubernetesServiceApiV1, error) {
+	sess.lazy(&sess.satelliteOnce, &sess.satelliteClientErr, sess.initSatellite)
 	return sess.satelliteClient, sess.satelliteClientErr
 }
 
 // CIS Filters
-func (sess clientSession) CisFiltersSession() (*cisfiltersv1.FiltersV1, error) {
+func (sess *clientSession) CisFiltersSession() (*cisfiltersv1.FiltersV1, error) {
+	sess.lazy(&sess.cisFiltersOnce, &sess.cisFiltersErr, sess.initCisFilters)
 	if sess.cisFiltersErr != nil {
 		return sess.cisFiltersClient, sess.cisFiltersErr
 	}
@@ -898,150 +1102,101 @@ func (sess clientSession) CisFiltersSession() (*cisfiltersv1.FiltersV1, error) {
 }
 
 // Activity Tracker API
-func (session clientSession) AtrackerV1() (*atrackerv1.AtrackerV1, error) {
+func (session *clientSession) AtrackerV1() (*atrackerv1.AtrackerV1, error) {
+	session.lazy(&session.atrackerOnce, &session.atrackerClientErr, session.initAtracker)
 	return session.atrackerClient, session.atrackerClientErr
 }
 
-// ClientSession configures and returns a fully initialized ClientSession
+// ClientSession configures and returns a fully initialized ClientSession. It
+// authenticates with IBM Cloud, the service clients are built on their first use.
 func (c *Config) ClientSession() (interface{}, error) {
+	endpoints, err := loadEndpointsFile(c.EndpointsFile)
+	if err != nil {
+		return nil, err
+	}
+	c.endpoints = endpoints.merge(c.Endpoints)
+	c.limiter = newRateLimiter(c.RateLimit)
+	c.assumeSource = nil
+	if c.BluemixAPIKey == "" && c.IAMToken == "" {
+		c.tokenSource = c.newIAMTokenSource()
+	}
 	sess, err := newSession(c)
 	if err != nil {
 		return nil, err
 	}
 	log.Printf("[INFO] Configured Region: %s\n", c.Region)
-	session := clientSession{
+	session := &clientSession{
 		session: sess,
+		config:  c,
 	}
 
 	if sess.BluemixSession == nil {
 		//Can be nil only  if bluemix_api_key is not provided
 		log.Println("Skipping Bluemix Clients configuration")
 		session.bluemixSessionErr = errEmptyBluemixCredentials
-		session.accountConfigErr = errEmptyBluemixCredentials
-		session.accountV1ConfigErr = errEmptyBluemixCredentials
-		session.csConfigErr = errEmptyBluemixCredentials
-		session.csv2ConfigErr = errEmptyBluemixCredentials
-		session.containerRegistryClientErr = errEmptyBluemixCredentials
-		session.kpErr = errEmptyBluemixCredentials
-		session.pushServiceClientErr = errEmptyBluemixCredentials
-		session.appConfigurationClientErr = errEmptyBluemixCredentials
-		session.kmsErr = errEmptyBluemixCredentials
-		session.cfConfigErr = errEmptyBluemixCredentials
-		session.cisConfigErr = errEmptyBluemixCredentials
-		session.functionConfigErr = errEmptyBluemixCredentials
-		session.globalSearchConfigErr = errEmptyBluemixCredentials
-		session.globalTaggingConfigErr = errEmptyBluemixCredentials
-		session.globalTaggingConfigErrV1 = errEmptyBluemixCredentials
-		session.hpcsEndpointErr = errEmptyBluemixCredentials
-		session.iamUUMConfigErrV2 = errEmptyBluemixCredentials
-		session.icdConfigErr = errEmptyBluemixCredentials
-		session.resourceCatalogConfigErr = errEmptyBluemixCredentials
-		session.resourceManagerErr = errEmptyBluemixCredentials
-		session.resourceManagementConfigErrv2 = errEmptyBluemixCredentials
-		session.resourceControllerConfigErr = errEmptyBluemixCredentials
-		session.resourceControllerConfigErrv2 = errEmptyBluemixCredentials
-		session.enterpriseManagementClientErr = errEmptyBluemixCredentials
-		session.resourceControllerErr = errEmptyBluemixCredentials
-		session.catalogManagementClientErr = errEmptyBluemixCredentials
-		session.powerConfigErr = errEmptyBluemixCredentials
-		session.ibmpiConfigErr = errEmptyBluemixCredentials
-		session.userManagementErr = errEmptyBluemixCredentials
-		session.certManagementErr = errEmptyBluemixCredentials
-		session.vpcErr = errEmptyBluemixCredentials
-		session.apigatewayErr = errEmptyBluemixCredentials
-		session.pDNSErr = errEmptyBluemixCredentials
 		session.bmxUserFetchErr = errEmptyBluemixCredentials
-		session.directlinkErr = errEmptyBluemixCredentials
-		session.dlProviderErr = errEmptyBluemixCredentials
-		session.cosConfigErr = errEmptyBluemixCredentials
-		session.transitgatewayErr = errEmptyBluemixCredentials
-		session.functionIAMNamespaceErr = errEmptyBluemixCredentials
-		session.cisDNSErr = errEmptyBluemixCredentials
-		session.cisDNSBulkErr = errEmptyBluemixCredentials
-		session.cisGLBPoolErr = errEmptyBluemixCredentials
-		session.cisGLBErr = errEmptyBluemixCredentials
-		session.cisGLBHealthCheckErr = errEmptyBluemixCredentials
-		session.cisIPErr = errEmptyBluemixCredentials
-		session.cisZonesErr = errEmptyBluemixCredentials
-		session.cisRLErr = errEmptyBluemixCredentials
-		session.cisPageRuleErr = errEmptyBluemixCredentials
-		session.cisEdgeFunctionErr = errEmptyBluemixCredentials
-		session.cisSSLErr = errEmptyBluemixCredentials
-		session.cisWAFPackageErr = errEmptyBluemixCredentials
-		session.cisDomainSettingsErr = errEmptyBluemixCredentials
-		session.cisRoutingErr = errEmptyBluemixCredentials
-		session.cisWAFGroupErr = errEmptyBluemixCredentials
-		session.cisCacheErr = errEmptyBluemixCredentials
-		session.cisCustomPageErr = errEmptyBluemixCredentials
-		session.cisAccessRuleErr = errEmptyBluemixCredentials
-		session.cisUARuleErr = errEmptyBluemixCredentials
-		session.cisLockdownErr = errEmptyBluemixCredentials
-		session.cisRangeAppErr = errEmptyBluemixCredentials
-		session.cisWAFRuleErr = errEmptyBluemixCredentials
-		session.iamIdentityErr = errEmptyBluemixCredentials
-		session.secretsManagerClientErr = errEmptyBluemixCredentials
-		session.cisFiltersErr = errEmptyBluemixCredentials
-		session.schematicsClientErr = errEmptyBluemixCredentials
-		session.satelliteClientErr = errEmptyBluemixCredentials
-		session.iamPolicyManagementErr = errEmptyBluemixCredentials
-
 		return session, nil
 	}
 
 	if sess.BluemixSession.Config.BluemixAPIKey != "" {
+		// the session transport retries the failed requests
 		err = authenticateAPIKey(sess.BluemixSession)
 		if err != nil {
-			for count := c.RetryCount; count >= 0; count-- {
-				if err == nil || !isRetryable(err) {
-					break
-				}
-				time.Sleep(c.RetryDelay)
-				log.Printf("Retrying IAM Authentication %d", count)
-				err = authenticateAPIKey(sess.BluemixSession)
-			}
-			if err != nil {
-				session.bmxUserFetchErr = fmt.Errorf("Error occured while fetching auth key for account user details: %q", err)
-				session.functionConfigErr = fmt.Errorf("Error occured while fetching auth key for function: %q", err)
-				session.powerConfigErr = fmt.Errorf("Error occured while fetching the auth key for power iaas: %q", err)
-				session.ibmpiConfigErr = fmt.Errorf("Error occured while fetching the auth key for power iaas: %q", err)
-			}
-		}
-		err = authenticateCF(sess.BluemixSession)
-		if err != nil {
-			for count := c.RetryCount; count >= 0; count-- {
-				if err == nil || !isRetryable(err) {
-					break
-				}
-				time.Sleep(c.RetryDelay)
-				log.Printf("Retrying CF Authentication %d", count)
-				err = authenticateCF(sess.BluemixSession)
-			}
-			if err != nil {
-				session.functionConfigErr = fmt.Errorf("Error occured while fetching auth key for function: %q", err)
-			}
+			session.bmxUserFetchErr = fmt.Errorf("Error occured while fetching auth key for account user details: %q", err)
+			session.functionConfigErr = fmt.Errorf("Error occured while fetching auth key for function: %q", err)
+			session.powerConfigErr = fmt.Errorf("Error occured while fetching the auth key for power iaas: %q", err)
+			session.ibmpiConfigErr = fmt.Errorf("Error occured while fetching the auth key for power iaas: %q", err)
 		}
 	}
 
-	if sess.BluemixSession.Config.IAMAccessToken != "" && sess.BluemixSession.Config.BluemixAPIKey == "" {
+	if sess.BluemixSession.Config.IAMAccessToken != "" && sess.BluemixSession.Config.BluemixAPIKey == "" && c.tokenSource == nil {
 		err := refreshToken(sess.BluemixSession)
 		if err != nil {
-			for count := c.RetryCount; count >= 0; count-- {
-				if err == nil || !isRetryable(err) {
-					break
-				}
-				time.Sleep(c.RetryDelay)
-				log.Printf("Retrying refresh token %d", count)
-				err = refreshToken(sess.BluemixSession)
-			}
-			if err != nil {
-				return nil, fmt.Errorf("Error occured while refreshing the token: %q", err)
-			}
+			return nil, fmt.Errorf("Error occured while refreshing the token: %q", err)
 		}
 
 	}
-	userConfig, err := fetchUserDetails(sess.BluemixSession, c.RetryCount, c.RetryDelay)
+	if c.BluemixAPIKey != "" {
+		session.authenticator = &core.IamAuthenticator{
+			ApiKey: c.BluemixAPIKey,
+			URL:    c.iamEndpoint() + "/identity/token",
+			Client: c.iamClient(),
+		}
+	} else if c.tokenSource != nil {
+		session.authenticator = &tokenSourceAuthenticator{source: c.tokenSource}
+	} else if strings.HasPrefix(sess.BluemixSession.Config.IAMAccessToken, "Bearer") {
+		session.authenticator = &core.BearerTokenAuthenticator{
+			BearerToken: sess.BluemixSession.Config.IAMAccessToken[7:],
+		}
+	} else {
+		session.authenticator = &core.BearerTokenAuthenticator{
+			BearerToken: sess.BluemixSession.Config.IAMAccessToken,
+		}
+	}
+
+	if c.Assume != nil {
+		assumed := c.newAssumeTokenSource(session.authenticator)
+		token, err := assumed.Token()
+		if err != nil {
+			return nil, err
+		}
+		// every client acts as the assumed profile from here, the IBM Cloud
+		// session must not authenticate with the API key again. The
+		// credentials stay in c and session.authenticator, assumed
+		// exchanges them again when its token expires and the Cloud Foundry
+		// clients still authenticate with the API key.
+		c.assumeSource = assumed
+		sess.BluemixSession.Config.BluemixAPIKey = ""
+		sess.BluemixSession.Config.IAMAccessToken = "Bearer " + token
+		sess.BluemixSession.Config.IAMRefreshToken = ""
+		session.authenticator = &tokenSourceAuthenticator{source: assumed}
+	}
+
+	userConfig, err := fetchUserDetails(sess.BluemixSession, c.newJWKSCache(), c.RetryCount, c.RetryDelay)
 	if err != nil {
 		session.bmxUserFetchErr = fmt.Errorf("Error occured while fetching account user details: %q", err)
+	} else if account := c.expectedAccount(); account != "" && userConfig.userAccount != account {
+		return nil, fmt.Errorf("The IAM token is for account %s, not for the expected account %s", userConfig.userAccount, account)
 	}
 	session.bmxUserDetails = userConfig
 
@@ -1050,155 +1205,188 @@ func (c *Config) ClientSession() (interface{}, error) {
 		sess.SoftLayerSession.IAMRefreshToken = sess.BluemixSession.Config.IAMRefreshToken
 	}
 
-	session.functionClient, session.functionConfigErr = FunctionClient(sess.BluemixSession.Config)
-
 	BluemixRegion = sess.BluemixSession.Config.Region
 
-	accv1API, err := accountv1.New(sess.BluemixSession)
+	return session, nil
+}
+
+// lazy builds a client with init the first time it is requested. Every client
+// needs the IBM Cloud credentials, without them err is set instead.
+func (sess *clientSession) lazy(once *sync.Once, err *error, init func()) {
+	once.Do(func() {
+		if sess.session.BluemixSession == nil {
+			*err = errEmptyBluemixCredentials
+			return
+		}
+		init()
+	})
+}
+
+// authenticateCF fetches the UAA tokens used by the Cloud Foundry and Cloud
+// Functions clients, once.
+func (sess *clientSession) authenticateCF() error {
+	sess.cfAuthOnce.Do(func() {
+		bmxSession := sess.session.BluemixSession
+		apiKey := sess.config.BluemixAPIKey
+		if apiKey == "" {
+			return
+		}
+		sess.cfAuthErr = authenticateCF(bmxSession, apiKey)
+	})
+	return sess.cfAuthErr
+}
+
+func (sess *clientSession) initFunctionClient() {
+	if sess.functionConfigErr != nil {
+		return
+	}
+	if err := sess.authenticateCF(); err != nil {
+		sess.functionConfigErr = fmt.Errorf("Error occured while fetching auth key for function: %q", err)
+		return
+	}
+	sess.functionClient, sess.functionConfigErr = FunctionClient(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_FUNCTIONS_API_ENDPOINT").Config)
+}
+
+func (sess *clientSession) initAccountV1() {
+	accv1API, err := accountv1.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_ACCOUNT_MANAGEMENT_API_ENDPOINT"))
 	if err != nil {
-		session.accountV1ConfigErr = fmt.Errorf("Error occured while configuring Bluemix Accountv1 Service: %q", err)
+		sess.accountV1ConfigErr = fmt.Errorf("Error occured while configuring Bluemix Accountv1 Service: %q", err)
 	}
-	session.bmxAccountv1ServiceAPI = accv1API
+	sess.bmxAccountv1ServiceAPI = accv1API
+}
 
-	accAPI, err := accountv2.New(sess.BluemixSession)
+func (sess *clientSession) initAccount() {
+	accAPI, err := accountv2.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_ACCOUNT_MANAGEMENT_API_ENDPOINT"))
 	if err != nil {
-		session.accountConfigErr = fmt.Errorf("Error occured while configuring  Account Service: %q", err)
+		sess.accountConfigErr = fmt.Errorf("Error occured while configuring  Account Service: %q", err)
 	}
-	session.bmxAccountServiceAPI = accAPI
+	sess.bmxAccountServiceAPI = accAPI
+}
 
-	cfAPI, err := mccpv2.New(sess.BluemixSession)
+func (sess *clientSession) initMccp() {
+	// a failed CF authentication surfaces on the first MCCP call
+	sess.authenticateCF()
+	cfAPI, err := mccpv2.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_MCCP_API_ENDPOINT"))
 	if err != nil {
-		session.cfConfigErr = fmt.Errorf("Error occured while configuring MCCP service: %q", err)
+		sess.cfConfigErr = fmt.Errorf("Error occured while configuring MCCP service: %q", err)
 	}
-	session.cfServiceAPI = cfAPI
+	sess.cfServiceAPI = cfAPI
+}
 
-	clusterAPI, err := containerv1.New(sess.BluemixSession)
+func (sess *clientSession) initContainer() {
+	clusterAPI, err := containerv1.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_CS_API_ENDPOINT"))
 	if err != nil {
-		session.csConfigErr = fmt.Errorf("Error occured while configuring Container Service for K8s cluster: %q", err)
+		sess.csConfigErr = fmt.Errorf("Error occured while configuring Container Service for K8s cluster: %q", err)
 	}
-	session.csServiceAPI = clusterAPI
+	sess.csServiceAPI = clusterAPI
+}
 
-	v2clusterAPI, err := containerv2.New(sess.BluemixSession)
+func (sess *clientSession) initVpcContainer() {
+	v2clusterAPI, err := containerv2.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_CS_API_ENDPOINT"))
 	if err != nil {
-		session.csv2ConfigErr = fmt.Errorf("Error occured while configuring vpc Container Service for K8s cluster: %q", err)
+		sess.csv2ConfigErr = fmt.Errorf("Error occured while configuring vpc Container Service for K8s cluster: %q", err)
 	}
-	session.csv2ServiceAPI = v2clusterAPI
+	sess.csv2ServiceAPI = v2clusterAPI
+}
 
-	hpcsAPI, err := hpcs.New(sess.BluemixSession)
+func (sess *clientSession) initHpcsEndpoint() {
+	hpcsAPI, err := hpcs.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_HPCS_API_ENDPOINT"))
 	if err != nil {
-		session.hpcsEndpointErr = fmt.Errorf("Error occured while configuring hpcs Endpoint: %q", err)
+		sess.hpcsEndpointErr = fmt.Errorf("Error occured while configuring hpcs Endpoint: %q", err)
 	}
-	session.hpcsEndpointAPI = hpcsAPI
+	sess.hpcsEndpointAPI = hpcsAPI
+}
 
+// kpClientConfig returns the Key Protect client configuration of the regional kms endpoint.
+func (sess *clientSession) kpClientConfig() kp.ClientConfig {
+	c := sess.config
 	kpurl := contructEndpoint(fmt.Sprintf("%s.kms", c.Region), cloudEndpoint)
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		kpurl = contructEndpoint(fmt.Sprintf("private.%s.kms", c.Region), cloudEndpoint)
 	}
-	var options kp.ClientConfig
-	if c.BluemixAPIKey != "" {
-		options = kp.ClientConfig{
-			BaseURL: envFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, kpurl),
-			APIKey:  sess.BluemixSession.Config.BluemixAPIKey, //pragma: allowlist secret
-			// InstanceID:    "42fET57nnadurKXzXAedFLOhGqETfIGYxOmQXkFgkJV9",
-			Verbose: kp.VerboseFailOnly,
-		}
-
-	} else {
-		options = kp.ClientConfig{
-			BaseURL:       envFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, kpurl),
-			Authorization: sess.BluemixSession.Config.IAMAccessToken,
-			// InstanceID:    "42fET57nnadurKXzXAedFLOhGqETfIGYxOmQXkFgkJV9",
+	if sess.session.BluemixSession.Config.BluemixAPIKey != "" {
+		return kp.ClientConfig{
+			BaseURL: c.endpointFor("IBMCLOUD_KP_API_ENDPOINT", kpurl),
+			APIKey:  sess.session.BluemixSession.Config.BluemixAPIKey, //pragma: allowlist secret
 			Verbose: kp.VerboseFailOnly,
 		}
 	}
-	kpAPIclient, err := kp.New(options, kp.DefaultTransport())
-	if err != nil {
-		session.kpErr = fmt.Errorf("Error occured while configuring Key Protect Service: %q", err)
+	return kp.ClientConfig{
+		BaseURL:       c.endpointFor("IBMCLOUD_KP_API_ENDPOINT", kpurl),
+		Authorization: sess.session.BluemixSession.Config.IAMAccessToken,
+		Verbose:       kp.VerboseFailOnly,
 	}
-	session.kpAPI = kpAPIclient
-
-	kmsurl := contructEndpoint(fmt.Sprintf("%s.kms", c.Region), cloudEndpoint)
-	if c.Visibility == "private" || c.Visibility == "public-and-private" {
-		kmsurl = contructEndpoint(fmt.Sprintf("private.%s.kms", c.Region), cloudEndpoint)
-	}
-	var kmsOptions kp.ClientConfig
-	if c.BluemixAPIKey != "" {
-		kmsOptions = kp.ClientConfig{
-			BaseURL: envFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, kmsurl),
-			APIKey:  sess.BluemixSession.Config.BluemixAPIKey, //pragma: allowlist secret
-			// InstanceID:    "5af62d5d-5d90-4b84-bbcd-90d2123ae6c8",
-			Verbose: kp.VerboseFailOnly,
-		}
+}
 
-	} else {
-		kmsOptions = kp.ClientConfig{
-			BaseURL:       envFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, kmsurl),
-			Authorization: sess.BluemixSession.Config.IAMAccessToken,
-			// InstanceID:    "5af62d5d-5d90-4b84-bbcd-90d2123ae6c8",
-			Verbose: kp.VerboseFailOnly,
-		}
-	}
-	kmsAPIclient, err := kp.New(kmsOptions, DefaultTransport())
+func (sess *clientSession) initKeyProtect() {
+	kpAPIclient, err := kp.New(sess.kpClientConfig(), sess.config.wrapTransport(kp.DefaultTransport()))
 	if err != nil {
-		session.kmsErr = fmt.Errorf("Error occured while configuring key Service: %q", err)
+		sess.kpErr = fmt.Errorf("Error occured while configuring Key Protect Service: %q", err)
 	}
-	session.kmsAPI = kmsAPIclient
-
-	var authenticator core.Authenticator
+	sess.kpAPI = kpAPIclient
+}
 
-	if c.BluemixAPIKey != "" {
-		iamURL := iamidentity.DefaultServiceURL
-		if c.Visibility == "private" || c.Visibility == "public-and-private" {
-			if c.Region == "us-south" || c.Region == "us-east" {
-				iamURL = contructEndpoint(fmt.Sprintf("private.%s.iam", c.Region), cloudEndpoint)
-			} else {
-				iamURL = contructEndpoint("private.iam", cloudEndpoint)
-			}
-		}
-		authenticator = &core.IamAuthenticator{
-			ApiKey: c.BluemixAPIKey,
-			URL:    envFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamURL) + "/identity/token",
-		}
-	} else if strings.HasPrefix(sess.BluemixSession.Config.IAMAccessToken, "Bearer") {
-		authenticator = &core.BearerTokenAuthenticator{
-			BearerToken: sess.BluemixSession.Config.IAMAccessToken[7:],
-		}
-	} else {
-		authenticator = &core.BearerTokenAuthenticator{
-			BearerToken: sess.BluemixSession.Config.IAMAccessToken,
-		}
+func (sess *clientSession) initKeyManagement() {
+	kmsAPIclient, err := kp.New(sess.kpClientConfig(), sess.config.wrapTransport(DefaultTransport()))
+	if err != nil {
+		sess.kmsErr = fmt.Errorf("Error occured while configuring key Service: %q", err)
 	}
+	sess.kmsAPI = kmsAPIclient
+}
 
+func (sess *clientSession) initAppID() {
+	c := sess.config
 	appIDEndpoint := fmt.Sprintf("https://%s.appid.cloud.ibm.com", c.Region)
 	appIDClientOptions := &appid.AppIDManagementV4Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_APPID_MANAGEMENT_API_ENDPOINT"}, appIDEndpoint),
+		Authenticator: sess.authenticator,
+		URL:           c.endpointFor("IBMCLOUD_APPID_MANAGEMENT_API_ENDPOINT", appIDEndpoint),
 	}
 
 	appIDClient, err := appid.NewAppIDManagementV4(appIDClientOptions)
 
 	if err != nil {
-		session.appidErr = fmt.Errorf("error occured while configuring AppID service: #{err}")
+		sess.appidErr = fmt.Errorf("error occured while configuring AppID service: #{err}")
 	}
 
 	if appIDClient != nil {
-		appIDClient.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries(appIDClient.Service)
 	}
 
-	session.appidAPI = appIDClient
+	sess.appidAPI = appIDClient
+}
 
+func (sess *clientSession) initCatalogManagement() {
+	c := sess.config
 	// Construct an "options" struct for creating the service client.
 	catalogManagementURL := "https://cm.globalcatalog.cloud.ibm.com/api/v1-beta"
 	if c.Visibility == "private" {
-		session.catalogManagementClientErr = fmt.Errorf("Catalog Management resource doesnot support private endpoints")
+		sess.catalogManagementClientErr = fmt.Errorf("Catalog Management resource doesnot support private endpoints")
 	}
 	catalogManagementClientOptions := &catalogmanagementv1.CatalogManagementV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_CATALOG_MANAGEMENT_API_ENDPOINT"}, catalogManagementURL),
-		Authenticator: authenticator,
+		URL:           c.endpointFor("IBMCLOUD_CATALOG_MANAGEMENT_API_ENDPOINT", catalogManagementURL),
+		Authenticator: sess.authenticator,
 	}
 
+	// Construct the service client.
+	var err error
+	sess.catalogManagementClient, err = catalogmanagementv1.NewCatalogManagementV1(catalogManagementClientOptions)
+	if err == nil {
+		// Enable retries for API calls
+		c.enableRetries(sess.catalogManagementClient.Service)
+		// Add custom header for analytics
+		sess.catalogManagementClient.SetDefaultHeaders(gohttp.Header{
+			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
+		})
+	} else {
+		sess.catalogManagementClientErr = fmt.Errorf("Error occurred while configuring Catalog Management API service: %q", err)
+	}
+}
+
+func (sess *clientSession) initAtracker() {
+	c := sess.config
 	// Construct an "options" struct for creating the atracker service client.
 	var atrackerClientURL string
+	var err error
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		atrackerClientURL, err = atrackerv1.GetServiceURLForRegion("private." + c.Region)
 		if err != nil && c.Visibility == "public-and-private" {
@@ -1211,35 +1399,26 @@ func (c *Config) ClientSession() (interface{}, error) {
 		atrackerClientURL = atrackerv1.DefaultServiceURL
 	}
 	atrackerClientOptions := &atrackerv1.AtrackerV1Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_ATRACKER_API_ENDPOINT"}, atrackerClientURL),
+		Authenticator: sess.authenticator,
+		URL:           c.endpointFor("IBMCLOUD_ATRACKER_API_ENDPOINT", atrackerClientURL),
 	}
 
 	// Construct the service client.
-	session.atrackerClient, err = atrackerv1.NewAtrackerV1(atrackerClientOptions)
+	sess.atrackerClient, err = atrackerv1.NewAtrackerV1(atrackerClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.atrackerClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries(sess.atrackerClient.Service)
 		// Add custom header for analytics
-		session.atrackerClient.SetDefaultHeaders(gohttp.Header{
+		sess.atrackerClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
 	} else {
-		session.atrackerClientErr = fmt.Errorf("Error occurred while configuring Activity Tracker API service: %q", err)
+		sess.atrackerClientErr = fmt.Errorf("Error occurred while configuring Activity Tracker API service: %q", err)
 	}
+}
 
-	// Construct the service client.
-	session.catalogManagementClient, err = catalogmanagementv1.NewCatalogManagementV1(catalogManagementClientOptions)
-	if err == nil {
-		// Enable retries for API calls
-		session.catalogManagementClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
-		// Add custom header for analytics
-		session.catalogManagementClient.SetDefaultHeaders(gohttp.Header{
-			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
-		})
-	} else {
-		session.catalogManagementClientErr = fmt.Errorf("Error occurred while configuring Catalog Management API service: %q", err)
-	}
+func (sess *clientSession) initSchematics() {
+	c := sess.config
 	schematicsEndpoint := "https://schematics.cloud.ibm.com"
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		if c.Region == "us-south" || c.Region == "us-east" {
@@ -1251,27 +1430,30 @@ func (c *Config) ClientSession() (interface{}, error) {
 		}
 	}
 	schematicsClientOptions := &schematicsv1.SchematicsV1Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_SCHEMATICS_API_ENDPOINT"}, schematicsEndpoint),
+		Authenticator: sess.authenticator,
+		URL:           c.endpointFor("IBMCLOUD_SCHEMATICS_API_ENDPOINT", schematicsEndpoint),
 	}
 
 	// Construct the service client.
 	schematicsClient, err := schematicsv1.NewSchematicsV1(schematicsClientOptions)
 	// Enable retries for API calls
 	if schematicsClient != nil && schematicsClient.Service != nil {
-		schematicsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries(schematicsClient.Service)
 		if err != nil {
-			session.schematicsClientErr = fmt.Errorf("Error occurred while configuring Schematics Service API service: %q", err)
+			sess.schematicsClientErr = fmt.Errorf("Error occurred while configuring Schematics Service API service: %q", err)
 		}
 	}
-	session.schematicsClient = schematicsClient
+	sess.schematicsClient = schematicsClient
+}
 
+func (sess *clientSession) initVpc() {
+	c := sess.config
 	vpcurl := contructEndpoint(fmt.Sprintf("%s.iaas", c.Region), fmt.Sprintf("%s/v1", cloudEndpoint))
 	if c.Visibility == "private" {
 		if c.Region == "us-south" || c.Region == "us-east" {
 			vpcurl = contructEndpoint(fmt.Sprintf("%s.private.iaas", c.Region), fmt.Sprintf("%s/v1", cloudEndpoint))
 		} else {
-			session.vpcErr = fmt.Errorf("VPC supports private endpoints only in us-south and us-east")
+			sess.vpcErr = fmt.Errorf("VPC supports private endpoints only in us-south and us-east")
 		}
 	}
 	if c.Visibility == "public-and-private" {
@@ -1281,48 +1463,59 @@ func (c *Config) ClientSession() (interface{}, error) {
 		vpcurl = contructEndpoint(fmt.Sprintf("%s.iaas", c.Region), fmt.Sprintf("%s/v1", cloudEndpoint))
 	}
 	vpcoptions := &vpc.VpcV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_IS_NG_API_ENDPOINT"}, vpcurl),
-		Authenticator: authenticator,
+		URL:           c.endpointFor("IBMCLOUD_IS_NG_API_ENDPOINT", vpcurl),
+		Authenticator: sess.authenticator,
 	}
 	vpcclient, err := vpc.NewVpcV1(vpcoptions)
 	if err != nil {
-		session.vpcErr = fmt.Errorf("Error occured while configuring vpc service: %q", err)
+		sess.vpcErr = fmt.Errorf("Error occured while configuring vpc service: %q", err)
 	}
 	if vpcclient != nil && vpcclient.Service != nil {
-		vpcclient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries(vpcclient.Service)
 	}
-	session.vpcAPI = vpcclient
+	sess.vpcAPI = vpcclient
+}
 
+func (sess *clientSession) initPushService() {
+	c := sess.config
 	pnurl := fmt.Sprintf("https://%s.imfpush.cloud.ibm.com/imfpush/v1", c.Region)
 	if c.Visibility == "private" {
-		session.pushServiceClientErr = fmt.Errorf("Push Notifications Service API doesnot support private endpoints")
+		sess.pushServiceClientErr = fmt.Errorf("Push Notifications Service API doesnot support private endpoints")
 	}
 	pushNotificationOptions := &pushservicev1.PushServiceV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_PUSH_API_ENDPOINT"}, pnurl),
-		Authenticator: authenticator,
+		URL:           c.endpointFor("IBMCLOUD_PUSH_API_ENDPOINT", pnurl),
+		Authenticator: sess.authenticator,
 	}
 	pnclient, err := pushservicev1.NewPushServiceV1(pushNotificationOptions)
 	if pnclient != nil {
 		// Enable retries for API calls
-		pnclient.EnableRetries(c.RetryCount, c.RetryDelay)
-		session.pushServiceClient = pnclient
+		c.enableRetries(pnclient.Service)
+		sess.pushServiceClient = pnclient
 	} else {
-		session.pushServiceClientErr = fmt.Errorf("Error occured while configuring push notification service: %q", err)
+		sess.pushServiceClientErr = fmt.Errorf("Error occured while configuring push notification service: %q", err)
 	}
+}
+
+func (sess *clientSession) initAppConfiguration() {
+	c := sess.config
 	if c.Visibility == "private" {
-		session.appConfigurationClientErr = fmt.Errorf("App Configuration Service API doesnot support private endpoints")
+		sess.appConfigurationClientErr = fmt.Errorf("App Configuration Service API doesnot support private endpoints")
 	}
 	appConfigurationClientOptions := &appconfigurationv1.AppConfigurationV1Options{
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
 	appConfigClient, err := appconfigurationv1.NewAppConfigurationV1(appConfigurationClientOptions)
 	if appConfigClient != nil {
 		// Enable retries for API calls
-		appConfigClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
-		session.appConfigurationClient = appConfigClient
+		c.enableRetries(appConfigClient.Service)
+		sess.appConfigurationClient = appConfigClient
 	} else {
-		session.appConfigurationClientErr = fmt.Errorf("Error occurred while configuring App Configuration service: %q", err)
+		sess.appConfigurationClientErr = fmt.Errorf("Error occurred while configuring App Configuration service: %q", err)
 	}
+}
+
+func (sess *clientSession) initContainerRegistry() {
+	c := sess.config
 	// Construct an "options" struct for creating the service client.
 	containerRegistryClientURL, err := containerregistryv1.GetServiceURLForRegion(c.Region)
 	if err != nil {
@@ -1335,53 +1528,67 @@ func (c *Config) ClientSession() (interface{}, error) {
 		}
 	}
 	containerRegistryClientOptions := &containerregistryv1.ContainerRegistryV1Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_CR_API_ENDPOINT"}, containerRegistryClientURL),
-		Account:       core.StringPtr(userConfig.userAccount),
+		Authenticator: sess.authenticator,
+		URL:           c.endpointFor("IBMCLOUD_CR_API_ENDPOINT", containerRegistryClientURL),
+		Account:       core.StringPtr(sess.bmxUserDetails.userAccount),
 	}
 
 	// Construct the service client.
-	session.containerRegistryClient, err = containerregistryv1.NewContainerRegistryV1(containerRegistryClientOptions)
+	sess.containerRegistryClient, err = containerregistryv1.NewContainerRegistryV1(containerRegistryClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.containerRegistryClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries(sess.containerRegistryClient.Service)
 		// Add custom header for analytics
-		session.containerRegistryClient.SetDefaultHeaders(gohttp.Header{
+		sess.containerRegistryClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
 	} else {
-		session.containerRegistryClientErr = fmt.Errorf("Error occurred while configuring IBM Cloud Container Registry API service: %q", err)
+		sess.containerRegistryClientErr = fmt.Errorf("Error occurred while configuring IBM Cloud Container Registry API service: %q", err)
 	}
+}
 
-	//cosconfigurl := fmt.Sprintf("https://%s.iaas.cloud.ibm.com/v1", c.Region)
+func (sess *clientSession) initCosConfig() {
+	c := sess.config
 	cosconfigoptions := &cosconfig.ResourceConfigurationV1Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_COS_CONFIG_ENDPOINT"}, "https://config.cloud-object-storage.cloud.ibm.com/v1"),
+		Authenticator: sess.authenticator,
+		URL:           c.endpointFor("IBMCLOUD_COS_CONFIG_ENDPOINT", "https://config.cloud-object-storage.cloud.ibm.com/v1"),
 	}
 	cosconfigclient, err := cosconfig.NewResourceConfigurationV1(cosconfigoptions)
 	if err != nil {
-		session.cosConfigErr = fmt.Errorf("Error occured while configuring COS config service: %q", err)
+		sess.cosConfigErr = fmt.Errorf("Error occured while configuring COS config service: %q", err)
+	}
+	if cosconfigclient != nil && cosconfigclient.Service != nil {
+		c.enableRetries(cosconfigclient.Service)
 	}
-	session.cosConfigAPI = cosconfigclient
+	sess.cosConfigAPI = cosconfigclient
+}
 
-	cisAPI, err := cisv1.New(sess.BluemixSession)
+func (sess *clientSession) initCis() {
+	cisAPI, err := cisv1.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_CIS_API_ENDPOINT"))
 	if err != nil {
-		session.cisConfigErr = fmt.Errorf("Error occured while configuring Cloud Internet Services: %q", err)
+		sess.cisConfigErr = fmt.Errorf("Error occured while configuring Cloud Internet Services: %q", err)
 	}
-	session.cisServiceAPI = cisAPI
+	sess.cisServiceAPI = cisAPI
+}
 
-	globalSearchAPI, err := globalsearchv2.New(sess.BluemixSession)
+func (sess *clientSession) initGlobalSearch() {
+	globalSearchAPI, err := globalsearchv2.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_GS_API_ENDPOINT"))
 	if err != nil {
-		session.globalSearchConfigErr = fmt.Errorf("Error occured while configuring Global Search: %q", err)
+		sess.globalSearchConfigErr = fmt.Errorf("Error occured while configuring Global Search: %q", err)
 	}
-	session.globalSearchServiceAPI = globalSearchAPI
+	sess.globalSearchServiceAPI = globalSearchAPI
+}
 
-	globalTaggingAPI, err := globaltaggingv3.New(sess.BluemixSession)
+func (sess *clientSession) initGlobalTagging() {
+	globalTaggingAPI, err := globaltaggingv3.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_GT_API_ENDPOINT"))
 	if err != nil {
-		session.globalTaggingConfigErr = fmt.Errorf("Error occured while configuring Global Tagging: %q", err)
+		sess.globalTaggingConfigErr = fmt.Errorf("Error occured while configuring Global Tagging: %q", err)
 	}
-	session.globalTaggingServiceAPI = globalTaggingAPI
+	sess.globalTaggingServiceAPI = globalTaggingAPI
+}
 
+func (sess *clientSession) initGlobalTaggingV1() {
+	c := sess.config
 	globalTaggingEndpoint := "https://tags.global-search-tagging.cloud.ibm.com"
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		var globalTaggingRegion string
@@ -1394,594 +1601,784 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 
 	globalTaggingV1Options := &globaltaggingv1.GlobalTaggingV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_GT_API_ENDPOINT"}, globalTaggingEndpoint),
-		Authenticator: authenticator,
+		URL:           c.endpointFor("IBMCLOUD_GT_API_ENDPOINT", globalTaggingEndpoint),
+		Authenticator: sess.authenticator,
 	}
 
 	globalTaggingAPIV1, err := globaltaggingv1.NewGlobalTaggingV1(globalTaggingV1Options)
 	if err != nil {
-		session.globalTaggingConfigErrV1 = fmt.Errorf("Error occured while configuring Global Tagging: %q", err)
+		sess.globalTaggingConfigErrV1 = fmt.Errorf("Error occured while configuring Global Tagging: %q", err)
 	}
 	if globalTaggingAPIV1 != nil {
-		session.globalTaggingServiceAPIV1 = *globalTaggingAPIV1
-		session.globalTaggingServiceAPIV1.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		sess.globalTaggingServiceAPIV1 = *globalTaggingAPIV1
+		c.enableRetries(sess.globalTaggingServiceAPIV1.Service)
 	}
+}
 
-	iamuumv2, err := iamuumv2.New(sess.BluemixSession)
+func (sess *clientSession) initIAMUUMV2() {
+	iamuumAPI, err := iamuumv2.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_IAM_API_ENDPOINT"))
 	if err != nil {
-		session.iamUUMConfigErrV2 = fmt.Errorf("Error occured while configuring Bluemix IAMUUM Service: %q", err)
+		sess.iamUUMConfigErrV2 = fmt.Errorf("Error occured while configuring Bluemix IAMUUM Service: %q", err)
 	}
-	session.iamUUMServiceAPIV2 = iamuumv2
+	sess.iamUUMServiceAPIV2 = iamuumAPI
+}
 
-	icdAPI, err := icdv4.New(sess.BluemixSession)
+func (sess *clientSession) initICD() {
+	icdAPI, err := icdv4.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_ICD_API_ENDPOINT"))
 	if err != nil {
-		session.icdConfigErr = fmt.Errorf("Error occured while configuring IBM Cloud Database Services: %q", err)
+		sess.icdConfigErr = fmt.Errorf("Error occured while configuring IBM Cloud Database Services: %q", err)
 	}
-	session.icdServiceAPI = icdAPI
+	sess.icdServiceAPI = icdAPI
+}
 
-	resourceCatalogAPI, err := catalog.New(sess.BluemixSession)
+func (sess *clientSession) initResourceCatalog() {
+	resourceCatalogAPI, err := catalog.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_RESOURCE_CATALOG_API_ENDPOINT"))
 	if err != nil {
-		session.resourceCatalogConfigErr = fmt.Errorf("Error occured while configuring Resource Catalog service: %q", err)
+		sess.resourceCatalogConfigErr = fmt.Errorf("Error occured while configuring Resource Catalog service: %q", err)
 	}
-	session.resourceCatalogServiceAPI = resourceCatalogAPI
+	sess.resourceCatalogServiceAPI = resourceCatalogAPI
+}
 
-	resourceManagementAPIv2, err := managementv2.New(sess.BluemixSession)
+func (sess *clientSession) initResourceManagementV2() {
+	resourceManagementAPIv2, err := managementv2.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_RESOURCE_MANAGEMENT_API_ENDPOINT"))
 	if err != nil {
-		session.resourceManagementConfigErrv2 = fmt.Errorf("Error occured while configuring Resource Management service: %q", err)
+		sess.resourceManagementConfigErrv2 = fmt.Errorf("Error occured while configuring Resource Management service: %q", err)
 	}
-	session.resourceManagementServiceAPIv2 = resourceManagementAPIv2
+	sess.resourceManagementServiceAPIv2 = resourceManagementAPIv2
+}
 
-	resourceControllerAPI, err := controller.New(sess.BluemixSession)
+func (sess *clientSession) initResourceControllerV1() {
+	resourceControllerAPI, err := controller.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT"))
 	if err != nil {
-		session.resourceControllerConfigErr = fmt.Errorf("Error occured while configuring Resource Controller service: %q", err)
+		sess.resourceControllerConfigErr = fmt.Errorf("Error occured while configuring Resource Controller service: %q", err)
 	}
-	session.resourceControllerServiceAPI = resourceControllerAPI
+	sess.resourceControllerServiceAPI = resourceControllerAPI
+}
 
-	ResourceControllerAPIv2, err := controllerv2.New(sess.BluemixSession)
+func (sess *clientSession) initResourceControllerV2() {
+	ResourceControllerAPIv2, err := controllerv2.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT"))
 	if err != nil {
-		session.resourceControllerConfigErrv2 = fmt.Errorf("Error occured while configuring Resource Controller v2 service: %q", err)
+		sess.resourceControllerConfigErrv2 = fmt.Errorf("Error occured while configuring Resource Controller v2 service: %q", err)
 	}
-	session.resourceControllerServiceAPIv2 = ResourceControllerAPIv2
+	sess.resourceControllerServiceAPIv2 = ResourceControllerAPIv2
+}
 
-	userManagementAPI, err := usermanagementv2.New(sess.BluemixSession)
+func (sess *clientSession) initUserManagement() {
+	userManagementAPI, err := usermanagementv2.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_USER_MANAGEMENT_ENDPOINT"))
 	if err != nil {
-		session.userManagementErr = fmt.Errorf("Error occured while configuring user management service: %q", err)
+		sess.userManagementErr = fmt.Errorf("Error occured while configuring user management service: %q", err)
 	}
-	session.userManagementAPI = userManagementAPI
-	certManagementAPI, err := certificatemanager.New(sess.BluemixSession)
+	sess.userManagementAPI = userManagementAPI
+}
+
+func (sess *clientSession) initCertManagement() {
+	certManagementAPI, err := certificatemanager.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_CERTIFICATE_MANAGER_API_ENDPOINT"))
 	if err != nil {
-		session.certManagementErr = fmt.Errorf("Error occured while configuring Certificate manager service: %q", err)
+		sess.certManagementErr = fmt.Errorf("Error occured while configuring Certificate manager service: %q", err)
 	}
-	session.certManagementAPI = certManagementAPI
+	sess.certManagementAPI = certManagementAPI
+}
 
-	namespaceFunction, err := functions.New(sess.BluemixSession)
+func (sess *clientSession) initFunctionIAMNamespace() {
+	namespaceFunction, err := functions.New(sess.config.bluemixSessionFor(sess.session.BluemixSession, "IBMCLOUD_FUNCTIONS_API_ENDPOINT"))
 	if err != nil {
-		session.functionIAMNamespaceErr = fmt.Errorf("Error occured while configuring Cloud Funciton Service : %q", err)
+		sess.functionIAMNamespaceErr = fmt.Errorf("Error occured while configuring Cloud Funciton Service : %q", err)
 	}
-	session.functionIAMNamespaceAPI = namespaceFunction
+	sess.functionIAMNamespaceAPI = namespaceFunction
+}
 
+func (sess *clientSession) initAPIGateway() {
+	c := sess.config
 	apicurl := contructEndpoint(fmt.Sprintf("api.%s.apigw", c.Region), fmt.Sprintf("%s/controller", cloudEndpoint))
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		apicurl = contructEndpoint(fmt.Sprintf("api.private.%s.apigw", c.Region), fmt.Sprintf("%s/controller", cloudEndpoint))
 	}
 	APIGatewayControllerAPIV1Options := &apigateway.ApiGatewayControllerApiV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_API_GATEWAY_ENDPOINT"}, apicurl),
+		URL:           c.endpointFor("IBMCLOUD_API_GATEWAY_ENDPOINT", apicurl),
 		Authenticator: &core.NoAuthAuthenticator{},
 	}
 	apigatewayAPI, err := apigateway.NewApiGatewayControllerApiV1(APIGatewayControllerAPIV1Options)
 	if err != nil {
-		session.apigatewayErr = fmt.Errorf("Error occured while configuring  APIGateway service: %q", err)
+		sess.apigatewayErr = fmt.Errorf("Error occured while configuring  APIGateway service: %q", err)
 	}
-	session.apigatewayAPI = apigatewayAPI
+	if apigatewayAPI != nil && apigatewayAPI.Service != nil {
+		c.enableRetries(apigatewayAPI.Service)
+	}
+	sess.apigatewayAPI = apigatewayAPI
+}
 
-	ibmpisession, err := ibmpisession.New(sess.BluemixSession.Config.IAMAccessToken, c.Region, false, 90000000000, session.bmxUserDetails.userAccount, c.Zone)
+func (sess *clientSession) initIBMPI() {
+	c := sess.config
+	ibmpisession, err := ibmpisession.New(sess.session.BluemixSession.Config.IAMAccessToken, c.Region, false, 90000000000, sess.bmxUserDetails.userAccount, c.Zone)
 	if err != nil {
-		session.ibmpiConfigErr = err
-		return nil, err
+		sess.ibmpiConfigErr = err
+		sess.powerConfigErr = err
+		return
+	}
+	// power-go-client only honours IBMCLOUD_POWER_API_ENDPOINT, so point its transport at its endpoint override if there is one
+	if transport, ok := ibmpisession.Power.Transport.(*httptransport.Runtime); ok {
+		if powerEndpoint := c.endpointFor("IBMCLOUD_POWER_API_ENDPOINT", ""); powerEndpoint != "" {
+			transport.Host = powerEndpoint
+		}
+		transport.Transport = c.retryTransport(c.wrapTransport(transport.Transport))
 	}
 
-	session.ibmpiSession = ibmpisession
+	sess.ibmpiSession = ibmpisession
+}
 
+func (sess *clientSession) initPrivateDNS() {
+	c := sess.config
 	pdnsURL := dns.DefaultServiceURL
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		pdnsURL = contructEndpoint("api.private.dns-svcs", fmt.Sprintf("%s/v1", cloudEndpoint))
 	}
 	dnsOptions := &dns.DnsSvcsV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_PRIVATE_DNS_API_ENDPOINT"}, pdnsURL),
-		Authenticator: authenticator,
+		URL:           c.endpointFor("IBMCLOUD_PRIVATE_DNS_API_ENDPOINT", pdnsURL),
+		Authenticator: sess.authenticator,
 	}
 
-	session.pDNSClient, session.pDNSErr = dns.NewDnsSvcsV1(dnsOptions)
-	if session.pDNSErr != nil {
-		session.pDNSErr = fmt.Errorf("Error occured while configuring PrivateDNS Service: %s", session.pDNSErr)
+	sess.pDNSClient, sess.pDNSErr = dns.NewDnsSvcsV1(dnsOptions)
+	if sess.pDNSErr != nil {
+		sess.pDNSErr = fmt.Errorf("Error occured while configuring PrivateDNS Service: %s", sess.pDNSErr)
 	}
-	if session.pDNSClient != nil && session.pDNSClient.Service != nil {
-		session.pDNSClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.pDNSClient != nil && sess.pDNSClient.Service != nil {
+		c.enableRetries(sess.pDNSClient.Service)
 	}
+}
 
+func (sess *clientSession) initDirectlink() {
+	c := sess.config
 	ver := time.Now().Format("2006-01-02")
-
 	dlURL := dl.DefaultServiceURL
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		dlURL = contructEndpoint("private.directlink", fmt.Sprintf("%s/v1", cloudEndpoint))
 	}
 	directlinkOptions := &dl.DirectLinkV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_DL_API_ENDPOINT"}, dlURL),
-		Authenticator: authenticator,
+		URL:           c.endpointFor("IBMCLOUD_DL_API_ENDPOINT", dlURL),
+		Authenticator: sess.authenticator,
 		Version:       &ver,
 	}
 
-	session.directlinkAPI, session.directlinkErr = dl.NewDirectLinkV1(directlinkOptions)
-	if session.directlinkErr != nil {
-		session.directlinkErr = fmt.Errorf("Error occured while configuring Direct Link Service: %s", session.directlinkErr)
+	sess.directlinkAPI, sess.directlinkErr = dl.NewDirectLinkV1(directlinkOptions)
+	if sess.directlinkErr != nil {
+		sess.directlinkErr = fmt.Errorf("Error occured while configuring Direct Link Service: %s", sess.directlinkErr)
 	}
-	if session.directlinkAPI != nil && session.directlinkAPI.Service != nil {
-		session.directlinkAPI.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.directlinkAPI != nil && sess.directlinkAPI.Service != nil {
+		c.enableRetries(sess.directlinkAPI.Service)
 	}
+}
 
-	//Direct link provider
+func (sess *clientSession) initDirectlinkProvider() {
+	c := sess.config
+	ver := time.Now().Format("2006-01-02")
 	dlproviderURL := dlProviderV2.DefaultServiceURL
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		dlproviderURL = contructEndpoint("private.directlink", fmt.Sprintf("%s/provider/v2", cloudEndpoint))
 	}
 	directLinkProviderV2Options := &dlProviderV2.DirectLinkProviderV2Options{
-		URL:           envFallBack([]string{"IBMCLOUD_DL_PROVIDER_API_ENDPOINT"}, dlproviderURL),
-		Authenticator: authenticator,
+		URL:           c.endpointFor("IBMCLOUD_DL_PROVIDER_API_ENDPOINT", dlproviderURL),
+		Authenticator: sess.authenticator,
 		Version:       &ver,
 	}
 
-	session.dlProviderAPI, session.dlProviderErr = dlProviderV2.NewDirectLinkProviderV2(directLinkProviderV2Options)
-	if session.dlProviderErr != nil {
-		session.dlProviderErr = fmt.Errorf("Error occured while configuring Direct Link Provider Service: %s", session.dlProviderErr)
+	sess.dlProviderAPI, sess.dlProviderErr = dlProviderV2.NewDirectLinkProviderV2(directLinkProviderV2Options)
+	if sess.dlProviderErr != nil {
+		sess.dlProviderErr = fmt.Errorf("Error occured while configuring Direct Link Provider Service: %s", sess.dlProviderErr)
 	}
-	if session.dlProviderAPI != nil && session.dlProviderAPI.Service != nil {
-		session.dlProviderAPI.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.dlProviderAPI != nil && sess.dlProviderAPI.Service != nil {
+		c.enableRetries(sess.dlProviderAPI.Service)
 	}
+}
 
+func (sess *clientSession) initTransitGateway() {
+	c := sess.config
 	tgURL := tg.DefaultServiceURL
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		tgURL = contructEndpoint("private.transit", fmt.Sprintf("%s/v1", cloudEndpoint))
 	}
 	transitgatewayOptions := &tg.TransitGatewayApisV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_TG_API_ENDPOINT"}, tgURL),
-		Authenticator: authenticator,
+		URL:           c.endpointFor("IBMCLOUD_TG_API_ENDPOINT", tgURL),
+		Authenticator: sess.authenticator,
 		Version:       CreateVersionDate(),
 	}
 
-	session.transitgatewayAPI, session.transitgatewayErr = tg.NewTransitGatewayApisV1(transitgatewayOptions)
-	if session.transitgatewayErr != nil {
-		session.transitgatewayErr = fmt.Errorf("Error occured while configuring Transit Gateway Service: %s", session.transitgatewayErr)
+	sess.transitgatewayAPI, sess.transitgatewayErr = tg.NewTransitGatewayApisV1(transitgatewayOptions)
+	if sess.transitgatewayErr != nil {
+		sess.transitgatewayErr = fmt.Errorf("Error occured while configuring Transit Gateway Service: %s", sess.transitgatewayErr)
 	}
-	if session.transitgatewayAPI != nil && session.transitgatewayAPI.Service != nil {
-		session.transitgatewayAPI.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.transitgatewayAPI != nil && sess.transitgatewayAPI.Service != nil {
+		c.enableRetries(sess.transitgatewayAPI.Service)
 	}
+}
 
-	// CIS Service instances starts here.
-	cisURL := contructEndpoint("api.cis", cloudEndpoint)
-	if c.Visibility == "private" {
-		// cisURL = contructEndpoint("api.private.cis", cloudEndpoint)
-		session.cisZonesErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisDNSBulkErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisGLBPoolErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisGLBErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisGLBHealthCheckErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisIPErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisRLErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisPageRuleErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisEdgeFunctionErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisSSLErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisWAFPackageErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisDomainSettingsErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisRoutingErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisWAFGroupErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisCacheErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisCustomPageErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisAccessRuleErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisUARuleErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisLockdownErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisRangeAppErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisWAFRuleErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-		session.cisFiltersErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
-	}
-	cisEndPoint := envFallBack([]string{"IBMCLOUD_CIS_API_ENDPOINT"}, cisURL)
+// cisEndpoint returns the endpoint of the CIS services, which have no private endpoints.
+func (sess *clientSession) cisEndpoint() (string, error) {
+	if sess.config.Visibility == "private" {
+		return "", fmt.Errorf("CIS Service doesnt support private endpoints.")
+	}
+	return sess.config.endpointFor("IBMCLOUD_CIS_API_ENDPOINT", contructEndpoint("api.cis", cloudEndpoint)), nil
+}
 
+func (sess *clientSession) initCisZones() {
 	// IBM Network CIS Zones service
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisZonesErr = err
+		return
+	}
 	cisZonesV1Opt := &ciszonesv1.ZonesV1Options{
 		URL:           cisEndPoint,
 		Crn:           core.StringPtr(""),
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
-	session.cisZonesV1Client, session.cisZonesErr = ciszonesv1.NewZonesV1(cisZonesV1Opt)
-	if session.cisZonesErr != nil {
-		session.cisZonesErr = fmt.Errorf(
+	sess.cisZonesV1Client, sess.cisZonesErr = ciszonesv1.NewZonesV1(cisZonesV1Opt)
+	if sess.cisZonesErr != nil {
+		sess.cisZonesErr = fmt.Errorf(
 			"Error occured while configuring CIS Zones service: %s",
-			session.cisZonesErr)
+			sess.cisZonesErr)
 	}
-	if session.cisZonesV1Client != nil && session.cisZonesV1Client.Service != nil {
-		session.cisZonesV1Client.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisZonesV1Client != nil && sess.cisZonesV1Client.Service != nil {
+		sess.config.enableRetries(sess.cisZonesV1Client.Service)
 	}
+}
 
+func (sess *clientSession) initCisDNSRecords() {
 	// IBM Network CIS DNS Record service
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisDNSErr = err
+		return
+	}
 	cisDNSRecordsOpt := &cisdnsrecordsv1.DnsRecordsV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
-	session.cisDNSRecordsClient, session.cisDNSErr = cisdnsrecordsv1.NewDnsRecordsV1(cisDNSRecordsOpt)
-	if session.cisDNSErr != nil {
-		session.cisDNSErr = fmt.Errorf("Error occured while configuring CIS DNS Service: %s", session.cisDNSErr)
+	sess.cisDNSRecordsClient, sess.cisDNSErr = cisdnsrecordsv1.NewDnsRecordsV1(cisDNSRecordsOpt)
+	if sess.cisDNSErr != nil {
+		sess.cisDNSErr = fmt.Errorf("Error occured while configuring CIS DNS Service: %s", sess.cisDNSErr)
 	}
-	if session.cisDNSRecordsClient != nil && session.cisDNSRecordsClient.Service != nil {
-		session.cisDNSRecordsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisDNSRecordsClient != nil && sess.cisDNSRecordsClient.Service != nil {
+		sess.config.enableRetries(sess.cisDNSRecordsClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisDNSRecordBulk() {
 	// IBM Network CIS DNS Record bulk service
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisDNSBulkErr = err
+		return
+	}
 	cisDNSRecordBulkOpt := &cisdnsbulkv1.DnsRecordBulkV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
-	session.cisDNSRecordBulkClient, session.cisDNSBulkErr = cisdnsbulkv1.NewDnsRecordBulkV1(cisDNSRecordBulkOpt)
-	if session.cisDNSBulkErr != nil {
-		session.cisDNSBulkErr = fmt.Errorf(
+	sess.cisDNSRecordBulkClient, sess.cisDNSBulkErr = cisdnsbulkv1.NewDnsRecordBulkV1(cisDNSRecordBulkOpt)
+	if sess.cisDNSBulkErr != nil {
+		sess.cisDNSBulkErr = fmt.Errorf(
 			"Error occured while configuration CIS DNS bulk service : %s",
-			session.cisDNSBulkErr)
+			sess.cisDNSBulkErr)
 	}
-	if session.cisDNSRecordBulkClient != nil && session.cisDNSRecordBulkClient.Service != nil {
-		session.cisDNSRecordBulkClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisDNSRecordBulkClient != nil && sess.cisDNSRecordBulkClient.Service != nil {
+		sess.config.enableRetries(sess.cisDNSRecordBulkClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisGLBPool() {
 	// IBM Network CIS Global load balancer pool
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisGLBPoolErr = err
+		return
+	}
 	cisGLBPoolOpt := &cisglbpoolv0.GlobalLoadBalancerPoolsV0Options{
 		URL:           cisEndPoint,
 		Crn:           core.StringPtr(""),
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
-	session.cisGLBPoolClient, session.cisGLBPoolErr =
+	sess.cisGLBPoolClient, sess.cisGLBPoolErr =
 		cisglbpoolv0.NewGlobalLoadBalancerPoolsV0(cisGLBPoolOpt)
-	if session.cisGLBPoolErr != nil {
-		session.cisGLBPoolErr =
+	if sess.cisGLBPoolErr != nil {
+		sess.cisGLBPoolErr =
 			fmt.Errorf("Error occured while configuring CIS GLB Pool service: %s",
-				session.cisGLBPoolErr)
+				sess.cisGLBPoolErr)
 	}
-	if session.cisGLBPoolClient != nil && session.cisGLBPoolClient.Service != nil {
-		session.cisGLBPoolClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisGLBPoolClient != nil && sess.cisGLBPoolClient.Service != nil {
+		sess.config.enableRetries(sess.cisGLBPoolClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisGLB() {
 	// IBM Network CIS Global load balancer
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisGLBErr = err
+		return
+	}
 	cisGLBOpt := &cisglbv1.GlobalLoadBalancerV1Options{
 		URL:            cisEndPoint,
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
 	}
-	session.cisGLBClient, session.cisGLBErr = cisglbv1.NewGlobalLoadBalancerV1(cisGLBOpt)
-	if session.cisGLBErr != nil {
-		session.cisGLBErr =
+	sess.cisGLBClient, sess.cisGLBErr = cisglbv1.NewGlobalLoadBalancerV1(cisGLBOpt)
+	if sess.cisGLBErr != nil {
+		sess.cisGLBErr =
 			fmt.Errorf("Error occured while configuring CIS GLB service: %s",
-				session.cisGLBErr)
+				sess.cisGLBErr)
 	}
-	if session.cisGLBClient != nil && session.cisGLBClient.Service != nil {
-		session.cisGLBClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisGLBClient != nil && sess.cisGLBClient.Service != nil {
+		sess.config.enableRetries(sess.cisGLBClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisGLBHealthCheck() {
 	// IBM Network CIS Global load balancer health check/monitor
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisGLBHealthCheckErr = err
+		return
+	}
 	cisGLBHealthCheckOpt := &cisglbhealthcheckv1.GlobalLoadBalancerMonitorV1Options{
 		URL:           cisEndPoint,
 		Crn:           core.StringPtr(""),
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
-	session.cisGLBHealthCheckClient, session.cisGLBHealthCheckErr =
+	sess.cisGLBHealthCheckClient, sess.cisGLBHealthCheckErr =
 		cisglbhealthcheckv1.NewGlobalLoadBalancerMonitorV1(cisGLBHealthCheckOpt)
-	if session.cisGLBHealthCheckErr != nil {
-		session.cisGLBHealthCheckErr =
+	if sess.cisGLBHealthCheckErr != nil {
+		sess.cisGLBHealthCheckErr =
 			fmt.Errorf("Error occured while configuring CIS GLB Health Check service: %s",
-				session.cisGLBHealthCheckErr)
+				sess.cisGLBHealthCheckErr)
 	}
-	if session.cisGLBHealthCheckClient != nil && session.cisGLBHealthCheckClient.Service != nil {
-		session.cisGLBHealthCheckClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisGLBHealthCheckClient != nil && sess.cisGLBHealthCheckClient.Service != nil {
+		sess.config.enableRetries(sess.cisGLBHealthCheckClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisIP() {
 	// IBM Network CIS IP
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisIPErr = err
+		return
+	}
 	cisIPOpt := &cisipv1.CisIpApiV1Options{
 		URL:           cisEndPoint,
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
-	session.cisIPClient, session.cisIPErr = cisipv1.NewCisIpApiV1(cisIPOpt)
-	if session.cisIPErr != nil {
-		session.cisIPErr = fmt.Errorf("Error occured while configuring CIS IP service: %s",
-			session.cisIPErr)
+	sess.cisIPClient, sess.cisIPErr = cisipv1.NewCisIpApiV1(cisIPOpt)
+	if sess.cisIPErr != nil {
+		sess.cisIPErr = fmt.Errorf("Error occured while configuring CIS IP service: %s",
+			sess.cisIPErr)
 	}
-	if session.cisIPClient != nil && session.cisIPClient.Service != nil {
-		session.cisIPClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisIPClient != nil && sess.cisIPClient.Service != nil {
+		sess.config.enableRetries(sess.cisIPClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisRL() {
 	// IBM Network CIS Zone Rate Limit
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisRLErr = err
+		return
+	}
 	cisRLOpt := &cisratelimitv1.ZoneRateLimitsV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
-	session.cisRLClient, session.cisRLErr = cisratelimitv1.NewZoneRateLimitsV1(cisRLOpt)
-	if session.cisRLErr != nil {
-		session.cisRLErr = fmt.Errorf(
+	sess.cisRLClient, sess.cisRLErr = cisratelimitv1.NewZoneRateLimitsV1(cisRLOpt)
+	if sess.cisRLErr != nil {
+		sess.cisRLErr = fmt.Errorf(
 			"Error occured while cofiguring CIS Zone Rate Limit service: %s",
-			session.cisRLErr)
+			sess.cisRLErr)
 	}
-	if session.cisRLClient != nil && session.cisRLClient.Service != nil {
-		session.cisRLClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisRLClient != nil && sess.cisRLClient.Service != nil {
+		sess.config.enableRetries(sess.cisRLClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisPageRule() {
 	// IBM Network CIS Page Rules
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisPageRuleErr = err
+		return
+	}
 	cisPageRuleOpt := &cispagerulev1.PageRuleApiV1Options{
 		URL:           cisEndPoint,
 		Crn:           core.StringPtr(""),
 		ZoneID:        core.StringPtr(""),
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
-	session.cisPageRuleClient, session.cisPageRuleErr = cispagerulev1.NewPageRuleApiV1(cisPageRuleOpt)
-	if session.cisPageRuleErr != nil {
-		session.cisPageRuleErr = fmt.Errorf(
+	sess.cisPageRuleClient, sess.cisPageRuleErr = cispagerulev1.NewPageRuleApiV1(cisPageRuleOpt)
+	if sess.cisPageRuleErr != nil {
+		sess.cisPageRuleErr = fmt.Errorf(
 			"Error occured while cofiguring CIS Page Rule service: %s",
-			session.cisPageRuleErr)
+			sess.cisPageRuleErr)
 	}
-	if session.cisPageRuleClient != nil && session.cisPageRuleClient.Service != nil {
-		session.cisPageRuleClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisPageRuleClient != nil && sess.cisPageRuleClient.Service != nil {
+		sess.config.enableRetries(sess.cisPageRuleClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisEdgeFunction() {
 	// IBM Network CIS Edge Function
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisEdgeFunctionErr = err
+		return
+	}
 	cisEdgeFunctionOpt := &cisedgefunctionv1.EdgeFunctionsApiV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
-	session.cisEdgeFunctionClient, session.cisEdgeFunctionErr =
+	sess.cisEdgeFunctionClient, sess.cisEdgeFunctionErr =
 		cisedgefunctionv1.NewEdgeFunctionsApiV1(cisEdgeFunctionOpt)
-	if session.cisEdgeFunctionErr != nil {
-		session.cisEdgeFunctionErr =
+	if sess.cisEdgeFunctionErr != nil {
+		sess.cisEdgeFunctionErr =
 			fmt.Errorf("Error occured while configuring CIS Edge Function service: %s",
-				session.cisEdgeFunctionErr)
+				sess.cisEdgeFunctionErr)
 	}
-	if session.cisEdgeFunctionClient != nil && session.cisEdgeFunctionClient.Service != nil {
-		session.cisEdgeFunctionClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisEdgeFunctionClient != nil && sess.cisEdgeFunctionClient.Service != nil {
+		sess.config.enableRetries(sess.cisEdgeFunctionClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisSSL() {
 	// IBM Network CIS SSL certificate
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisSSLErr = err
+		return
+	}
 	cisSSLOpt := &cissslv1.SslCertificateApiV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
 
-	session.cisSSLClient, session.cisSSLErr = cissslv1.NewSslCertificateApiV1(cisSSLOpt)
-	if session.cisSSLErr != nil {
-		session.cisSSLErr =
+	sess.cisSSLClient, sess.cisSSLErr = cissslv1.NewSslCertificateApiV1(cisSSLOpt)
+	if sess.cisSSLErr != nil {
+		sess.cisSSLErr =
 			fmt.Errorf("Error occured while configuring CIS SSL certificate service: %s",
-				session.cisSSLErr)
+				sess.cisSSLErr)
 	}
-	if session.cisSSLClient != nil && session.cisSSLClient.Service != nil {
-		session.cisSSLClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisSSLClient != nil && sess.cisSSLClient.Service != nil {
+		sess.config.enableRetries(sess.cisSSLClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisWAFPackage() {
 	// IBM Network CIS WAF Package
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisWAFPackageErr = err
+		return
+	}
 	cisWAFPackageOpt := &ciswafpackagev1.WafRulePackagesApiV1Options{
 		URL:           cisEndPoint,
 		Crn:           core.StringPtr(""),
 		ZoneID:        core.StringPtr(""),
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
-	session.cisWAFPackageClient, session.cisWAFPackageErr =
+	sess.cisWAFPackageClient, sess.cisWAFPackageErr =
 		ciswafpackagev1.NewWafRulePackagesApiV1(cisWAFPackageOpt)
-	if session.cisWAFPackageErr != nil {
-		session.cisWAFPackageErr =
+	if sess.cisWAFPackageErr != nil {
+		sess.cisWAFPackageErr =
 			fmt.Errorf("Error occured while configuration CIS WAF Package service: %s",
-				session.cisWAFPackageErr)
+				sess.cisWAFPackageErr)
 	}
-	if session.cisWAFPackageClient != nil && session.cisWAFPackageClient.Service != nil {
-		session.cisWAFPackageClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisWAFPackageClient != nil && sess.cisWAFPackageClient.Service != nil {
+		sess.config.enableRetries(sess.cisWAFPackageClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisDomainSettings() {
 	// IBM Network CIS Domain settings
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisDomainSettingsErr = err
+		return
+	}
 	cisDomainSettingsOpt := &cisdomainsettingsv1.ZonesSettingsV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
-	session.cisDomainSettingsClient, session.cisDomainSettingsErr =
+	sess.cisDomainSettingsClient, sess.cisDomainSettingsErr =
 		cisdomainsettingsv1.NewZonesSettingsV1(cisDomainSettingsOpt)
-	if session.cisDomainSettingsErr != nil {
-		session.cisDomainSettingsErr =
+	if sess.cisDomainSettingsErr != nil {
+		sess.cisDomainSettingsErr =
 			fmt.Errorf("Error occured while configuring CIS Domain Settings service: %s",
-				session.cisDomainSettingsErr)
+				sess.cisDomainSettingsErr)
 	}
-	if session.cisDomainSettingsClient != nil && session.cisDomainSettingsClient.Service != nil {
-		session.cisDomainSettingsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisDomainSettingsClient != nil && sess.cisDomainSettingsClient.Service != nil {
+		sess.config.enableRetries(sess.cisDomainSettingsClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisRouting() {
 	// IBM Network CIS Routing
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisRoutingErr = err
+		return
+	}
 	cisRoutingOpt := &cisroutingv1.RoutingV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
-	session.cisRoutingClient, session.cisRoutingErr =
+	sess.cisRoutingClient, sess.cisRoutingErr =
 		cisroutingv1.NewRoutingV1(cisRoutingOpt)
-	if session.cisRoutingErr != nil {
-		session.cisRoutingErr =
+	if sess.cisRoutingErr != nil {
+		sess.cisRoutingErr =
 			fmt.Errorf("Error occured while configuring CIS Routing service: %s",
-				session.cisRoutingErr)
+				sess.cisRoutingErr)
 	}
-	if session.cisRoutingClient != nil && session.cisRoutingClient.Service != nil {
-		session.cisRoutingClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisRoutingClient != nil && sess.cisRoutingClient.Service != nil {
+		sess.config.enableRetries(sess.cisRoutingClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisWAFGroup() {
 	// IBM Network CIS WAF Group
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisWAFGroupErr = err
+		return
+	}
 	cisWAFGroupOpt := &ciswafgroupv1.WafRuleGroupsApiV1Options{
 		URL:           cisEndPoint,
 		Crn:           core.StringPtr(""),
 		ZoneID:        core.StringPtr(""),
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
-	session.cisWAFGroupClient, session.cisWAFGroupErr =
+	sess.cisWAFGroupClient, sess.cisWAFGroupErr =
 		ciswafgroupv1.NewWafRuleGroupsApiV1(cisWAFGroupOpt)
-	if session.cisWAFGroupErr != nil {
-		session.cisWAFGroupErr =
+	if sess.cisWAFGroupErr != nil {
+		sess.cisWAFGroupErr =
 			fmt.Errorf("Error occured while configuring CIS WAF Group service: %s",
-				session.cisWAFGroupErr)
+				sess.cisWAFGroupErr)
 	}
-	if session.cisWAFGroupClient != nil && session.cisWAFGroupClient.Service != nil {
-		session.cisWAFGroupClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisWAFGroupClient != nil && sess.cisWAFGroupClient.Service != nil {
+		sess.config.enableRetries(sess.cisWAFGroupClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisCache() {
 	// IBM Network CIS Cache service
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisCacheErr = err
+		return
+	}
 	cisCacheOpt := &ciscachev1.CachingApiV1Options{
 		URL:           cisEndPoint,
 		Crn:           core.StringPtr(""),
 		ZoneID:        core.StringPtr(""),
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
-	session.cisCacheClient, session.cisCacheErr =
+	sess.cisCacheClient, sess.cisCacheErr =
 		ciscachev1.NewCachingApiV1(cisCacheOpt)
-	if session.cisCacheErr != nil {
-		session.cisCacheErr =
+	if sess.cisCacheErr != nil {
+		sess.cisCacheErr =
 			fmt.Errorf("Error occured while configuring CIS Caching service: %s",
-				session.cisCacheErr)
+				sess.cisCacheErr)
 	}
-	if session.cisCacheClient != nil && session.cisCacheClient.Service != nil {
-		session.cisCacheClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisCacheClient != nil && sess.cisCacheClient.Service != nil {
+		sess.config.enableRetries(sess.cisCacheClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisCustomPage() {
 	// IBM Network CIS Custom pages service
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisCustomPageErr = err
+		return
+	}
 	cisCustomPageOpt := &ciscustompagev1.CustomPagesV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
 
-	session.cisCustomPageClient, session.cisCustomPageErr =
+	sess.cisCustomPageClient, sess.cisCustomPageErr =
 		ciscustompagev1.NewCustomPagesV1(cisCustomPageOpt)
-	if session.cisCustomPageErr != nil {
-		session.cisCustomPageErr =
+	if sess.cisCustomPageErr != nil {
+		sess.cisCustomPageErr =
 			fmt.Errorf("Error occured while configuring CIS Custom Pages service: %s",
-				session.cisCustomPageErr)
+				sess.cisCustomPageErr)
 	}
-	if session.cisCustomPageClient != nil && session.cisCustomPageClient.Service != nil {
-		session.cisCustomPageClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisCustomPageClient != nil && sess.cisCustomPageClient.Service != nil {
+		sess.config.enableRetries(sess.cisCustomPageClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisAccessRule() {
 	// IBM Network CIS Firewall Access rule
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisAccessRuleErr = err
+		return
+	}
 	cisAccessRuleOpt := &cisaccessrulev1.ZoneFirewallAccessRulesV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
-	session.cisAccessRuleClient, session.cisAccessRuleErr =
+	sess.cisAccessRuleClient, sess.cisAccessRuleErr =
 		cisaccessrulev1.NewZoneFirewallAccessRulesV1(cisAccessRuleOpt)
-	if session.cisAccessRuleErr != nil {
-		session.cisAccessRuleErr =
+	if sess.cisAccessRuleErr != nil {
+		sess.cisAccessRuleErr =
 			fmt.Errorf("Error occured while configuring CIS Firewall Access Rule service: %s",
-				session.cisAccessRuleErr)
+				sess.cisAccessRuleErr)
 	}
-	if session.cisAccessRuleClient != nil && session.cisAccessRuleClient.Service != nil {
-		session.cisAccessRuleClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisAccessRuleClient != nil && sess.cisAccessRuleClient.Service != nil {
+		sess.config.enableRetries(sess.cisAccessRuleClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisUARule() {
 	// IBM Network CIS Firewall User Agent Blocking rule
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisUARuleErr = err
+		return
+	}
 	cisUARuleOpt := &cisuarulev1.UserAgentBlockingRulesV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
-	session.cisUARuleClient, session.cisUARuleErr =
+	sess.cisUARuleClient, sess.cisUARuleErr =
 		cisuarulev1.NewUserAgentBlockingRulesV1(cisUARuleOpt)
-	if session.cisUARuleErr != nil {
-		session.cisUARuleErr =
+	if sess.cisUARuleErr != nil {
+		sess.cisUARuleErr =
 			fmt.Errorf("Error occured while configuring CIS Firewall User Agent Blocking Rule service: %s",
-				session.cisUARuleErr)
+				sess.cisUARuleErr)
 	}
-	if session.cisUARuleClient != nil && session.cisUARuleClient.Service != nil {
-		session.cisUARuleClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisUARuleClient != nil && sess.cisUARuleClient.Service != nil {
+		sess.config.enableRetries(sess.cisUARuleClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisLockdown() {
 	// IBM Network CIS Firewall Lockdown rule
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisLockdownErr = err
+		return
+	}
 	cisLockdownOpt := &cislockdownv1.ZoneLockdownV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
-	session.cisLockdownClient, session.cisLockdownErr =
+	sess.cisLockdownClient, sess.cisLockdownErr =
 		cislockdownv1.NewZoneLockdownV1(cisLockdownOpt)
-	if session.cisLockdownErr != nil {
-		session.cisLockdownErr =
+	if sess.cisLockdownErr != nil {
+		sess.cisLockdownErr =
 			fmt.Errorf("Error occured while configuring CIS Firewall Lockdown Rule service: %s",
-				session.cisLockdownErr)
+				sess.cisLockdownErr)
 	}
-	if session.cisLockdownClient != nil && session.cisLockdownClient.Service != nil {
-		session.cisLockdownClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisLockdownClient != nil && sess.cisLockdownClient.Service != nil {
+		sess.config.enableRetries(sess.cisLockdownClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisRangeApp() {
 	// IBM Network CIS Range Application rule
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisRangeAppErr = err
+		return
+	}
 	cisRangeAppOpt := &cisrangeappv1.RangeApplicationsV1Options{
 		URL:            cisEndPoint,
 		Crn:            core.StringPtr(""),
 		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
+		Authenticator:  sess.authenticator,
 	}
-	session.cisRangeAppClient, session.cisRangeAppErr =
+	sess.cisRangeAppClient, sess.cisRangeAppErr =
 		cisrangeappv1.NewRangeApplicationsV1(cisRangeAppOpt)
-	if session.cisRangeAppErr != nil {
-		session.cisRangeAppErr =
+	if sess.cisRangeAppErr != nil {
+		sess.cisRangeAppErr =
 			fmt.Errorf("Error occured while configuring CIS Range Application rule service: %s",
-				session.cisRangeAppErr)
+				sess.cisRangeAppErr)
 	}
-	if session.cisRangeAppClient != nil && session.cisRangeAppClient.Service != nil {
-		session.cisRangeAppClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisRangeAppClient != nil && sess.cisRangeAppClient.Service != nil {
+		sess.config.enableRetries(sess.cisRangeAppClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisWAFRule() {
 	// IBM Network CIS WAF Rule Service
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisWAFRuleErr = err
+		return
+	}
 	cisWAFRuleOpt := &ciswafrulev1.WafRulesApiV1Options{
 		URL:           cisEndPoint,
 		Crn:           core.StringPtr(""),
 		ZoneID:        core.StringPtr(""),
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
-	session.cisWAFRuleClient, session.cisWAFRuleErr =
+	sess.cisWAFRuleClient, sess.cisWAFRuleErr =
 		ciswafrulev1.NewWafRulesApiV1(cisWAFRuleOpt)
-	if session.cisWAFRuleErr != nil {
-		session.cisWAFRuleErr = fmt.Errorf(
+	if sess.cisWAFRuleErr != nil {
+		sess.cisWAFRuleErr = fmt.Errorf(
 			"Error occured while configuring CIS WAF Rules service: %s",
-			session.cisWAFRuleErr)
+			sess.cisWAFRuleErr)
 	}
-	if session.cisWAFRuleClient != nil && session.cisWAFRuleClient.Service != nil {
-		session.cisWAFRuleClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisWAFRuleClient != nil && sess.cisWAFRuleClient.Service != nil {
+		sess.config.enableRetries(sess.cisWAFRuleClient.Service)
 	}
+}
 
+func (sess *clientSession) initCisFilters() {
 	// IBM Network CIS Filters
+	cisEndPoint, err := sess.cisEndpoint()
+	if err != nil {
+		sess.cisFiltersErr = err
+		return
+	}
 	cisFiltersOpt := &cisfiltersv1.FiltersV1Options{
 		URL:           cisEndPoint,
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
-	session.cisFiltersClient, session.cisFiltersErr = cisfiltersv1.NewFiltersV1(cisFiltersOpt)
-	if session.cisFiltersErr != nil {
-		session.cisFiltersErr =
+	sess.cisFiltersClient, sess.cisFiltersErr = cisfiltersv1.NewFiltersV1(cisFiltersOpt)
+	if sess.cisFiltersErr != nil {
+		sess.cisFiltersErr =
 			fmt.Errorf("Error occured while configuring CIS Filters : %s",
-				session.cisFiltersErr)
+				sess.cisFiltersErr)
 	}
-	if session.cisFiltersClient != nil && session.cisFiltersClient.Service != nil {
-		session.cisFiltersClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+	if sess.cisFiltersClient != nil && sess.cisFiltersClient.Service != nil {
+		sess.config.enableRetries(sess.cisFiltersClient.Service)
 	}
+}
 
+func (sess *clientSession) initIAMIdentity() {
+	c := sess.config
 	// iamIdenityURL := fmt.Sprintf("https://%s.iam.cloud.ibm.com/v1", c.Region)
 	iamURL := iamidentity.DefaultServiceURL
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
@@ -1992,18 +2389,21 @@ func (c *Config) ClientSession() (interface{}, error) {
 		}
 	}
 	iamIdentityOptions := &iamidentity.IamIdentityV1Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamURL),
+		Authenticator: sess.authenticator,
+		URL:           c.endpointFor("IBMCLOUD_IAM_API_ENDPOINT", iamURL),
 	}
 	iamIdentityClient, err := iamidentity.NewIamIdentityV1(iamIdentityOptions)
 	if err != nil {
-		session.iamIdentityErr = fmt.Errorf("Error occured while configuring IAM Identity service: %q", err)
+		sess.iamIdentityErr = fmt.Errorf("Error occured while configuring IAM Identity service: %q", err)
 	}
 	if iamIdentityClient != nil && iamIdentityClient.Service != nil {
-		iamIdentityClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries(iamIdentityClient.Service)
 	}
-	session.iamIdentityAPI = iamIdentityClient
+	sess.iamIdentityAPI = iamIdentityClient
+}
 
+func (sess *clientSession) initIAMPolicyManagement() {
+	c := sess.config
 	iamPolicyManagementURL := iampolicymanagement.DefaultServiceURL
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		if c.Region == "us-south" || c.Region == "us-east" {
@@ -2013,18 +2413,21 @@ func (c *Config) ClientSession() (interface{}, error) {
 		}
 	}
 	iamPolicyManagementOptions := &iampolicymanagement.IamPolicyManagementV1Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamPolicyManagementURL),
+		Authenticator: sess.authenticator,
+		URL:           c.endpointFor("IBMCLOUD_IAM_API_ENDPOINT", iamPolicyManagementURL),
 	}
 	iamPolicyManagementClient, err := iampolicymanagement.NewIamPolicyManagementV1(iamPolicyManagementOptions)
 	if err != nil {
-		session.iamPolicyManagementErr = fmt.Errorf("Error occured while configuring IAM Policy Management service: %q", err)
+		sess.iamPolicyManagementErr = fmt.Errorf("Error occured while configuring IAM Policy Management service: %q", err)
 	}
 	if iamPolicyManagementClient != nil && iamPolicyManagementClient.Service != nil {
-		iamPolicyManagementClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries(iamPolicyManagementClient.Service)
 	}
-	session.iamPolicyManagementAPI = iamPolicyManagementClient
+	sess.iamPolicyManagementAPI = iamPolicyManagementClient
+}
 
+func (sess *clientSession) initResourceManager() {
+	c := sess.config
 	rmURL := resourcemanager.DefaultServiceURL
 	if c.Visibility == "private" {
 		if c.Region == "us-south" || c.Region == "us-east" {
@@ -2042,18 +2445,21 @@ func (c *Config) ClientSession() (interface{}, error) {
 		}
 	}
 	resourceManagerOptions := &resourcemanager.ResourceManagerV2Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_RESOURCE_MANAGEMENT_API_ENDPOINT"}, rmURL),
+		Authenticator: sess.authenticator,
+		URL:           c.endpointFor("IBMCLOUD_RESOURCE_MANAGEMENT_API_ENDPOINT", rmURL),
 	}
 	resourceManagerClient, err := resourcemanager.NewResourceManagerV2(resourceManagerOptions)
 	if err != nil {
-		session.resourceManagerErr = fmt.Errorf("Error occured while configuring Resource Manager service: %q", err)
+		sess.resourceManagerErr = fmt.Errorf("Error occured while configuring Resource Manager service: %q", err)
 	}
 	if resourceManagerClient != nil {
-		resourceManagerClient.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries(resourceManagerClient.Service)
 	}
-	session.resourceManagerAPI = resourceManagerClient
+	sess.resourceManagerAPI = resourceManagerClient
+}
 
+func (sess *clientSession) initEnterpriseManagement() {
+	c := sess.config
 	enterpriseURL := enterprisemanagementv1.DefaultServiceURL
 	if c.Visibility == "private" {
 		if c.Region == "us-south" || c.Region == "us-east" || c.Region == "eu-fr" {
@@ -2072,17 +2478,20 @@ func (c *Config) ClientSession() (interface{}, error) {
 		}
 	}
 	enterpriseManagementClientOptions := &enterprisemanagementv1.EnterpriseManagementV1Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_ENTERPRISE_API_ENDPOINT"}, enterpriseURL),
+		Authenticator: sess.authenticator,
+		URL:           c.endpointFor("IBMCLOUD_ENTERPRISE_API_ENDPOINT", enterpriseURL),
 	}
 	enterpriseManagementClient, err := enterprisemanagementv1.NewEnterpriseManagementV1(enterpriseManagementClientOptions)
 	if err == nil {
-		enterpriseManagementClient.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries(enterpriseManagementClient.Service)
 	} else {
-		session.enterpriseManagementClientErr = fmt.Errorf("Error occurred while configuring IBM Cloud Enterprise Management API service: %q", err)
+		sess.enterpriseManagementClientErr = fmt.Errorf("Error occurred while configuring IBM Cloud Enterprise Management API service: %q", err)
 	}
-	session.enterpriseManagementClient = enterpriseManagementClient
+	sess.enterpriseManagementClient = enterpriseManagementClient
+}
 
+func (sess *clientSession) initResourceController() {
+	c := sess.config
 	// resource controller API
 	rcURL := resourcecontroller.DefaultServiceURL
 	if c.Visibility == "private" {
@@ -2101,54 +2510,60 @@ func (c *Config) ClientSession() (interface{}, error) {
 		}
 	}
 	resourceControllerOptions := &resourcecontroller.ResourceControllerV2Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT"}, rcURL),
+		Authenticator: sess.authenticator,
+		URL:           c.endpointFor("IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT", rcURL),
 	}
 	resourceControllerClient, err := resourcecontroller.NewResourceControllerV2(resourceControllerOptions)
 	if err != nil {
-		session.resourceControllerErr = fmt.Errorf("Error occured while configuring Resource Controller service: %q", err)
+		sess.resourceControllerErr = fmt.Errorf("Error occured while configuring Resource Controller service: %q", err)
 	}
 	if resourceControllerClient != nil {
-		resourceControllerClient.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries(resourceControllerClient.Service)
 	}
-	session.resourceControllerAPI = resourceControllerClient
-	// var authenticator2 *core.BearerTokenAuthenticator
+	sess.resourceControllerAPI = resourceControllerClient
+}
+
+func (sess *clientSession) initSecretsManager() {
 	// Construct an "options" struct for creating the service client.
 	secretsManagerClientOptions := &secretsmanagerv1.SecretsManagerV1Options{
-		Authenticator: authenticator,
+		Authenticator: sess.authenticator,
 	}
 
 	/// Construct the service client.
-	session.secretsManagerClient, err = secretsmanagerv1.NewSecretsManagerV1(secretsManagerClientOptions)
+	var err error
+	sess.secretsManagerClient, err = secretsmanagerv1.NewSecretsManagerV1(secretsManagerClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.secretsManagerClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		sess.config.enableRetries(sess.secretsManagerClient.Service)
 		// Add custom header for analytics
-		session.secretsManagerClient.SetDefaultHeaders(gohttp.Header{
+		sess.secretsManagerClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
 	} else {
-		session.secretsManagerClientErr = fmt.Errorf("Error occurred while configuring IBM Cloud Secrets Manager API service: %q", err)
+		sess.secretsManagerClientErr = fmt.Errorf("Error occurred while configuring IBM Cloud Secrets Manager API service: %q", err)
 	}
+}
 
+func (sess *clientSession) initSatellite() {
+	c := sess.config
 	containerEndpoint := kubernetesserviceapiv1.DefaultServiceURL
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		containerEndpoint = contructEndpoint(fmt.Sprintf("private.%s.containers", c.Region), fmt.Sprintf("%s/global", cloudEndpoint))
 	}
 
 	kubernetesServiceV1Options := &kubernetesserviceapiv1.KubernetesServiceApiV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_SATELLITE_API_ENDPOINT"}, containerEndpoint),
-		Authenticator: authenticator,
+		URL:           c.endpointFor("IBMCLOUD_SATELLITE_API_ENDPOINT", containerEndpoint),
+		Authenticator: sess.authenticator,
 	}
 
-	session.satelliteClient, err = kubernetesserviceapiv1.NewKubernetesServiceApiV1(kubernetesServiceV1Options)
+	var err error
+	sess.satelliteClient, err = kubernetesserviceapiv1.NewKubernetesServiceApiV1(kubernetesServiceV1Options)
 	if err != nil {
-		session.satelliteClientErr = fmt.Errorf("Error occured while configuring satellite client: %q", err)
+		sess.satelliteClientErr = fmt.Errorf("Error occured while configuring satellite client: %q", err)
+		return
 	}
 	// Enable retries for API calls
-	session.satelliteClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
-
-	return session, nil
+	c.enableRetries(sess.satelliteClient.Service)
 }
 
 // CreateVersionDate requires mandatory version attribute. Any date from 2019-12-13 up to the currentdate may be provided. Specify the current date to request the latest version.
@@ -2160,20 +2575,39 @@ func CreateVersionDate() *string {
 func newSession(c *Config) (*Session, error) {
 	ibmSession := &Session{}
 
+	softlayerEndpoint := c.SoftLayerEndpointURL
+	if softlayerEndpoint == "" || softlayerEndpoint == SoftlayerRestEndpoint {
+		softlayerEndpoint = c.endpointFor("IBMCLOUD_SL_API_ENDPOINT", softlayerEndpoint)
+	}
 	softlayerSession := &slsession.Session{
-		Endpoint:  c.SoftLayerEndpointURL,
-		Timeout:   c.SoftLayerTimeout,
-		UserName:  c.SoftLayerUserName,
-		APIKey:    c.SoftLayerAPIKey,
-		Debug:     os.Getenv("TF_LOG") != "",
-		Retries:   c.RetryCount,
-		RetryWait: c.RetryDelay,
+		Endpoint: softlayerEndpoint,
+		Timeout:  c.SoftLayerTimeout,
+		UserName: c.SoftLayerUserName,
+		APIKey:   c.SoftLayerAPIKey,
+		// with http_logging, the logging transport logs the requests with
+		// their credentials redacted instead of the session
+		Debug: os.Getenv("TF_LOG") != "" && !c.HTTPLogging,
+		// the retry transport applies the provider retry policy, the
+		// session does not retry itself
+		HTTPClient: &gohttp.Client{
+			Transport: c.retryTransport(c.wrapTransport(DefaultTransport())),
+		},
+	}
+
+	iamToken, iamRefreshToken := c.IAMToken, c.IAMRefreshToken
+	if c.tokenSource != nil {
+		token, err := c.tokenSource.Token()
+		if err != nil {
+			return nil, err
+		}
+		// tokenSourceTransport renews the token, there is no refresh token
+		iamToken, iamRefreshToken = "Bearer "+token, ""
 	}
 
-	if c.IAMToken != "" {
+	if iamToken != "" {
 		log.Println("Configuring SoftLayer Session with token")
-		softlayerSession.IAMToken = c.IAMToken
-		softlayerSession.IAMRefreshToken = c.IAMRefreshToken
+		softlayerSession.IAMToken = iamToken
+		softlayerSession.IAMRefreshToken = iamRefreshToken
 	}
 	if c.SoftLayerAPIKey != "" && c.SoftLayerUserName != "" {
 		log.Println("Configuring SoftLayer Session with API key")
@@ -2187,21 +2621,24 @@ func newSession(c *Config) (*Session, error) {
 		return nil, fmt.Errorf("iam_token and iam_refresh_token must be provided")
 	}
 
-	if c.IAMToken != "" && c.IAMRefreshToken != "" {
+	if (c.IAMToken != "" && c.IAMRefreshToken != "") || c.tokenSource != nil {
 		log.Println("Configuring IBM Cloud Session with token")
 		var sess *bxsession.Session
 		bmxConfig := &bluemix.Config{
-			IAMAccessToken:  c.IAMToken,
-			IAMRefreshToken: c.IAMRefreshToken,
-			//Comment out debug mode for v0.12
-			//Debug:         os.Getenv("TF_LOG") != "",
-			HTTPTimeout:   c.BluemixTimeout,
-			Region:        c.Region,
-			ResourceGroup: c.ResourceGroup,
-			RetryDelay:    &c.RetryDelay,
-			MaxRetries:    &c.RetryCount,
-			Visibility:    c.Visibility,
+			IAMAccessToken:  iamToken,
+			IAMRefreshToken: iamRefreshToken,
+			HTTPTimeout:     c.BluemixTimeout,
+			Region:          c.Region,
+			ResourceGroup:   c.ResourceGroup,
+			MaxRetries:      helpers.Int(0),
+			Visibility:      c.Visibility,
+		}
+		if iamEndpoint := c.endpointFor("IBMCLOUD_IAM_API_ENDPOINT", ""); iamEndpoint != "" {
+			bmxConfig.TokenProviderEndpoint = &iamEndpoint
 		}
+		bmxConfig.HTTPClient = http.NewHTTPClient(bmxConfig)
+		// the retry transport applies the provider retry policy, the clients do not retry themselves
+		bmxConfig.HTTPClient.Transport = c.retryTransport(c.wrapTransport(bmxConfig.HTTPClient.Transport))
 		sess, err := bxsession.New(bmxConfig)
 		if err != nil {
 			return nil, err
@@ -2214,16 +2651,19 @@ func newSession(c *Config) (*Session, error) {
 		var sess *bxsession.Session
 		bmxConfig := &bluemix.Config{
 			BluemixAPIKey: c.BluemixAPIKey,
-			//Comment out debug mode for v0.12
-			//Debug:         os.Getenv("TF_LOG") != "",
 			HTTPTimeout:   c.BluemixTimeout,
 			Region:        c.Region,
 			ResourceGroup: c.ResourceGroup,
-			RetryDelay:    &c.RetryDelay,
-			MaxRetries:    &c.RetryCount,
+			MaxRetries:    helpers.Int(0),
 			Visibility:    c.Visibility,
 			//PowerServiceInstance: c.PowerServiceInstance,
 		}
+		if iamEndpoint := c.endpointFor("IBMCLOUD_IAM_API_ENDPOINT", ""); iamEndpoint != "" {
+			bmxConfig.TokenProviderEndpoint = &iamEndpoint
+		}
+		bmxConfig.HTTPClient = http.NewHTTPClient(bmxConfig)
+		// the retry transport applies the provider retry policy, the clients do not retry themselves
+		bmxConfig.HTTPClient.Transport = c.retryTransport(c.wrapTransport(bmxConfig.HTTPClient.Transport))
 		sess, err := bxsession.New(bmxConfig)
 		if err != nil {
 			return nil, err
@@ -2240,6 +2680,7 @@ func authenticateAPIKey(sess *bxsession.Session) error {
 		DefaultHeader: gohttp.Header{
 			"User-Agent": []string{http.UserAgent()},
 		},
+		HTTPClient: config.HTTPClient,
 	})
 	if err != nil {
 		return err
@@ -2247,20 +2688,21 @@ func authenticateAPIKey(sess *bxsession.Session) error {
 	return tokenRefresher.AuthenticateAPIKey(config.BluemixAPIKey)
 }
 
-func authenticateCF(sess *bxsession.Session) error {
+func authenticateCF(sess *bxsession.Session, apiKey string) error {
 	config := sess.Config
 	tokenRefresher, err := authentication.NewUAARepository(config, &rest.Client{
 		DefaultHeader: gohttp.Header{
 			"User-Agent": []string{http.UserAgent()},
 		},
+		HTTPClient: config.HTTPClient,
 	})
 	if err != nil {
 		return err
 	}
-	return tokenRefresher.AuthenticateAPIKey(config.BluemixAPIKey)
+	return tokenRefresher.AuthenticateAPIKey(apiKey)
 }
 
-func fetchUserDetails(sess *bxsession.Session, retries int, retryDelay time.Duration) (*UserConfig, error) {
+func fetchUserDetails(sess *bxsession.Session, keys *jwksCache, retries int, retryDelay time.Duration) (*UserConfig, error) {
 	config := sess.Config
 	user := UserConfig{}
 	var bluemixToken string
@@ -2271,28 +2713,36 @@ func fetchUserDetails(sess *bxsession.Session, retries int, retryDelay time.Dura
 		bluemixToken = config.IAMAccessToken
 	}
 
-	token, err := jwt.Parse(bluemixToken, func(token *jwt.Token) (interface{}, error) {
-		return "", nil
-	})
-	//TODO validate with key
-	if err != nil && !strings.Contains(err.Error(), "key is of invalid type") {
+	claims, err := verifyIAMToken(bluemixToken, keys)
+	if err != nil {
+		if _, ok := err.(*unknownIAMKeyError); ok {
+			return &user, err
+		}
 		if retries > 0 {
 			if config.BluemixAPIKey != "" {
 				time.Sleep(retryDelay)
 				log.Printf("Retrying authentication for user details %d", retries)
 				_ = authenticateAPIKey(sess)
-				return fetchUserDetails(sess, retries-1, retryDelay)
+				return fetchUserDetails(sess, keys, retries-1, retryDelay)
 			}
 		}
 		return &user, err
 	}
-	claims := token.Claims.(jwt.MapClaims)
-	if email, ok := claims["email"]; ok {
-		user.userEmail = email.(string)
+	if email, ok := claims["email"].(string); ok {
+		user.userEmail = email
+	}
+	var ok bool
+	if user.userID, ok = claims["id"].(string); !ok {
+		return &user, fmt.Errorf("The IAM token has no id claim")
+	}
+	account, _ := claims["account"].(map[string]interface{})
+	if user.userAccount, ok = account["bss"].(string); !ok {
+		return &user, fmt.Errorf("The IAM token has no account.bss claim, it is not scoped to an account")
+	}
+	iss, ok := claims["iss"].(string)
+	if !ok {
+		return &user, fmt.Errorf("The IAM token has no iss claim")
 	}
-	user.userID = claims["id"].(string)
-	user.userAccount = claims["account"].(map[string]interface{})["bss"].(string)
-	iss := claims["iss"].(string)
 	if strings.Contains(iss, "https://iam.cloud.ibm.com") {
 		user.cloudName = "bluemix"
 	} else {
@@ -2310,6 +2760,7 @@ func refreshToken(sess *bxsession.Session) error {
 		DefaultHeader: gohttp.Header{
 			"User-Agent": []string{http.UserAgent()},
 		},
+		HTTPClient: config.HTTPClient,
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,377 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	gohttp "net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	// DefaultCRTokenFilePath is where IBM Cloud compute resources mount their compute resource token
+	DefaultCRTokenFilePath = "/var/run/secrets/tokens/vault-token"
+
+	crTokenGrantType = "urn:ibm:params:oauth:grant-type:cr-token"
+	assumeGrantType  = "urn:ibm:params:oauth:grant-type:assume"
+
+	// tokens are renewed when they expire in less than this
+	tokenExpiryWindow = 5 * time.Minute
+)
+
+// AssumeProfile is the trusted profile, possibly in another account, the
+// provider assumes with the token of its credentials.
+type AssumeProfile struct {
+	// ProfileID, ProfileCRN or ProfileName and AccountID select the profile
+	ProfileID   string
+	ProfileCRN  string
+	ProfileName string
+	AccountID   string
+}
+
+// expandAssumeProfile returns the trusted profile of the provider assume
+// block, or nil when the block is not set.
+func expandAssumeProfile(l []interface{}) *AssumeProfile {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	m := l[0].(map[string]interface{})
+	return &AssumeProfile{
+		ProfileID:   m["profile_id"].(string),
+		ProfileCRN:  m["profile_crn"].(string),
+		ProfileName: m["profile_name"].(string),
+		AccountID:   m["account_id"].(string),
+	}
+}
+
+// iamTokenSource provides the IAM access token of the provider when it
+// authenticates with a trusted profile or with a token file, or when it
+// assumes a trusted profile. The token is cached and renewed shortly before it
+// expires, re-reading the token file so the short lived tokens rotated by the
+// compute resource are picked up.
+type iamTokenSource struct {
+	// profileID, profileCRN or profileName and accountID select the trusted
+	// profile the token is exchanged for. Without them tokenFile holds an IAM
+	// access token that is used as is.
+	profileID   string
+	profileCRN  string
+	profileName string
+	accountID   string
+	tokenFile   string
+
+	// base returns the IAM access token of the caller when the trusted
+	// profile is assumed. Otherwise the compute resource token is read from
+	// tokenFile.
+	base func() (string, error)
+
+	iamURL string
+	client *gohttp.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+	// first is the token the sessions were configured with, the first one
+	// returned, see tokenSourceTransport. Only it and the current token are
+	// kept, the renewed tokens in between are never sent by a session.
+	first string
+}
+
+// iamTokenResponse is the response of the IAM token API
+type iamTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Expiration  int64  `json:"expiration"`
+}
+
+// newIAMTokenSource returns the token source of the trusted profile or token
+// file settings of c, or nil when they are not set.
+func (c *Config) newIAMTokenSource() *iamTokenSource {
+	if c.IAMProfileID == "" && c.IAMProfileName == "" && c.IAMTokenFile == "" {
+		return nil
+	}
+	ts := &iamTokenSource{
+		profileID:   c.IAMProfileID,
+		profileName: c.IAMProfileName,
+		tokenFile:   c.IAMTokenFile,
+		iamURL:      c.iamEndpoint() + "/identity/token",
+		client:      c.iamClient(),
+	}
+	if ts.usesProfile() {
+		ts.tokenFile = c.CRTokenFile
+		if ts.tokenFile == "" {
+			ts.tokenFile = DefaultCRTokenFilePath
+		}
+	}
+	return ts
+}
+
+// newAssumeTokenSource returns the token source of the trusted profile in
+// c.Assume, assumed with the token base authenticates requests with.
+func (c *Config) newAssumeTokenSource(base core.Authenticator) *iamTokenSource {
+	ts := &iamTokenSource{
+		profileID:   c.Assume.ProfileID,
+		profileCRN:  c.Assume.ProfileCRN,
+		profileName: c.Assume.ProfileName,
+		accountID:   c.Assume.AccountID,
+		iamURL:      c.iamEndpoint() + "/identity/token",
+		client:      c.iamClient(),
+	}
+	ts.base = func() (string, error) {
+		req, err := gohttp.NewRequest("POST", ts.iamURL, nil)
+		if err != nil {
+			return "", err
+		}
+		if err := base.Authenticate(req); err != nil {
+			return "", err
+		}
+		return strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "), nil
+	}
+	return ts
+}
+
+func (c *Config) iamClient() *gohttp.Client {
+	return &gohttp.Client{
+		Timeout:   30 * time.Second,
+		Transport: c.wrapTransport(DefaultTransport()),
+	}
+}
+
+// iamEndpoint returns the IAM endpoint for the region and visibility of c.
+func (c *Config) iamEndpoint() string {
+	iamURL := "https://iam.cloud.ibm.com"
+	if c.Visibility == "private" || c.Visibility == "public-and-private" {
+		if c.Region == "us-south" || c.Region == "us-east" {
+			iamURL = contructEndpoint(fmt.Sprintf("private.%s.iam", c.Region), cloudEndpoint)
+		} else {
+			iamURL = contructEndpoint("private.iam", cloudEndpoint)
+		}
+	}
+	return c.endpointFor("IBMCLOUD_IAM_API_ENDPOINT", iamURL)
+}
+
+func (ts *iamTokenSource) usesProfile() bool {
+	return ts.profileID != "" || ts.profileCRN != "" || ts.profileName != ""
+}
+
+// profile describes the trusted profile of ts in log and error messages.
+func (ts *iamTokenSource) profile() string {
+	switch {
+	case ts.profileID != "":
+		return ts.profileID
+	case ts.profileCRN != "":
+		return ts.profileCRN
+	case ts.accountID != "":
+		return fmt.Sprintf("%s in account %s", ts.profileName, ts.accountID)
+	}
+	return ts.profileName
+}
+
+// Token returns a valid IAM access token, without the Bearer prefix.
+func (ts *iamTokenSource) Token() (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.token != "" && time.Until(ts.expires) > tokenExpiryWindow {
+		return ts.token, nil
+	}
+
+	if ts.base != nil {
+		accessToken, err := ts.base()
+		if err != nil {
+			return "", fmt.Errorf("Error authenticating to assume trusted profile %s: %s", ts.profile(), err)
+		}
+		log.Printf("[DEBUG] Assuming trusted profile %s", ts.profile())
+		return ts.exchange(url.Values{
+			"grant_type":   {assumeGrantType},
+			"access_token": {accessToken},
+		}, "IAM access token")
+	}
+
+	data, err := ioutil.ReadFile(ts.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("Error reading token file %s: %s", ts.tokenFile, err)
+	}
+	fileToken := strings.TrimPrefix(strings.TrimSpace(string(data)), "Bearer ")
+	if fileToken == "" {
+		return "", fmt.Errorf("Token file %s is empty", ts.tokenFile)
+	}
+
+	if !ts.usesProfile() {
+		expires, err := tokenExpiry(fileToken)
+		if err != nil {
+			return "", fmt.Errorf("Error reading the IAM token in %s: %s", ts.tokenFile, err)
+		}
+		if time.Now().After(expires) {
+			return "", fmt.Errorf("The IAM token in %s expired at %s", ts.tokenFile, expires.Format(time.RFC3339))
+		}
+		ts.token, ts.expires = fileToken, expires
+		ts.remember(ts.token)
+		return ts.token, nil
+	}
+
+	log.Printf("[DEBUG] Exchanging the compute resource token in %s for an IAM token", ts.tokenFile)
+	return ts.exchange(url.Values{
+		"grant_type": {crTokenGrantType},
+		"cr_token":   {fileToken},
+	}, "compute resource token")
+}
+
+// exchange trades the token in the grant form for an IAM access token of the
+// trusted profile and caches it. subject names the traded token in errors.
+func (ts *iamTokenSource) exchange(form url.Values, subject string) (string, error) {
+	switch {
+	case ts.profileID != "":
+		form.Set("profile_id", ts.profileID)
+	case ts.profileCRN != "":
+		form.Set("profile_crn", ts.profileCRN)
+	default:
+		form.Set("profile_name", ts.profileName)
+		if ts.accountID != "" {
+			form.Set("account", ts.accountID)
+		}
+	}
+	resp, err := ts.requestToken(form)
+	if err != nil {
+		return "", fmt.Errorf("Error exchanging the %s for a token of trusted profile %s: %s", subject, ts.profile(), err)
+	}
+	ts.token = resp.AccessToken
+	switch {
+	case resp.Expiration > 0:
+		ts.expires = time.Unix(resp.Expiration, 0)
+	case resp.ExpiresIn > 0:
+		ts.expires = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	default:
+		ts.expires, _ = tokenExpiry(resp.AccessToken)
+	}
+	ts.remember(ts.token)
+	return ts.token, nil
+}
+
+func (ts *iamTokenSource) remember(token string) {
+	if ts.first == "" {
+		ts.first = token
+	}
+}
+
+// hasIssued reports whether token is the first or the current token of ts.
+func (ts *iamTokenSource) hasIssued(token string) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return token != "" && (token == ts.first || token == ts.token)
+}
+
+// requestToken posts form to the IAM token API.
+func (ts *iamTokenSource) requestToken(form url.Values) (*iamTokenResponse, error) {
+	req, err := gohttp.NewRequest("POST", ts.iamURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	httpResp, err := ts.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	body, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != gohttp.StatusOK {
+		return nil, fmt.Errorf("%d %s", httpResp.StatusCode, body)
+	}
+	resp := &iamTokenResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("Error parsing the IAM token response: %s", err)
+	}
+	if resp.AccessToken == "" {
+		return nil, fmt.Errorf("The IAM token response has no access token")
+	}
+	return resp, nil
+}
+
+// tokenExpiry returns the expiry of the JWT token, read from its exp claim
+// without verifying the signature.
+func tokenExpiry(token string) (time.Time, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil {
+		return time.Time{}, err
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return time.Time{}, fmt.Errorf("the token has no exp claim")
+	}
+	return time.Unix(int64(exp), 0), nil
+}
+
+// tokenSourceAuthenticator is the go-sdk-core Authenticator of the IBM Cloud
+// SDK clients when the provider authenticates with an iamTokenSource.
+type tokenSourceAuthenticator struct {
+	source *iamTokenSource
+}
+
+func (a *tokenSourceAuthenticator) AuthenticationType() string {
+	return "bearerToken"
+}
+
+func (a *tokenSourceAuthenticator) Validate() error {
+	if a.source == nil {
+		return fmt.Errorf("No IAM token source configured")
+	}
+	return nil
+}
+
+func (a *tokenSourceAuthenticator) Authenticate(req *gohttp.Request) error {
+	token, err := a.source.Token()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// tokenSourceTransport keeps the IAM token of the bluemix-go, SoftLayer and
+// Power sessions valid. They are configured with the token the source returned
+// at the start and cannot renew it themselves, so the requests that carry a
+// token of the source are sent with its current token instead. Every other
+// request, like the UAA authenticated Cloud Foundry ones, is sent as is.
+type tokenSourceTransport struct {
+	config *Config
+	next   gohttp.RoundTripper
+}
+
+// sessionTokenSource returns the source of the token the sessions of c are
+// configured with: the assumed trusted profile if there is one, or the trusted
+// profile or token file source.
+func (c *Config) sessionTokenSource() *iamTokenSource {
+	if c.assumeSource != nil {
+		return c.assumeSource
+	}
+	return c.tokenSource
+}
+
+func (t *tokenSourceTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	source := t.config.sessionTokenSource()
+	authorization := req.Header.Get("Authorization")
+	if token := strings.TrimPrefix(authorization, "Bearer "); source != nil && token != authorization && source.hasIssued(token) {
+		current, err := source.Token()
+		if err != nil {
+			return nil, err
+		}
+		if current != token {
+			// a RoundTripper must not modify the request it was given
+			req = req.Clone(req.Context())
+			req.Header.Set("Authorization", "Bearer "+current)
+		}
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,345 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	gohttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+func testIAMToken(t *testing.T, expires time.Time) string {
+	return testIAMAccountToken(t, expires, "account-1234")
+}
+
+func testIAMAccountToken(t *testing.T, expires time.Time, account string) string {
+	return signTestToken(t, testIAMSigningKey, testIAMKeyID, jwt.MapClaims{
+		"exp":     expires.Unix(),
+		"iam_id":  "iam-Profile-1234",
+		"id":      "iam-Profile-1234",
+		"iss":     "https://iam.cloud.ibm.com/identity",
+		"account": map[string]interface{}{"bss": account},
+	})
+}
+
+// mockIAMServer serves the cr-token and assume grants of the IAM token API and
+// the IAM signing keys, it records the tokens it exchanged. Assumed profiles
+// are in account-child.
+func mockIAMServer(t *testing.T, profileID string, exchanged *[]string) *httptest.Server {
+	return httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		if r.URL.Path == "/identity/keys" {
+			w.Write(testJWKS(testIAMKeyID, &testIAMSigningKey.PublicKey))
+			return
+		}
+		if r.URL.Path != "/identity/token" {
+			w.WriteHeader(gohttp.StatusNotFound)
+			return
+		}
+		r.ParseForm()
+		account, subject := "account-1234", r.Form.Get("cr_token")
+		if r.Form.Get("grant_type") == assumeGrantType {
+			account, subject = "account-child", r.Form.Get("access_token")
+		}
+		if subject == "" || r.Form.Get("profile_id") != profileID {
+			w.WriteHeader(gohttp.StatusBadRequest)
+			w.Write([]byte(`{"errorCode":"BXNIM0109E","errorMessage":"Property missing or empty."}`))
+			return
+		}
+		*exchanged = append(*exchanged, subject)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": testIAMAccountToken(t, time.Now().Add(time.Hour), account),
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+}
+
+func writeTokenFile(t *testing.T, dir, token string) string {
+	path := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestIAMTokenSourceTrustedProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crtoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var exchanged []string
+	server := mockIAMServer(t, "Profile-1234", &exchanged)
+	defer server.Close()
+	os.Setenv("IBMCLOUD_IAM_API_ENDPOINT", server.URL)
+	defer os.Unsetenv("IBMCLOUD_IAM_API_ENDPOINT")
+
+	config := &Config{
+		Region:       "us-south",
+		IAMProfileID: "Profile-1234",
+		CRTokenFile:  writeTokenFile(t, dir, "cr-token-1"),
+	}
+	ts := config.newIAMTokenSource()
+	authenticator := &tokenSourceAuthenticator{source: ts}
+
+	req, _ := gohttp.NewRequest("GET", "https://us-south.iaas.cloud.ibm.com/v1/vpcs", nil)
+	if err := authenticator.Authenticate(req); err != nil {
+		t.Fatalf("Unexpected error authenticating: %s", err)
+	}
+	if !strings.HasPrefix(req.Header.Get("Authorization"), "Bearer eyJ") {
+		t.Errorf("Unexpected Authorization header %q", req.Header.Get("Authorization"))
+	}
+	if _, err := ts.Token(); err != nil {
+		t.Fatal(err)
+	}
+	if len(exchanged) != 1 {
+		t.Errorf("Expected the token to be cached, got %d exchanges", len(exchanged))
+	}
+
+	// the compute resource rotates its token, it is re-read once the IAM token expires
+	writeTokenFile(t, dir, "cr-token-2")
+	ts.expires = time.Now()
+	if _, err := ts.Token(); err != nil {
+		t.Fatal(err)
+	}
+	if len(exchanged) != 2 || exchanged[0] != "cr-token-1" || exchanged[1] != "cr-token-2" {
+		t.Errorf("Unexpected compute resource tokens exchanged %v", exchanged)
+	}
+
+	ts.profileID = "Profile-unknown"
+	ts.expires = time.Now()
+	if _, err := ts.Token(); err == nil || !strings.Contains(err.Error(), "BXNIM0109E") {
+		t.Errorf("Expected the IAM error, got %v", err)
+	}
+}
+
+func TestIAMTokenSourceTokenFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iamtoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	token := testIAMToken(t, time.Now().Add(time.Hour))
+	config := &Config{IAMTokenFile: writeTokenFile(t, dir, "Bearer "+token)}
+	ts := config.newIAMTokenSource()
+	got, err := ts.Token()
+	if err != nil {
+		t.Fatalf("Unexpected error reading the token file: %s", err)
+	}
+	if got != token {
+		t.Errorf("Expected the token of the file, got %q", got)
+	}
+
+	writeTokenFile(t, dir, testIAMToken(t, time.Now().Add(-time.Minute)))
+	ts.expires = time.Now()
+	if _, err := ts.Token(); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("Expected an error for an expired token, got %v", err)
+	}
+
+	if (&Config{}).newIAMTokenSource() != nil {
+		t.Error("Expected no token source without trusted profile or token file")
+	}
+}
+
+func TestTokenSourceTransport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iamtoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var received []string
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		received = append(received, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	token := testIAMToken(t, time.Now().Add(time.Hour))
+	config := &Config{IAMTokenFile: writeTokenFile(t, dir, token)}
+	config.tokenSource = config.newIAMTokenSource()
+	if _, err := config.tokenSource.Token(); err != nil {
+		t.Fatal(err)
+	}
+	client := &gohttp.Client{Transport: config.wrapTransport(DefaultTransport())}
+
+	// the session tokens are fixed, the token source renews them once they expire
+	renewed := testIAMToken(t, time.Now().Add(2*time.Hour))
+	writeTokenFile(t, dir, renewed)
+	config.tokenSource.expires = time.Now()
+	for _, authorization := range []string{"Bearer " + token, "bearer uaa-token", "Bearer other-token"} {
+		req, _ := gohttp.NewRequest("GET", server.URL, nil)
+		req.Header.Set("Authorization", authorization)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if req.Header.Get("Authorization") != authorization {
+			t.Error("Expected the request not to be modified")
+		}
+	}
+	want := []string{"Bearer " + renewed, "bearer uaa-token", "Bearer other-token"}
+	if !reflect.DeepEqual(received, want) {
+		t.Errorf("Expected the Authorization headers %v, got %v", want, received)
+	}
+
+	// the first token is still replaced after the next renewal, the renewed
+	// tokens in between are not kept
+	latest := testIAMToken(t, time.Now().Add(3*time.Hour))
+	writeTokenFile(t, dir, latest)
+	config.tokenSource.expires = time.Now()
+	received = nil
+	req, _ := gohttp.NewRequest("GET", server.URL, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if want := []string{"Bearer " + latest}; !reflect.DeepEqual(received, want) {
+		t.Errorf("Expected the Authorization headers %v, got %v", want, received)
+	}
+	if config.tokenSource.hasIssued(renewed) {
+		t.Error("Expected the renewed token to be forgotten once replaced")
+	}
+}
+
+func TestClientSessionWithTrustedProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crtoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var exchanged []string
+	server := mockIAMServer(t, "Profile-1234", &exchanged)
+	defer server.Close()
+	os.Setenv("IBMCLOUD_IAM_API_ENDPOINT", server.URL)
+	defer os.Unsetenv("IBMCLOUD_IAM_API_ENDPOINT")
+
+	config := &Config{
+		Region:       "us-south",
+		Visibility:   "public",
+		IAMProfileID: "Profile-1234",
+		CRTokenFile:  writeTokenFile(t, dir, "cr-token"),
+	}
+	meta, err := config.ClientSession()
+	if err != nil {
+		t.Fatalf("Unexpected error configuring the client session: %s", err)
+	}
+	sess := meta.(ClientSession)
+	user, err := sess.BluemixUserDetails()
+	if err != nil {
+		t.Fatalf("Unexpected error reading the user details: %s", err)
+	}
+	if user.userAccount != "account-1234" {
+		t.Errorf("Expected the account of the trusted profile token, got %q", user.userAccount)
+	}
+	if _, ok := meta.(*clientSession).authenticator.(*tokenSourceAuthenticator); !ok {
+		t.Error("Expected the SDK clients to authenticate with the trusted profile")
+	}
+	session := meta.(*clientSession).session
+	for name, transport := range map[string]gohttp.RoundTripper{
+		"IBM Cloud": session.BluemixSession.Config.HTTPClient.Transport,
+		"SoftLayer": session.SoftLayerSession.HTTPClient.Transport,
+	} {
+		if retrying, ok := transport.(*retryingTransport); ok {
+			transport = retrying.next
+		}
+		if _, ok := transport.(*tokenSourceTransport); !ok {
+			t.Errorf("Expected the %s session to renew the trusted profile token", name)
+		}
+	}
+	if len(exchanged) != 1 {
+		t.Errorf("Expected 1 token exchange, got %d", len(exchanged))
+	}
+}
+
+func TestClientSessionAssumesTrustedProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "iamtoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var exchanged []string
+	server := mockIAMServer(t, "Profile-child", &exchanged)
+	defer server.Close()
+	os.Setenv("IBMCLOUD_IAM_API_ENDPOINT", server.URL)
+	defer os.Unsetenv("IBMCLOUD_IAM_API_ENDPOINT")
+
+	callerToken := testIAMToken(t, time.Now().Add(time.Hour))
+	config := &Config{
+		Region:       "us-south",
+		Visibility:   "public",
+		IAMTokenFile: writeTokenFile(t, dir, callerToken),
+		Assume:       &AssumeProfile{ProfileID: "Profile-child"},
+	}
+	meta, err := config.ClientSession()
+	if err != nil {
+		t.Fatalf("Unexpected error configuring the client session: %s", err)
+	}
+	if len(exchanged) != 1 || exchanged[0] != callerToken {
+		t.Errorf("Expected the caller token to be exchanged, got %v", exchanged)
+	}
+	sess := meta.(*clientSession)
+	user, err := sess.BluemixUserDetails()
+	if err != nil {
+		t.Fatalf("Unexpected error reading the user details: %s", err)
+	}
+	if user.userAccount != "account-child" {
+		t.Errorf("Expected the account of the assumed profile, got %q", user.userAccount)
+	}
+	req, _ := gohttp.NewRequest("GET", "https://us-south.iaas.cloud.ibm.com/v1/vpcs", nil)
+	if err := sess.authenticator.Authenticate(req); err != nil {
+		t.Fatal(err)
+	}
+	if req.Header.Get("Authorization") != sess.session.BluemixSession.Config.IAMAccessToken {
+		t.Error("Expected the SDK clients to authenticate as the assumed profile")
+	}
+
+	// the IBM Cloud session keeps the first assumed token, it is renewed by
+	// assuming the profile again with the caller token
+	var received string
+	server2 := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		received = r.Header.Get("Authorization")
+	}))
+	defer server2.Close()
+	config.assumeSource.expires = time.Now()
+	req, _ = gohttp.NewRequest("GET", server2.URL, nil)
+	req.Header.Set("Authorization", sess.session.BluemixSession.Config.IAMAccessToken)
+	resp, err := sess.session.BluemixSession.Config.HTTPClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(exchanged) != 2 || exchanged[1] != callerToken {
+		t.Errorf("Expected the caller token to be exchanged again, got %v", exchanged)
+	}
+	if received != "Bearer "+config.assumeSource.token {
+		t.Errorf("Expected the renewed token of the assumed profile, got %q", received)
+	}
+
+	config.Assume = &AssumeProfile{ProfileName: "child", AccountID: "account-child"}
+	if _, err := config.ClientSession(); err == nil || !strings.Contains(err.Error(), "child in account account-child") {
+		t.Errorf("Expected an error assuming an unknown profile, got %v", err)
+	}
+
+	config.Assume = &AssumeProfile{ProfileID: "Profile-child"}
+	config.AccountID = "account-1234"
+	if _, err := config.ClientSession(); err == nil || !strings.Contains(err.Error(), "not for the expected account account-1234") {
+		t.Errorf("Expected an error for the account of the assumed profile, got %v", err)
+	}
+}
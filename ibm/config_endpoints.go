@@ -0,0 +1,136 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	bluemix "github.com/IBM-Cloud/bluemix-go"
+	bxsession "github.com/IBM-Cloud/bluemix-go/session"
+)
+
+// endpointsFile holds the service endpoint overrides read from the file given
+// by endpoints_file_path, or set by the endpoints blocks of the provider. It is
+// keyed by the IBMCLOUD_*_ENDPOINT variable name of the service, then by
+// visibility ("public" or "private"), then by region:
+//
+//	{
+//	  "IBMCLOUD_IS_NG_API_ENDPOINT": {
+//	    "public":  {"us-south": "https://us-south.iaas.cloud.ibm.com/v1"},
+//	    "private": {"us-south": "https://us-south.private.iaas.cloud.ibm.com/v1"}
+//	  }
+//	}
+type endpointsFile map[string]map[string]map[string]string
+
+// loadEndpointsFile reads and parses the endpoints file at path. An empty path
+// yields an empty set of overrides.
+func loadEndpointsFile(path string) (endpointsFile, error) {
+	if path == "" {
+		return endpointsFile{}, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading endpoints file %s: %s", path, err)
+	}
+	endpoints := endpointsFile{}
+	if err := json.Unmarshal(data, &endpoints); err != nil {
+		return nil, fmt.Errorf("Error parsing endpoints file %s: %s", path, err)
+	}
+	return endpoints, nil
+}
+
+// expandEndpoints returns the overrides of the endpoints blocks of the
+// provider. An endpoint without a region is the endpoint of region, the region
+// of the provider.
+func expandEndpoints(l []interface{}, region string) endpointsFile {
+	endpoints := endpointsFile{}
+	for _, e := range l {
+		m := e.(map[string]interface{})
+		service, visibility := m["service"].(string), m["visibility"].(string)
+		r := m["region"].(string)
+		if r == "" {
+			r = region
+		}
+		if endpoints[service] == nil {
+			endpoints[service] = map[string]map[string]string{}
+		}
+		if endpoints[service][visibility] == nil {
+			endpoints[service][visibility] = map[string]string{}
+		}
+		endpoints[service][visibility][r] = m["url"].(string)
+	}
+	return endpoints
+}
+
+// merge returns the overrides of f updated with the ones of inline, which take
+// precedence.
+func (f endpointsFile) merge(inline endpointsFile) endpointsFile {
+	merged := endpointsFile{}
+	for _, endpoints := range []endpointsFile{f, inline} {
+		for service, visibilities := range endpoints {
+			if merged[service] == nil {
+				merged[service] = map[string]map[string]string{}
+			}
+			for visibility, regions := range visibilities {
+				if merged[service][visibility] == nil {
+					merged[service][visibility] = map[string]string{}
+				}
+				for region, url := range regions {
+					merged[service][visibility][region] = url
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// lookup returns the endpoint configured for key in the given visibility and
+// region. For public-and-private the private endpoint is preferred and the public
+// one is used when the file has no private entry.
+func (f endpointsFile) lookup(key, visibility, region string) (string, bool) {
+	visibilities := []string{visibility}
+	if visibility == "public-and-private" {
+		visibilities = []string{"private", "public"}
+	}
+	for _, v := range visibilities {
+		if url := f[key][v][region]; url != "" {
+			return url, true
+		}
+	}
+	return "", false
+}
+
+// fileFallBack returns the endpoint configured for key, or defaultValue when the
+// file has no entry for it.
+func (f endpointsFile) fileFallBack(key, visibility, region, defaultValue string) string {
+	if url, ok := f.lookup(key, visibility, region); ok {
+		return url
+	}
+	return defaultValue
+}
+
+// endpointFor resolves the endpoint of the service identified by key. The
+// environment variable of the same name takes precedence over the endpoints
+// blocks and file, which in turn take precedence over defaultValue.
+func (c *Config) endpointFor(key, defaultValue string) string {
+	return envFallBack([]string{key}, c.endpoints.fileFallBack(key, c.Visibility, c.Region, defaultValue))
+}
+
+// bluemixSessionFor returns the session to build a bluemix-go service client
+// from. bluemix-go resolves endpoints from the IBMCLOUD_*_ENDPOINT variables on
+// its own, so the session is only copied with an explicit endpoint when the
+// variable is unset and the endpoints blocks or file have an entry for key.
+func (c *Config) bluemixSessionFor(sess *bxsession.Session, key string) *bxsession.Session {
+	if os.Getenv(key) != "" {
+		return sess
+	}
+	url, ok := c.endpoints.lookup(key, c.Visibility, c.Region)
+	if !ok {
+		return sess
+	}
+	return sess.Copy(&bluemix.Config{Endpoint: &url})
+}
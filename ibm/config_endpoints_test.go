@@ -0,0 +1,134 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const testEndpointsFile = `{
+	"IBMCLOUD_IS_NG_API_ENDPOINT": {
+		"public": {"us-south": "https://vpc.example.com/v1"},
+		"private": {"us-south": "https://vpc.private.example.com/v1"}
+	},
+	"IBMCLOUD_ICD_API_ENDPOINT": {
+		"public": {"eu-de": "https://icd.example.com"}
+	}
+}`
+
+func TestLoadEndpointsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "endpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "endpoints.json")
+	if err := ioutil.WriteFile(path, []byte(testEndpointsFile), 0600); err != nil {
+		t.Fatal(err)
+	}
+	endpoints, err := loadEndpointsFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error loading endpoints file: %s", err)
+	}
+
+	cases := []struct {
+		key, visibility, region, expected string
+	}{
+		{"IBMCLOUD_IS_NG_API_ENDPOINT", "public", "us-south", "https://vpc.example.com/v1"},
+		{"IBMCLOUD_IS_NG_API_ENDPOINT", "private", "us-south", "https://vpc.private.example.com/v1"},
+		{"IBMCLOUD_IS_NG_API_ENDPOINT", "public-and-private", "us-south", "https://vpc.private.example.com/v1"},
+		{"IBMCLOUD_IS_NG_API_ENDPOINT", "public", "eu-de", "default"},
+		{"IBMCLOUD_ICD_API_ENDPOINT", "public-and-private", "eu-de", "https://icd.example.com"},
+		{"IBMCLOUD_ICD_API_ENDPOINT", "private", "eu-de", "default"},
+		{"IBMCLOUD_CIS_API_ENDPOINT", "public", "us-south", "default"},
+	}
+	for _, c := range cases {
+		if got := endpoints.fileFallBack(c.key, c.visibility, c.region, "default"); got != c.expected {
+			t.Errorf("%s (%s, %s): expected %q, got %q", c.key, c.visibility, c.region, c.expected, got)
+		}
+	}
+
+	if _, err := loadEndpointsFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected an error for a missing endpoints file")
+	}
+}
+
+func TestEndpointForPrefersEnvironment(t *testing.T) {
+	config := &Config{
+		Region:     "us-south",
+		Visibility: "public",
+		endpoints: endpointsFile{
+			"IBMCLOUD_IS_NG_API_ENDPOINT": {"public": {"us-south": "https://vpc.example.com/v1"}},
+		},
+	}
+	if got := config.endpointFor("IBMCLOUD_IS_NG_API_ENDPOINT", "default"); got != "https://vpc.example.com/v1" {
+		t.Errorf("Expected endpoint from file, got %q", got)
+	}
+
+	os.Setenv("IBMCLOUD_IS_NG_API_ENDPOINT", "https://vpc.env.example.com/v1")
+	defer os.Unsetenv("IBMCLOUD_IS_NG_API_ENDPOINT")
+	if got := config.endpointFor("IBMCLOUD_IS_NG_API_ENDPOINT", "default"); got != "https://vpc.env.example.com/v1" {
+		t.Errorf("Expected endpoint from environment, got %q", got)
+	}
+}
+
+func TestEndpointsBlock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "endpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "endpoints.json")
+	if err := ioutil.WriteFile(path, []byte(testEndpointsFile), 0600); err != nil {
+		t.Fatal(err)
+	}
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":              "us-south",
+		"endpoints_file_path": path,
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"service": "IBMCLOUD_IS_NG_API_ENDPOINT",
+				"url":     "https://vpc.inline.example.com/v1",
+			},
+			map[string]interface{}{
+				"service":    "IBMCLOUD_CIS_API_ENDPOINT",
+				"url":        "https://cis.inline.example.com",
+				"visibility": "private",
+				"region":     "eu-de",
+			},
+		},
+	})
+	config, err := providerConfig(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoints, err := loadEndpointsFile(config.EndpointsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoints = endpoints.merge(config.Endpoints)
+
+	cases := []struct {
+		key, visibility, region, expected string
+	}{
+		// the endpoints blocks take precedence over the file
+		{"IBMCLOUD_IS_NG_API_ENDPOINT", "public", "us-south", "https://vpc.inline.example.com/v1"},
+		{"IBMCLOUD_IS_NG_API_ENDPOINT", "private", "us-south", "https://vpc.private.example.com/v1"},
+		{"IBMCLOUD_ICD_API_ENDPOINT", "public", "eu-de", "https://icd.example.com"},
+		{"IBMCLOUD_CIS_API_ENDPOINT", "private", "eu-de", "https://cis.inline.example.com"},
+		{"IBMCLOUD_CIS_API_ENDPOINT", "public", "eu-de", "default"},
+	}
+	for _, c := range cases {
+		if got := endpoints.fileFallBack(c.key, c.visibility, c.region, "default"); got != c.expected {
+			t.Errorf("%s (%s, %s): expected %q, got %q", c.key, c.visibility, c.region, c.expected, got)
+		}
+	}
+}
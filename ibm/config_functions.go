@@ -21,12 +21,19 @@ const DefaultServiceURL = "https://us-south.functions.cloud.ibm.com"
 //FunctionClient ...
 func FunctionClient(c *bluemix.Config) (*whisk.Client, error) {
 	baseEndpoint := getBaseURL(c.Region)
+	if c.Endpoint != nil {
+		baseEndpoint = *c.Endpoint
+	}
 	u, err := url.Parse(fmt.Sprintf("%s/api", baseEndpoint))
 	if err != nil {
 		return nil, err
 	}
 
-	functionsClient, err := whisk.NewClient(http.DefaultClient, &whisk.Config{
+	transport := DefaultTransport()
+	if c.HTTPClient != nil && c.HTTPClient.Transport != nil {
+		transport = c.HTTPClient.Transport
+	}
+	functionsClient, err := whisk.NewClient(&http.Client{Transport: transport}, &whisk.Config{
 		Host:    u.Host,
 		Version: "v1",
 	})
@@ -0,0 +1,191 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	gohttp "net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// the keys are fetched again for an unknown key ID at most this often, so
+// tokens signed with unknown keys do not flood IAM with requests
+const jwksRefreshInterval = time.Minute
+
+// unknownIAMKeyError is returned for a token signed with a key that is not in
+// the JWKS. It is permanent, a new token from the same IAM is signed with the
+// same keys.
+type unknownIAMKeyError struct {
+	kid string
+	url string
+}
+
+func (e *unknownIAMKeyError) Error() string {
+	if e.url == "" {
+		return fmt.Sprintf("The IAM token is signed with unknown key %s", e.kid)
+	}
+	return fmt.Sprintf("The IAM token is signed with unknown key %s, it is not in %s", e.kid, e.url)
+}
+
+// jwksCache holds the public keys IAM signs its tokens with, fetched from the
+// JSON Web Key Set at url. Keys are fetched on first use and again when a token
+// is signed with a key that is not in the cache, as IAM rotates its keys.
+type jwksCache struct {
+	url    string
+	client *gohttp.Client
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PublicKey
+	fetched time.Time
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// newJWKSCache returns the key cache of the JWKS URL of c, by default the keys
+// of the IAM endpoint. It returns nil when the acceptance tests replay the
+// cassette of c, its token signatures are scrubbed and cannot be verified.
+// Only testAccUseCassette sets the cassette of a Config.
+func (c *Config) newJWKSCache() *jwksCache {
+	if c.cassette != nil && c.cassette.mode == cassetteModeReplay {
+		log.Println("[WARN] IAM token signatures are not verified when replaying cassettes")
+		return nil
+	}
+	url := c.IAMJWKSURL
+	if url == "" {
+		url = c.iamEndpoint() + "/identity/keys"
+	}
+	return &jwksCache{url: url, client: c.iamClient()}
+}
+
+// key returns the public key with ID kid.
+func (jc *jwksCache) key(kid string) (*rsa.PublicKey, error) {
+	jc.mu.Lock()
+	defer jc.mu.Unlock()
+	if key, ok := jc.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(jc.fetched) < jwksRefreshInterval {
+		return nil, &unknownIAMKeyError{kid: kid}
+	}
+	if err := jc.fetch(); err != nil {
+		return nil, err
+	}
+	if key, ok := jc.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, &unknownIAMKeyError{kid: kid, url: jc.url}
+}
+
+func (jc *jwksCache) fetch() error {
+	log.Printf("[DEBUG] Fetching the IAM token signing keys from %s", jc.url)
+	resp, err := jc.client.Get(jc.url)
+	if err != nil {
+		return fmt.Errorf("Error fetching the IAM token signing keys: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != gohttp.StatusOK {
+		return fmt.Errorf("Error fetching the IAM token signing keys from %s: %s", jc.url, resp.Status)
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("Error parsing the IAM token signing keys from %s: %s", jc.url, err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		key, err := k.rsaPublicKey()
+		if err != nil {
+			return fmt.Errorf("Error parsing IAM token signing key %s: %s", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	jc.keys, jc.fetched = keys, time.Now()
+	return nil
+}
+
+func (k jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.N, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %s", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.E, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %s", err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid exponent %s", exponent)
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+// keyfunc is the jwt.Keyfunc verifying IAM tokens with the keys of the cache.
+func (jc *jwksCache) keyfunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("The IAM token is signed with unexpected algorithm %v", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("The IAM token has no key ID")
+	}
+	return jc.key(kid)
+}
+
+// verifyIAMToken verifies the signature and the expiry of the IAM token and
+// returns its claims. Without keys the claims are returned unverified.
+func verifyIAMToken(token string, keys *jwksCache) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	if keys == nil {
+		if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil {
+			return nil, fmt.Errorf("Error parsing the IAM token: %s", err)
+		}
+		return claims, nil
+	}
+	_, err := jwt.ParseWithClaims(token, claims, keys.keyfunc)
+	if err == nil {
+		return claims, nil
+	}
+	if ve, ok := err.(*jwt.ValidationError); ok {
+		if ve.Inner != nil && ve.Errors&jwt.ValidationErrorUnverifiable != 0 {
+			return nil, ve.Inner
+		}
+		if ve.Errors&jwt.ValidationErrorExpired != 0 {
+			if exp, ok := claims["exp"].(float64); ok {
+				return nil, fmt.Errorf("The IAM token expired at %s", time.Unix(int64(exp), 0).Format(time.RFC3339))
+			}
+			return nil, fmt.Errorf("The IAM token is expired")
+		}
+	}
+	return nil, fmt.Errorf("Error verifying the IAM token: %s", err)
+}
+
+// expectedAccount returns the account the IAM token of the provider must be
+// for, or "" when any account is accepted.
+func (c *Config) expectedAccount() string {
+	if c.AccountID != "" {
+		return c.AccountID
+	}
+	if c.Assume != nil {
+		return c.Assume.AccountID
+	}
+	return ""
+}
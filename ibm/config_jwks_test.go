@@ -0,0 +1,209 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	gohttp "net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM-Cloud/bluemix-go"
+	bxsession "github.com/IBM-Cloud/bluemix-go/session"
+	"github.com/golang-jwt/jwt"
+)
+
+const testIAMKeyID = "20210901"
+
+var testIAMSigningKey = generateTestKey()
+
+func generateTestKey() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func testJWKS(kid string, key *rsa.PublicKey) []byte {
+	data, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	return data
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// mockJWKSServer serves the JWKS of the key with ID *kid and counts the
+// fetches.
+func mockJWKSServer(kid *string, key **rsa.PrivateKey, fetches *int) *httptest.Server {
+	return httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		*fetches++
+		w.Write(testJWKS(*kid, &(*key).PublicKey))
+	}))
+}
+
+func TestVerifyIAMToken(t *testing.T) {
+	kid, key, fetches := testIAMKeyID, testIAMSigningKey, 0
+	server := mockJWKSServer(&kid, &key, &fetches)
+	defer server.Close()
+	keys := (&Config{IAMJWKSURL: server.URL}).newJWKSCache()
+
+	valid := jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix(), "id": "IBMid-1234"}
+	for i := 0; i < 2; i++ {
+		claims, err := verifyIAMToken(signTestToken(t, testIAMSigningKey, testIAMKeyID, valid), keys)
+		if err != nil {
+			t.Fatalf("Unexpected error verifying a valid token: %s", err)
+		}
+		if claims["id"] != "IBMid-1234" {
+			t.Errorf("Unexpected claims %v", claims)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("Expected the keys to be cached, got %d fetches", fetches)
+	}
+
+	expired := jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix(), "id": "IBMid-1234"}
+	cases := map[string]struct {
+		token string
+		err   string
+	}{
+		"expired": {
+			token: signTestToken(t, testIAMSigningKey, testIAMKeyID, expired),
+			err:   "The IAM token expired at",
+		},
+		"forged": {
+			token: signTestToken(t, generateTestKey(), testIAMKeyID, valid),
+			err:   "verification error",
+		},
+		"unknown key": {
+			token: signTestToken(t, testIAMSigningKey, "unknown", valid),
+			err:   "unknown key unknown",
+		},
+		"no key ID": {
+			token: signTestToken(t, testIAMSigningKey, "", valid),
+			err:   "no key ID",
+		},
+	}
+	hs256, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, valid).SignedString([]byte("secret"))
+	cases["unexpected algorithm"] = struct {
+		token string
+		err   string
+	}{hs256, "unexpected algorithm HS256"}
+	for name, c := range cases {
+		if _, err := verifyIAMToken(c.token, keys); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected error %q, got %v", name, c.err, err)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("Expected unknown keys to be fetched at most once per %s, got %d fetches", jwksRefreshInterval, fetches)
+	}
+}
+
+func TestVerifyIAMTokenKeyRotation(t *testing.T) {
+	kid, key, fetches := testIAMKeyID, testIAMSigningKey, 0
+	server := mockJWKSServer(&kid, &key, &fetches)
+	defer server.Close()
+	keys := (&Config{IAMJWKSURL: server.URL}).newJWKSCache()
+
+	claims := jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()}
+	if _, err := verifyIAMToken(signTestToken(t, key, kid, claims), keys); err != nil {
+		t.Fatal(err)
+	}
+
+	kid, key = "20211001", generateTestKey()
+	keys.fetched = time.Now().Add(-jwksRefreshInterval)
+	if _, err := verifyIAMToken(signTestToken(t, key, kid, claims), keys); err != nil {
+		t.Fatalf("Unexpected error verifying a token signed with a rotated key: %s", err)
+	}
+	if fetches != 2 {
+		t.Errorf("Expected the keys to be fetched again for the rotated key, got %d fetches", fetches)
+	}
+}
+
+func TestFetchUserDetailsMissingClaims(t *testing.T) {
+	kid, key, fetches := testIAMKeyID, testIAMSigningKey, 0
+	server := mockJWKSServer(&kid, &key, &fetches)
+	defer server.Close()
+	keys := (&Config{IAMJWKSURL: server.URL}).newJWKSCache()
+
+	exp := time.Now().Add(time.Hour).Unix()
+	cases := map[string]jwt.MapClaims{
+		"id claim":          {"exp": exp, "account": map[string]interface{}{"bss": "1234"}, "iss": "https://iam.cloud.ibm.com/identity"},
+		"account.bss claim": {"exp": exp, "id": "IBMid-1234", "account": map[string]interface{}{}, "iss": "https://iam.cloud.ibm.com/identity"},
+		"iss claim":         {"exp": exp, "id": "IBMid-1234", "account": map[string]interface{}{"bss": "1234"}},
+	}
+	for claim, claims := range cases {
+		sess := &bxsession.Session{Config: &bluemix.Config{
+			IAMAccessToken: "Bearer " + signTestToken(t, testIAMSigningKey, testIAMKeyID, claims),
+		}}
+		if _, err := fetchUserDetails(sess, keys, 0, 0); err == nil || !strings.Contains(err.Error(), "no "+claim) {
+			t.Errorf("Expected an error for the missing %s, got %v", claim, err)
+		}
+	}
+}
+
+func TestFetchUserDetailsUnknownKey(t *testing.T) {
+	kid, key, fetches := testIAMKeyID, testIAMSigningKey, 0
+	server := mockJWKSServer(&kid, &key, &fetches)
+	defer server.Close()
+	keys := (&Config{IAMJWKSURL: server.URL}).newJWKSCache()
+
+	sess := &bxsession.Session{Config: &bluemix.Config{
+		BluemixAPIKey:  "apikey",
+		IAMAccessToken: "Bearer " + signTestToken(t, testIAMSigningKey, "unknown", jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()}),
+	}}
+	start := time.Now()
+	_, err := fetchUserDetails(sess, keys, 3, 2*time.Second)
+	if _, ok := err.(*unknownIAMKeyError); !ok {
+		t.Errorf("Expected an unknown key error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected an unknown key not to be retried, took %s", elapsed)
+	}
+}
+
+func TestVerifyIAMTokenReplay(t *testing.T) {
+	os.Setenv("IBMCLOUD_CASSETTE_MODE", cassetteModeReplay)
+	defer os.Unsetenv("IBMCLOUD_CASSETTE_MODE")
+
+	// the environment alone does not disable the verification
+	if (&Config{}).newJWKSCache() == nil {
+		t.Fatal("Expected signature verification without a replayed cassette")
+	}
+	keys := (&Config{cassette: &cassette{mode: cassetteModeReplay}}).newJWKSCache()
+	if keys != nil {
+		t.Fatal("Expected no signature verification when replaying cassettes")
+	}
+	token := signTestToken(t, testIAMSigningKey, testIAMKeyID, jwt.MapClaims{"id": "IBMid-1234"})
+	scrubbed := scrubSecrets(token)
+	claims, err := verifyIAMToken(scrubbed, keys)
+	if err != nil {
+		t.Fatalf("Unexpected error reading a replayed token: %s", err)
+	}
+	if claims["id"] != "IBMid-1234" {
+		t.Errorf("Unexpected claims %v", claims)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	gohttp "net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Defaults of the provider retry block
+const (
+	defaultRetryMinBackoff = 1 * time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy configures how failed API calls are retried
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// MinBackoff and MaxBackoff bound the exponential backoff between attempts
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Jitter randomises each backoff between half and all of its value
+	Jitter bool
+	// HonorRetryAfter waits for the delay given by a Retry-After header
+	HonorRetryAfter bool
+	// RetryableStatusCodes are retried on top of 408, 429 and the 5xx codes
+	RetryableStatusCodes []int
+	// RetryableErrors are substrings of error messages that are retried
+	RetryableErrors []string
+}
+
+// retryPolicy returns the configured retry policy. Without a retry block the
+// policy matches the behaviour of BaseService.EnableRetries with max_retries.
+func (c *Config) retryPolicy() *RetryPolicy {
+	if c.RetryPolicy != nil {
+		return c.RetryPolicy
+	}
+	return &RetryPolicy{
+		MaxRetries:      c.RetryCount,
+		MinBackoff:      defaultRetryMinBackoff,
+		MaxBackoff:      c.RetryDelay,
+		HonorRetryAfter: true,
+	}
+}
+
+// checkRetry is the retryablehttp.CheckRetry of the IBM Cloud SDK clients. It
+// extends core.IBMCloudSDKRetryPolicy with 408, as the provider retried it
+// before, and the configured status codes and error messages.
+func (p *RetryPolicy) checkRetry(ctx context.Context, resp *gohttp.Response, err error) (bool, error) {
+	retry, checkErr := core.IBMCloudSDKRetryPolicy(ctx, resp, err)
+	if retry || ctx.Err() != nil {
+		return retry, checkErr
+	}
+	if err != nil && p.isRetryableMessage(err.Error()) {
+		return true, nil
+	}
+	if resp != nil && (resp.StatusCode == gohttp.StatusRequestTimeout || p.isRetryableStatusCode(resp.StatusCode)) {
+		return true, nil
+	}
+	return retry, checkErr
+}
+
+// backoff is the retryablehttp.Backoff of the IBM Cloud SDK clients. The
+// delay of a Retry-After header is capped at max, so a server can't stall a
+// run.
+func (p *RetryPolicy) backoff(min, max time.Duration, attemptNum int, resp *gohttp.Response) time.Duration {
+	if p.HonorRetryAfter && resp != nil {
+		if wait, ok := retryAfter(resp); ok {
+			if max > 0 && wait > max {
+				wait = max
+			}
+			return wait
+		}
+	}
+	wait := time.Duration(math.Pow(2, float64(attemptNum)) * float64(min))
+	if wait <= 0 || wait > max {
+		wait = max
+	}
+	if p.Jitter && wait > 1 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)))
+	}
+	return wait
+}
+
+func (p *RetryPolicy) isRetryableStatusCode(code int) bool {
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) isRetryableMessage(msg string) bool {
+	for _, s := range p.RetryableErrors {
+		if s != "" && strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header, given either in seconds or as an HTTP date.
+func retryAfter(resp *gohttp.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := gohttp.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// expandRetryPolicy builds the RetryPolicy from the provider retry block.
+// maxRetries is used when the block does not set max_attempts.
+func expandRetryPolicy(l []interface{}, maxRetries int) *RetryPolicy {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	retry := l[0].(map[string]interface{})
+	policy := &RetryPolicy{
+		MaxRetries:      maxRetries,
+		MinBackoff:      time.Duration(retry["min_backoff"].(int)) * time.Second,
+		MaxBackoff:      time.Duration(retry["max_backoff"].(int)) * time.Second,
+		Jitter:          retry["jitter"].(bool),
+		HonorRetryAfter: retry["honor_retry_after"].(bool),
+	}
+	if attempts, ok := retry["max_attempts"].(int); ok && attempts > 0 {
+		policy.MaxRetries = attempts - 1
+	}
+	if policy.MaxBackoff < policy.MinBackoff {
+		policy.MaxBackoff = policy.MinBackoff
+	}
+	for _, code := range retry["retryable_status_codes"].(*schema.Set).List() {
+		policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, code.(int))
+	}
+	for _, msg := range retry["retryable_errors"].([]interface{}) {
+		policy.RetryableErrors = append(policy.RetryableErrors, msg.(string))
+	}
+	return policy
+}
@@ -0,0 +1,202 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"io/ioutil"
+	gohttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type testRetryService struct {
+	client *gohttp.Client
+}
+
+func (s *testRetryService) SetHTTPClient(client *gohttp.Client) {
+	s.client = client
+}
+
+func TestExpandRetryPolicy(t *testing.T) {
+	if policy := expandRetryPolicy([]interface{}{}, 10); policy != nil {
+		t.Errorf("Expected no policy without a retry block, got %+v", policy)
+	}
+
+	policy := expandRetryPolicy([]interface{}{map[string]interface{}{
+		"max_attempts":           3,
+		"min_backoff":            10,
+		"max_backoff":            5,
+		"jitter":                 false,
+		"honor_retry_after":      true,
+		"retryable_status_codes": schema.NewSet(schema.HashInt, []interface{}{409}),
+		"retryable_errors":       []interface{}{"connection reset"},
+	}}, 10)
+	if policy.MaxRetries != 2 {
+		t.Errorf("Expected 2 retries for 3 attempts, got %d", policy.MaxRetries)
+	}
+	if policy.MaxBackoff != 10*time.Second {
+		t.Errorf("Expected max_backoff to be raised to min_backoff, got %s", policy.MaxBackoff)
+	}
+	if !policy.isRetryableStatusCode(409) || policy.isRetryableStatusCode(404) {
+		t.Errorf("Unexpected retryable status codes %v", policy.RetryableStatusCodes)
+	}
+	if !policy.isRetryableMessage("read tcp: connection reset by peer") {
+		t.Error("Expected a configured error message to be retryable")
+	}
+
+	policy = expandRetryPolicy([]interface{}{map[string]interface{}{
+		"max_attempts":           0,
+		"min_backoff":            1,
+		"max_backoff":            30,
+		"jitter":                 true,
+		"honor_retry_after":      true,
+		"retryable_status_codes": schema.NewSet(schema.HashInt, nil),
+		"retryable_errors":       []interface{}{},
+	}}, 10)
+	if policy.MaxRetries != 10 {
+		t.Errorf("Expected max_retries to be used without max_attempts, got %d", policy.MaxRetries)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 8 * time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second}
+	for attempt, want := range expected {
+		if got := policy.backoff(policy.MinBackoff, policy.MaxBackoff, attempt, nil); got != want {
+			t.Errorf("Attempt %d: expected %s, got %s", attempt, want, got)
+		}
+	}
+
+	policy.Jitter = true
+	for i := 0; i < 100; i++ {
+		got := policy.backoff(policy.MinBackoff, policy.MaxBackoff, 3, nil)
+		if got < 4*time.Second || got > 8*time.Second {
+			t.Fatalf("Jittered backoff %s out of [4s, 8s]", got)
+		}
+	}
+
+	resp := &gohttp.Response{Header: gohttp.Header{"Retry-After": []string{"3"}}}
+	if got := policy.backoff(policy.MinBackoff, policy.MaxBackoff, 0, resp); got > time.Second {
+		t.Errorf("Expected Retry-After to be ignored, got %s", got)
+	}
+	policy.HonorRetryAfter = true
+	if got := policy.backoff(policy.MinBackoff, policy.MaxBackoff, 0, resp); got != 3*time.Second {
+		t.Errorf("Expected Retry-After to be honored, got %s", got)
+	}
+	resp.Header.Set("Retry-After", "3600")
+	if got := policy.backoff(policy.MinBackoff, policy.MaxBackoff, 0, resp); got != policy.MaxBackoff {
+		t.Errorf("Expected Retry-After to be capped at %s, got %s", policy.MaxBackoff, got)
+	}
+}
+
+func TestRetryPolicyCheckRetry(t *testing.T) {
+	policy := &RetryPolicy{RetryableStatusCodes: []int{409}}
+	ctx := context.Background()
+	cases := []struct {
+		code  int
+		retry bool
+	}{
+		{200, false},
+		{404, false},
+		{408, true},
+		{409, true},
+		{429, true},
+		{503, true},
+	}
+	for _, c := range cases {
+		retry, _ := policy.checkRetry(ctx, &gohttp.Response{StatusCode: c.code}, nil)
+		if retry != c.retry {
+			t.Errorf("Status %d: expected retry %t, got %t", c.code, c.retry, retry)
+		}
+	}
+}
+
+func TestEnableRetriesUsesPolicy(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(gohttp.StatusConflict)
+			return
+		}
+		w.WriteHeader(gohttp.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &Config{RetryPolicy: &RetryPolicy{
+		MaxRetries:           2,
+		MinBackoff:           time.Millisecond,
+		MaxBackoff:           time.Millisecond,
+		Jitter:               true,
+		RetryableStatusCodes: []int{409},
+	}}
+	service := &testRetryService{}
+	config.enableRetries(service)
+	resp, err := service.client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != gohttp.StatusOK || calls != 3 {
+		t.Errorf("Expected success after 3 calls, got status %d after %d calls", resp.StatusCode, calls)
+	}
+}
+
+func TestRetryTransportUsesPolicy(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 3 {
+			w.WriteHeader(gohttp.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(gohttp.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &Config{RetryPolicy: &RetryPolicy{
+		MaxRetries: 2,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+	}}
+	client := &gohttp.Client{Transport: config.retryTransport(DefaultTransport())}
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name":"test"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != gohttp.StatusOK || len(bodies) != 3 {
+		t.Errorf("Expected success after 3 calls, got status %d after %d calls", resp.StatusCode, len(bodies))
+	}
+	for _, body := range bodies {
+		if body != `{"name":"test"}` {
+			t.Errorf("Expected every attempt to send the body, got %q", body)
+		}
+	}
+
+	// max_attempts = 1 disables the retries of every client
+	bodies = nil
+	config.RetryPolicy.MaxRetries = 0
+	client = &gohttp.Client{Transport: config.retryTransport(DefaultTransport())}
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	service := &testRetryService{}
+	config.enableRetries(service)
+	// go-sdk-core reports the status of the last attempt as an error
+	if _, err := service.client.Get(server.URL); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Expected the 503 error, got %v", err)
+	}
+	if len(bodies) != 2 {
+		t.Errorf("Expected no retries, got %d calls", len(bodies))
+	}
+}
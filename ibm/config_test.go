@@ -0,0 +1,59 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"testing"
+
+	"github.com/IBM-Cloud/bluemix-go"
+	bxsession "github.com/IBM-Cloud/bluemix-go/session"
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+func testClientSession(visibility string) *clientSession {
+	return &clientSession{
+		session: &Session{
+			BluemixSession: &bxsession.Session{Config: &bluemix.Config{Region: "us-south"}},
+		},
+		config:        &Config{Region: "us-south", Visibility: visibility},
+		authenticator: &core.NoAuthAuthenticator{},
+	}
+}
+
+func TestClientSessionBuildsClientsOnFirstUse(t *testing.T) {
+	sess := testClientSession("public")
+	if sess.vpcAPI != nil || sess.cisDNSRecordsClient != nil {
+		t.Fatal("Expected no client to be built before it is requested")
+	}
+
+	vpcClient, err := sess.VpcV1API()
+	if err != nil {
+		t.Fatalf("Unexpected error building the VPC client: %s", err)
+	}
+	if vpcClient.Service.GetServiceURL() != "https://us-south.iaas.cloud.ibm.com/v1" {
+		t.Errorf("Unexpected VPC endpoint %s", vpcClient.Service.GetServiceURL())
+	}
+	again, _ := sess.VpcV1API()
+	if again != vpcClient {
+		t.Error("Expected the VPC client to be built once")
+	}
+	if sess.cisDNSRecordsClient != nil || sess.directlinkAPI != nil {
+		t.Error("Expected the clients that were not requested to stay unbuilt")
+	}
+}
+
+func TestClientSessionErrors(t *testing.T) {
+	sess := &clientSession{session: &Session{}, config: &Config{}}
+	if _, err := sess.VpcV1API(); err != errEmptyBluemixCredentials {
+		t.Errorf("Expected errEmptyBluemixCredentials without credentials, got %v", err)
+	}
+
+	sess = testClientSession("private")
+	if _, err := sess.CisDNSRecordClientSession(); err == nil {
+		t.Error("Expected an error for CIS with private visibility")
+	}
+	if _, err := sess.TransitGatewayV1API(); err != nil {
+		t.Errorf("Unexpected error building the transit gateway client: %s", err)
+	}
+}
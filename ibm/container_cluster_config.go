@@ -0,0 +1,282 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	gohttp "net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	v1 "github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
+)
+
+const (
+	clusterConfigEndpointPrivate = "private"
+	clusterConfigEndpointLink    = "link"
+)
+
+// clusterConfigClient is the REST client embedded by the container service
+// clients of bluemix-go. Their cluster config calls always write to disk, the
+// in-memory mode uses the client directly.
+type clusterConfigClient interface {
+	Get(path string, respV interface{}, extraHeader ...interface{}) (*gohttp.Response, error)
+	Post(path string, data interface{}, respV interface{}, extraHeader ...interface{}) (*gohttp.Response, error)
+}
+
+// openShiftTokenFetcher logs in an OpenShift cluster and adds the OAuth token
+// to its kubeconfig
+type openShiftTokenFetcher interface {
+	FetchOCTokenForKubeConfig(kubecfg []byte, cMeta *v2.ClusterInfo, skipSSLVerification bool) ([]byte, error)
+}
+
+// clusterKubeConfig is the part of a kubeconfig the provider reads, the
+// certificates are referenced by their file name in the config archive
+type clusterKubeConfig struct {
+	Clusters []struct {
+		Cluster struct {
+			Server               string `json:"server"`
+			CertificateAuthority string `json:"certificate-authority"`
+		} `json:"cluster"`
+	} `json:"clusters"`
+	Users []struct {
+		Name string `json:"name"`
+		User struct {
+			ClientCertificate string `json:"client-certificate"`
+			ClientKey         string `json:"client-key"`
+			Token             string `json:"token"`
+			AuthProvider      struct {
+				Config struct {
+					IDToken string `json:"id-token"`
+				} `json:"config"`
+			} `json:"auth-provider"`
+		} `json:"user"`
+	} `json:"users"`
+}
+
+// fetchClusterConfigInMemory returns the access details of a classic or VPC
+// cluster without writing its config to disk
+func fetchClusterConfigInMemory(d *schema.ResourceData, meta interface{}, name string, admin, network bool, endpointType string) (v1.ClusterKeyInfo, error) {
+	csClient, err := meta.(ClientSession).VpcContainerAPI()
+	if err != nil {
+		return v1.ClusterKeyInfo{}, err
+	}
+	client, ok := csClient.(clusterConfigClient)
+	if !ok {
+		return v1.ClusterKeyInfo{}, fmt.Errorf("The container service client does not support in-memory cluster configs")
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return v1.ClusterKeyInfo{}, err
+	}
+
+	var cluster v2.ClusterInfo
+	_, err = client.Get(fmt.Sprintf("/v2/getCluster?v1-compatible&cluster=%s", url.QueryEscape(name)), &cluster, targetEnv.ToMap())
+	if err != nil {
+		return v1.ClusterKeyInfo{}, fmt.Errorf("Error retrieving the cluster %s: %s", name, err)
+	}
+
+	var config []byte
+	if cluster.Provider == "classic" {
+		classicClient, err := meta.(ClientSession).ContainerAPI()
+		if err != nil {
+			return v1.ClusterKeyInfo{}, err
+		}
+		classicConfigClient, ok := classicClient.(clusterConfigClient)
+		if !ok {
+			return v1.ClusterKeyInfo{}, fmt.Errorf("The container service client does not support in-memory cluster configs")
+		}
+		classicTargetEnv, err := getClusterTargetHeader(d, meta)
+		if err != nil {
+			return v1.ClusterKeyInfo{}, err
+		}
+		config, err = getClassicClusterConfigZip(classicConfigClient, name, admin, network, classicTargetEnv.ToMap())
+		if err != nil {
+			return v1.ClusterKeyInfo{}, err
+		}
+	} else {
+		// satellite clusters are only reachable with the admin config of
+		// the link endpoint
+		if cluster.Provider == "satellite" {
+			admin = true
+			endpointType = clusterConfigEndpointLink
+		}
+		config, err = getClusterConfigZip(client, name, admin, network, endpointType, targetEnv.ToMap())
+		if err != nil {
+			return v1.ClusterKeyInfo{}, err
+		}
+	}
+
+	keyInfo, kubeConfig, err := parseClusterConfigZip(config)
+	if err != nil {
+		return v1.ClusterKeyInfo{}, fmt.Errorf("Error reading the config of the cluster %s: %s", name, err)
+	}
+
+	if cluster.Type == "openshift" && cluster.Provider != "satellite" {
+		fetcher, ok := csClient.Clusters().(openShiftTokenFetcher)
+		if !ok {
+			return v1.ClusterKeyInfo{}, fmt.Errorf("The container service client does not support OpenShift logins")
+		}
+		kubeConfig, err = fetcher.FetchOCTokenForKubeConfig(kubeConfig, &cluster, cluster.IsStagingSatelliteCluster())
+		if err != nil {
+			return v1.ClusterKeyInfo{}, err
+		}
+		if err = setOpenShiftClusterKeyInfo(&keyInfo, kubeConfig); err != nil {
+			return v1.ClusterKeyInfo{}, fmt.Errorf("Error reading the config of the cluster %s: %s", name, err)
+		}
+	}
+
+	// the classic API has no private kubeconfig, the master is reached on
+	// the private service endpoint of the cluster
+	if cluster.Provider == "classic" && endpointType == clusterConfigEndpointPrivate {
+		if cluster.ServiceEndpoints.PrivateServiceEndpointURL == "" {
+			return v1.ClusterKeyInfo{}, fmt.Errorf("The cluster %s has no private service endpoint", name)
+		}
+		keyInfo.Host = cluster.ServiceEndpoints.PrivateServiceEndpointURL
+	}
+
+	return keyInfo, nil
+}
+
+// getClusterConfigZip downloads the config archive of a cluster with the v2 API
+func getClusterConfigZip(client clusterConfigClient, name string, admin, network bool, endpointType string, headers map[string]string) ([]byte, error) {
+	body := map[string]interface{}{
+		"cluster": name,
+		"format":  "zip",
+	}
+	if admin {
+		body["admin"] = true
+	}
+	if network {
+		body["network"] = true
+	}
+	if endpointType != "" {
+		body["endpointType"] = endpointType
+	}
+	var config bytes.Buffer
+	if _, err := client.Post("/v2/applyRBACAndGetKubeconfig", body, &config, headers); err != nil {
+		return nil, fmt.Errorf("Error downloading the config of the cluster %s: %s", name, err)
+	}
+	return config.Bytes(), nil
+}
+
+// getClassicClusterConfigZip downloads the config archive of a classic cluster
+// with the v1 API
+func getClassicClusterConfigZip(client clusterConfigClient, name string, admin, network bool, headers map[string]string) ([]byte, error) {
+	rawURL := fmt.Sprintf("/v1/clusters/%s/config", url.PathEscape(name))
+	if admin {
+		rawURL += "/admin"
+	}
+	if network {
+		rawURL += "?createNetworkConfig=true"
+	}
+	var config bytes.Buffer
+	if _, err := client.Get(rawURL, &config, headers); err != nil {
+		return nil, fmt.Errorf("Error downloading the config of the cluster %s: %s", name, err)
+	}
+	return config.Bytes(), nil
+}
+
+// parseClusterConfigZip reads the access details of a cluster from its config
+// archive. The archive files are matched by name because the v1 archive nests
+// them in a directory, and the certificates are the ones the kubeconfig
+// references since the network config adds the calico certificates.
+func parseClusterConfigZip(config []byte) (v1.ClusterKeyInfo, []byte, error) {
+	keyInfo := v1.ClusterKeyInfo{}
+	archive, err := zip.NewReader(bytes.NewReader(config), int64(len(config)))
+	if err != nil {
+		return keyInfo, nil, err
+	}
+
+	files := map[string]string{}
+	var kubeConfig []byte
+	for _, f := range archive.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return keyInfo, nil, err
+		}
+		content, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return keyInfo, nil, err
+		}
+		fileName := path.Base(f.Name)
+		if strings.HasSuffix(fileName, ".yml") || strings.HasSuffix(fileName, ".yaml") {
+			kubeConfig = content
+			continue
+		}
+		files[fileName] = string(content)
+	}
+	if kubeConfig == nil {
+		return keyInfo, nil, fmt.Errorf("Unable to locate the kubeconfig in the config archive")
+	}
+
+	var yamlConfig clusterKubeConfig
+	if err = yaml.Unmarshal(kubeConfig, &yamlConfig); err != nil {
+		return keyInfo, nil, fmt.Errorf("Error parsing the kubeconfig: %s", err)
+	}
+	caFile, certFile, keyFile := "", "admin.pem", "admin-key.pem"
+	if len(yamlConfig.Clusters) != 0 {
+		keyInfo.Host = yamlConfig.Clusters[0].Cluster.Server
+		if ca := yamlConfig.Clusters[0].Cluster.CertificateAuthority; ca != "" {
+			caFile = path.Base(ca)
+		}
+	}
+	if len(yamlConfig.Users) != 0 {
+		user := yamlConfig.Users[0].User
+		keyInfo.Token = user.AuthProvider.Config.IDToken
+		if keyInfo.Token == "" {
+			keyInfo.Token = user.Token
+		}
+		if user.ClientCertificate != "" {
+			certFile = path.Base(user.ClientCertificate)
+		}
+		if user.ClientKey != "" {
+			keyFile = path.Base(user.ClientKey)
+		}
+	}
+	if caFile == "" {
+		for fileName := range files {
+			if strings.HasPrefix(fileName, "ca") && strings.HasSuffix(fileName, ".pem") {
+				caFile = fileName
+			}
+		}
+	}
+	keyInfo.ClusterCACertificate = files[caFile]
+	keyInfo.Admin = files[certFile]
+	keyInfo.AdminKey = files[keyFile]
+
+	return keyInfo, kubeConfig, nil
+}
+
+// setOpenShiftClusterKeyInfo sets the IAM token of an OpenShift kubeconfig.
+// The OpenShift master serves a public certificate, the CA of the archive is
+// not used.
+func setOpenShiftClusterKeyInfo(keyInfo *v1.ClusterKeyInfo, kubeConfig []byte) error {
+	var yamlConfig clusterKubeConfig
+	if err := yaml.Unmarshal(kubeConfig, &yamlConfig); err != nil {
+		return fmt.Errorf("Error parsing the kubeconfig: %s", err)
+	}
+	for _, user := range yamlConfig.Users {
+		if strings.HasPrefix(user.Name, "IAM") {
+			keyInfo.Token = user.User.Token
+		}
+	}
+	if len(yamlConfig.Clusters) != 0 {
+		keyInfo.Host = yamlConfig.Clusters[0].Cluster.Server
+	}
+	keyInfo.ClusterCACertificate = ""
+	return nil
+}
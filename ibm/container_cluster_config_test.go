@@ -0,0 +1,198 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	gohttp "net/http"
+	"reflect"
+	"testing"
+
+	v1 "github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
+)
+
+const testClusterKubeConfig = `apiVersion: v1
+clusters:
+- name: mycluster/1234
+  cluster:
+    certificate-authority: ca-aaa00-mycluster.pem
+    server: https://c100.us-south.containers.cloud.ibm.com:30001
+contexts:
+- name: mycluster/1234
+  context:
+    cluster: mycluster/1234
+    user: admin
+current-context: mycluster/1234
+kind: Config
+users:
+- name: admin
+  user:
+    client-certificate: admin.pem
+    client-key: admin-key.pem
+`
+
+func testClusterConfigZip(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// testClusterConfigClient serves a config archive and records the requests
+type testClusterConfigClient struct {
+	config []byte
+	path   string
+	body   interface{}
+}
+
+func (c *testClusterConfigClient) Get(path string, respV interface{}, extraHeader ...interface{}) (*gohttp.Response, error) {
+	c.path = path
+	_, err := io.Copy(respV.(io.Writer), bytes.NewReader(c.config))
+	return nil, err
+}
+
+func (c *testClusterConfigClient) Post(path string, data interface{}, respV interface{}, extraHeader ...interface{}) (*gohttp.Response, error) {
+	c.body = data
+	return c.Get(path, respV, extraHeader...)
+}
+
+func TestParseClusterConfigZip(t *testing.T) {
+	// the v1 archive nests the files in a directory and the network config
+	// adds the calico certificates
+	config := testClusterConfigZip(t, map[string]string{
+		"kubeConfig-mycluster/kube-config-dal10-mycluster.yml": testClusterKubeConfig,
+		"kubeConfig-mycluster/ca-aaa00-mycluster.pem":          "cluster ca",
+		"kubeConfig-mycluster/admin.pem":                       "admin cert",
+		"kubeConfig-mycluster/admin-key.pem":                   "admin key",
+		"kubeConfig-mycluster/ca.pem":                          "calico ca",
+		"kubeConfig-mycluster/calicoctl.cfg.template":          "etcdCACertFile: {{ .certDir }}/ca.pem",
+	})
+	keyInfo, kubeConfig, err := parseClusterConfigZip(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(kubeConfig) != testClusterKubeConfig {
+		t.Errorf("Unexpected kubeconfig %s", kubeConfig)
+	}
+	if keyInfo.Host != "https://c100.us-south.containers.cloud.ibm.com:30001" || keyInfo.ClusterCACertificate != "cluster ca" ||
+		keyInfo.Admin != "admin cert" || keyInfo.AdminKey != "admin key" || keyInfo.Token != "" || keyInfo.FilePath != "" {
+		t.Errorf("Unexpected cluster key info %+v", keyInfo)
+	}
+
+	if _, _, err = parseClusterConfigZip(testClusterConfigZip(t, map[string]string{"admin.pem": "admin cert"})); err == nil {
+		t.Error("Expected an error for an archive without kubeconfig")
+	}
+	if _, _, err = parseClusterConfigZip([]byte("not a zip")); err == nil {
+		t.Error("Expected an error for an invalid archive")
+	}
+}
+
+func TestParseClusterConfigZipToken(t *testing.T) {
+	config := testClusterConfigZip(t, map[string]string{
+		"kube-config-aaa00-mycluster.yaml": `apiVersion: v1
+clusters:
+- name: mycluster
+  cluster:
+    server: https://c100.private.us-south.containers.cloud.ibm.com:30001
+users:
+- name: user
+  user:
+    auth-provider:
+      name: oidc
+      config:
+        id-token: id-token-1234
+`,
+		"ca-aaa00-mycluster.pem": "cluster ca",
+	})
+	keyInfo, _, err := parseClusterConfigZip(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyInfo.Host != "https://c100.private.us-south.containers.cloud.ibm.com:30001" || keyInfo.Token != "id-token-1234" ||
+		keyInfo.ClusterCACertificate != "cluster ca" || keyInfo.Admin != "" || keyInfo.AdminKey != "" {
+		t.Errorf("Unexpected cluster key info %+v", keyInfo)
+	}
+
+	if err = setOpenShiftClusterKeyInfo(&keyInfo, []byte(`clusters:
+- cluster:
+    server: https://c100-e.us-south.containers.cloud.ibm.com:30002
+users:
+- name: admin
+  user:
+    client-certificate: admin.pem
+- name: IAM#user@example.com/c100-e-us-south-containers-cloud-ibm-com:30002
+  user:
+    token: sha256~1234
+`)); err != nil {
+		t.Fatal(err)
+	}
+	if keyInfo.Host != "https://c100-e.us-south.containers.cloud.ibm.com:30002" || keyInfo.Token != "sha256~1234" || keyInfo.ClusterCACertificate != "" {
+		t.Errorf("Unexpected OpenShift cluster key info %+v", keyInfo)
+	}
+}
+
+func TestGetClusterConfigZip(t *testing.T) {
+	config := testClusterConfigZip(t, map[string]string{"config.yml": testClusterKubeConfig})
+	client := &testClusterConfigClient{config: config}
+
+	got, err := getClusterConfigZip(client, "mycluster", true, true, clusterConfigEndpointPrivate, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, config) {
+		t.Error("Unexpected config archive")
+	}
+	want := map[string]interface{}{
+		"cluster":      "mycluster",
+		"format":       "zip",
+		"admin":        true,
+		"network":      true,
+		"endpointType": clusterConfigEndpointPrivate,
+	}
+	if client.path != "/v2/applyRBACAndGetKubeconfig" || !reflect.DeepEqual(client.body, want) {
+		t.Errorf("Unexpected request %s %v", client.path, client.body)
+	}
+
+	cases := []struct {
+		admin, network bool
+		path           string
+	}{
+		{false, false, "/v1/clusters/my%20cluster/config"},
+		{true, false, "/v1/clusters/my%20cluster/config/admin"},
+		{true, true, "/v1/clusters/my%20cluster/config/admin?createNetworkConfig=true"},
+	}
+	for _, c := range cases {
+		if _, err = getClassicClusterConfigZip(client, "my cluster", c.admin, c.network, nil); err != nil {
+			t.Fatal(err)
+		}
+		if client.path != c.path {
+			t.Errorf("Expected %s, got %s", c.path, client.path)
+		}
+	}
+}
+
+func TestRetryClusterConfig(t *testing.T) {
+	calls := 0
+	_, err := retryClusterConfig(func() (v1.ClusterKeyInfo, error) {
+		calls++
+		return v1.ClusterKeyInfo{}, fmt.Errorf("Invalid cluster")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("Expected a single failed call, got %d calls and %v", calls, err)
+	}
+}
@@ -5,7 +5,6 @@ package ibm
 
 import (
 	"fmt"
-	"io/ioutil"
 	"log"
 	"os"
 	"time"
@@ -147,15 +146,8 @@ func dataSourceIBMCISDNSRecordsRead(d *schema.ResourceData, meta interface{}) er
 		}
 		sess.Crn = core.StringPtr(crn)
 		sess.ZoneIdentifier = core.StringPtr(zoneID)
-		opt := sess.NewGetDnsRecordsBulkOptions()
-		result, response, err := sess.GetDnsRecordsBulk(opt)
+		buf, err := exportCISDNSRecords(sess)
 		if err != nil {
-			log.Printf("Error exporting dns records: %s", response)
-			return err
-		}
-		buf, err := ioutil.ReadAll(result)
-		if err != nil {
-			log.Printf("Error while reading io reader")
 			return err
 		}
 
@@ -0,0 +1,65 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	cisDNSRecordsExportZoneFile = "zone_file"
+)
+
+func dataSourceIBMCISDNSRecordsExport() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMCISDNSRecordsExportRead,
+
+		Schema: map[string]*schema.Schema{
+			cisID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "CIS instance crn",
+			},
+			cisDomainID: {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Associated CIS domain",
+				DiffSuppressFunc: suppressDomainIDDiff,
+			},
+			cisDNSRecordsExportZoneFile: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The dns records of the zone as a BIND zone file",
+			},
+		},
+	}
+}
+
+func dataSourceIBMCISDNSRecordsExportRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := meta.(ClientSession).CisDNSRecordBulkClientSession()
+	if err != nil {
+		return err
+	}
+
+	crn := d.Get(cisID).(string)
+	zoneID, _, err := convertTftoCisTwoVar(d.Get(cisDomainID).(string))
+	if err != nil {
+		return err
+	}
+	sess.Crn = core.StringPtr(crn)
+	sess.ZoneIdentifier = core.StringPtr(zoneID)
+
+	zoneFile, err := exportCISDNSRecords(sess)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error exporting the dns records of the zone %s: %s", zoneID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", zoneID, crn))
+	d.Set(cisDomainID, zoneID)
+	d.Set(cisDNSRecordsExportZoneFile, string(zoneFile))
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMCisDNSRecordsExportDataSource_basic(t *testing.T) {
+	node := "data.ibm_cis_dns_records_export.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckCis(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCisDNSRecordsExportDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(node, "zone_file",
+						regexp.MustCompile(fmt.Sprintf(`test\.%s\.\s+\d+\s+IN\s+A\s+192\.168\.0\.10`, regexp.QuoteMeta(cisDomainStatic)))),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCisDNSRecordsExportDataSourceConfig() string {
+	return testAccCheckIBMCisDNSRecordConfigCisDSBasic("test", cisDomainStatic) + `
+	data "ibm_cis_dns_records_export" "test" {
+		cis_id    = data.ibm_cis.cis.id
+		domain_id = ibm_cis_dns_record.test.domain_id
+	}`
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	homedir "github.com/mitchellh/go-homedir"
 
 	v1 "github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
@@ -82,6 +83,19 @@ func dataSourceIBMContainerClusterConfig() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			"in_memory": {
+				Description:   "If set to true, the cluster config is fetched in memory and only returned as attributes, nothing is written to config_dir",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"config_dir"},
+			},
+			"endpoint_type": {
+				Description:  "The endpoint of the cluster master to connect to, private for the private service endpoint. Supported with in_memory only",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{clusterConfigEndpointPrivate}, false),
+			},
 			"config_file_path": {
 				Description: "The absolute path to the kubernetes config yml file ",
 				Type:        schema.TypeString,
@@ -121,16 +135,34 @@ func dataSourceIBMContainerClusterConfig() *schema.Resource {
 }
 
 func dataSourceIBMContainerClusterConfigRead(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("cluster_name_id").(string)
+	admin := d.Get("admin").(bool)
+	network := d.Get("network").(bool)
+
+	if d.Get("in_memory").(bool) {
+		endpointType := d.Get("endpoint_type").(string)
+		clusterKeyDetails, err := retryClusterConfig(func() (v1.ClusterKeyInfo, error) {
+			// For the Network config we need to gather the certs so we must override the admin value
+			return fetchClusterConfigInMemory(d, meta, name, admin || network, network, endpointType)
+		})
+		if err != nil {
+			return fmt.Errorf("Error fetching the cluster config [%s]: %s", name, err)
+		}
+		setClusterKeyDetails(d, clusterKeyDetails)
+		d.SetId(name)
+		return nil
+	}
+	if _, ok := d.GetOk("endpoint_type"); ok {
+		return fmt.Errorf(`"endpoint_type" is only supported when "in_memory" is set to true`)
+	}
+
 	csClient, err := meta.(ClientSession).VpcContainerAPI()
 	if err != nil {
 		return err
 	}
 	csAPI := csClient.Clusters()
-	name := d.Get("cluster_name_id").(string)
 	download := d.Get("download").(bool)
-	admin := d.Get("admin").(bool)
 	configDir := d.Get("config_dir").(string)
-	network := d.Get("network").(bool)
 
 	if len(configDir) == 0 {
 		configDir, err = homedir.Dir()
@@ -158,58 +190,27 @@ func dataSourceIBMContainerClusterConfigRead(d *schema.ResourceData, meta interf
 		if network {
 			// For the Network config we need to gather the certs so we must override the admin value
 			var calicoConfigFilePath string
-			var clusterKeyDetails v1.ClusterKeyInfo
-			err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+			clusterKeyDetails, err := retryClusterConfig(func() (v1.ClusterKeyInfo, error) {
+				var clusterKeyDetails v1.ClusterKeyInfo
 				var err error
 				calicoConfigFilePath, clusterKeyDetails, err = csAPI.StoreConfigDetail(name, configDir, admin || true, network, targetEnv)
-				if err != nil {
-					log.Printf("[DEBUG] Failed to fetch cluster config err %s", err)
-					if strings.Contains(err.Error(), "Could not login to openshift account runtime error:") {
-						return resource.RetryableError(err)
-					}
-					return resource.NonRetryableError(err)
-				}
-				return nil
+				return clusterKeyDetails, err
 			})
-			if isResourceTimeoutError(err) {
-				calicoConfigFilePath, clusterKeyDetails, err = csAPI.StoreConfigDetail(name, configDir, admin || true, network, targetEnv)
-			}
 			if err != nil {
 				return fmt.Errorf("Error downloading the cluster config [%s]: %s", name, err)
 			}
 			d.Set("calico_config_file_path", calicoConfigFilePath)
-			d.Set("admin_key", clusterKeyDetails.AdminKey)
-			d.Set("admin_certificate", clusterKeyDetails.Admin)
-			d.Set("ca_certificate", clusterKeyDetails.ClusterCACertificate)
-			d.Set("host", clusterKeyDetails.Host)
-			d.Set("token", clusterKeyDetails.Token)
+			setClusterKeyDetails(d, clusterKeyDetails)
 			d.Set("config_file_path", clusterKeyDetails.FilePath)
 
 		} else {
-			var clusterKeyDetails v1.ClusterKeyInfo
-			err = resource.Retry(5*time.Minute, func() *resource.RetryError {
-				var err error
-				clusterKeyDetails, err = csAPI.GetClusterConfigDetail(name, configDir, admin, targetEnv)
-				if err != nil {
-					log.Printf("[DEBUG] Failed to fetch cluster config err %s", err)
-					if strings.Contains(err.Error(), "Could not login to openshift account runtime error:") {
-						return resource.RetryableError(err)
-					}
-					return resource.NonRetryableError(err)
-				}
-				return nil
+			clusterKeyDetails, err := retryClusterConfig(func() (v1.ClusterKeyInfo, error) {
+				return csAPI.GetClusterConfigDetail(name, configDir, admin, targetEnv)
 			})
-			if isResourceTimeoutError(err) {
-				clusterKeyDetails, err = csAPI.GetClusterConfigDetail(name, configDir, admin, targetEnv)
-			}
 			if err != nil {
 				return fmt.Errorf("Error downloading the cluster config [%s]: %s", name, err)
 			}
-			d.Set("admin_key", clusterKeyDetails.AdminKey)
-			d.Set("admin_certificate", clusterKeyDetails.Admin)
-			d.Set("ca_certificate", clusterKeyDetails.ClusterCACertificate)
-			d.Set("host", clusterKeyDetails.Host)
-			d.Set("token", clusterKeyDetails.Token)
+			setClusterKeyDetails(d, clusterKeyDetails)
 			d.Set("config_file_path", clusterKeyDetails.FilePath)
 		}
 	}
@@ -218,3 +219,33 @@ func dataSourceIBMContainerClusterConfigRead(d *schema.ResourceData, meta interf
 	d.Set("config_dir", configDir)
 	return nil
 }
+
+// retryClusterConfig retries the config fetch while the login to an OpenShift
+// cluster fails
+func retryClusterConfig(fetch func() (v1.ClusterKeyInfo, error)) (v1.ClusterKeyInfo, error) {
+	var clusterKeyDetails v1.ClusterKeyInfo
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		var err error
+		clusterKeyDetails, err = fetch()
+		if err != nil {
+			log.Printf("[DEBUG] Failed to fetch cluster config err %s", err)
+			if strings.Contains(err.Error(), "Could not login to openshift account runtime error:") {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		clusterKeyDetails, err = fetch()
+	}
+	return clusterKeyDetails, err
+}
+
+func setClusterKeyDetails(d *schema.ResourceData, clusterKeyDetails v1.ClusterKeyInfo) {
+	d.Set("admin_key", clusterKeyDetails.AdminKey)
+	d.Set("admin_certificate", clusterKeyDetails.Admin)
+	d.Set("ca_certificate", clusterKeyDetails.ClusterCACertificate)
+	d.Set("host", clusterKeyDetails.Host)
+	d.Set("token", clusterKeyDetails.Token)
+}
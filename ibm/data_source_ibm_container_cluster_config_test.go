@@ -58,6 +58,31 @@ func TestAccIBMContainer_ClusterConfigCalicoDataSourceBasic(t *testing.T) {
 	})
 }
 
+func TestAccIBMContainer_ClusterConfigInMemoryDataSourceBasic(t *testing.T) {
+	clusterName := fmt.Sprintf("tf-cluster-config-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerClusterInMemoryConfigDataSource(clusterName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_container_cluster_config.testacc_ds_cluster", "config_dir", ""),
+					resource.TestCheckResourceAttr("data.ibm_container_cluster_config.testacc_ds_cluster", "config_file_path", ""),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "host"),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "ca_certificate"),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "admin_certificate"),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "admin_key"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckIBMContainerClusterDataSourceConfig(clustername string) string {
 	return fmt.Sprintf(`
 resource "ibm_container_cluster" "testacc_cluster" {
@@ -92,3 +117,22 @@ data "ibm_container_cluster_config" "testacc_ds_cluster" {
   network         = true
 }`, clustername, datacenter, machineType, publicVlanID, privateVlanID)
 }
+
+func testAccCheckIBMContainerClusterInMemoryConfigDataSource(clustername string) string {
+	return fmt.Sprintf(`
+resource "ibm_container_cluster" "testacc_cluster" {
+  name            = "%s"
+  datacenter      = "%s"
+  machine_type    = "%s"
+  hardware        = "shared"
+  wait_till       = "MasterNodeReady"
+  public_vlan_id  = "%s"
+  private_vlan_id = "%s"
+}
+
+data "ibm_container_cluster_config" "testacc_ds_cluster" {
+  cluster_name_id = ibm_container_cluster.testacc_cluster.id
+  admin           = true
+  in_memory       = true
+}`, clustername, datacenter, machineType, publicVlanID, privateVlanID)
+}
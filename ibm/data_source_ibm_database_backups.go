@@ -0,0 +1,165 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceIBMDatabaseBackups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMDatabaseBackupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID (CRN) of the database deployment",
+			},
+			"backup_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"scheduled", "on_demand"}, false),
+				Description:  "Filter the backups by type: scheduled or on_demand",
+			},
+			"restorable_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only list the backups that can be restored to a new deployment",
+			},
+			"backups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The backups of the deployment, most recent first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backup_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID (CRN) of the backup",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the backup: scheduled or on_demand",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the backup",
+						},
+						"is_downloadable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the backup can be downloaded",
+						},
+						"is_restorable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the backup can be restored to a new deployment",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The creation time of the backup",
+						},
+					},
+				},
+			},
+			"point_in_time_recovery_supported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the deployment can be restored to a point in time",
+			},
+			"earliest_point_in_time_recovery_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The earliest time the deployment can be restored to, the latest is the current time",
+			},
+			"service": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The service of the deployment, for the ibm_database that restores a backup",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The database version of the deployment",
+			},
+		},
+	}
+}
+
+func dataSourceIBMDatabaseBackupsRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	icdId := EscapeUrlParm(deploymentID)
+	cdb, err := icdClient.Cdbs().GetCdb(icdId)
+	if err != nil {
+		return diagFromErr(newAPIError("Error getting database", err, nil).withAttributes("deployment_id"))
+	}
+	backups, err := listDatabaseBackups(client, icdId)
+	if err != nil {
+		return diagFromErr(newAPIError("Error getting database backups", err, nil))
+	}
+
+	earliestPITRTime := ""
+	pitrSupported := databaseSupportsPITR(cdb.Type)
+	if pitrSupported {
+		earliestPITRTime, err = getDatabaseEarliestPITRTime(client, icdId)
+		if err != nil {
+			return diagFromErr(newAPIError("Error getting database point in time recovery data", err, nil))
+		}
+	}
+
+	d.SetId(deploymentID)
+	d.Set("backups", flattenDatabaseBackups(backups, d.Get("backup_type").(string), d.Get("restorable_only").(bool)))
+	d.Set("point_in_time_recovery_supported", pitrSupported)
+	d.Set("earliest_point_in_time_recovery_time", earliestPITRTime)
+	d.Set("service", databaseTypeService(cdb.Type))
+	d.Set("version", cdb.Version)
+
+	return nil
+}
+
+func flattenDatabaseBackups(backups []databaseBackup, backupType string, restorableOnly bool) []map[string]interface{} {
+	sorted := make([]databaseBackup, 0, len(backups))
+	for _, backup := range backups {
+		if (backupType != "" && backup.Type != backupType) || (restorableOnly && !backup.IsRestorable) {
+			continue
+		}
+		sorted = append(sorted, backup)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt > sorted[j].CreatedAt
+	})
+
+	result := make([]map[string]interface{}, 0, len(sorted))
+	for _, backup := range sorted {
+		result = append(result, map[string]interface{}{
+			"backup_id":       backup.ID,
+			"type":            backup.Type,
+			"status":          backup.Status,
+			"is_downloadable": backup.IsDownloadable,
+			"is_restorable":   backup.IsRestorable,
+			"created_at":      backup.CreatedAt,
+		})
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabaseBackupsDataSource_Basic(t *testing.T) {
+	databaseResourceGroup := "default"
+	testName := fmt.Sprintf("tf-Pgress-%d", acctest.RandIntRange(10, 100))
+	name := "data.ibm_database_backups.backups"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseBackupsDataSourceConfig(databaseResourceGroup, testName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "backups.#", "1"),
+					resource.TestCheckResourceAttrPair(name, "backups.0.backup_id", "ibm_database_backup.backup", "id"),
+					resource.TestCheckResourceAttr(name, "service", "databases-for-postgresql"),
+					resource.TestCheckResourceAttr(name, "point_in_time_recovery_supported", "true"),
+					resource.TestCheckResourceAttrSet(name, "earliest_point_in_time_recovery_time"),
+					resource.TestCheckResourceAttrPair(name, "version", "ibm_database."+testName, "version"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenDatabaseBackups(t *testing.T) {
+	backups := []databaseBackup{
+		{ID: "backup-1", Type: "scheduled", IsRestorable: true, CreatedAt: "2021-08-01T00:00:00Z"},
+		{ID: "backup-2", Type: "on_demand", IsRestorable: false, CreatedAt: "2021-08-03T00:00:00Z"},
+		{ID: "backup-3", Type: "on_demand", IsRestorable: true, CreatedAt: "2021-08-02T00:00:00Z"},
+	}
+	for _, tc := range []struct {
+		backupType     string
+		restorableOnly bool
+		want           []string
+	}{
+		{"", false, []string{"backup-2", "backup-3", "backup-1"}},
+		{"on_demand", false, []string{"backup-2", "backup-3"}},
+		{"", true, []string{"backup-3", "backup-1"}},
+		{"scheduled", true, []string{"backup-1"}},
+	} {
+		var got []string
+		for _, backup := range flattenDatabaseBackups(backups, tc.backupType, tc.restorableOnly) {
+			got = append(got, backup["backup_id"].(string))
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("%q, %t: expected %q, got %q", tc.backupType, tc.restorableOnly, tc.want, got)
+		}
+	}
+}
+
+func testAccCheckIBMDatabaseBackupsDataSourceConfig(databaseResourceGroup, name string) string {
+	return testAccCheckIBMDatabaseBackupConfig(databaseResourceGroup, name) + `
+	data "ibm_database_backups" "backups" {
+		deployment_id = ibm_database_backup.backup.deployment_id
+		backup_type   = "on_demand"
+	}
+	`
+}
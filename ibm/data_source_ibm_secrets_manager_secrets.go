@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	"github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
@@ -255,46 +254,11 @@ func datasourceIBMSecretsManagerSecretsValidator() *ResourceValidator {
 }
 
 func dataSourceIBMSecretsManagerSecretsRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	bluemixSession, err := meta.(ClientSession).BluemixSession()
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	region := bluemixSession.Config.Region
-
-	secretsManagerClient, err := meta.(ClientSession).SecretsManagerV1()
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	rContollerClient, err := meta.(ClientSession).ResourceControllerAPIV2()
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
 	instanceID := d.Get("instance_id").(string)
-	endpointType := d.Get("endpoint_type").(string)
-	var smEndpointURL string
-
-	rContollerAPI := rContollerClient.ResourceServiceInstanceV2()
-
-	instanceData, err := rContollerAPI.GetInstance(instanceID)
+	secretsManagerClient, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	instanceCRN := instanceData.Crn.String()
-
-	crnData := strings.Split(instanceCRN, ":")
-
-	if crnData[4] == "secrets-manager" {
-		if endpointType == "private" {
-			smEndpointURL = "https://" + instanceID + "private" + "." + region + ".secrets-manager.appdomain.cloud"
-		} else {
-			smEndpointURL = "https://" + instanceID + "." + region + ".secrets-manager.appdomain.cloud"
-		}
-		smUrl := envFallBack([]string{"IBMCLOUD_SECRETS_MANAGER_API_ENDPOINT"}, smEndpointURL)
-		secretsManagerClient.Service.Options.URL = smUrl
-	} else {
-		return diag.FromErr(fmt.Errorf("Invalid or unsupported service Instance"))
-	}
 
 	listAllSecretsOptions := &secretsmanagerv1.ListAllSecretsOptions{}
 
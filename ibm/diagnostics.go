@@ -0,0 +1,226 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// apiError is a failed call to an IBM Cloud API along with the details users
+// need to fix their configuration or to open a support case: the HTTP status,
+// the error code and the request ID the service assigned to the call.
+type apiError struct {
+	// The failed operation, ex: "Error creating VPC"
+	Summary string
+	Err     error
+
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+
+	// The field the service rejected, as named by the API
+	Target string
+	// The argument of Target, nil when it is unknown
+	AttributePath cty.Path
+}
+
+// newAPIError returns the apiError of err, the error of the operation
+// summary. response is the *core.DetailedResponse of the call for the
+// services using the IBM go-sdk-core, nil for the other SDKs whose errors
+// carry the response details (bmxerror.RequestFailure, sl.Error). err is nil
+// when the call succeeded without returning a result.
+func newAPIError(summary string, err error, response *core.DetailedResponse) *apiError {
+	e := &apiError{
+		Summary: summary,
+		Err:     err,
+		Message: "the service returned no result",
+	}
+	if err != nil {
+		e.Message = err.Error()
+	}
+
+	switch t := err.(type) {
+	case bmxerror.RequestFailure:
+		e.StatusCode = t.StatusCode()
+		e.Code = t.Code()
+		e.Message = t.Description()
+		var body map[string]interface{}
+		if json.Unmarshal([]byte(t.Description()), &body) == nil {
+			e.parseBody(body)
+		}
+	case sl.Error:
+		e.StatusCode = t.StatusCode
+		e.Code = t.Exception
+		if t.Message != "" {
+			e.Message = t.Message
+		}
+	}
+
+	if response != nil {
+		e.StatusCode = response.StatusCode
+		for _, h := range requestIDHeaders {
+			if id := response.Headers.Get(h); id != "" {
+				e.RequestID = id
+				break
+			}
+		}
+		if body, ok := response.Result.(map[string]interface{}); ok {
+			e.parseBody(body)
+		}
+	}
+	e.Message = strings.TrimSpace(e.Message)
+	return e
+}
+
+// parseBody reads the details of the error response body of the services:
+//
+//	{"errors": [{"code": "", "message": "", "target": {"name": ""}}], "trace": ""}
+//	{"code": "", "description": "", "incidentID": ""}
+//	{"error": "", "message": "", "transaction_id": ""}
+func (e *apiError) parseBody(body map[string]interface{}) {
+	var code, message string
+	if errs, ok := body["errors"].([]interface{}); ok && len(errs) > 0 {
+		if first, ok := errs[0].(map[string]interface{}); ok {
+			code = firstString(first, "code")
+			message = firstString(first, "message")
+			if target, ok := first["target"].(map[string]interface{}); ok {
+				e.Target = firstString(target, "name")
+			}
+		}
+	}
+	if code == "" {
+		code = firstString(body, "code", "errorCode")
+	}
+	if message == "" {
+		message = firstString(body, "description", "message", "error", "errorMessage")
+	}
+	if code != "" {
+		e.Code = code
+	}
+	if message != "" {
+		e.Message = message
+	}
+	if e.RequestID == "" {
+		e.RequestID = firstString(body, "trace", "incidentID", "transaction_id", "request_id")
+	}
+}
+
+// firstString returns the first non empty string value of keys in m.
+func firstString(m map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		if v, ok := m[k].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// withAttributes reports the field the service rejected on its argument.
+// attributes are the arguments the request was built from, with the form
+// "attribute" when the API names the field the same, or "field=attribute"
+// otherwise. A nested argument is given by its path: "boot_volume.0.name".
+func (e *apiError) withAttributes(attributes ...string) *apiError {
+	if e.Target == "" {
+		return e
+	}
+	for _, a := range attributes {
+		field, attribute := a, a
+		if i := strings.Index(a, "="); i >= 0 {
+			field, attribute = a[:i], a[i+1:]
+		}
+		if e.Target == field || strings.HasPrefix(e.Target, field+".") {
+			e.AttributePath = attributePath(attribute)
+			return e
+		}
+	}
+	return e
+}
+
+func (e *apiError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Summary, e.Message)
+	if details := e.details(); len(details) > 0 {
+		msg = fmt.Sprintf("%s (%s)", msg, strings.Join(details, ", "))
+	}
+	return msg
+}
+
+func (e *apiError) Unwrap() error {
+	return e.Err
+}
+
+func (e *apiError) details() []string {
+	var details []string
+	if e.StatusCode != 0 {
+		details = append(details, fmt.Sprintf("HTTP %d", e.StatusCode))
+	}
+	if e.Code != "" {
+		details = append(details, "code: "+e.Code)
+	}
+	if e.RequestID != "" {
+		details = append(details, "request ID: "+e.RequestID)
+	}
+	return details
+}
+
+// Diagnostics returns the error diagnostic of e, on its argument when it is
+// known.
+func (e *apiError) Diagnostics() diag.Diagnostics {
+	var detail []string
+	if e.StatusCode != 0 {
+		detail = append(detail, fmt.Sprintf("HTTP status: %d", e.StatusCode))
+	}
+	if e.Code != "" {
+		detail = append(detail, "Error code: "+e.Code)
+	}
+	if e.Target != "" {
+		detail = append(detail, "Rejected field: "+e.Target)
+	}
+	if e.RequestID != "" {
+		detail = append(detail, "Request ID: "+e.RequestID,
+			"Include the request ID when you open a support case with IBM Cloud.")
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("%s: %s", e.Summary, e.Message),
+			Detail:        strings.Join(detail, "\n"),
+			AttributePath: e.AttributePath,
+		},
+	}
+}
+
+// diagFromErr returns the diagnostics of err, with the details of the API
+// error it wraps if any.
+func diagFromErr(err error) diag.Diagnostics {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.Diagnostics()
+	}
+	return diag.FromErr(err)
+}
+
+// attributePath returns the path of the argument key, a dotted path whose
+// numeric parts are list indexes: "boot_volume.0.name".
+func attributePath(key string) cty.Path {
+	var path cty.Path
+	for _, part := range strings.Split(key, ".") {
+		if i, err := strconv.Atoi(part); err == nil {
+			path = path.IndexInt(i)
+		} else {
+			path = path.GetAttr(part)
+		}
+	}
+	return path
+}
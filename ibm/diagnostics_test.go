@@ -0,0 +1,185 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"errors"
+	"fmt"
+	gohttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestNewAPIErrorVPC(t *testing.T) {
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "4b2a5d3c-req")
+		w.WriteHeader(gohttp.StatusBadRequest)
+		fmt.Fprint(w, `{
+			"errors": [{"code": "resource_group_not_found", "message": "Resource group not found", "target": {"name": "resource_group.id", "type": "field"}}],
+			"trace": "9c1f-trace"
+		}`)
+	}))
+	defer server.Close()
+	sess, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := "vpc"
+	_, response, err := sess.CreateVPC(&vpcv1.CreateVPCOptions{Name: &name})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	apiErr := newAPIError("Error while creating VPC", err, response).withAttributes(isVPCName, isVPCResourceGroup)
+
+	want := &apiError{
+		Summary:       "Error while creating VPC",
+		Err:           err,
+		StatusCode:    400,
+		Code:          "resource_group_not_found",
+		Message:       "Resource group not found",
+		RequestID:     "4b2a5d3c-req",
+		Target:        "resource_group.id",
+		AttributePath: cty.GetAttrPath("resource_group"),
+	}
+	if fmt.Sprintf("%#v", apiErr) != fmt.Sprintf("%#v", want) {
+		t.Errorf("Expected %#v, got %#v", want, apiErr)
+	}
+
+	wantErr := "Error while creating VPC: Resource group not found (HTTP 400, code: resource_group_not_found, request ID: 4b2a5d3c-req)"
+	if apiErr.Error() != wantErr {
+		t.Errorf("Expected %q, got %q", wantErr, apiErr.Error())
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		response *core.DetailedResponse
+		want     apiError
+	}{
+		"bluemix": {
+			err: bmxerror.NewRequestFailure("ServerErrorResponse", `{"incidentID": "abc-123", "code": "E0007", "description": "The cluster name is not valid.", "type": "General"}`, 409),
+			want: apiError{
+				StatusCode: 409,
+				Code:       "E0007",
+				Message:    "The cluster name is not valid.",
+				RequestID:  "abc-123",
+			},
+		},
+		"bluemix text": {
+			err:  bmxerror.NewRequestFailure("ServerErrorResponse", "Bad Gateway\n", 502),
+			want: apiError{StatusCode: 502, Code: "ServerErrorResponse", Message: "Bad Gateway"},
+		},
+		"softlayer": {
+			err: sl.Error{StatusCode: 404, Exception: "SoftLayer_Exception_ObjectNotFound", Message: "Unable to find object with id of '42'."},
+			want: apiError{
+				StatusCode: 404,
+				Code:       "SoftLayer_Exception_ObjectNotFound",
+				Message:    "Unable to find object with id of '42'.",
+			},
+		},
+		"platform services": {
+			err: errors.New("Forbidden"),
+			response: &core.DetailedResponse{
+				StatusCode: 403,
+				Headers:    gohttp.Header{"Transaction-Id": []string{"bss-1234"}},
+				Result:     map[string]interface{}{"errors": []interface{}{map[string]interface{}{"code": "forbidden", "message": "Forbidden"}}, "trace": "trace-5678"},
+			},
+			want: apiError{StatusCode: 403, Code: "forbidden", Message: "Forbidden", RequestID: "bss-1234"},
+		},
+		"trace of the body": {
+			err: errors.New("Not Found"),
+			response: &core.DetailedResponse{
+				StatusCode: 404,
+				Headers:    gohttp.Header{},
+				Result:     map[string]interface{}{"message": "Secret not found", "trace": "trace-5678"},
+			},
+			want: apiError{StatusCode: 404, Message: "Secret not found", RequestID: "trace-5678"},
+		},
+		"network": {
+			err:  errors.New("dial tcp: i/o timeout"),
+			want: apiError{Message: "dial tcp: i/o timeout"},
+		},
+		"no result": {
+			response: &core.DetailedResponse{StatusCode: 200, Headers: gohttp.Header{}},
+			want:     apiError{StatusCode: 200, Message: "the service returned no result"},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := newAPIError("Error", c.err, c.response)
+			c.want.Summary, c.want.Err = "Error", c.err
+			if fmt.Sprintf("%#v", *got) != fmt.Sprintf("%#v", c.want) {
+				t.Errorf("Expected %#v, got %#v", c.want, *got)
+			}
+		})
+	}
+}
+
+func TestAPIErrorWithAttributes(t *testing.T) {
+	cases := map[string]struct {
+		target string
+		want   cty.Path
+	}{
+		"same name":       {"name", cty.GetAttrPath("name")},
+		"nested field":    {"vpc.id", cty.GetAttrPath("vpc")},
+		"renamed field":   {"boot_volume_attachment.volume.name", cty.GetAttrPath("boot_volume").IndexInt(0).GetAttr("name")},
+		"unknown field":   {"keys.0.id", nil},
+		"prefix of field": {"vpc_name", nil},
+		"no target":       {"", nil},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &apiError{Target: c.target}
+			e.withAttributes("name", "vpc", "boot_volume_attachment=boot_volume.0.name")
+			if !e.AttributePath.Equals(c.want) {
+				t.Errorf("Expected the path %#v, got %#v", c.want, e.AttributePath)
+			}
+		})
+	}
+}
+
+func TestDiagFromErr(t *testing.T) {
+	apiErr := &apiError{
+		Summary:       "Error creating volume",
+		Err:           errors.New("Invalid capacity"),
+		StatusCode:    400,
+		Code:          "volume_capacity_invalid",
+		Message:       "Invalid capacity",
+		RequestID:     "req-42",
+		Target:        "capacity",
+		AttributePath: cty.GetAttrPath("capacity"),
+	}
+	diags := diagFromErr(fmt.Errorf("Error waiting: %w", apiErr))
+	if len(diags) != 1 {
+		t.Fatalf("Expected one diagnostic, got %v", diags)
+	}
+	d := diags[0]
+	if d.Severity != diag.Error || d.Summary != "Error creating volume: Invalid capacity" || !d.AttributePath.Equals(cty.GetAttrPath("capacity")) {
+		t.Errorf("Unexpected diagnostic %#v", d)
+	}
+	for _, want := range []string{"HTTP status: 400", "Error code: volume_capacity_invalid", "Rejected field: capacity", "Request ID: req-42"} {
+		if !strings.Contains(d.Detail, want) {
+			t.Errorf("Expected %q in the detail, got %q", want, d.Detail)
+		}
+	}
+
+	diags = diagFromErr(errors.New("plain"))
+	if len(diags) != 1 || diags[0].Summary != "plain" || diags[0].AttributePath != nil {
+		t.Errorf("Expected the error as is, got %#v", diags)
+	}
+}
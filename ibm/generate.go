@@ -0,0 +1,417 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/IBM-Cloud/bluemix-go/api/resource/resourcev2/controllerv2"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// GenerateOptions configures Generate.
+type GenerateOptions struct {
+	// OutputDir is the directory the .tf files are written to
+	OutputDir string
+	// Region of the generated resources, the provider default when empty
+	Region string
+	// ResourceTypes limits the generated resources, every supported type when empty
+	ResourceTypes []string
+}
+
+// discoveredResource is an existing resource found in the account
+type discoveredResource struct {
+	id   string
+	name string
+}
+
+// resourceDiscoverer lists the existing resources of a resource type
+type resourceDiscoverer struct {
+	resourceType string
+	list         func(meta interface{}) ([]discoveredResource, error)
+}
+
+// generatorDiscoverers are the resource types Generate supports, in the order
+// they are generated. Resources are referenced by the resources after them.
+var generatorDiscoverers = []resourceDiscoverer{
+	{"ibm_is_vpc", discoverISVPCs},
+	{"ibm_is_subnet", discoverISSubnets},
+	{"ibm_is_security_group", discoverISSecurityGroups},
+	{"ibm_is_instance", discoverISInstances},
+	{"ibm_cos_bucket", discoverCOSBuckets},
+	{"ibm_iam_access_group", discoverIAMAccessGroups},
+}
+
+// GenerateResourceTypes returns the resource types Generate supports.
+func GenerateResourceTypes() []string {
+	types := make([]string, 0, len(generatorDiscoverers))
+	for _, g := range generatorDiscoverers {
+		types = append(types, g.resourceType)
+	}
+	return types
+}
+
+// Generate writes the Terraform configuration of the existing resources of
+// the account the provider environment variables authenticate to, one file
+// per resource type, and an imports.tf file with the import blocks that adopt
+// them into the state.
+func Generate(ctx context.Context, opts GenerateOptions) error {
+	discoverers, err := selectDiscoverers(opts.ResourceTypes)
+	if err != nil {
+		return err
+	}
+
+	provider := Provider()
+	raw := map[string]interface{}{}
+	if opts.Region != "" {
+		raw["region"] = opts.Region
+	}
+	if err := diagError(provider.Configure(ctx, terraform.NewResourceConfigRaw(raw))); err != nil {
+		return fmt.Errorf("Error configuring the provider: %s", err)
+	}
+	meta := provider.Meta()
+
+	g := newConfigGenerator()
+	for _, discoverer := range discoverers {
+		resource := provider.ResourcesMap[discoverer.resourceType]
+		found, err := discoverer.list(meta)
+		if err != nil {
+			return fmt.Errorf("Error listing %s resources: %s", discoverer.resourceType, err)
+		}
+		log.Printf("[INFO] Found %d %s resources", len(found), discoverer.resourceType)
+		for _, f := range found {
+			d, err := readDiscoveredResource(ctx, resource, f.id, meta)
+			if err != nil {
+				return fmt.Errorf("Error reading %s %s: %s", discoverer.resourceType, f.id, err)
+			}
+			if d == nil {
+				log.Printf("[WARN] %s %s no longer exists, skipping it", discoverer.resourceType, f.id)
+				continue
+			}
+			g.add(discoverer.resourceType, f.name, resource, d)
+		}
+	}
+
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return err
+	}
+	for name, content := range g.files() {
+		path := filepath.Join(opts.OutputDir, name)
+		if err := ioutil.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("Error writing %s: %s", path, err)
+		}
+		log.Printf("[INFO] Wrote %s", path)
+	}
+	return nil
+}
+
+func selectDiscoverers(resourceTypes []string) ([]resourceDiscoverer, error) {
+	if len(resourceTypes) == 0 {
+		return generatorDiscoverers, nil
+	}
+	selected := map[string]bool{}
+	for _, t := range resourceTypes {
+		selected[t] = true
+	}
+	var discoverers []resourceDiscoverer
+	for _, g := range generatorDiscoverers {
+		if selected[g.resourceType] {
+			discoverers = append(discoverers, g)
+			delete(selected, g.resourceType)
+		}
+	}
+	if len(selected) > 0 {
+		var unsupported []string
+		for t := range selected {
+			unsupported = append(unsupported, t)
+		}
+		sort.Strings(unsupported)
+		return nil, fmt.Errorf("Cannot generate %s, the supported resource types are %s",
+			strings.Join(unsupported, ", "), strings.Join(GenerateResourceTypes(), ", "))
+	}
+	return discoverers, nil
+}
+
+// readDiscoveredResource reads the resource with ID id the way terraform
+// import does: through the importer of the resource, then its read function.
+// It returns nil when the resource does not exist.
+func readDiscoveredResource(ctx context.Context, resource *schema.Resource, id string, meta interface{}) (*schema.ResourceData, error) {
+	d := resource.Data(nil)
+	d.SetId(id)
+	if importer := resource.Importer; importer != nil {
+		var imported []*schema.ResourceData
+		var err error
+		switch {
+		case importer.StateContext != nil:
+			imported, err = importer.StateContext(ctx, d, meta)
+		case importer.State != nil:
+			imported, err = importer.State(d, meta)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(imported) > 0 {
+			d = imported[0]
+		}
+	}
+	state, diags := resource.RefreshWithoutUpgrade(ctx, d.State(), meta)
+	if err := diagError(diags); err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, nil
+	}
+	return resource.Data(state), nil
+}
+
+// diagError returns the errors of diags as an error, or nil
+func diagError(diags diag.Diagnostics) error {
+	var msgs []string
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		msg := d.Summary
+		if d.Detail != "" {
+			msg = fmt.Sprintf("%s: %s", msg, d.Detail)
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "\n"))
+}
+
+func discoverISVPCs(meta interface{}) ([]discoveredResource, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	var found []discoveredResource
+	start := ""
+	for {
+		options := &vpcv1.ListVpcsOptions{}
+		if start != "" {
+			options.Start = &start
+		}
+		vpcs, response, err := sess.ListVpcs(options)
+		if err != nil {
+			return nil, fmt.Errorf("Error Fetching vpcs %s\n%s", err, response)
+		}
+		for _, vpc := range vpcs.Vpcs {
+			found = append(found, discoveredResource{id: *vpc.ID, name: *vpc.Name})
+		}
+		start = GetNext(vpcs.Next)
+		if start == "" {
+			return found, nil
+		}
+	}
+}
+
+func discoverISSubnets(meta interface{}) ([]discoveredResource, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	var found []discoveredResource
+	start := ""
+	for {
+		options := &vpcv1.ListSubnetsOptions{}
+		if start != "" {
+			options.Start = &start
+		}
+		subnets, response, err := sess.ListSubnets(options)
+		if err != nil {
+			return nil, fmt.Errorf("Error Fetching subnets %s\n%s", err, response)
+		}
+		for _, subnet := range subnets.Subnets {
+			found = append(found, discoveredResource{id: *subnet.ID, name: *subnet.Name})
+		}
+		start = GetNext(subnets.Next)
+		if start == "" {
+			return found, nil
+		}
+	}
+}
+
+// discoverISSecurityGroups lists the security groups, except the default
+// security groups of the VPCs that are created and deleted with their VPC.
+func discoverISSecurityGroups(meta interface{}) ([]discoveredResource, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	defaults := map[string]bool{}
+	start := ""
+	for {
+		options := &vpcv1.ListVpcsOptions{}
+		if start != "" {
+			options.Start = &start
+		}
+		vpcs, response, err := sess.ListVpcs(options)
+		if err != nil {
+			return nil, fmt.Errorf("Error Fetching vpcs %s\n%s", err, response)
+		}
+		for _, vpc := range vpcs.Vpcs {
+			if vpc.DefaultSecurityGroup != nil && vpc.DefaultSecurityGroup.ID != nil {
+				defaults[*vpc.DefaultSecurityGroup.ID] = true
+			}
+		}
+		start = GetNext(vpcs.Next)
+		if start == "" {
+			break
+		}
+	}
+
+	var found []discoveredResource
+	for {
+		options := &vpcv1.ListSecurityGroupsOptions{}
+		if start != "" {
+			options.Start = &start
+		}
+		sgs, response, err := sess.ListSecurityGroups(options)
+		if err != nil {
+			return nil, fmt.Errorf("Error Fetching security groups %s\n%s", err, response)
+		}
+		for _, sg := range sgs.SecurityGroups {
+			if defaults[*sg.ID] {
+				continue
+			}
+			found = append(found, discoveredResource{id: *sg.ID, name: *sg.Name})
+		}
+		start = GetNext(sgs.Next)
+		if start == "" {
+			return found, nil
+		}
+	}
+}
+
+func discoverISInstances(meta interface{}) ([]discoveredResource, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	var found []discoveredResource
+	start := ""
+	for {
+		options := &vpcv1.ListInstancesOptions{}
+		if start != "" {
+			options.Start = &start
+		}
+		instances, response, err := sess.ListInstances(options)
+		if err != nil {
+			return nil, fmt.Errorf("Error Fetching instances %s\n%s", err, response)
+		}
+		for _, instance := range instances.Instances {
+			found = append(found, discoveredResource{id: *instance.ID, name: *instance.Name})
+		}
+		start = GetNext(instances.Next)
+		if start == "" {
+			return found, nil
+		}
+	}
+}
+
+// cosLocationConstraintRegexp matches the location constraint of a bucket,
+// its location followed by its storage class
+var cosLocationConstraintRegexp = regexp.MustCompile(`^(.+)-([a-z]+)$`)
+
+// discoverCOSBuckets lists the buckets of every Cloud Object Storage instance.
+// The buckets of every location are listed from the endpoint of the provider
+// region. The bucket IDs have the format of the IDs of resource ibm_cos_bucket.
+func discoverCOSBuckets(meta interface{}) ([]discoveredResource, error) {
+	rsConClient, err := meta.(ClientSession).ResourceControllerAPIV2()
+	if err != nil {
+		return nil, err
+	}
+	rsCatClient, err := meta.(ClientSession).ResourceCatalogAPI()
+	if err != nil {
+		return nil, err
+	}
+	bxSession, err := meta.(ClientSession).BluemixSession()
+	if err != nil {
+		return nil, err
+	}
+	serviceOff, err := rsCatClient.ResourceCatalog().FindByName("cloud-object-storage", true)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the cloud object storage offering: %s", err)
+	}
+	if len(serviceOff) == 0 {
+		return nil, fmt.Errorf("Error retrieving the cloud object storage offering: no offering found")
+	}
+	instances, err := rsConClient.ResourceServiceInstanceV2().ListInstances(controllerv2.ServiceInstanceQuery{
+		ServiceID: serviceOff[0].ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var found []discoveredResource
+	for _, instance := range instances {
+		s3Client, err := getS3Client(bxSession, bxSession.Config.Region, "public", instance.ID)
+		if err != nil {
+			return nil, err
+		}
+		err = s3Client.ListBucketsExtendedPages(&s3.ListBucketsExtendedInput{}, func(page *s3.ListBucketsExtendedOutput, lastPage bool) bool {
+			for _, b := range page.Buckets {
+				m := cosLocationConstraintRegexp.FindStringSubmatch(*b.LocationConstraint)
+				if m == nil {
+					log.Printf("[WARN] Skipping bucket %s with unknown location %s", *b.Name, *b.LocationConstraint)
+					continue
+				}
+				apiType := "rl"
+				switch location := m[1]; {
+				case stringInSlice(location, singleSiteLocation):
+					apiType = "ssl"
+				case stringInSlice(location, crossRegionLocation):
+					apiType = "crl"
+				}
+				bucketID := fmt.Sprintf("%s:%s:%s:meta:%s:%s:%s", strings.Replace(instance.ID, "::", "", -1), "bucket", *b.Name, apiType, m[1], "public")
+				found = append(found, discoveredResource{id: bucketID, name: *b.Name})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error listing the buckets of %s: %s", instance.ID, err)
+		}
+	}
+	return found, nil
+}
+
+func discoverIAMAccessGroups(meta interface{}) ([]discoveredResource, error) {
+	iamuumClient, err := meta.(ClientSession).IAMUUMAPIV2()
+	if err != nil {
+		return nil, err
+	}
+	userDetails, err := meta.(ClientSession).BluemixUserDetails()
+	if err != nil {
+		return nil, err
+	}
+	groups, err := iamuumClient.AccessGroup().List(userDetails.userAccount)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving access groups: %s", err)
+	}
+	var found []discoveredResource
+	for _, group := range groups {
+		// the Public Access group is managed by IAM
+		if group.ID == "AccessGroupId-PublicAccess" {
+			continue
+		}
+		found = append(found, discoveredResource{id: group.ID, name: group.Name})
+	}
+	return found, nil
+}
@@ -0,0 +1,272 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// characters that are not allowed in resource names
+var resourceLabelRegexp = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// generatedResource is a resource of the generated configuration
+type generatedResource struct {
+	resourceType string
+	name         string
+	resource     *schema.Resource
+	d            *schema.ResourceData
+}
+
+func (r *generatedResource) address() string {
+	return r.resourceType + "." + r.name
+}
+
+// configGenerator renders read resources as Terraform configuration. The IDs
+// and CRNs of the generated resources are replaced by references to them in
+// the attributes of the other resources.
+type configGenerator struct {
+	resources []*generatedResource
+	names     map[string]bool
+	// refs maps the IDs and CRNs of the resources to their attribute
+	refs map[string]resourceRef
+}
+
+// resourceRef is an attribute of a generated resource
+type resourceRef struct {
+	resource *generatedResource
+	attr     string
+}
+
+func newConfigGenerator() *configGenerator {
+	return &configGenerator{
+		names: map[string]bool{},
+		refs:  map[string]resourceRef{},
+	}
+}
+
+// add adds the resource read in d, named after name.
+func (g *configGenerator) add(resourceType, name string, resource *schema.Resource, d *schema.ResourceData) {
+	label := resourceLabel(name, d.Id())
+	for i := 2; g.names[resourceType+"."+label]; i++ {
+		label = fmt.Sprintf("%s_%d", resourceLabel(name, d.Id()), i)
+	}
+	r := &generatedResource{resourceType: resourceType, name: label, resource: resource, d: d}
+	g.names[r.address()] = true
+	g.resources = append(g.resources, r)
+
+	if _, ok := g.refs[d.Id()]; !ok {
+		g.refs[d.Id()] = resourceRef{r, "id"}
+	}
+	if _, ok := resource.Schema["crn"]; ok {
+		if crn, ok := d.Get("crn").(string); ok && crn != "" && crn != d.Id() {
+			g.refs[crn] = resourceRef{r, "crn"}
+		}
+	}
+}
+
+// resourceLabel returns a valid resource name for the resource named name.
+func resourceLabel(name, id string) string {
+	label := strings.Trim(resourceLabelRegexp.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if label == "" {
+		label = strings.Trim(resourceLabelRegexp.ReplaceAllString(strings.ToLower(id), "_"), "_-")
+	}
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "r_" + label
+	}
+	return label
+}
+
+func resourceTraversal(r *generatedResource, attr string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: r.resourceType},
+		hcl.TraverseAttr{Name: r.name},
+		hcl.TraverseAttr{Name: attr},
+	}
+}
+
+// files returns the generated files by name: a file per resource type and
+// imports.tf.
+func (g *configGenerator) files() map[string][]byte {
+	files := map[string]*hclwrite.File{}
+	imports := hclwrite.NewEmptyFile()
+	for _, r := range g.resources {
+		f, ok := files[r.resourceType]
+		if !ok {
+			f = hclwrite.NewEmptyFile()
+			files[r.resourceType] = f
+		} else {
+			f.Body().AppendNewline()
+		}
+		block := f.Body().AppendNewBlock("resource", []string{r.resourceType, r.name})
+		values := map[string]interface{}{}
+		for k := range r.resource.Schema {
+			values[k] = r.d.Get(k)
+		}
+		g.writeBody(block.Body(), r.resource.Schema, values, r)
+
+		if len(imports.Body().Blocks()) > 0 {
+			imports.Body().AppendNewline()
+		}
+		importBlock := imports.Body().AppendNewBlock("import", nil)
+		importBlock.Body().SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: r.resourceType},
+			hcl.TraverseAttr{Name: r.name},
+		})
+		importBlock.Body().SetAttributeValue("id", cty.StringVal(r.d.Id()))
+	}
+
+	out := map[string][]byte{}
+	for resourceType, f := range files {
+		out[resourceType+".tf"] = hclwrite.Format(f.Bytes())
+	}
+	if len(g.resources) > 0 {
+		out["imports.tf"] = hclwrite.Format(imports.Bytes())
+	}
+	return out
+}
+
+// writeBody writes the arguments of schemaMap with their values to body. The
+// computed only, deprecated and unset arguments are left out, as are the
+// arguments conflicting with an argument that is written.
+func (g *configGenerator) writeBody(body *hclwrite.Body, schemaMap map[string]*schema.Schema, values map[string]interface{}, self *generatedResource) {
+	keys := make([]string, 0, len(schemaMap))
+	for k := range schemaMap {
+		keys = append(keys, k)
+	}
+	// required arguments first
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := schemaMap[keys[i]].Required, schemaMap[keys[j]].Required
+		if ri != rj {
+			return ri
+		}
+		return keys[i] < keys[j]
+	})
+
+	written := map[string]bool{}
+	for _, k := range keys {
+		s := schemaMap[k]
+		v := values[k]
+		if (s.Computed && !s.Optional) || s.Deprecated != "" || conflictsWithWritten(s, written) {
+			continue
+		}
+		if s.Default != nil && fmt.Sprint(v) == fmt.Sprint(s.Default) {
+			continue
+		}
+		if isZeroValue(v) || s.Sensitive {
+			if s.Required {
+				body.AppendUnstructuredTokens(hclwrite.Tokens{{
+					Type:  hclsyntax.TokenComment,
+					Bytes: []byte(fmt.Sprintf("# TODO: %s is required and could not be read\n", k)),
+				}})
+			}
+			continue
+		}
+		written[k] = true
+
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			for _, item := range listValue(v) {
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				g.writeBody(body.AppendNewBlock(k, nil).Body(), elem.Schema, m, self)
+			}
+			continue
+		}
+		body.SetAttributeRaw(k, g.tokens(s, v, self))
+	}
+}
+
+func conflictsWithWritten(s *schema.Schema, written map[string]bool) bool {
+	for _, c := range s.ConflictsWith {
+		// conflicts of nested arguments have the form block.0.argument
+		if written[c[strings.LastIndex(c, ".")+1:]] {
+			return true
+		}
+	}
+	return false
+}
+
+// tokens returns the expression of the value v of an argument with schema s.
+func (g *configGenerator) tokens(s *schema.Schema, v interface{}, self *generatedResource) hclwrite.Tokens {
+	switch s.Type {
+	case schema.TypeString:
+		str := fmt.Sprint(v)
+		if ref, ok := g.refs[str]; ok && ref.resource != self {
+			return hclwrite.TokensForTraversal(resourceTraversal(ref.resource, ref.attr))
+		}
+		return hclwrite.TokensForValue(cty.StringVal(str))
+	case schema.TypeInt:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v.(int))))
+	case schema.TypeFloat:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v.(float64)))
+	case schema.TypeBool:
+		return hclwrite.TokensForValue(cty.BoolVal(v.(bool)))
+	case schema.TypeMap:
+		m := v.(map[string]interface{})
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		toks := hclwrite.Tokens{{Type: hclsyntax.TokenOBrace, Bytes: []byte("{")}, {Type: hclsyntax.TokenNewline, Bytes: []byte("\n")}}
+		for _, k := range keys {
+			toks = append(toks, hclwrite.TokensForValue(cty.StringVal(k))...)
+			toks = append(toks, &hclwrite.Token{Type: hclsyntax.TokenEqual, Bytes: []byte("=")})
+			toks = append(toks, hclwrite.TokensForValue(cty.StringVal(fmt.Sprint(m[k])))...)
+			toks = append(toks, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+		}
+		return append(toks, &hclwrite.Token{Type: hclsyntax.TokenCBrace, Bytes: []byte("}")})
+	}
+
+	elem, ok := s.Elem.(*schema.Schema)
+	if !ok {
+		elem = &schema.Schema{Type: schema.TypeString}
+	}
+	toks := hclwrite.Tokens{{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")}}
+	for i, item := range listValue(v) {
+		if i > 0 {
+			toks = append(toks, &hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")})
+		}
+		toks = append(toks, g.tokens(elem, item, self)...)
+	}
+	return append(toks, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+}
+
+func listValue(v interface{}) []interface{} {
+	switch l := v.(type) {
+	case *schema.Set:
+		return l.List()
+	case []interface{}:
+		return l
+	}
+	return nil
+}
+
+func isZeroValue(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case int:
+		return t == 0
+	case float64:
+		return t == 0
+	case bool:
+		return !t
+	case map[string]interface{}:
+		return len(t) == 0
+	}
+	return len(listValue(v)) == 0
+}
@@ -0,0 +1,195 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testGeneratorResources() (vpc, subnet *schema.Resource) {
+	vpc = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":           {Type: schema.TypeString, Required: true},
+			"classic_access": {Type: schema.TypeBool, Optional: true, Default: false},
+			"resource_group": {Type: schema.TypeString, Optional: true, Computed: true},
+			"tags":           {Type: schema.TypeSet, Optional: true, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}, Set: resourceIBMVPCHash},
+			"crn":            {Type: schema.TypeString, Computed: true},
+			"status":         {Type: schema.TypeString, Computed: true},
+		},
+	}
+	subnet = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":                     {Type: schema.TypeString, Required: true},
+			"vpc":                      {Type: schema.TypeString, Required: true},
+			"ipv4_cidr_block":          {Type: schema.TypeString, Optional: true, ConflictsWith: []string{"total_ipv4_address_count"}},
+			"total_ipv4_address_count": {Type: schema.TypeInt, Optional: true, ConflictsWith: []string{"ipv4_cidr_block"}},
+			"ip_version":               {Type: schema.TypeString, Optional: true, Default: "ipv4"},
+			"network_acl":              {Type: schema.TypeString, Optional: true, Deprecated: "use the network ACL attachment"},
+			"password":                 {Type: schema.TypeString, Required: true, Sensitive: true},
+			"public_gateway": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"zone": {Type: schema.TypeString, Required: true},
+						"vpc":  {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		},
+	}
+	return vpc, subnet
+}
+
+func TestConfigGenerator(t *testing.T) {
+	vpc, subnet := testGeneratorResources()
+	g := newConfigGenerator()
+
+	d := schema.TestResourceDataRaw(t, vpc.Schema, map[string]interface{}{
+		"name":           "My VPC",
+		"resource_group": "rg-1234",
+		"tags":           []interface{}{"env:dev"},
+	})
+	d.SetId("r006-vpc")
+	d.Set("crn", "crn:v1:bluemix:public:is:us-south:a/1234::vpc:r006-vpc")
+	d.Set("status", "available")
+	g.add("ibm_is_vpc", "My VPC", vpc, d)
+
+	for _, id := range []string{"0717-subnet-1", "0717-subnet-2"} {
+		d = schema.TestResourceDataRaw(t, subnet.Schema, map[string]interface{}{
+			"name":                     "subnet",
+			"vpc":                      "r006-vpc",
+			"ipv4_cidr_block":          "10.240.0.0/24",
+			"total_ipv4_address_count": 256,
+			"ip_version":               "ipv4",
+			"network_acl":              "r006-acl",
+			"public_gateway": []interface{}{map[string]interface{}{
+				"zone": "us-south-1",
+				"vpc":  "crn:v1:bluemix:public:is:us-south:a/1234::vpc:r006-vpc",
+			}},
+		})
+		d.SetId(id)
+		g.add("ibm_is_subnet", "subnet", subnet, d)
+	}
+
+	files := g.files()
+	want := map[string]string{
+		"ibm_is_vpc.tf": `resource "ibm_is_vpc" "my_vpc" {
+  name           = "My VPC"
+  resource_group = "rg-1234"
+  tags           = ["env:dev"]
+}
+`,
+		"ibm_is_subnet.tf": `resource "ibm_is_subnet" "subnet" {
+  name = "subnet"
+  # TODO: password is required and could not be read
+  vpc             = ibm_is_vpc.my_vpc.id
+  ipv4_cidr_block = "10.240.0.0/24"
+  public_gateway {
+    zone = "us-south-1"
+    vpc  = ibm_is_vpc.my_vpc.crn
+  }
+}
+
+resource "ibm_is_subnet" "subnet_2" {
+  name = "subnet"
+  # TODO: password is required and could not be read
+  vpc             = ibm_is_vpc.my_vpc.id
+  ipv4_cidr_block = "10.240.0.0/24"
+  public_gateway {
+    zone = "us-south-1"
+    vpc  = ibm_is_vpc.my_vpc.crn
+  }
+}
+`,
+		"imports.tf": `import {
+  to = ibm_is_vpc.my_vpc
+  id = "r006-vpc"
+}
+
+import {
+  to = ibm_is_subnet.subnet
+  id = "0717-subnet-1"
+}
+
+import {
+  to = ibm_is_subnet.subnet_2
+  id = "0717-subnet-2"
+}
+`,
+	}
+	if len(files) != len(want) {
+		t.Errorf("Expected %d files, got %d", len(want), len(files))
+	}
+	for name, content := range want {
+		if got := string(files[name]); got != content {
+			t.Errorf("Unexpected %s:\n%s\nwant:\n%s", name, got, content)
+		}
+	}
+}
+
+func TestResourceLabel(t *testing.T) {
+	cases := map[string][2]string{
+		"my-vpc":           {"my-vpc", "r006-1234"},
+		"prod_web_01":      {"Prod Web 01", "r006-1234"},
+		"r_1st-subnet":     {"1st-subnet", "0717-1234"},
+		"r006-1234":        {"", "r006-1234"},
+		"accessgroupid-12": {"", "AccessGroupId-12"},
+	}
+	for want, c := range cases {
+		if got := resourceLabel(c[0], c[1]); got != want {
+			t.Errorf("Expected %s for %q, got %s", want, c[0], got)
+		}
+	}
+}
+
+func TestSelectDiscoverers(t *testing.T) {
+	discoverers, err := selectDiscoverers([]string{"ibm_iam_access_group", "ibm_is_vpc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(discoverers) != 2 || discoverers[0].resourceType != "ibm_is_vpc" {
+		t.Errorf("Expected the resource types in generation order, got %v", discoverers)
+	}
+	if _, err := selectDiscoverers([]string{"ibm_is_vpc", "ibm_unknown"}); err == nil || !strings.Contains(err.Error(), "Cannot generate ibm_unknown") {
+		t.Errorf("Expected an error for an unsupported resource type, got %v", err)
+	}
+	for _, resourceType := range GenerateResourceTypes() {
+		if _, ok := Provider().ResourcesMap[resourceType]; !ok {
+			t.Errorf("Unknown resource type %s", resourceType)
+		}
+	}
+}
+
+func TestReadDiscoveredResource(t *testing.T) {
+	resource, _ := testGeneratorResources()
+	resource.Importer = &schema.ResourceImporter{
+		State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			d.Set("resource_group", "rg-imported")
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+	resource.Read = func(d *schema.ResourceData, meta interface{}) error {
+		if d.Id() == "r006-gone" {
+			d.SetId("")
+			return nil
+		}
+		return d.Set("name", "vpc-"+d.Id())
+	}
+
+	d, err := readDiscoveredResource(context.Background(), resource, "r006-1234", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("name") != "vpc-r006-1234" || d.Get("resource_group") != "rg-imported" {
+		t.Errorf("Expected the imported and read attributes, got %v and %v", d.Get("name"), d.Get("resource_group"))
+	}
+	if d, err := readDiscoveredResource(context.Background(), resource, "r006-gone", nil); err != nil || d != nil {
+		t.Errorf("Expected no resource for a deleted resource, got %v, %v", d, err)
+	}
+}
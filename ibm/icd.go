@@ -0,0 +1,234 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	gohttp "net/http"
+	"strings"
+
+	"github.com/IBM-Cloud/bluemix-go/api/icd/icdv4"
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+	"github.com/IBM-Cloud/bluemix-go/utils"
+)
+
+// icdRequestClient is the REST client embedded by the ICD client of
+// bluemix-go, for the deployment APIs icdv4 does not cover: the user types and
+// roles, and the configuration
+type icdRequestClient interface {
+	Get(path string, respV interface{}, extraHeader ...interface{}) (*gohttp.Response, error)
+	Post(path string, data interface{}, respV interface{}, extraHeader ...interface{}) (*gohttp.Response, error)
+	Patch(path string, data interface{}, respV interface{}, extraHeader ...interface{}) (*gohttp.Response, error)
+	DeleteWithResp(path string, respV interface{}, extraHeader ...interface{}) (*gohttp.Response, error)
+}
+
+func icdRequests(icdClient icdv4.ICDServiceAPI) (icdRequestClient, error) {
+	client, ok := icdClient.(icdRequestClient)
+	if !ok {
+		return nil, fmt.Errorf("[ERROR] The database client does not support the deployment requests")
+	}
+	return client, nil
+}
+
+// databaseLockKey serializes the tasks of a deployment, ICD runs one task of a
+// deployment at a time
+func databaseLockKey(deploymentID string) string {
+	return "database_key_" + deploymentID
+}
+
+// databaseEntryID returns the ID of an entry of a deployment. The deployment
+// ID is a CRN, which contains "/" in its scope and ends with "::".
+func databaseEntryID(deploymentID string, parts ...string) string {
+	return strings.Join(append([]string{deploymentID}, parts...), "/")
+}
+
+// parseDatabaseEntryID returns the deployment ID of an entry ID and its n
+// parts. The last part may contain "/", ex: a CIDR.
+func parseDatabaseEntryID(id string, n int) (string, []string, error) {
+	i := strings.Index(id, "::/")
+	if i < 0 {
+		return "", nil, fmt.Errorf("[ERROR] Incorrect ID %s: the ID must start with the deployment CRN", id)
+	}
+	deploymentID, rest := id[:i+2], id[i+3:]
+	parts := strings.SplitN(rest, "/", n)
+	if len(parts) != n {
+		return "", nil, fmt.Errorf("[ERROR] Incorrect ID %s: the ID must have %d parts after the deployment CRN", id, n)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return "", nil, fmt.Errorf("[ERROR] Incorrect ID %s: the ID has an empty part", id)
+		}
+	}
+	return deploymentID, parts, nil
+}
+
+func isICDNotFound(err error) bool {
+	apiErr, ok := err.(bmxerror.RequestFailure)
+	return ok && apiErr.StatusCode() == 404
+}
+
+// getDatabaseDeploymentExists returns false when the deployment was deleted
+func getDatabaseDeploymentExists(icdClient icdv4.ICDServiceAPI, deploymentID string) (bool, error) {
+	_, err := icdClient.Cdbs().GetCdb(deploymentID)
+	if err != nil {
+		if isICDNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// databaseUser is a user of a deployment with its type, the icdv4 users are
+// the "database" users without role
+type databaseUser struct {
+	UserName string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Role     string `json:"role,omitempty"`
+}
+
+func databaseUserPath(deploymentID, userType string) string {
+	return fmt.Sprintf("/v4/ibm/deployments/%s/users/%s", utils.EscapeUrlParm(deploymentID), utils.EscapeUrlParm(userType))
+}
+
+func createDatabaseUser(client icdRequestClient, deploymentID, userType string, user databaseUser) (icdv4.Task, error) {
+	taskResult := icdv4.TaskResult{}
+	body := map[string]interface{}{"user": user}
+	_, err := client.Post(databaseUserPath(deploymentID, userType), body, &taskResult)
+	return taskResult.Task, err
+}
+
+func updateDatabaseUser(client icdRequestClient, deploymentID, userType, userName string, user databaseUser) (icdv4.Task, error) {
+	taskResult := icdv4.TaskResult{}
+	body := map[string]interface{}{"user": user}
+	_, err := client.Patch(databaseUserPath(deploymentID, userType)+"/"+utils.EscapeUrlParm(userName), body, &taskResult)
+	return taskResult.Task, err
+}
+
+func deleteDatabaseUser(client icdRequestClient, deploymentID, userType, userName string) (icdv4.Task, error) {
+	taskResult := icdv4.TaskResult{}
+	_, err := client.DeleteWithResp(databaseUserPath(deploymentID, userType)+"/"+utils.EscapeUrlParm(userName), &taskResult)
+	return taskResult.Task, err
+}
+
+// getDatabaseUserExists returns false when the user was deleted. ICD has no
+// API to read a user, the connection of a user only exists for the existing
+// users.
+func getDatabaseUserExists(client icdRequestClient, deploymentID, userType, userName string) (bool, error) {
+	var connection map[string]interface{}
+	_, err := client.Get(databaseUserPath(deploymentID, userType)+"/"+utils.EscapeUrlParm(userName)+"/connections/public", &connection)
+	if err != nil {
+		if isICDNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// getDatabaseConfigurationSchema returns the settings of a deployment that
+// can be configured, by name
+func getDatabaseConfigurationSchema(client icdRequestClient, deploymentID string) (map[string]map[string]interface{}, error) {
+	result := struct {
+		Schema map[string]map[string]interface{} `json:"schema"`
+	}{}
+	_, err := client.Get(fmt.Sprintf("/v4/ibm/deployments/%s/configuration/schema", utils.EscapeUrlParm(deploymentID)), &result)
+	return result.Schema, err
+}
+
+// getDatabaseConfiguration returns the current settings of a deployment. ok
+// is false for the deployments that do not report their settings.
+func getDatabaseConfiguration(client icdRequestClient, deploymentID string) (configuration map[string]interface{}, ok bool, err error) {
+	result := struct {
+		Configuration map[string]interface{} `json:"configuration"`
+	}{}
+	_, err = client.Get(fmt.Sprintf("/v4/ibm/deployments/%s/configuration", utils.EscapeUrlParm(deploymentID)), &result)
+	if err != nil {
+		if apiErr, isAPIErr := err.(bmxerror.RequestFailure); isAPIErr &&
+			(apiErr.StatusCode() == gohttp.StatusNotFound || apiErr.StatusCode() == gohttp.StatusMethodNotAllowed) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return result.Configuration, result.Configuration != nil, nil
+}
+
+func updateDatabaseConfiguration(client icdRequestClient, deploymentID string, configuration map[string]interface{}) (icdv4.Task, error) {
+	taskResult := icdv4.TaskResult{}
+	body := map[string]interface{}{"configuration": configuration}
+	_, err := client.Patch(fmt.Sprintf("/v4/ibm/deployments/%s/configuration", utils.EscapeUrlParm(deploymentID)), body, &taskResult)
+	return taskResult.Task, err
+}
+
+// databaseBackup is a backup of a deployment, its ID is a CRN
+type databaseBackup struct {
+	ID             string `json:"id"`
+	DeploymentID   string `json:"deployment_id"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	IsDownloadable bool   `json:"is_downloadable"`
+	IsRestorable   bool   `json:"is_restorable"`
+	CreatedAt      string `json:"created_at"`
+}
+
+func listDatabaseBackups(client icdRequestClient, deploymentID string) ([]databaseBackup, error) {
+	result := struct {
+		Backups []databaseBackup `json:"backups"`
+	}{}
+	_, err := client.Get(fmt.Sprintf("/v4/ibm/deployments/%s/backups", utils.EscapeUrlParm(deploymentID)), &result)
+	return result.Backups, err
+}
+
+func getDatabaseBackup(client icdRequestClient, backupID string) (databaseBackup, error) {
+	result := struct {
+		Backup databaseBackup `json:"backup"`
+	}{}
+	_, err := client.Get(fmt.Sprintf("/v4/ibm/backups/%s", utils.EscapeUrlParm(backupID)), &result)
+	return result.Backup, err
+}
+
+// createDatabaseBackup starts an on-demand backup of a deployment. The task
+// does not return the backup, it is the on-demand backup that is added to the
+// backups of the deployment.
+func createDatabaseBackup(client icdRequestClient, deploymentID string) (icdv4.Task, error) {
+	taskResult := icdv4.TaskResult{}
+	_, err := client.Post(fmt.Sprintf("/v4/ibm/deployments/%s/backups", utils.EscapeUrlParm(deploymentID)), map[string]interface{}{}, &taskResult)
+	return taskResult.Task, err
+}
+
+// getDatabaseEarliestPITRTime returns the earliest time a deployment can be
+// restored to, the latest is the current time
+func getDatabaseEarliestPITRTime(client icdRequestClient, deploymentID string) (string, error) {
+	result := struct {
+		PITRData struct {
+			EarliestPITRTime string `json:"earliest_point_in_time_recovery_time"`
+		} `json:"point_in_time_recovery_data"`
+	}{}
+	_, err := client.Get(fmt.Sprintf("/v4/ibm/deployments/%s/point_in_time_recovery_data", utils.EscapeUrlParm(deploymentID)), &result)
+	return result.PITRData.EarliestPITRTime, err
+}
+
+// databaseSupportsPITR tells if the deployments of a database type can be
+// restored to a point in time
+func databaseSupportsPITR(dbType string) bool {
+	return dbType == "postgresql"
+}
+
+// databaseServiceType returns the database type of a service, ex: postgresql
+// for databases-for-postgresql
+func databaseServiceType(service string) string {
+	if strings.HasPrefix(service, "messages-for-") {
+		return service[len("messages-for-"):]
+	}
+	return strings.TrimPrefix(service, "databases-for-")
+}
+
+// databaseTypeService returns the service of a database type, ex:
+// databases-for-postgresql for postgresql
+func databaseTypeService(dbType string) string {
+	if dbType == "rabbitmq" {
+		return "messages-for-" + dbType
+	}
+	return "databases-for-" + dbType
+}
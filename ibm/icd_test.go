@@ -0,0 +1,277 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"encoding/json"
+	"fmt"
+	gohttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	bluemix "github.com/IBM-Cloud/bluemix-go"
+	"github.com/IBM-Cloud/bluemix-go/client"
+)
+
+const testDatabaseDeploymentID = "crn:v1:bluemix:public:databases-for-postgresql:us-south:a/4448261269a14562b839e0a3019ed980:0b8c37b0-0f01-421a-bb32-056c6565b461::"
+
+func TestParseDatabaseEntryID(t *testing.T) {
+	deploymentID, parts, err := parseDatabaseEntryID(databaseEntryID(testDatabaseDeploymentID, "database", "user123"), 2)
+	if err != nil || deploymentID != testDatabaseDeploymentID || !reflect.DeepEqual(parts, []string{"database", "user123"}) {
+		t.Errorf("Unexpected %q, %q, %v", deploymentID, parts, err)
+	}
+	deploymentID, parts, err = parseDatabaseEntryID(databaseEntryID(testDatabaseDeploymentID, "172.168.1.0/24"), 1)
+	if err != nil || deploymentID != testDatabaseDeploymentID || !reflect.DeepEqual(parts, []string{"172.168.1.0/24"}) {
+		t.Errorf("Unexpected %q, %q, %v", deploymentID, parts, err)
+	}
+	for _, id := range []string{
+		"user123",
+		testDatabaseDeploymentID,
+		databaseEntryID(testDatabaseDeploymentID, "user123"),
+		databaseEntryID(testDatabaseDeploymentID, "database", ""),
+	} {
+		if _, _, err := parseDatabaseEntryID(id, 2); err == nil {
+			t.Errorf("Expected an error for %q", id)
+		}
+	}
+}
+
+func TestDatabaseConfigurationDefaults(t *testing.T) {
+	configurationSchema := map[string]map[string]interface{}{
+		"max_connections":       {"type": "integer", "default": float64(115), "requires_restart": true},
+		"deadlock_timeout":      {"type": "integer", "default": float64(10000)},
+		"max_replication_slots": {"type": "integer"},
+	}
+	previous := map[string]interface{}{"max_connections": float64(200), "deadlock_timeout": float64(5000), "max_replication_slots": float64(20)}
+	configuration := map[string]interface{}{"deadlock_timeout": float64(2000), "work_mem": float64(4)}
+
+	want := map[string]interface{}{"max_connections": float64(115)}
+	if got := databaseConfigurationDefaults(configurationSchema, previous, configuration); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := invalidDatabaseSettings(configurationSchema, configuration); !reflect.DeepEqual(got, []string{"work_mem is unknown"}) {
+		t.Errorf("Unexpected incorrect settings %q", got)
+	}
+
+	configurationSchema = map[string]map[string]interface{}{
+		"max_connections":  {"type": "integer", "minimum": json.Number("115"), "maximum": json.Number("5000")},
+		"random_page_cost": {"type": "number"},
+		"log_connections":  {"type": "string", "choices": []interface{}{"on", "off"}},
+		"archive_mode":     {"type": "boolean"},
+	}
+	for _, c := range []struct {
+		configuration map[string]interface{}
+		want          []string
+	}{
+		{map[string]interface{}{"max_connections": float64(200), "random_page_cost": 1.5, "log_connections": "on", "archive_mode": true}, nil},
+		{map[string]interface{}{"max_connections": "200"}, []string{"max_connections must be a number"}},
+		{map[string]interface{}{"max_connections": 200.5}, []string{"max_connections must be an integer"}},
+		{map[string]interface{}{"max_connections": float64(10)}, []string{"max_connections must be at least 115"}},
+		{map[string]interface{}{"max_connections": float64(6000), "archive_mode": "on"}, []string{"archive_mode must be a boolean", "max_connections must be at most 5000"}},
+		{map[string]interface{}{"log_connections": "all"}, []string{"log_connections must be one of [on off]"}},
+		{map[string]interface{}{"log_connections": false}, []string{"log_connections must be a string"}},
+	} {
+		if got := invalidDatabaseSettings(configurationSchema, c.configuration); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v: expected %q, got %q", c.configuration, c.want, got)
+		}
+	}
+	if _, err := expandDatabaseConfiguration(`["max_connections"]`); err == nil {
+		t.Error("Expected an error for a configuration that is not an object")
+	}
+}
+
+func TestDatabaseRequests(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		if r.Body != nil && (r.Method == gohttp.MethodPost || r.Method == gohttp.MethodPatch) {
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			bodies = append(bodies, body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v4/ibm/deployments/"+testDatabaseDeploymentID+"/users/database/missing/connections/public":
+			w.WriteHeader(gohttp.StatusNotFound)
+			fmt.Fprint(w, `{"errors": "Not Found"}`)
+		case r.URL.Path == "/v4/ibm/deployments/"+testDatabaseDeploymentID+"/configuration" && r.Method == gohttp.MethodGet:
+			fmt.Fprint(w, `{"configuration": {"max_connections": 200}}`)
+		case r.URL.Path == "/v4/ibm/deployments/"+testDatabaseDeploymentID+"/configuration/schema":
+			fmt.Fprint(w, `{"schema": {"max_connections": {"type": "integer", "default": 115, "requires_restart": true}}}`)
+		case r.Method == gohttp.MethodGet:
+			fmt.Fprint(w, `{"connection": {"postgres": {}}}`)
+		default:
+			fmt.Fprint(w, `{"task": {"id": "task-1234", "status": "running"}}`)
+		}
+	}))
+	defer server.Close()
+
+	retries := 0
+	c := client.New(&bluemix.Config{Endpoint: &server.URL, MaxRetries: &retries}, bluemix.ICDService, nil)
+	icdId := EscapeUrlParm(testDatabaseDeploymentID)
+	deploymentPath := "/v4/ibm/deployments/" + icdId
+
+	task, err := createDatabaseUser(c, icdId, "database", databaseUser{UserName: "user123", Password: "password12", Role: "-@all +@read"})
+	if err != nil || task.Id != "task-1234" {
+		t.Fatalf("Unexpected task %v, %v", task, err)
+	}
+	if _, err = updateDatabaseUser(c, icdId, "database", "user123", databaseUser{Password: "password34"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = deleteDatabaseUser(c, icdId, "database", "user123"); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := getDatabaseUserExists(c, icdId, "database", "user123"); !exists || err != nil {
+		t.Errorf("Expected the user, got %t, %v", exists, err)
+	}
+	if exists, err := getDatabaseUserExists(c, icdId, "database", "missing"); exists || err != nil {
+		t.Errorf("Expected no user, got %t, %v", exists, err)
+	}
+	configurationSchema, err := getDatabaseConfigurationSchema(c, icdId)
+	if err != nil || fmt.Sprint(configurationSchema["max_connections"]["default"]) != "115" {
+		t.Errorf("Unexpected configuration schema %v, %v", configurationSchema, err)
+	}
+	if _, err = updateDatabaseConfiguration(c, icdId, map[string]interface{}{"max_connections": 200}); err != nil {
+		t.Fatal(err)
+	}
+	if configuration, ok, err := getDatabaseConfiguration(c, icdId); !ok || err != nil || fmt.Sprint(configuration) != "map[max_connections:200]" {
+		t.Errorf("Unexpected configuration %v, %t, %v", configuration, ok, err)
+	}
+
+	wantRequests := []string{
+		"POST " + deploymentPath + "/users/database",
+		"PATCH " + deploymentPath + "/users/database/user123",
+		"DELETE " + deploymentPath + "/users/database/user123",
+		"GET " + deploymentPath + "/users/database/user123/connections/public",
+		"GET " + deploymentPath + "/users/database/missing/connections/public",
+		"GET " + deploymentPath + "/configuration/schema",
+		"PATCH " + deploymentPath + "/configuration",
+		"GET " + deploymentPath + "/configuration",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("Expected the requests %q, got %q", wantRequests, requests)
+	}
+	wantBodies := []map[string]interface{}{
+		{"user": map[string]interface{}{"username": "user123", "password": "password12", "role": "-@all +@read"}},
+		{"user": map[string]interface{}{"password": "password34"}},
+		{"configuration": map[string]interface{}{"max_connections": float64(200)}},
+	}
+	if !reflect.DeepEqual(bodies, wantBodies) {
+		t.Errorf("Expected the bodies %v, got %v", wantBodies, bodies)
+	}
+}
+
+func TestDatabaseBackupRequests(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == gohttp.MethodPost:
+			fmt.Fprint(w, `{"task": {"id": "task-1234", "status": "running"}}`)
+		case r.URL.Path == "/v4/ibm/deployments/"+testDatabaseDeploymentID+"/backups":
+			fmt.Fprint(w, `{"backups": [{"id": "backup-1", "deployment_id": "`+testDatabaseDeploymentID+`", "type": "on_demand", "status": "completed", "is_restorable": true}]}`)
+		case r.URL.Path == "/v4/ibm/backups/backup-1":
+			fmt.Fprint(w, `{"backup": {"id": "backup-1", "type": "scheduled", "is_downloadable": true}}`)
+		default:
+			fmt.Fprint(w, `{"point_in_time_recovery_data": {"earliest_point_in_time_recovery_time": "2021-08-01T00:00:00Z"}}`)
+		}
+	}))
+	defer server.Close()
+
+	retries := 0
+	c := client.New(&bluemix.Config{Endpoint: &server.URL, MaxRetries: &retries}, bluemix.ICDService, nil)
+	icdId := EscapeUrlParm(testDatabaseDeploymentID)
+	deploymentPath := "/v4/ibm/deployments/" + icdId
+
+	task, err := createDatabaseBackup(c, icdId)
+	if err != nil || task.Id != "task-1234" {
+		t.Fatalf("Unexpected task %v, %v", task, err)
+	}
+	backups, err := listDatabaseBackups(c, icdId)
+	want := []databaseBackup{{ID: "backup-1", DeploymentID: testDatabaseDeploymentID, Type: "on_demand", Status: "completed", IsRestorable: true}}
+	if err != nil || !reflect.DeepEqual(backups, want) {
+		t.Errorf("Expected the backups %v, got %v, %v", want, backups, err)
+	}
+	backup, err := getDatabaseBackup(c, "backup-1")
+	if err != nil || backup.Type != "scheduled" || !backup.IsDownloadable {
+		t.Errorf("Unexpected backup %v, %v", backup, err)
+	}
+	earliest, err := getDatabaseEarliestPITRTime(c, icdId)
+	if err != nil || earliest != "2021-08-01T00:00:00Z" {
+		t.Errorf("Unexpected earliest point in time recovery time %q, %v", earliest, err)
+	}
+
+	wantRequests := []string{
+		"POST " + deploymentPath + "/backups",
+		"GET " + deploymentPath + "/backups",
+		"GET /v4/ibm/backups/backup-1",
+		"GET " + deploymentPath + "/point_in_time_recovery_data",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("Expected the requests %q, got %q", wantRequests, requests)
+	}
+}
+
+func TestDatabaseServiceType(t *testing.T) {
+	for service, dbType := range map[string]string{
+		"databases-for-postgresql": "postgresql",
+		"messages-for-rabbitmq":    "rabbitmq",
+	} {
+		if got := databaseServiceType(service); got != dbType {
+			t.Errorf("Expected %s for %s, got %s", dbType, service, got)
+		}
+		if got := databaseTypeService(dbType); got != service {
+			t.Errorf("Expected %s for %s, got %s", service, dbType, got)
+		}
+	}
+}
+
+func TestCheckDatabaseRestoreSource(t *testing.T) {
+	for _, tc := range []struct {
+		service       string
+		version       string
+		sourceID      string
+		sourceVersion string
+		valid         bool
+	}{
+		{"databases-for-postgresql", "", testDatabaseDeploymentID, "12", true},
+		{"databases-for-postgresql", "12", testDatabaseDeploymentID, "12", true},
+		{"databases-for-postgresql", "13", testDatabaseDeploymentID, "12", true},
+		{"databases-for-postgresql", "11", testDatabaseDeploymentID, "12", false},
+		{"databases-for-postgresql", "9.6", testDatabaseDeploymentID, "", true},
+		{"databases-for-redis", "", testDatabaseDeploymentID, "12", false},
+		{"databases-for-postgresql", "", "backup-1", "", false},
+	} {
+		err := checkDatabaseRestoreSource(tc.service, tc.version, tc.sourceID, tc.sourceVersion)
+		if (err == nil) != tc.valid {
+			t.Errorf("%s %s from %s %s: unexpected %v", tc.service, tc.version, tc.sourceID, tc.sourceVersion, err)
+		}
+	}
+}
+
+func TestCheckDatabasePITRTime(t *testing.T) {
+	now := time.Date(2021, 8, 10, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		pitrTime string
+		earliest string
+		valid    bool
+	}{
+		{"2021-08-05T00:00:00Z", "2021-08-01T00:00:00Z", true},
+		{"2021-08-05T00:00:00Z", "", true},
+		{"2021-07-30T00:00:00Z", "2021-08-01T00:00:00Z", false},
+		{"2021-08-11T00:00:00Z", "2021-08-01T00:00:00Z", false},
+		{"2021-08-05 00:00:00", "2021-08-01T00:00:00Z", false},
+	} {
+		err := checkDatabasePITRTime(tc.pitrTime, tc.earliest, now)
+		if (err == nil) != tc.valid {
+			t.Errorf("%s, earliest %s: unexpected %v", tc.pitrTime, tc.earliest, err)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+// Package keylock provides read/write locks keyed by strings, used to
+// serialize the changes of the resources sharing a parent, ex: the rules of a
+// security group.
+package keylock
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Manager is a store of read/write locks keyed by strings. The locks of
+// several keys are always taken in the order of the keys, so two holders
+// locking the same keys cannot deadlock each other. A holder must not lock a
+// key it already holds.
+type Manager struct {
+	// Timeout bounds the wait for a lock when the context has no deadline, 0
+	// for no bound
+	Timeout time.Duration
+
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+// Holder is a holder of a lock
+type Holder struct {
+	// The name of the holder, ex: "ibm_is_lb_pool_member create"
+	Name  string
+	Write bool
+	Since time.Time
+}
+
+func (h Holder) String() string {
+	mode := "read"
+	if h.Write {
+		mode = "write"
+	}
+	return fmt.Sprintf("%s (%s lock for %s)", h.Name, mode, time.Since(h.Since).Round(time.Second))
+}
+
+// Stats is the contention of the lock of a key
+type Stats struct {
+	// The number of locks granted
+	Acquired int
+	// The number of locks granted after a wait
+	Contended int
+	// The total and longest waits for the lock
+	Waited  time.Duration
+	MaxWait time.Duration
+}
+
+type keyLock struct {
+	holders        []*Holder
+	writersWaiting int
+	// released is closed and replaced when the lock is released
+	released chan struct{}
+	stats    Stats
+}
+
+// available returns whether the lock can be granted: writers need the key to
+// be free, readers only wait for the writers, including the waiting ones.
+func (l *keyLock) available(write bool) bool {
+	if write {
+		return len(l.holders) == 0
+	}
+	if l.writersWaiting > 0 {
+		return false
+	}
+	for _, h := range l.holders {
+		if h.Write {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *keyLock) notify() {
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+// TimeoutError is returned when a lock is not granted before the deadline or
+// the cancellation of the context
+type TimeoutError struct {
+	Key    string
+	Holder string
+	Waited time.Duration
+	// The holders of the key when the wait ended
+	Holders []Holder
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	holders := make([]string, len(e.Holders))
+	for i, h := range e.Holders {
+		holders[i] = h.String()
+	}
+	held := "waiting writers"
+	if len(holders) > 0 {
+		held = strings.Join(holders, ", ")
+	}
+	return fmt.Sprintf("%s gave up waiting for the lock on %q after %s (%s), held by %s", e.Holder, e.Key, e.Waited.Round(time.Second), e.Err, held)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// NewManager returns a Manager bounding the waits for the locks by timeout
+// when the context has no deadline.
+func NewManager(timeout time.Duration) *Manager {
+	return &Manager{
+		Timeout: timeout,
+		locks:   make(map[string]*keyLock),
+	}
+}
+
+// Lock takes the write locks of keys for holder and returns the function
+// releasing them.
+func (m *Manager) Lock(ctx context.Context, holder string, keys ...string) (func(), error) {
+	return m.Acquire(ctx, holder, keys, nil)
+}
+
+// RLock takes the read locks of keys for holder and returns the function
+// releasing them.
+func (m *Manager) RLock(ctx context.Context, holder string, keys ...string) (func(), error) {
+	return m.Acquire(ctx, holder, nil, keys)
+}
+
+// Acquire takes the write locks of write and the read locks of read for
+// holder, in the order of the keys, and returns the function releasing them.
+// A key in both lists is write locked. When a lock cannot be granted before
+// the deadline of ctx, the locks already taken are released and a
+// *TimeoutError is returned.
+func (m *Manager) Acquire(ctx context.Context, holder string, write, read []string) (func(), error) {
+	if _, ok := ctx.Deadline(); !ok && m.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.Timeout)
+		defer cancel()
+	}
+
+	modes := make(map[string]bool, len(write)+len(read))
+	for _, key := range read {
+		modes[key] = false
+	}
+	for _, key := range write {
+		modes[key] = true
+	}
+	keys := make([]string, 0, len(modes))
+	for key := range modes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	held := make(map[string]*Holder, len(keys))
+	release := func() {
+		for i := len(keys) - 1; i >= 0; i-- {
+			if h, ok := held[keys[i]]; ok {
+				m.release(keys[i], h)
+			}
+		}
+	}
+	for _, key := range keys {
+		h, err := m.acquire(ctx, key, holder, modes[key])
+		if err != nil {
+			release()
+			return nil, err
+		}
+		held[key] = h
+	}
+
+	var once sync.Once
+	return func() { once.Do(release) }, nil
+}
+
+func (m *Manager) acquire(ctx context.Context, key, holder string, write bool) (*Holder, error) {
+	start := time.Now()
+	waiting := false
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l := m.get(key)
+	for !l.available(write) {
+		if !waiting {
+			waiting = true
+			if write {
+				l.writersWaiting++
+			}
+			log.Printf("[DEBUG] %s is waiting for the lock on %q, held by %s", holder, key, l.holderNames())
+		}
+		released := l.released
+		m.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			m.mu.Lock()
+			if write {
+				l.writersWaiting--
+				l.notify()
+			}
+			return nil, &TimeoutError{
+				Key:     key,
+				Holder:  holder,
+				Waited:  time.Since(start),
+				Holders: l.holderList(),
+				Err:     ctx.Err(),
+			}
+		case <-released:
+		}
+		m.mu.Lock()
+	}
+
+	if waiting && write {
+		l.writersWaiting--
+	}
+	h := &Holder{Name: holder, Write: write, Since: time.Now()}
+	l.holders = append(l.holders, h)
+	l.stats.Acquired++
+	if waiting {
+		waited := time.Since(start)
+		l.stats.Contended++
+		l.stats.Waited += waited
+		if waited > l.stats.MaxWait {
+			l.stats.MaxWait = waited
+		}
+		log.Printf("[DEBUG] %s locked %q after waiting %s", holder, key, waited.Round(time.Millisecond))
+	}
+	return h, nil
+}
+
+func (m *Manager) release(key string, h *Holder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l := m.get(key)
+	for i, held := range l.holders {
+		if held == h {
+			l.holders = append(l.holders[:i], l.holders[i+1:]...)
+			break
+		}
+	}
+	l.notify()
+}
+
+// get returns the lock of key, m.mu must be held
+func (m *Manager) get(key string) *keyLock {
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyLock{released: make(chan struct{})}
+		m.locks[key] = l
+	}
+	return l
+}
+
+func (l *keyLock) holderList() []Holder {
+	holders := make([]Holder, len(l.holders))
+	for i, h := range l.holders {
+		holders[i] = *h
+	}
+	return holders
+}
+
+func (l *keyLock) holderNames() string {
+	names := make([]string, len(l.holders))
+	for i, h := range l.holders {
+		names[i] = h.Name
+	}
+	if len(names) == 0 {
+		return "waiting writers"
+	}
+	return strings.Join(names, ", ")
+}
+
+// Holders returns the holders of the locked keys, to find out which holder
+// blocks the others.
+func (m *Manager) Holders() map[string][]Holder {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	holders := make(map[string][]Holder)
+	for key, l := range m.locks {
+		if len(l.holders) > 0 {
+			holders[key] = l.holderList()
+		}
+	}
+	return holders
+}
+
+// Stats returns the contention of the keys locked so far
+func (m *Manager) Stats() map[string]Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := make(map[string]Stats, len(m.locks))
+	for key, l := range m.locks {
+		stats[key] = l.stats
+	}
+	return stats
+}
@@ -0,0 +1,189 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package keylock
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockAsync takes the lock in a goroutine and returns a channel closed once
+// the lock is granted.
+func lockAsync(t *testing.T, lock func() (func(), error)) <-chan func() {
+	ch := make(chan func(), 1)
+	go func() {
+		unlock, err := lock()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		ch <- unlock
+	}()
+	return ch
+}
+
+func expectBlocked(t *testing.T, ch <-chan func(), msg string) {
+	t.Helper()
+	select {
+	case <-ch:
+		t.Fatal(msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func expectGranted(t *testing.T, ch <-chan func(), msg string) func() {
+	t.Helper()
+	select {
+	case unlock := <-ch:
+		return unlock
+	case <-time.After(time.Second):
+		t.Fatal(msg)
+	}
+	return nil
+}
+
+func waitForWriters(t *testing.T, m *Manager, key string) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		m.mu.Lock()
+		waiting := m.get(key).writersWaiting
+		m.mu.Unlock()
+		if waiting > 0 {
+			return
+		}
+	}
+	t.Fatal("Writer is not waiting")
+}
+
+func TestManagerLock(t *testing.T) {
+	m := NewManager(0)
+	ctx := context.Background()
+	unlock, err := m.Lock(ctx, "first", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second := lockAsync(t, func() (func(), error) { return m.Lock(ctx, "second", "foo") })
+	other := lockAsync(t, func() (func(), error) { return m.Lock(ctx, "other", "bar") })
+	expectBlocked(t, second, "Second lock was able to be taken")
+	expectGranted(t, other, "Lock on a different key blocked")()
+
+	unlock()
+	unlock()
+	expectGranted(t, second, "Second lock blocked after unlock")()
+}
+
+func TestManagerRLock(t *testing.T) {
+	m := NewManager(0)
+	ctx := context.Background()
+	unlockRead, err := m.RLock(ctx, "reader", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectGranted(t, lockAsync(t, func() (func(), error) { return m.RLock(ctx, "reader 2", "foo") }), "Readers blocked each other")()
+
+	writer := lockAsync(t, func() (func(), error) { return m.Lock(ctx, "writer", "foo") })
+	expectBlocked(t, writer, "Writer was able to lock a key being read")
+	reader := lockAsync(t, func() (func(), error) { return m.RLock(ctx, "reader 3", "foo") })
+	expectBlocked(t, reader, "Reader overtook a waiting writer")
+
+	unlockRead()
+	unlockWrite := expectGranted(t, writer, "Writer blocked after the readers")
+	expectBlocked(t, reader, "Reader was able to lock a key being written")
+	unlockWrite()
+	expectGranted(t, reader, "Reader blocked after the writer")()
+}
+
+func TestManagerTimeout(t *testing.T) {
+	m := NewManager(50 * time.Millisecond)
+	unlock, err := m.Lock(context.Background(), "ibm_is_lb_pool update", "lb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+	unlockOther, err := m.Lock(context.Background(), "ibm_is_lb_pool_member create", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlockOther()
+
+	_, err = m.Lock(context.Background(), "ibm_is_lb_pool_member create", "a", "lb")
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a timeout, got %v", err)
+	}
+	if timeoutErr.Key != "lb" || len(timeoutErr.Holders) != 1 || timeoutErr.Holders[0].Name != "ibm_is_lb_pool update" {
+		t.Errorf("Expected the holder of the key, got %#v", timeoutErr)
+	}
+	if !strings.Contains(err.Error(), `held by ibm_is_lb_pool update (write lock for`) {
+		t.Errorf("Expected the holder in the error, got %q", err)
+	}
+	if holders := m.Holders(); len(holders) != 1 || len(holders["lb"]) != 1 {
+		t.Errorf("Expected the locks taken before the timeout to be released, got %v", holders)
+	}
+}
+
+func TestManagerCancelWriter(t *testing.T) {
+	m := NewManager(0)
+	unlock, err := m.RLock(context.Background(), "reader", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	writer := make(chan error)
+	go func() {
+		_, err := m.Lock(ctx, "writer", "foo")
+		writer <- err
+	}()
+	waitForWriters(t, m, "foo")
+	reader := lockAsync(t, func() (func(), error) { return m.RLock(context.Background(), "reader 2", "foo") })
+	expectBlocked(t, reader, "Reader overtook a waiting writer")
+
+	cancel()
+	if err := <-writer; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the writer to be cancelled, got %v", err)
+	}
+	expectGranted(t, reader, "Reader blocked after the writer gave up")()
+}
+
+func TestManagerOrderedKeys(t *testing.T) {
+	m := NewManager(5 * time.Second)
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			unlock, err := m.Acquire(context.Background(), "a then b", []string{"a"}, []string{"b"})
+			if err != nil {
+				errs <- err
+				return
+			}
+			unlock()
+		}()
+		go func() {
+			defer wg.Done()
+			unlock, err := m.Acquire(context.Background(), "b then a", []string{"b"}, []string{"a"})
+			if err != nil {
+				errs <- err
+				return
+			}
+			unlock()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if stats := m.Stats(); stats["a"].Acquired != 100 || stats["b"].Acquired != 100 {
+		t.Errorf("Expected 100 locks of each key, got %#v", stats)
+	}
+}
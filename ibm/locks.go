@@ -0,0 +1,39 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/internal/keylock"
+)
+
+// defaultLockTimeout bounds the wait for a lock of the operations without a
+// deadline, the default timeout of the operations of a resource
+const defaultLockTimeout = 20 * time.Minute
+
+// This is a global lock manager for use within this plugin.
+var ibmLocks = keylock.NewManager(defaultLockTimeout)
+
+// lockHolder names an operation of d in the diagnostics of the locks, ex:
+// "ibm_is_lb_pool_member delete (r006-1234/r006-5678/r006-9abc)".
+func lockHolder(d *schema.ResourceData, operation string) string {
+	if id := d.Id(); id != "" {
+		return fmt.Sprintf("%s (%s)", operation, id)
+	}
+	return operation
+}
+
+// acquireLocks takes the locks of keys and the read locks of read for an
+// operation of d. The wait is bounded by the timeout of the operation,
+// timeoutKey, rather than by the default timeout of ibmLocks.
+func acquireLocks(d *schema.ResourceData, timeoutKey, operation string, keys, read []string) (func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(timeoutKey))
+	defer cancel()
+	return ibmLocks.Acquire(ctx, lockHolder(d, operation), keys, read)
+}
@@ -0,0 +1,88 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/internal/keylock"
+)
+
+func TestLockHolder(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceIBMISSecurityGroupRule().Schema, map[string]interface{}{})
+	if holder := lockHolder(d, "ibm_is_security_group_rule create"); holder != "ibm_is_security_group_rule create" {
+		t.Errorf("Unexpected holder %q", holder)
+	}
+	d.SetId("r006-sg.r006-rule")
+	if holder := lockHolder(d, "ibm_is_security_group_rule delete"); holder != "ibm_is_security_group_rule delete (r006-sg.r006-rule)" {
+		t.Errorf("Unexpected holder %q", holder)
+	}
+}
+
+func TestLockSecurityGroupRule(t *testing.T) {
+	locks := ibmLocks
+	ibmLocks = keylock.NewManager(50 * time.Millisecond)
+	defer func() { ibmLocks = locks }()
+
+	d := schema.TestResourceDataRaw(t, resourceIBMISSecurityGroupRule().Schema, map[string]interface{}{})
+	unlock, err := lockSecurityGroupRule(d, schema.TimeoutCreate, "ibm_is_security_group_rule create", &parsedIBMISSecurityGroupRuleDictionary{
+		secgrpID:       "r006-sg",
+		remoteSecGrpID: "r006-remote",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	holders := ibmLocks.Holders()
+	if h := holders[securityGroupLockKey("r006-sg")]; len(h) != 1 || !h[0].Write {
+		t.Errorf("Expected a write lock of the security group, got %v", h)
+	}
+	if h := holders[securityGroupLockKey("r006-remote")]; len(h) != 1 || h[0].Write {
+		t.Errorf("Expected a read lock of the remote security group, got %v", h)
+	}
+
+	// other rules may reference the remote group, its deletion waits for them
+	unlockRead, err := ibmLocks.RLock(context.Background(), "ibm_is_security_group_rule create", securityGroupLockKey("r006-remote"))
+	if err != nil {
+		t.Fatalf("Expected the rules referencing the remote group to share it, got %v", err)
+	}
+	unlockRead()
+	_, err = ibmLocks.Lock(context.Background(), "ibm_is_security_group delete", securityGroupLockKey("r006-remote"))
+	var timeoutErr *keylock.TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Holders[0].Name != "ibm_is_security_group_rule create" {
+		t.Errorf("Expected the deletion of the remote group to wait for the rule, got %v", err)
+	}
+}
+
+func TestAcquireLocksTimeout(t *testing.T) {
+	locks := ibmLocks
+	ibmLocks = keylock.NewManager(time.Hour)
+	defer func() { ibmLocks = locks }()
+
+	resource := &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(50 * time.Millisecond),
+		},
+	}
+	d := resource.Data(nil)
+	d.SetId("r006-pool")
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_lb_pool delete", []string{"load_balancer_key_r006-lb"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	// the wait ends with the timeout of the operation, not the default timeout
+	_, err = acquireLocks(d, schema.TimeoutDelete, "ibm_is_lb_pool_member delete", nil, []string{"load_balancer_key_r006-lb"})
+	var timeoutErr *keylock.TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Holders[0].Name != "ibm_is_lb_pool delete (r006-pool)" {
+		t.Errorf("Expected the wait to end with the timeout of the operation, got %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Defaults of the provider polling block
+const (
+	defaultPollMinInterval = 5 * time.Second
+	defaultPollMaxInterval = 60 * time.Second
+)
+
+// the weight of the last transition in the expected duration of a kind
+const transitionTimeWeight = 0.5
+
+// the growth of the interval while a transition takes longer than expected
+const pollBackoffFactor = 1.5
+
+// the checks without a resource before a wait fails, like
+// resource.StateChangeConf
+const defaultPollNotFoundChecks = 20
+
+// errNotModified is returned by a pollRefreshFunc when the service answered
+// 304 Not Modified to a conditional request: the state did not change.
+var errNotModified = errors.New("not modified")
+
+// PollingPolicy configures the waits for the state of the resources
+type PollingPolicy struct {
+	// MinInterval and MaxInterval bound the interval between two checks
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	// Adaptive polls around the end of the transitions, based on how long
+	// the previous transitions of the same kind took
+	Adaptive bool
+}
+
+func defaultPollingPolicy() *PollingPolicy {
+	return &PollingPolicy{
+		MinInterval: defaultPollMinInterval,
+		MaxInterval: defaultPollMaxInterval,
+		Adaptive:    true,
+	}
+}
+
+// pollingPolicy returns the polling policy of the provider.
+func pollingPolicy(meta interface{}) *PollingPolicy {
+	if sess, ok := meta.(ClientSession); ok && sess.PollingPolicy() != nil {
+		return sess.PollingPolicy()
+	}
+	return defaultPollingPolicy()
+}
+
+func expandPollingPolicy(l []interface{}) *PollingPolicy {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	polling := l[0].(map[string]interface{})
+	policy := &PollingPolicy{
+		MinInterval: time.Duration(polling["min_interval"].(int)) * time.Second,
+		MaxInterval: time.Duration(polling["max_interval"].(int)) * time.Second,
+		Adaptive:    polling["adaptive"].(bool),
+	}
+	if policy.MaxInterval < policy.MinInterval {
+		policy.MaxInterval = policy.MinInterval
+	}
+	return policy
+}
+
+// pollRefreshFunc returns the state of a resource. etag is the entity tag of
+// the previous response, "" for the first check: when the service supports
+// conditional requests, the function sends it in an If-None-Match header and
+// returns errNotModified when the resource did not change.
+type pollRefreshFunc func(etag string) (result interface{}, state string, newETag string, err error)
+
+// pollStateRefresh adapts a resource.StateRefreshFunc to a poller.
+func pollStateRefresh(refresh resource.StateRefreshFunc) pollRefreshFunc {
+	return func(string) (interface{}, string, string, error) {
+		result, state, err := refresh()
+		return result, state, "", err
+	}
+}
+
+// ifNoneMatchHeaders returns the headers of a conditional request for etag.
+func ifNoneMatchHeaders(etag string) map[string]string {
+	if etag == "" {
+		return nil
+	}
+	return map[string]string{"If-None-Match": etag}
+}
+
+// isNotModified returns whether response answers a conditional request for a
+// resource that did not change.
+func isNotModified(response *core.DetailedResponse) bool {
+	return response != nil && response.StatusCode == 304
+}
+
+// responseETag returns the entity tag of response, "" when there is none.
+func responseETag(response *core.DetailedResponse) string {
+	if response == nil || response.Headers == nil {
+		return ""
+	}
+	return response.Headers.Get("ETag")
+}
+
+// poller waits for a resource to reach a target state like
+// resource.StateChangeConf, with intervals adapted to the transition: close
+// to its expected end when transitions of the same Kind were observed, and
+// growing from the policy floor to its ceiling otherwise. Conditional
+// requests keep the checks cheap when the service supports ETags.
+type poller struct {
+	// The kind of transition the statistics are kept for, ex:
+	// "ibm_is_vpc:available"
+	Kind string
+	// The resource in the logs, ex: "VPC (r006-1234)"
+	Name string
+
+	Pending []string
+	Target  []string
+	Refresh pollRefreshFunc
+	Timeout time.Duration
+	// The minimum time before the first check
+	Delay  time.Duration
+	Policy *PollingPolicy
+	// The checks in a row without a resource, a nil result, before the wait
+	// fails with a resource.NotFoundError, 20 when 0
+	NotFoundChecks int
+}
+
+// transitionTimes is the expected duration of each kind of transition
+var transitionTimes = &transitionStats{expected: map[string]time.Duration{}}
+
+type transitionStats struct {
+	mu       sync.Mutex
+	expected map[string]time.Duration
+}
+
+func (s *transitionStats) get(kind string) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.expected[kind]
+	return d, ok
+}
+
+// record adds a transition of kind that took d to its expected duration.
+func (s *transitionStats) record(kind string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if expected, ok := s.expected[kind]; ok {
+		d = time.Duration(transitionTimeWeight*float64(d) + (1-transitionTimeWeight)*float64(expected))
+	}
+	s.expected[kind] = d
+}
+
+// interval returns the wait before the next check, elapsed after the start
+// of the wait and after late checks past the expected end of the transition.
+func (p *poller) interval(policy *PollingPolicy, elapsed time.Duration, late int) time.Duration {
+	var interval time.Duration
+	expected, ok := transitionTimes.get(p.Kind)
+	if policy.Adaptive && ok && elapsed < expected {
+		// check again halfway to the expected end of the transition
+		interval = (expected - elapsed) / 2
+	} else {
+		interval = time.Duration(float64(policy.MinInterval) * math.Pow(pollBackoffFactor, float64(late)))
+	}
+	if interval < policy.MinInterval {
+		interval = policy.MinInterval
+	}
+	if interval > policy.MaxInterval {
+		interval = policy.MaxInterval
+	}
+	return interval
+}
+
+// WaitForStateContext waits for the resource to reach a target state and
+// returns the result of the last check.
+func (p *poller) WaitForStateContext(ctx context.Context) (interface{}, error) {
+	policy := p.Policy
+	if policy == nil {
+		policy = defaultPollingPolicy()
+	}
+
+	notFoundChecks := p.NotFoundChecks
+	if notFoundChecks == 0 {
+		notFoundChecks = defaultPollNotFoundChecks
+	}
+
+	start := time.Now()
+	var (
+		result      interface{}
+		state       string
+		etag        string
+		checks      int
+		notModified int
+		notFound    int
+		late        int
+	)
+	wait := p.interval(policy, 0, 0)
+	if wait < p.Delay {
+		wait = p.Delay
+	}
+	for {
+		if remaining := p.Timeout - time.Since(start); wait > remaining {
+			wait = remaining
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		checks++
+		res, st, newETag, err := p.Refresh(etag)
+		switch {
+		case err == errNotModified:
+			notModified++
+		case err != nil:
+			return res, err
+		default:
+			result, state, etag = res, st, newETag
+		}
+
+		elapsed := time.Since(start)
+		if result == nil {
+			// like resource.StateChangeConf, a missing resource is in no
+			// state, the wait fails when it stays missing
+			notFound++
+			if notFound > notFoundChecks {
+				return nil, &resource.NotFoundError{Retries: notFound}
+			}
+		} else {
+			notFound = 0
+			if stringInSlice(state, p.Target) {
+				transitionTimes.record(p.Kind, elapsed)
+				log.Printf("[INFO] %s is %s after %s (%d checks, %d not modified)", p.Name, state, elapsed.Round(time.Second), checks, notModified)
+				return result, nil
+			}
+			if !stringInSlice(state, p.Pending) {
+				return result, &resource.UnexpectedStateError{State: state, ExpectedState: p.Target}
+			}
+		}
+		if elapsed >= p.Timeout {
+			return result, &resource.TimeoutError{LastState: state, Timeout: p.Timeout, ExpectedState: p.Target}
+		}
+
+		if expected, ok := transitionTimes.get(p.Kind); !ok || elapsed >= expected {
+			late++
+		}
+		wait = p.interval(policy, elapsed, late)
+		log.Printf("[DEBUG] Waiting for %s to be %v: %s after %s, next check in %s", p.Name, p.Target, state, elapsed.Round(time.Second), wait)
+	}
+}
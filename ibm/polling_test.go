@@ -0,0 +1,242 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gohttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// testPollingPolicy keeps the waits of the tests short
+var testPollingPolicy = &PollingPolicy{MinInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+func TestPollerInterval(t *testing.T) {
+	policy := &PollingPolicy{MinInterval: time.Second, MaxInterval: 10 * time.Second, Adaptive: true}
+	transitionTimes.record("test:interval", 20*time.Second)
+
+	cases := map[string]struct {
+		kind     string
+		adaptive bool
+		elapsed  time.Duration
+		late     int
+		want     time.Duration
+	}{
+		"floor":                  {"test:unknown", true, 0, 0, time.Second},
+		"backoff":                {"test:unknown", true, 0, 2, 2250 * time.Millisecond},
+		"ceiling":                {"test:unknown", true, 0, 10, 10 * time.Second},
+		"halfway to the end":     {"test:interval", true, 4 * time.Second, 0, 8 * time.Second},
+		"long expected duration": {"test:interval", true, 0, 0, 10 * time.Second},
+		"close to the end":       {"test:interval", true, 19 * time.Second, 0, time.Second},
+		"past the end":           {"test:interval", true, 30 * time.Second, 1, 1500 * time.Millisecond},
+		"not adaptive":           {"test:interval", false, 4 * time.Second, 0, time.Second},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &poller{Kind: c.kind}
+			policy := *policy
+			policy.Adaptive = c.adaptive
+			if got := p.interval(&policy, c.elapsed, c.late); got != c.want {
+				t.Errorf("Expected %s, got %s", c.want, got)
+			}
+		})
+	}
+}
+
+func TestTransitionStatsRecord(t *testing.T) {
+	s := &transitionStats{expected: map[string]time.Duration{}}
+	if _, ok := s.get("kind"); ok {
+		t.Error("Expected no duration before the first transition")
+	}
+	s.record("kind", 10*time.Second)
+	s.record("kind", 20*time.Second)
+	if d, _ := s.get("kind"); d != 15*time.Second {
+		t.Errorf("Expected 15s, got %s", d)
+	}
+}
+
+func TestPollerNotModified(t *testing.T) {
+	var etags []string
+	p := &poller{
+		Kind:    "test:not_modified",
+		Name:    "test resource",
+		Pending: []string{"pending"},
+		Target:  []string{"done"},
+		Timeout: time.Second,
+		Policy:  testPollingPolicy,
+		Refresh: func(etag string) (interface{}, string, string, error) {
+			etags = append(etags, etag)
+			switch len(etags) {
+			case 1:
+				return "v1", "pending", "v1", nil
+			case 2, 3:
+				return nil, "", etag, errNotModified
+			}
+			return "v2", "done", "v2", nil
+		},
+	}
+	result, err := p.WaitForStateContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "v2" {
+		t.Errorf("Expected the last result, got %v", result)
+	}
+	if want := []string{"", "v1", "v1", "v1"}; !reflect.DeepEqual(etags, want) {
+		t.Errorf("Expected the entity tags %q, got %q", want, etags)
+	}
+	if _, ok := transitionTimes.get(p.Kind); !ok {
+		t.Error("Expected the duration of the transition to be recorded")
+	}
+}
+
+func TestPollerErrors(t *testing.T) {
+	refreshErr := errors.New("Internal Server Error")
+	cases := map[string]struct {
+		result  interface{}
+		state   string
+		err     error
+		wantErr func(err error) bool
+	}{
+		"timeout": {
+			result: "result",
+			state:  "pending",
+			wantErr: func(err error) bool {
+				var timeoutErr *resource.TimeoutError
+				return errors.As(err, &timeoutErr) && timeoutErr.LastState == "pending"
+			},
+		},
+		"unexpected state": {
+			result: "result",
+			state:  "failed",
+			wantErr: func(err error) bool {
+				var stateErr *resource.UnexpectedStateError
+				return errors.As(err, &stateErr) && stateErr.State == "failed"
+			},
+		},
+		"refresh error": {
+			err:     refreshErr,
+			wantErr: func(err error) bool { return err == refreshErr },
+		},
+		"not found": {
+			wantErr: func(err error) bool {
+				var notFoundErr *resource.NotFoundError
+				return errors.As(err, &notFoundErr) && notFoundErr.Retries == 3
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &poller{
+				Kind:           "test:errors",
+				Pending:        []string{"pending"},
+				Target:         []string{"done", ""},
+				Timeout:        50 * time.Millisecond,
+				Policy:         testPollingPolicy,
+				NotFoundChecks: 2,
+				Refresh: func(string) (interface{}, string, string, error) {
+					return c.result, c.state, "", c.err
+				},
+			}
+			if _, err := p.WaitForStateContext(context.Background()); !c.wantErr(err) {
+				t.Errorf("Unexpected error %#v", err)
+			}
+		})
+	}
+}
+
+func TestPollerCancel(t *testing.T) {
+	p := &poller{
+		Kind:    "test:cancel",
+		Pending: []string{"pending"},
+		Target:  []string{"done"},
+		Timeout: 10 * time.Minute,
+		Delay:   10 * time.Minute,
+		Refresh: pollStateRefresh(func() (interface{}, string, error) {
+			return nil, "pending", nil
+		}),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := p.WaitForStateContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected the wait to be cancelled, got %v", err)
+	}
+}
+
+func TestExpandPollingPolicy(t *testing.T) {
+	if policy := expandPollingPolicy(nil); policy != nil {
+		t.Errorf("Expected no policy, got %#v", policy)
+	}
+	policy := expandPollingPolicy([]interface{}{map[string]interface{}{
+		"min_interval": 30,
+		"max_interval": 10,
+		"adaptive":     false,
+	}})
+	want := &PollingPolicy{MinInterval: 30 * time.Second, MaxInterval: 30 * time.Second}
+	if !reflect.DeepEqual(policy, want) {
+		t.Errorf("Expected %#v, got %#v", want, policy)
+	}
+}
+
+func TestPollerVPCNotModified(t *testing.T) {
+	var (
+		mu           sync.Mutex
+		gets         int
+		notModifieds int
+	)
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		gets++
+		if r.Header.Get("If-None-Match") == `W/"1"` && gets < 4 {
+			notModifieds++
+			w.WriteHeader(gohttp.StatusNotModified)
+			return
+		}
+		status, etag := isVPCPending, `W/"1"`
+		if gets >= 4 {
+			status, etag = isVPCAvailable, `W/"2"`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", etag)
+		fmt.Fprintf(w, `{"id": "r006-1234", "name": "vpc", "status": "%s"}`, status)
+	}))
+	defer server.Close()
+	sess, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &poller{
+		Kind:    "test:vpc",
+		Pending: []string{isVPCPending},
+		Target:  []string{isVPCAvailable, isVPCFailed},
+		Refresh: isVPCRefreshFunc(context.Background(), sess, "r006-1234"),
+		Timeout: time.Second,
+		Policy:  testPollingPolicy,
+	}
+	result, err := p.WaitForStateContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vpc := result.(*vpcv1.VPC); *vpc.Status != isVPCAvailable {
+		t.Errorf("Expected the VPC to be available, got %s", *vpc.Status)
+	}
+	if gets != 4 || notModifieds != 2 {
+		t.Errorf("Expected 4 checks with 2 not modified, got %d and %d", gets, notModifieds)
+	}
+}
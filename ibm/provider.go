@@ -9,13 +9,9 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
-	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/internal/mutexkv"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// This is a global MutexKV for use within this plugin.
-var ibmMutexKV = mutexkv.NewMutexKV()
-
 // Provider returns a *schema.Provider.
 func Provider() *schema.Provider {
 	return &schema.Provider{
@@ -108,7 +104,7 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "The Classic Infrastructure Endpoint",
-				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IAAS_CLASSIC_ENDPOINT_URL"}, "https://api.softlayer.com/rest/v3"),
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IAAS_CLASSIC_ENDPOINT_URL"}, SoftlayerRestEndpoint),
 			},
 			"iaas_classic_timeout": {
 				Type:        schema.TypeInt,
@@ -155,6 +151,79 @@ func Provider() *schema.Provider {
 				Description: "IAM Authentication refresh token",
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_IAM_REFRESH_TOKEN", "IBMCLOUD_IAM_REFRESH_TOKEN"}, nil),
 			},
+			"iam_profile_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "ID of the trusted profile the compute resource token is exchanged for",
+				DefaultFunc:   schema.MultiEnvDefaultFunc([]string{"IC_IAM_PROFILE_ID", "IBMCLOUD_IAM_PROFILE_ID"}, nil),
+				ConflictsWith: []string{"iam_profile_name"},
+			},
+			"iam_profile_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Name of the trusted profile the compute resource token is exchanged for",
+				DefaultFunc:   schema.MultiEnvDefaultFunc([]string{"IC_IAM_PROFILE_NAME", "IBMCLOUD_IAM_PROFILE_NAME"}, nil),
+				ConflictsWith: []string{"iam_profile_id"},
+			},
+			"cr_token_file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of the compute resource token file used with the trusted profile",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_CR_TOKEN_FILE_PATH", "IBMCLOUD_CR_TOKEN_FILE_PATH"}, nil),
+			},
+			"iam_token_file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of a file holding an IAM token, re-read when the token expires",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_IAM_TOKEN_FILE_PATH", "IBMCLOUD_IAM_TOKEN_FILE_PATH"}, nil),
+			},
+			"iam_jwks_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the JSON Web Key Set the IAM token is verified with",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_IAM_JWKS_URL", "IBMCLOUD_IAM_JWKS_URL"}, nil),
+			},
+			"account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the account the provider must operate in",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_ACCOUNT_ID", "IBMCLOUD_ACCOUNT_ID"}, nil),
+			},
+			"assume": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Trusted profile, possibly in another account, the provider assumes with the token of its credentials",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"profile_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"assume.0.profile_id", "assume.0.profile_crn", "assume.0.profile_name"},
+							Description:  "ID of the trusted profile to assume",
+						},
+						"profile_crn": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"assume.0.profile_id", "assume.0.profile_crn", "assume.0.profile_name"},
+							Description:  "CRN of the trusted profile to assume",
+						},
+						"profile_name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"assume.0.profile_id", "assume.0.profile_crn", "assume.0.profile_name"},
+							RequiredWith: []string{"assume.0.account_id"},
+							Description:  "Name of the trusted profile to assume, in the account account_id",
+						},
+						"account_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							RequiredWith: []string{"assume.0.profile_name"},
+							Description:  "ID of the account of the trusted profile profile_name",
+						},
+					},
+				},
+			},
 			"visibility": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -162,6 +231,200 @@ func Provider() *schema.Provider {
 				Description:  "Visibility of the provider if it is private or public.",
 				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"IC_VISIBILITY", "IBMCLOUD_VISIBILITY"}, "public"),
 			},
+			"endpoints_file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of the file that contains private and public regional endpoints mapping",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_ENDPOINTS_FILE_PATH", "IBMCLOUD_ENDPOINTS_FILE_PATH"}, nil),
+			},
+			"endpoints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Service endpoint overrides, they take precedence over the endpoints file",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the endpoint environment variable of the service, ex: IBMCLOUD_IS_NG_API_ENDPOINT",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Endpoint of the service",
+						},
+						"visibility": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "public",
+							ValidateFunc: validateAllowedStringValue([]string{"public", "private"}),
+							Description:  "Visibility of the endpoint, public or private",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Region of the endpoint, defaults to the region of the provider",
+						},
+					},
+				},
+			},
+			"http_logging": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Log every API request and response as JSON at the DEBUG level, with credentials redacted",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_HTTP_LOGGING", "IBMCLOUD_HTTP_LOGGING"}, false),
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Retry policy for the API calls made by the provider",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateAllowedRangeInt(1, 100),
+							Description:  "Total number of attempts for an API call, defaults to max_retries + 1",
+						},
+						"min_backoff": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      int(defaultRetryMinBackoff / time.Second),
+							ValidateFunc: validateAllowedRangeInt(1, 3600),
+							Description:  "Minimum wait in seconds between two attempts",
+						},
+						"max_backoff": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      int(defaultRetryMaxBackoff / time.Second),
+							ValidateFunc: validateAllowedRangeInt(1, 3600),
+							Description:  "Maximum wait in seconds between two attempts",
+						},
+						"jitter": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Randomise the wait between attempts",
+						},
+						"honor_retry_after": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Wait for the delay given by the Retry-After response header, up to max_backoff",
+						},
+						"retryable_status_codes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Set:         schema.HashInt,
+							Description: "HTTP status codes retried in addition to 408, 429 and the 5xx codes",
+						},
+						"retryable_errors": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Substrings of error messages that are retried",
+						},
+					},
+				},
+			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags attached to every resource that supports tags",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: InvokeValidator("ibm_resource_tag", "tags")},
+							Set:         resourceIBMVPCHash,
+							Description: "Tags attached to every resource that supports tags, in addition to the tags of the resource",
+						},
+					},
+				},
+			},
+			"rate_limit": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Client side rate limits for the API calls made by the provider",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"requests_per_second": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							ValidateFunc: validation.FloatAtLeast(0),
+							Description:  "Requests per second for the whole provider, 0 for no limit",
+						},
+						"service_requests_per_second": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							ValidateFunc: validation.FloatAtLeast(0),
+							Description:  "Requests per second for each service API host, 0 for no limit",
+						},
+						"burst": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validateAllowedRangeInt(1, 1000),
+							Description:  "Number of requests that can be sent at once",
+						},
+						"service": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Requests per second for the API hosts of a service",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"host": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "API host, or a domain of API hosts, of the service",
+									},
+									"requests_per_second": {
+										Type:         schema.TypeFloat,
+										Required:     true,
+										ValidateFunc: validation.FloatAtLeast(0),
+										Description:  "Requests per second for each API host of the service, 0 for no limit",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"polling": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Intervals of the checks made while waiting for the state of the resources",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_interval": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      int(defaultPollMinInterval / time.Second),
+							ValidateFunc: validateAllowedRangeInt(1, 3600),
+							Description:  "Minimum interval in seconds between two checks",
+						},
+						"max_interval": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      int(defaultPollMaxInterval / time.Second),
+							ValidateFunc: validateAllowedRangeInt(1, 3600),
+							Description:  "Maximum interval in seconds between two checks",
+						},
+						"adaptive": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Check around the expected end of the operations, learnt from the previous operations of the same kind",
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -184,6 +447,7 @@ func Provider() *schema.Provider {
 			"ibm_certificate_manager_certificate":    dataIBMCertificateManagerCertificate(),
 			"ibm_cis":                                dataSourceIBMCISInstance(),
 			"ibm_cis_dns_records":                    dataSourceIBMCISDNSRecords(),
+			"ibm_cis_dns_records_export":             dataSourceIBMCISDNSRecordsExport(),
 			"ibm_cis_certificates":                   dataIBMCISCertificates(),
 			"ibm_cis_global_load_balancers":          dataSourceIBMCISGlbs(),
 			"ibm_cis_origin_pools":                   dataSourceIBMCISOriginPools(),
@@ -204,6 +468,7 @@ func Provider() *schema.Provider {
 			"ibm_cis_waf_rules":                      dataSourceIBMCISWAFRules(),
 			"ibm_cis_filters":                        dataSourceIBMCISFilters(),
 			"ibm_database":                           dataSourceIBMDatabaseInstance(),
+			"ibm_database_backups":                   dataSourceIBMDatabaseBackups(),
 			"ibm_compute_bare_metal":                 dataSourceIBMComputeBareMetal(),
 			"ibm_compute_image_template":             dataSourceIBMComputeImageTemplate(),
 			"ibm_compute_placement_group":            dataSourceIBMComputePlacementGroup(),
@@ -436,6 +701,10 @@ func Provider() *schema.Provider {
 			"ibm_function_namespace":                             resourceIBMFunctionNamespace(),
 			"ibm_cis":                                            resourceIBMCISInstance(),
 			"ibm_database":                                       resourceIBMDatabaseInstance(),
+			"ibm_database_allowlist_entry":                       resourceIBMDatabaseAllowlistEntry(),
+			"ibm_database_backup":                                resourceIBMDatabaseBackup(),
+			"ibm_database_configuration":                         resourceIBMDatabaseConfiguration(),
+			"ibm_database_user":                                  resourceIBMDatabaseUser(),
 			"ibm_certificate_manager_import":                     resourceIBMCertificateManagerImport(),
 			"ibm_certificate_manager_order":                      resourceIBMCertificateManagerOrder(),
 			"ibm_cis_domain":                                     resourceIBMCISDomain(),
@@ -665,6 +934,14 @@ func Provider() *schema.Provider {
 			// Atracker
 			"ibm_atracker_target": resourceIBMAtrackerTarget(),
 			"ibm_atracker_route":  resourceIBMAtrackerRoute(),
+
+			// Secrets Manager
+			"ibm_secrets_manager_secret_group":             resourceIBMSecretsManagerSecretGroup(),
+			"ibm_secrets_manager_arbitrary_secret":         resourceIBMSecretsManagerArbitrarySecret(),
+			"ibm_secrets_manager_username_password_secret": resourceIBMSecretsManagerUsernamePasswordSecret(),
+			"ibm_secrets_manager_iam_credentials_secret":   resourceIBMSecretsManagerIAMCredentialsSecret(),
+			"ibm_secrets_manager_imported_cert":            resourceIBMSecretsManagerImportedCert(),
+			"ibm_secrets_manager_public_cert":              resourceIBMSecretsManagerPublicCert(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -768,8 +1045,10 @@ func Validator() ValidatorDict {
 				"ibm_satellite_location":                  resourceIBMSatelliteLocationValidator(),
 				"ibm_satellite_cluster":                   resourceIBMSatelliteClusterValidator(),
 				"ibm_pi_volume":                           resourceIBMPIVolumeValidator(),
+				"ibm_pi_network":                          resourceIBMPINetworkValidator(),
 				"ibm_atracker_target":                     resourceIBMAtrackerTargetValidator(),
 				"ibm_atracker_route":                      resourceIBMAtrackerRouteValidator(),
+				"ibm_secrets_manager_secret_group":        resourceIBMSecretsManagerSecretGroupValidator(),
 			},
 			DataSourceValidatorDictionary: map[string]*ResourceValidator{
 				"ibm_is_subnet":               dataSourceIBMISSubnetValidator(),
@@ -787,6 +1066,15 @@ func Validator() ValidatorDict {
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config, err := providerConfig(d)
+	if err != nil {
+		return nil, err
+	}
+	return config.ClientSession()
+}
+
+// providerConfig builds the Config of the provider block d
+func providerConfig(d *schema.ResourceData) (*Config, error) {
 	var bluemixAPIKey string
 	var bluemixTimeout int
 	var iamToken, iamRefreshToken string
@@ -802,6 +1090,26 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	if rtoken, ok := d.GetOk("iam_refresh_token"); ok {
 		iamRefreshToken = rtoken.(string)
 	}
+	var iamProfileID, iamProfileName, crTokenFile, iamTokenFile string
+	if v, ok := d.GetOk("iam_profile_id"); ok {
+		iamProfileID = v.(string)
+	}
+	if v, ok := d.GetOk("iam_profile_name"); ok {
+		iamProfileName = v.(string)
+	}
+	if v, ok := d.GetOk("cr_token_file_path"); ok {
+		crTokenFile = v.(string)
+	}
+	if v, ok := d.GetOk("iam_token_file_path"); ok {
+		iamTokenFile = v.(string)
+	}
+	var iamJWKSURL, accountID string
+	if v, ok := d.GetOk("iam_jwks_url"); ok {
+		iamJWKSURL = v.(string)
+	}
+	if v, ok := d.GetOk("account_id"); ok {
+		accountID = v.(string)
+	}
 	var softlayerUsername, softlayerAPIKey, softlayerEndpointUrl string
 	var softlayerTimeout int
 	if username, ok := d.GetOk("softlayer_username"); ok {
@@ -840,6 +1148,11 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		visibility = v.(string)
 	}
 
+	var endpointsFile string
+	if f, ok := d.GetOk("endpoints_file_path"); ok {
+		endpointsFile = f.(string)
+	}
+
 	resourceGrp := d.Get("resource_group").(string)
 	region := d.Get("region").(string)
 	zone := d.Get("zone").(string)
@@ -871,10 +1184,24 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		RiaasEndPoint:        riaasEndPoint,
 		IAMToken:             iamToken,
 		IAMRefreshToken:      iamRefreshToken,
+		IAMProfileID:         iamProfileID,
+		IAMProfileName:       iamProfileName,
+		CRTokenFile:          crTokenFile,
+		IAMTokenFile:         iamTokenFile,
+		IAMJWKSURL:           iamJWKSURL,
+		AccountID:            accountID,
 		Zone:                 zone,
 		Visibility:           visibility,
+		EndpointsFile:        endpointsFile,
+		Endpoints:            expandEndpoints(d.Get("endpoints").([]interface{}), region),
+		HTTPLogging:          d.Get("http_logging").(bool),
+		RetryPolicy:          expandRetryPolicy(d.Get("retry").([]interface{}), retryCount),
+		RateLimit:            expandRateLimit(d.Get("rate_limit").([]interface{})),
+		Polling:              expandPollingPolicy(d.Get("polling").([]interface{})),
+		Assume:               expandAssumeProfile(d.Get("assume").([]interface{})),
+		DefaultTags:          expandDefaultTags(d.Get("default_tags").([]interface{})),
 		//PowerServiceInstance: powerServiceInstance,
 	}
 
-	return config.ClientSession()
+	return &config, nil
 }
@@ -75,6 +75,9 @@ var hpcsInstanceID string
 var secretsManagerInstanceID string
 var secretsManagerSecretType string
 var secretsManagerSecretID string
+var secretsManagerPublicCertCA string
+var secretsManagerPublicCertDNS string
+var secretsManagerPublicCertCommonName string
 var hpcsAdmin1 string
 var hpcsToken1 string
 var hpcsAdmin2 string
@@ -554,6 +557,13 @@ func init() {
 		fmt.Println("[WARN] Set the environment variable SECRETS_MANAGER_SECRET_ID for testing data_source_ibm_secrets_manager_secret_test else tests will fail if this is not set correctly")
 	}
 
+	secretsManagerPublicCertCA = os.Getenv("SECRETS_MANAGER_PUBLIC_CERT_CA")
+	secretsManagerPublicCertDNS = os.Getenv("SECRETS_MANAGER_PUBLIC_CERT_DNS")
+	secretsManagerPublicCertCommonName = os.Getenv("SECRETS_MANAGER_PUBLIC_CERT_COMMON_NAME")
+	if secretsManagerPublicCertCA == "" || secretsManagerPublicCertDNS == "" || secretsManagerPublicCertCommonName == "" {
+		fmt.Println("[INFO] Set the environment variables SECRETS_MANAGER_PUBLIC_CERT_CA, SECRETS_MANAGER_PUBLIC_CERT_DNS and SECRETS_MANAGER_PUBLIC_CERT_COMMON_NAME for testing ibm_secrets_manager_public_cert resource else tests will fail if this is not set correctly")
+	}
+
 	tg_cross_network_account_id = os.Getenv("IBM_TG_CROSS_ACCOUNT_ID")
 	if tg_cross_network_account_id == "" {
 		fmt.Println("[INFO] Set the environment variable IBM_TG_CROSS_ACCOUNT_ID for testing ibm_tg_connection resource else  tests will fail if this is not set correctly")
@@ -605,7 +615,23 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProviderResourcesImportable(t *testing.T) {
+	// actions that do not manage a remote object
+	notImportable := map[string]bool{
+		"ibm_container_api_key_reset":         true,
+		"ibm_iam_authorization_policy_detach": true,
+	}
+	for name, r := range Provider().ResourcesMap {
+		if r.Importer == nil && !notImportable[name] {
+			t.Errorf("Resource %s has no importer", name)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
+	if testAccUseCassette(t) {
+		return
+	}
 	if v := os.Getenv("IC_API_KEY"); v == "" {
 		t.Fatal("IC_API_KEY must be set for acceptance tests")
 	}
@@ -617,6 +643,44 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
+// testAccUseCassette records the HTTP interactions of the test to, or replays
+// them from, a cassette named after the test when IBMCLOUD_CASSETTE_MODE is
+// set. The cassette is set on the Config of testAccProvider until the test
+// ends. It returns true when replaying, in which case placeholder credentials
+// are configured so the test runs without access to IBM Cloud.
+func testAccUseCassette(t *testing.T) bool {
+	if cassetteMode() == "" {
+		return false
+	}
+	c, err := startCassette(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccProvider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		config, err := providerConfig(d)
+		if err != nil {
+			return nil, err
+		}
+		config.cassette = c
+		return config.ClientSession()
+	}
+	t.Cleanup(func() {
+		testAccProvider.ConfigureFunc = providerConfigure
+		if err := stopCassette(c); err != nil {
+			t.Errorf("Error saving cassette: %s", err)
+		}
+	})
+	if cassetteMode() != cassetteModeReplay {
+		return false
+	}
+	for _, env := range []string{"IC_API_KEY", "IAAS_CLASSIC_API_KEY", "IAAS_CLASSIC_USERNAME"} {
+		if os.Getenv(env) == "" {
+			os.Setenv(env, redactedValue)
+		}
+	}
+	return true
+}
+
 func testAccPreCheckEnterprise(t *testing.T) {
 	if v := os.Getenv("IC_API_KEY"); v == "" {
 		t.Fatal("IC_API_KEY must be set for acceptance tests")
@@ -663,6 +727,20 @@ func testAccPreCheckHPCS(t *testing.T) {
 		t.Fatal("IBM_HPCS_TOKEN2 must be set for acceptance tests")
 	}
 }
+func testAccPreCheckSecretsManager(t *testing.T) {
+	testAccPreCheck(t)
+	if secretsManagerInstanceID == "" {
+		t.Fatal("SECRETS_MANAGER_INSTANCE_ID must be set for acceptance tests")
+	}
+}
+
+func testAccPreCheckSecretsManagerPublicCert(t *testing.T) {
+	testAccPreCheckSecretsManager(t)
+	if secretsManagerPublicCertCA == "" || secretsManagerPublicCertDNS == "" || secretsManagerPublicCertCommonName == "" {
+		t.Fatal("SECRETS_MANAGER_PUBLIC_CERT_CA, SECRETS_MANAGER_PUBLIC_CERT_DNS and SECRETS_MANAGER_PUBLIC_CERT_COMMON_NAME must be set for acceptance tests")
+	}
+}
+
 func testAccPreCheckCOS(t *testing.T) {
 	testAccPreCheck(t)
 	if cosCRN == "" {
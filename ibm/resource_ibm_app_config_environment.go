@@ -16,7 +16,7 @@ func resourceIbmAppConfigEnvironment() *schema.Resource {
 		Create:   resourceEnvironmentCreate,
 		Update:   resourceEnvironmentUpdate,
 		Delete:   resourceEnvironmentDelete,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "guid", "environment_id"),
 
 		Schema: map[string]*schema.Schema{
 			"guid": {
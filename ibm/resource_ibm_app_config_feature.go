@@ -20,7 +20,7 @@ func resourceIbmIbmAppConfigFeature() *schema.Resource {
 		Read:     resourceIbmIbmAppConfigFeatureRead,
 		Update:   resourceIbmIbmAppConfigFeatureUpdate,
 		Delete:   resourceIbmIbmAppConfigFeatureDelete,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "guid", "environment_id", "feature_id"),
 
 		Schema: map[string]*schema.Schema{
 			"guid": {
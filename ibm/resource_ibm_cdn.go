@@ -19,11 +19,12 @@ const str string = ".cdn.appdomain.cloud"
 
 func resourceIBMCDN() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceIBMCDNCreate,
-		Read:   resourceIBMCDNRead,
-		Update: resourceIBMCDNUpdate,
-		Delete: resourceIBMCDNDelete,
-		Exists: resourceIBMCDNExists,
+		Create:   resourceIBMCDNCreate,
+		Read:     resourceIBMCDNRead,
+		Update:   resourceIBMCDNUpdate,
+		Delete:   resourceIBMCDNDelete,
+		Exists:   resourceIBMCDNExists,
+		Importer: &schema.ResourceImporter{},
 
 		Schema: map[string]*schema.Schema{
 			"host_name": &schema.Schema{
@@ -349,6 +350,12 @@ func resourceIBMCDNRead(d *schema.ResourceData, meta interface{}) error {
 	cdnId := sl.String(d.Id())
 	///read the changes in the remote resource and update in the local resource.
 	read, err := service.ListDomainMappingByUniqueId(cdnId)
+	if err != nil {
+		return fmt.Errorf("Error retrieving CDN domain mapping %s: %s", d.Id(), err)
+	}
+	if len(read) == 0 {
+		return fmt.Errorf("No CDN domain mapping found with ID %s", d.Id())
+	}
 	///Print the response of the requested the service.
 	d.Set("originaddress", *read[0].OriginHost)
 	d.Set("vendorname", *read[0].VendorName)
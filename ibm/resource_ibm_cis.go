@@ -4,6 +4,7 @@
 package ibm
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -44,6 +46,12 @@ func resourceIBMCISInstance() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceTagsCustomizeDiff(diff, v)
+			},
+		),
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -94,6 +102,7 @@ func resourceIBMCISInstance() *schema.Resource {
 			"tags": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString, ValidateFunc: InvokeValidator("ibm_cis", "tag")},
 				Set:      schema.HashString,
 			},
@@ -225,7 +234,7 @@ func resourceIBMCISInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Error creating resource instance: %s %s", err, response)
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk("tags"); ok || v != "" {
+	if _, ok := d.GetOk("tags"); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange("tags")
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
@@ -4,6 +4,7 @@
 package ibm
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -11,13 +12,18 @@ import (
 	"strings"
 
 	"github.com/IBM/go-sdk-core/v4/core"
+	cisdnsrecordsv1 "github.com/IBM/networking-go-sdk/dnsrecordsv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
 	cisDNSRecordsImportFile               = "file"
+	cisDNSRecordsImportSync               = "sync"
 	cisDNSRecordsImportTotalRecordsParsed = "total_records_parsed"
 	cisDNSRecordsImportRecordsAdded       = "records_added"
+	cisDNSRecordsImportRecordsUpdated     = "records_updated"
+	cisDNSRecordsImportRecordsDeleted     = "records_deleted"
+	cisDNSRecordsImportRecordIDs          = "record_ids"
 )
 
 func resourceIBMCISDNSRecordsImport() *schema.Resource {
@@ -38,7 +44,12 @@ func resourceIBMCISDNSRecordsImport() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "File to import",
 				Required:    true,
-				ForceNew:    true,
+			},
+			cisDNSRecordsImportSync: {
+				Type:        schema.TypeBool,
+				Description: "Sync the zone with the file: add, update and delete the zone records to match the file",
+				Optional:    true,
+				Default:     false,
 			},
 			cisDNSRecordsImportTotalRecordsParsed: {
 				Type:        schema.TypeInt,
@@ -50,16 +61,38 @@ func resourceIBMCISDNSRecordsImport() *schema.Resource {
 				Description: "added records count",
 				Computed:    true,
 			},
+			cisDNSRecordsImportRecordsUpdated: {
+				Type:        schema.TypeInt,
+				Description: "updated records count of the last sync",
+				Computed:    true,
+			},
+			cisDNSRecordsImportRecordsDeleted: {
+				Type:        schema.TypeInt,
+				Description: "deleted records count of the last sync",
+				Computed:    true,
+			},
+			cisDNSRecordsImportRecordIDs: {
+				Type:        schema.TypeSet,
+				Description: "IDs of the zone records that match the file after the last sync, they are deleted with the resource",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
 		},
 
-		Create:   resourceCISDNSRecordsImportUpdate,
-		Read:     resourceCISDNSRecordsImportRead,
-		Update:   resourceCISDNSRecordsImportRead,
-		Delete:   resourceCISDNSRecordsImportDelete,
-		Importer: &schema.ResourceImporter{},
+		Create:        resourceCISDNSRecordsImportCreate,
+		Read:          resourceCISDNSRecordsImportRead,
+		Update:        resourceCISDNSRecordsImportUpdate,
+		Delete:        resourceCISDNSRecordsImportDelete,
+		CustomizeDiff: resourceCISDNSRecordsImportDiff,
+		Importer:      &schema.ResourceImporter{},
 	}
 }
-func resourceCISDNSRecordsImportUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceCISDNSRecordsImportCreate(d *schema.ResourceData, meta interface{}) error {
+	if d.Get(cisDNSRecordsImportSync).(bool) {
+		return resourceCISDNSRecordsImportSync(d, meta)
+	}
+
 	cisClient, err := meta.(ClientSession).CisDNSRecordBulkClientSession()
 	if err != nil {
 		return err
@@ -82,9 +115,7 @@ func resourceCISDNSRecordsImportUpdate(d *schema.ResourceData, meta interface{})
 		log.Printf("Error importing dns records: %v", response)
 		return err
 	}
-	id := fmt.Sprintf("%v:%v:%s:%s:%s", *result.Result.TotalRecordsParsed,
-		*result.Result.RecsAdded, file, zoneID, crn)
-	d.SetId(id)
+	d.SetId(cisDNSRecordsImportID(*result.Result.TotalRecordsParsed, *result.Result.RecsAdded, file, zoneID, crn))
 
 	return nil
 
@@ -105,8 +136,168 @@ func resourceCISDNSRecordsImportRead(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
+func resourceCISDNSRecordsImportUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.Get(cisDNSRecordsImportSync).(bool) {
+		return resourceCISDNSRecordsImportSync(d, meta)
+	}
+	return resourceCISDNSRecordsImportRead(d, meta)
+}
+
 func resourceCISDNSRecordsImportDelete(d *schema.ResourceData, meta interface{}) error {
-	// Nothing to delete on CIS DNS Record import resource
+	// Without sync, the imported records are left in the zone. With sync, the
+	// records of the last sync are deleted, the file may have changed since.
+	ids := d.Get(cisDNSRecordsImportRecordIDs).(*schema.Set).List()
+	if d.Get(cisDNSRecordsImportSync).(bool) && len(ids) > 0 {
+		sess, err := meta.(ClientSession).CisDNSRecordClientSession()
+		if err != nil {
+			return err
+		}
+		zoneID, _, err := convertTftoCisTwoVar(d.Get(cisDomainID).(string))
+		if err != nil {
+			return err
+		}
+		sess.Crn = core.StringPtr(d.Get(cisID).(string))
+		sess.ZoneIdentifier = core.StringPtr(zoneID)
+		for _, id := range ids {
+			log.Printf("[INFO] Deleting dns record %s", id)
+			_, response, err := sess.DeleteDnsRecord(sess.NewDeleteDnsRecordOptions(id.(string)))
+			if err != nil {
+				if response != nil && response.StatusCode == 404 {
+					continue
+				}
+				log.Printf("Error deleting dns record: %s", response)
+				return fmt.Errorf("[ERROR] Error deleting dns record %s: %s", id, err)
+			}
+		}
+	}
 	d.SetId("")
 	return nil
 }
+
+// resourceCISDNSRecordsImportSync makes the zone records match the file
+func resourceCISDNSRecordsImportSync(d *schema.ResourceData, meta interface{}) error {
+	crn := d.Get(cisID).(string)
+	zoneID, _, err := convertTftoCisTwoVar(d.Get(cisDomainID).(string))
+	if err != nil {
+		return err
+	}
+	file := d.Get(cisDNSRecordsImportFile).(string)
+
+	sess, desired, live, err := cisDNSRecordsImportRecords(meta, crn, zoneID, file)
+	if err != nil {
+		return err
+	}
+	plan := planCISDNSRecordsSync(desired, live)
+	added, err := applyCISDNSRecordsSync(sess, plan)
+	if err != nil {
+		return err
+	}
+
+	// after the sync, the records of the zone that are not deleted and the
+	// added records are the records of the file
+	deleted := map[string]bool{}
+	for _, record := range plan.Delete {
+		deleted[record.ID] = true
+	}
+	ids := added
+	for _, record := range live {
+		if !deleted[record.ID] {
+			ids = append(ids, record.ID)
+		}
+	}
+
+	d.SetId(cisDNSRecordsImportID(int64(len(desired)), int64(len(plan.Add)), file, zoneID, crn))
+	d.Set(cisDNSRecordsImportRecordsUpdated, len(plan.Update))
+	d.Set(cisDNSRecordsImportRecordsDeleted, len(plan.Delete))
+	d.Set(cisDNSRecordsImportRecordIDs, ids)
+	return resourceCISDNSRecordsImportRead(d, meta)
+}
+
+// resourceCISDNSRecordsImportDiff forces a new resource for a new file
+// without sync. With sync, it plans an update when the zone records do not
+// match the file, whether the file or the zone changed.
+func resourceCISDNSRecordsImportDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	if !diff.Get(cisDNSRecordsImportSync).(bool) {
+		if diff.HasChange(cisDNSRecordsImportFile) {
+			return diff.ForceNew(cisDNSRecordsImportFile)
+		}
+		return nil
+	}
+	for _, k := range []string{cisID, cisDomainID, cisDNSRecordsImportFile} {
+		if !diff.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	crn := diff.Get(cisID).(string)
+	zoneID, _, err := convertTftoCisTwoVar(diff.Get(cisDomainID).(string))
+	if err != nil {
+		return err
+	}
+	_, desired, live, err := cisDNSRecordsImportRecords(meta, crn, zoneID, diff.Get(cisDNSRecordsImportFile).(string))
+	if err != nil {
+		return err
+	}
+	plan := planCISDNSRecordsSync(desired, live)
+	if !plan.empty() {
+		log.Printf("[INFO] %d dns records to add, %d to update and %d to delete in the zone %s", len(plan.Add), len(plan.Update), len(plan.Delete), zoneID)
+		for _, k := range []string{cisDNSRecordsImportTotalRecordsParsed, cisDNSRecordsImportRecordsAdded,
+			cisDNSRecordsImportRecordsUpdated, cisDNSRecordsImportRecordsDeleted, cisDNSRecordsImportRecordIDs} {
+			if err := diff.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// cisDNSRecordsImportRecords returns the records of a zone file and the
+// records of the zone that the sync manages
+func cisDNSRecordsImportRecords(meta interface{}, crn, zoneID, file string) (*cisdnsrecordsv1.DnsRecordsV1, []cisZoneFileRecord, []cisZoneFileRecord, error) {
+	zonesClient, err := meta.(ClientSession).CisZonesV1ClientSession()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	zonesClient.Crn = core.StringPtr(crn)
+	zone, response, err := zonesClient.GetZone(zonesClient.NewGetZoneOptions(zoneID))
+	if err != nil {
+		log.Printf("[WARN] Error getting zone %v", response)
+		return nil, nil, nil, err
+	}
+	zoneName := *zone.Result.Name
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer f.Close()
+	desired, err := parseCISZoneFile(f, zoneName)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s (%s)", err, file)
+	}
+
+	sess, err := meta.(ClientSession).CisDNSRecordClientSession()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sess.Crn = core.StringPtr(crn)
+	sess.ZoneIdentifier = core.StringPtr(zoneID)
+	details, err := listCISDNSRecords(sess)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var live []cisZoneFileRecord
+	for _, detail := range details {
+		if record, ok := cisZoneRecordFromDetails(zoneName, detail); ok {
+			live = append(live, record)
+		}
+	}
+	return sess, desired, live, nil
+}
+
+func cisDNSRecordsImportID(parsed, added int64, file, zoneID, crn string) string {
+	return fmt.Sprintf("%v:%v:%s:%s:%s", parsed, added, file, zoneID, crn)
+}
@@ -5,6 +5,9 @@ package ibm
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -32,6 +35,82 @@ func TestAccIBMCisDNSRecordsImport_Basic(t *testing.T) {
 	})
 }
 
+func TestAccIBMCisDNSRecordsImport_Sync(t *testing.T) {
+	name := "ibm_cis_dns_records_import." + "test"
+	file := filepath.Join(os.TempDir(), "tf-acc-dns-records-sync.txt")
+	record := fmt.Sprintf("test-import-sync.%s.\t1\tIN\tA\t192.168.178.6\n", cisDomainStatic)
+	defer os.Remove(file)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckCis(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// The zone file is the export of the zone with a new record
+				Config: testAccCheckCisDNSRecordsExportConfig(),
+				Check:  testAccCheckIBMCisDNSRecordsWriteZoneFile("data.ibm_cis_dns_records_export.test", file, record),
+			},
+			{
+				Config: testAccCheckCisDNSRecordsImportConfigSync(file, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "records_added", "1"),
+					resource.TestCheckResourceAttr(name, "records_updated", "0"),
+					resource.TestCheckResourceAttr(name, "records_deleted", "0"),
+				),
+			},
+			{
+				PreConfig: func() {
+					zoneFile, err := ioutil.ReadFile(file)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := ioutil.WriteFile(file, []byte(strings.Replace(string(zoneFile), record, "", 1)), 0600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccCheckCisDNSRecordsImportConfigSync(file, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "records_added", "0"),
+					resource.TestCheckResourceAttr(name, "records_deleted", "1"),
+				),
+			},
+			{
+				// Without sync, the destroy leaves the records of the zone
+				Config: testAccCheckCisDNSRecordsImportConfigSync(file, false),
+				Check:  testAccCheckIBMCisDNSRecordsImportRemoveImportedRecords(name),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCisDNSRecordsWriteZoneFile(n, file, record string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		return ioutil.WriteFile(file, []byte(rs.Primary.Attributes["zone_file"]+"\n"+record), 0600)
+	}
+}
+
+func testAccCheckCisDNSRecordsExportConfig() string {
+	return testAccCheckIBMCisDomainDataSourceConfigBasic1() + `
+	data "ibm_cis_dns_records_export" "test" {
+		cis_id    = data.ibm_cis.cis.id
+		domain_id = data.ibm_cis_domain.cis_domain.domain_id
+	}`
+}
+
+func testAccCheckCisDNSRecordsImportConfigSync(file string, sync bool) string {
+	return testAccCheckIBMCisDomainDataSourceConfigBasic1() + fmt.Sprintf(`
+	resource "ibm_cis_dns_records_import" "test" {
+		cis_id    = data.ibm_cis.cis.id
+		domain_id = data.ibm_cis_domain.cis_domain.domain_id
+		file      = "%[1]s"
+		sync      = %[2]t
+	}`, file, sync)
+}
+
 func testAccCheckCisDNSRecordsImportConfigBasic1(file string) string {
 	return testAccCheckIBMCisDNSRecordConfigCisDSBasic(
 		"test-dns-record", cisDomainStatic) +
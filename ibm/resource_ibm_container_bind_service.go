@@ -17,7 +17,7 @@ func resourceIBMContainerBindService() *schema.Resource {
 		Read:     resourceIBMContainerBindServiceRead,
 		Update:   resourceIBMContainerBindServiceUpdate,
 		Delete:   resourceIBMContainerBindServiceDelete,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cluster", "service_instance", "namespace"),
 
 		Schema: map[string]*schema.Schema{
 			"cluster_name_id": {
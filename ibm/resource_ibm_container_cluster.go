@@ -48,7 +48,7 @@ const (
 const PUBLIC_SUBNET_TYPE = "public"
 
 func resourceIBMContainerCluster() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create:   resourceIBMContainerClusterCreate,
 		Read:     resourceIBMContainerClusterRead,
 		Update:   resourceIBMContainerClusterUpdate,
@@ -56,6 +56,8 @@ func resourceIBMContainerCluster() *schema.Resource {
 		Exists:   resourceIBMContainerClusterExists,
 		Importer: &schema.ResourceImporter{},
 
+		SchemaVersion: 1,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(90 * time.Minute),
 			Delete: schema.DefaultTimeout(45 * time.Minute),
@@ -64,7 +66,7 @@ func resourceIBMContainerCluster() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -604,6 +606,22 @@ func resourceIBMContainerCluster() *schema.Resource {
 			},
 		},
 	}
+	resource.StateUpgraders = stateUpgradersV0(resource, resourceIBMContainerClusterStateUpgradeV0)
+	return resource
+}
+
+// resourceIBMContainerClusterStateUpgradeV0 carries the deprecated worker_num
+// over to default_pool_size, which replaced it, for the clusters whose state
+// only has the worker count in worker_num.
+func resourceIBMContainerClusterStateUpgradeV0(_ context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	workerNum, ok := stateInt(rawState, "worker_num")
+	if !ok || workerNum <= 0 {
+		return rawState, nil
+	}
+	if poolSize, ok := stateInt(rawState, "default_pool_size"); !ok || poolSize == 0 {
+		rawState["default_pool_size"] = workerNum
+	}
+	return rawState, nil
 }
 
 func resourceIBMContainerClusterValidator() *ResourceValidator {
@@ -1268,7 +1286,7 @@ func resourceIBMContainerClusterUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if d.HasChange("tags") || v != "" {
+	if d.HasChange("tags") || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange("tags")
 		cluster, err := clusterAPI.Find(clusterID, targetEnv)
 		if err != nil {
@@ -43,7 +43,7 @@ func resourceIBMContainerVpcCluster() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -547,7 +547,7 @@ func resourceIBMContainerVpcClusterUpdate(d *schema.ResourceData, meta interface
 	clusterID := d.Id()
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if d.HasChange("tags") || v != "" {
+	if d.HasChange("tags") || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange("tags")
 		cluster, err := csClient.Clusters().GetCluster(clusterID, targetEnv)
 		if err != nil {
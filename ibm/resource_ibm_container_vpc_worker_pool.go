@@ -29,7 +29,7 @@ func resourceIBMContainerVpcWorkerPool() *schema.Resource {
 		Read:     resourceIBMContainerVpcWorkerPoolRead,
 		Delete:   resourceIBMContainerVpcWorkerPoolDelete,
 		Exists:   resourceIBMContainerVpcWorkerPoolExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cluster", "worker_pool_id"),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(90 * time.Minute),
 			Delete: schema.DefaultTimeout(90 * time.Minute),
@@ -23,7 +23,7 @@ func resourceIBMContainerWorkerPool() *schema.Resource {
 		Update:   resourceIBMContainerWorkerPoolUpdate,
 		Delete:   resourceIBMContainerWorkerPoolDelete,
 		Exists:   resourceIBMContainerWorkerPoolExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cluster", "worker_pool_id"),
 		Timeouts: &schema.ResourceTimeout{
 			Update: schema.DefaultTimeout(90 * time.Minute),
 		},
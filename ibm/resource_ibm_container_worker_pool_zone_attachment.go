@@ -23,7 +23,7 @@ func resourceIBMContainerWorkerPoolZoneAttachment() *schema.Resource {
 		Update:   resourceIBMContainerWorkerPoolZoneAttachmentUpdate,
 		Delete:   resourceIBMContainerWorkerPoolZoneAttachmentDelete,
 		Exists:   resourceIBMContainerWorkerPoolZoneAttachmentExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cluster", "worker_pool", "zone"),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(90 * time.Minute),
 			Update: schema.DefaultTimeout(90 * time.Minute),
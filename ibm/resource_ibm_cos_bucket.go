@@ -42,13 +42,17 @@ const (
 )
 
 func resourceIBMCOSBucket() *schema.Resource {
-	return &schema.Resource{
-		Read:     resourceIBMCOSBucketRead,
-		Create:   resourceIBMCOSBucketCreate,
-		Update:   resourceIBMCOSBucketUpdate,
-		Delete:   resourceIBMCOSBucketDelete,
-		Exists:   resourceIBMCOSBucketExists,
-		Importer: &schema.ResourceImporter{},
+	resource := &schema.Resource{
+		Read:   resourceIBMCOSBucketRead,
+		Create: resourceIBMCOSBucketCreate,
+		Update: resourceIBMCOSBucketUpdate,
+		Delete: resourceIBMCOSBucketDelete,
+		Exists: resourceIBMCOSBucketExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceIBMCOSBucketImport,
+		},
+
+		SchemaVersion: 1,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -325,6 +329,9 @@ func resourceIBMCOSBucket() *schema.Resource {
 			},
 		},
 	}
+	// the rule blocks of version 0 are lists of blocks like the current ones
+	resource.StateUpgraders = stateUpgradersV0(resource, stateUpgradeKeep)
+	return resource
 }
 
 func archiveRuleList(archiveList []interface{}) []*s3.LifecycleRule {
@@ -1120,6 +1127,26 @@ func selectCosApi(apiType string, bLocation string) (string, string) {
 	return "", ""
 }
 
+// resourceIBMCOSBucketImport validates the bucket ID, parseBucketId expects
+// the format $CRN:meta:$buckettype:$bucketlocation[:$endpointtype].
+func resourceIBMCOSBucketImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := validateIDParts(d.Id(), ":meta:", "bucket_crn", "bucket_type:bucket_location")
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(parts[0], ":bucket:") {
+		return nil, fmt.Errorf("Invalid ID %q, %s is not the CRN of a bucket", d.Id(), parts[0])
+	}
+	metaParts := strings.Split(parts[1], ":")
+	if len(metaParts) < 2 || len(metaParts) > 3 || metaParts[1] == "" {
+		return nil, fmt.Errorf("Invalid ID %q, the ID must have the format $CRN:meta:$buckettype:$bucketlocation or $CRN:meta:$buckettype:$bucketlocation:$endpointtype", d.Id())
+	}
+	if !stringInSlice(metaParts[0], []string{"ssl", "rl", "crl"}) {
+		return nil, fmt.Errorf("Invalid ID %q, the bucket type must be ssl, rl or crl, not %s", d.Id(), metaParts[0])
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func parseBucketId(id string, info string) string {
 	crn := strings.Split(id, ":meta:")[0]
 	meta := strings.Split(id, ":meta:")[1]
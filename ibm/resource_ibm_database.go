@@ -14,6 +14,7 @@ import (
 	"time"
 
 	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	version "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	validation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -192,7 +193,7 @@ func resourceIBMDatabaseInstance() *schema.Resource {
 				ConflictsWith: []string{"members_memory_allocation_mb", "members_disk_allocation_mb", "members_cpu_allocation_count"},
 			},
 			"plan_validation": {
-				Description: "For elasticsearch and postgres perform database parameter validation during the plan phase. Otherwise, database parameter validation happens in apply phase.",
+				Description: "For elasticsearch and postgres perform database parameter validation during the plan phase, and for all databases validate the backup or point in time recovery source. Otherwise, database parameter validation happens in apply phase.",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
@@ -738,14 +739,7 @@ func getDatabaseServiceDefaults(service string, meta interface{}) (*icdv4.Group,
 		return nil, fmt.Errorf("[ERROR] Error getting database client settings: %s", err)
 	}
 
-	var dbType string
-	if strings.HasPrefix(service, "messages-for-") {
-		dbType = service[len("messages-for-"):]
-	} else {
-		dbType = service[len("databases-for-"):]
-	}
-
-	groupDefaults, err := icdClient.Groups().GetDefaultGroups(dbType)
+	groupDefaults, err := icdClient.Groups().GetDefaultGroups(databaseServiceType(service))
 	if err != nil {
 		return nil, fmt.Errorf("ICD API is down for plan validation, set plan_validation=false %s", err)
 	}
@@ -847,7 +841,7 @@ func checkMbValue(name string, limits MbLimit, divider int, diff *schema.Resourc
 
 func resourceIBMDatabaseInstanceDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
 
-	err := resourceTagsCustomizeDiff(diff)
+	err := resourceTagsCustomizeDiff(diff, meta)
 	if err != nil {
 		return err
 	}
@@ -911,6 +905,150 @@ func resourceIBMDatabaseInstanceDiff(_ context.Context, diff *schema.ResourceDif
 		return fmt.Errorf("[ERROR] node_count, node_memory_allocation_mb, node_disk_allocation_mb, node_cpu_allocation_count only supported for postgresql and elasticsearch")
 	}
 
+	// The backup and the point in time recovery source are only used to
+	// create the deployment
+	if diff.Id() == "" && diff.Get("plan_validation").(bool) {
+		return validateDatabaseRestoreSource(diff, meta)
+	}
+
+	return nil
+}
+
+// validateDatabaseRestoreSource checks that the backup_id or the
+// point_in_time_recovery_deployment_id of a new deployment can be restored to
+// its service and version
+func validateDatabaseRestoreSource(diff *schema.ResourceDiff, meta interface{}) error {
+	backupID := diff.Get("backup_id").(string)
+	if !diff.NewValueKnown("backup_id") {
+		backupID = ""
+	}
+	pitrID := diff.Get("point_in_time_recovery_deployment_id").(string)
+	if !diff.NewValueKnown("point_in_time_recovery_deployment_id") {
+		pitrID = ""
+	}
+	if backupID == "" && pitrID == "" {
+		return nil
+	}
+
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error getting database client settings: %s", err)
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return err
+	}
+
+	service := diff.Get("service").(string)
+	dbVersion := ""
+	if diff.NewValueKnown("version") {
+		dbVersion = diff.Get("version").(string)
+	}
+
+	if backupID != "" {
+		backup, err := getDatabaseBackup(client, backupID)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error getting the database backup %s, set plan_validation=false to skip its validation: %s", backupID, err)
+		}
+		if !backup.IsRestorable {
+			return fmt.Errorf("[ERROR] The database backup %s cannot be restored, its status is %s", backupID, backup.Status)
+		}
+		sourceVersion, err := getDatabaseSourceVersion(icdClient, backup.DeploymentID)
+		if err != nil {
+			return err
+		}
+		if err := checkDatabaseRestoreSource(service, dbVersion, backup.DeploymentID, sourceVersion); err != nil {
+			return fmt.Errorf("[ERROR] backup_id %s: %s", backupID, err)
+		}
+	}
+
+	if pitrID != "" {
+		sourceVersion, err := getDatabaseSourceVersion(icdClient, pitrID)
+		if err != nil {
+			return err
+		}
+		if err := checkDatabaseRestoreSource(service, dbVersion, pitrID, sourceVersion); err != nil {
+			return fmt.Errorf("[ERROR] point_in_time_recovery_deployment_id %s: %s", pitrID, err)
+		}
+		if !databaseSupportsPITR(databaseServiceType(service)) {
+			return fmt.Errorf("[ERROR] point_in_time_recovery_deployment_id is not supported for %s", service)
+		}
+		pitrTime := diff.Get("point_in_time_recovery_time").(string)
+		if pitrTime != "" && diff.NewValueKnown("point_in_time_recovery_time") {
+			earliest, err := getDatabaseEarliestPITRTime(client, EscapeUrlParm(pitrID))
+			if err != nil {
+				return fmt.Errorf("[ERROR] Error getting the point in time recovery data of %s, set plan_validation=false to skip its validation: %s", pitrID, err)
+			}
+			if err := checkDatabasePITRTime(pitrTime, earliest, time.Now()); err != nil {
+				return fmt.Errorf("[ERROR] point_in_time_recovery_time %s: %s", pitrTime, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getDatabaseSourceVersion returns the version of the source deployment of a
+// restore, or "" when the source deployment was deleted
+func getDatabaseSourceVersion(icdClient icdv4.ICDServiceAPI, deploymentID string) (string, error) {
+	cdb, err := icdClient.Cdbs().GetCdb(EscapeUrlParm(deploymentID))
+	if err != nil {
+		if isICDNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("[ERROR] Error getting the database %s, set plan_validation=false to skip its validation: %s", deploymentID, err)
+	}
+	return cdb.Version, nil
+}
+
+// checkDatabaseRestoreSource checks that the source deployment has the service
+// of its CRN, and that the version is not older than the source version. ICD
+// upgrades the major version of a database by restoring it to a newer version.
+func checkDatabaseRestoreSource(service, dbVersion, sourceID, sourceVersion string) error {
+	crnParts := strings.Split(sourceID, ":")
+	if len(crnParts) < 5 {
+		return fmt.Errorf("the source %s is not a database CRN", sourceID)
+	}
+	if sourceService := crnParts[4]; sourceService != service {
+		return fmt.Errorf("the source is a %s deployment, it cannot be restored to %s", sourceService, service)
+	}
+	if dbVersion == "" || sourceVersion == "" {
+		return nil
+	}
+	v, err := version.NewVersion(dbVersion)
+	if err != nil {
+		return fmt.Errorf("incorrect version %s: %s", dbVersion, err)
+	}
+	sourceV, err := version.NewVersion(sourceVersion)
+	if err != nil {
+		return nil
+	}
+	if v.LessThan(sourceV) {
+		return fmt.Errorf("the source has the version %s, it cannot be restored to the older version %s", sourceVersion, dbVersion)
+	}
+	return nil
+}
+
+// checkDatabasePITRTime checks that a point in time recovery time is between
+// the earliest recovery time and now
+func checkDatabasePITRTime(pitrTime, earliest string, now time.Time) error {
+	t, err := time.Parse(time.RFC3339, pitrTime)
+	if err != nil {
+		return fmt.Errorf("the time must be in the RFC 3339 format, ex: 2020-04-20T05:27:36Z: %s", err)
+	}
+	if t.After(now) {
+		return fmt.Errorf("the time is in the future")
+	}
+	if earliest == "" {
+		return nil
+	}
+	earliestTime, err := time.Parse(time.RFC3339, earliest)
+	if err != nil {
+		return nil
+	}
+	if t.Before(earliestTime) {
+		return fmt.Errorf("the earliest time the source can be restored to is %s", earliest)
+	}
 	return nil
 }
 
@@ -1063,7 +1201,7 @@ func resourceIBMDatabaseInstanceCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk("tags"); ok || v != "" {
+	if _, ok := d.GetOk("tags"); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange("tags")
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
@@ -1408,6 +1546,14 @@ func resourceIBMDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{})
 	}
 	icdId := EscapeUrlParm(instanceID)
 
+	// the tasks of the database user, allowlist entry, configuration and
+	// backup resources of the deployment wait for the update tasks
+	unlock, err := ibmLocks.Lock(context.Background(), lockHolder(d, "ibm_database update"), databaseLockKey(instanceID))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if d.HasChange("node_count") {
 		err = horizontalScale(d, meta, icdClient)
 		if err != nil {
@@ -1772,6 +1918,15 @@ func resourceIBMDatabaseInstanceDelete(d *schema.ResourceData, meta interface{})
 		return err
 	}
 	id := d.Id()
+
+	// the deployment is not deleted while its database user, allowlist entry,
+	// configuration or backup resources run a task
+	unlock, err := ibmLocks.Lock(context.Background(), lockHolder(d, "ibm_database delete"), databaseLockKey(id))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	recursive := true
 	deleteReq := rc.DeleteResourceInstanceOptions{
 		Recursive: &recursive,
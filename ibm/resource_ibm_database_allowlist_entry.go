@@ -0,0 +1,168 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM-Cloud/bluemix-go/api/icd/icdv4"
+)
+
+func resourceIBMDatabaseAllowlistEntry() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseAllowlistEntryCreate,
+		ReadContext:   resourceIBMDatabaseAllowlistEntryRead,
+		DeleteContext: resourceIBMDatabaseAllowlistEntryDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID (CRN) of the database deployment",
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Allowlist IP address in CIDR notation",
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 32),
+				Description:  "Unique allowlist description",
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseAllowlistEntryCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	icdId := EscapeUrlParm(deploymentID)
+	whitelistReq := icdv4.WhitelistReq{
+		WhitelistEntry: icdv4.WhitelistEntry{
+			Address:     d.Get("address").(string),
+			Description: d.Get("description").(string),
+		},
+	}
+
+	unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_database_allowlist_entry create"), databaseLockKey(deploymentID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	task, err := icdClient.Whitelists().CreateWhitelist(icdId, whitelistReq)
+	if err != nil {
+		return diagFromErr(newAPIError(fmt.Sprintf("Error creating database allowlist entry %s", whitelistReq.WhitelistEntry.Address), err, nil).withAttributes("address", "description"))
+	}
+	_, err = waitForDatabaseTaskComplete(task.Id, d, meta, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) allowlist create task to complete for entry %s : %s", icdId, whitelistReq.WhitelistEntry.Address, err))
+	}
+
+	d.SetId(databaseEntryID(deploymentID, whitelistReq.WhitelistEntry.Address))
+
+	return resourceIBMDatabaseAllowlistEntryRead(context, d, meta)
+}
+
+func resourceIBMDatabaseAllowlistEntryRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	deploymentID, parts, err := parseDatabaseEntryID(d.Id(), 1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	address := parts[0]
+
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+
+	whitelist, err := icdClient.Whitelists().GetWhitelist(EscapeUrlParm(deploymentID))
+	if err != nil {
+		if isICDNotFound(err) {
+			log.Printf("[WARN] Database %s not found, removing the allowlist entry %s from the state", deploymentID, address)
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(newAPIError("Error getting database allowlist", err, nil))
+	}
+	entry, ok := findDatabaseAllowlistEntry(whitelist, address)
+	if !ok {
+		log.Printf("[WARN] Database allowlist entry %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("deployment_id", deploymentID)
+	d.Set("address", entry.Address)
+	d.Set("description", entry.Description)
+
+	return nil
+}
+
+func resourceIBMDatabaseAllowlistEntryDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	icdId := EscapeUrlParm(deploymentID)
+	address := d.Get("address").(string)
+
+	unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_database_allowlist_entry delete"), databaseLockKey(deploymentID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	task, err := icdClient.Whitelists().DeleteWhitelist(icdId, address)
+	if err != nil {
+		if isICDNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(newAPIError(fmt.Sprintf("Error deleting database allowlist entry %s", address), err, nil))
+	}
+	_, err = waitForDatabaseTaskComplete(task.Id, d, meta, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) allowlist delete task to complete for ipAddress %s : %s", icdId, address, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func findDatabaseAllowlistEntry(whitelist icdv4.Whitelist, address string) (icdv4.WhitelistEntry, bool) {
+	for _, entry := range whitelist.WhitelistEntrys {
+		if entry.Address == address {
+			return entry, true
+		}
+	}
+	return icdv4.WhitelistEntry{}, false
+}
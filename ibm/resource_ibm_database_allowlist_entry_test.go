@@ -0,0 +1,126 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMDatabaseAllowlistEntry_Basic(t *testing.T) {
+	databaseResourceGroup := "default"
+	testName := fmt.Sprintf("tf-Pgress-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_allowlist_entry.entry"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseAllowlistEntryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseAllowlistEntryConfig(databaseResourceGroup, testName, "172.168.1.0/24", "desc1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMDatabaseAllowlistEntryExists(name),
+					resource.TestCheckResourceAttr(name, "address", "172.168.1.0/24"),
+					resource.TestCheckResourceAttr(name, "description", "desc1"),
+				),
+			},
+			{
+				Config: testAccCheckIBMDatabaseAllowlistEntryConfig(databaseResourceGroup, testName, "172.168.1.2/32", "desc2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMDatabaseAllowlistEntryExists(name),
+					resource.TestCheckResourceAttr(name, "address", "172.168.1.2/32"),
+					resource.TestCheckResourceAttr(name, "description", "desc2"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseAllowlistEntryExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		exists, err := testAccGetIBMDatabaseAllowlistEntryExists(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Database allowlist entry %s does not exist", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckIBMDatabaseAllowlistEntryDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_database_allowlist_entry" {
+			continue
+		}
+		exists, err := testAccGetIBMDatabaseAllowlistEntryExists(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("Database allowlist entry %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccGetIBMDatabaseAllowlistEntryExists(id string) (bool, error) {
+	deploymentID, parts, err := parseDatabaseEntryID(id, 1)
+	if err != nil {
+		return false, err
+	}
+	icdClient, err := testAccProvider.Meta().(ClientSession).ICDAPI()
+	if err != nil {
+		return false, err
+	}
+	whitelist, err := icdClient.Whitelists().GetWhitelist(EscapeUrlParm(deploymentID))
+	if err != nil {
+		if isICDNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	_, ok := findDatabaseAllowlistEntry(whitelist, parts[0])
+	return ok, nil
+}
+
+func testAccCheckIBMDatabaseAllowlistEntryConfig(databaseResourceGroup, name, address, description string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		name = "%[1]s"
+	}
+
+	resource "ibm_database" "%[2]s" {
+		resource_group_id            = data.ibm_resource_group.test_acc.id
+		name                         = "%[2]s"
+		service                      = "databases-for-postgresql"
+		plan                         = "standard"
+		location                     = "us-south"
+		adminpassword                = "password12"
+		members_memory_allocation_mb = 2048
+		members_disk_allocation_mb   = 10240
+	}
+
+	resource "ibm_database_allowlist_entry" "entry" {
+		deployment_id = ibm_database.%[2]s.id
+		address       = "%[3]s"
+		description   = "%[4]s"
+	}
+	`, databaseResourceGroup, name, address, description)
+}
@@ -0,0 +1,167 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceIBMDatabaseBackup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseBackupCreate,
+		ReadContext:   resourceIBMDatabaseBackupRead,
+		DeleteContext: resourceIBMDatabaseBackupDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID (CRN) of the database deployment",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the backup: scheduled or on_demand",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the backup",
+			},
+			"is_downloadable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the backup can be downloaded",
+			},
+			"is_restorable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the backup can be restored to a new deployment",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation time of the backup",
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseBackupCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	icdId := EscapeUrlParm(deploymentID)
+
+	unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_database_backup create"), databaseLockKey(deploymentID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	backups, err := listDatabaseBackups(client, icdId)
+	if err != nil {
+		return diagFromErr(newAPIError("Error getting database backups", err, nil))
+	}
+	task, err := createDatabaseBackup(client, icdId)
+	if err != nil {
+		return diagFromErr(newAPIError(fmt.Sprintf("Error creating database (%s) backup", icdId), err, nil))
+	}
+	_, err = waitForDatabaseTaskComplete(task.Id, d, meta, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) backup task to complete: %s", icdId, err))
+	}
+
+	newBackups, err := listDatabaseBackups(client, icdId)
+	if err != nil {
+		return diagFromErr(newAPIError("Error getting database backups", err, nil))
+	}
+	backup, ok := findNewDatabaseBackup(backups, newBackups)
+	if !ok {
+		return diag.FromErr(fmt.Errorf("[ERROR] The database (%s) backup task %s completed without an on-demand backup", icdId, task.Id))
+	}
+
+	d.SetId(backup.ID)
+
+	return resourceIBMDatabaseBackupRead(context, d, meta)
+}
+
+func resourceIBMDatabaseBackupRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backup, err := getDatabaseBackup(client, d.Id())
+	if err != nil {
+		if isICDNotFound(err) {
+			log.Printf("[WARN] Database backup %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(newAPIError("Error getting database backup", err, nil))
+	}
+
+	d.Set("deployment_id", backup.DeploymentID)
+	d.Set("type", backup.Type)
+	d.Set("status", backup.Status)
+	d.Set("is_downloadable", backup.IsDownloadable)
+	d.Set("is_restorable", backup.IsRestorable)
+	d.Set("created_at", backup.CreatedAt)
+
+	return nil
+}
+
+// resourceIBMDatabaseBackupDelete only removes the backup from the state, ICD
+// has no API to delete a backup. The on-demand backups expire with the
+// retention of the deployment backups.
+func resourceIBMDatabaseBackupDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[WARN] Database backup %s cannot be deleted, it is kept until it expires", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// findNewDatabaseBackup returns the on-demand backup of backups that is not in
+// previous
+func findNewDatabaseBackup(previous, backups []databaseBackup) (databaseBackup, bool) {
+	ids := make(map[string]bool, len(previous))
+	for _, backup := range previous {
+		ids[backup.ID] = true
+	}
+	var newBackup databaseBackup
+	found := false
+	for _, backup := range backups {
+		if backup.Type != "on_demand" || ids[backup.ID] {
+			continue
+		}
+		if !found || backup.CreatedAt > newBackup.CreatedAt {
+			newBackup, found = backup, true
+		}
+	}
+	return newBackup, found
+}
@@ -0,0 +1,100 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMDatabaseBackup_Basic(t *testing.T) {
+	databaseResourceGroup := "default"
+	testName := fmt.Sprintf("tf-Pgress-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_backup.backup"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseBackupConfig(databaseResourceGroup, testName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMDatabaseBackupExists(name),
+					resource.TestCheckResourceAttr(name, "type", "on_demand"),
+					resource.TestCheckResourceAttr(name, "status", "completed"),
+					resource.TestCheckResourceAttr(name, "is_restorable", "true"),
+					resource.TestCheckResourceAttrPair(name, "deployment_id", "ibm_database."+testName, "id"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFindNewDatabaseBackup(t *testing.T) {
+	previous := []databaseBackup{
+		{ID: "backup-1", Type: "scheduled", CreatedAt: "2021-08-01T00:00:00Z"},
+		{ID: "backup-2", Type: "on_demand", CreatedAt: "2021-08-02T00:00:00Z"},
+	}
+	backups := append(previous,
+		databaseBackup{ID: "backup-3", Type: "scheduled", CreatedAt: "2021-08-04T00:00:00Z"},
+		databaseBackup{ID: "backup-4", Type: "on_demand", CreatedAt: "2021-08-03T00:00:00Z"},
+	)
+	if backup, ok := findNewDatabaseBackup(previous, backups); !ok || backup.ID != "backup-4" {
+		t.Errorf("Expected backup-4, got %v, %t", backup, ok)
+	}
+	if backup, ok := findNewDatabaseBackup(previous, previous); ok {
+		t.Errorf("Expected no backup, got %v", backup)
+	}
+}
+
+func testAccCheckIBMDatabaseBackupExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		icdClient, err := testAccProvider.Meta().(ClientSession).ICDAPI()
+		if err != nil {
+			return err
+		}
+		client, err := icdRequests(icdClient)
+		if err != nil {
+			return err
+		}
+		_, err = getDatabaseBackup(client, rs.Primary.ID)
+		return err
+	}
+}
+
+func testAccCheckIBMDatabaseBackupConfig(databaseResourceGroup, name string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		name = "%[1]s"
+	}
+
+	resource "ibm_database" "%[2]s" {
+		resource_group_id            = data.ibm_resource_group.test_acc.id
+		name                         = "%[2]s"
+		service                      = "databases-for-postgresql"
+		plan                         = "standard"
+		location                     = "us-south"
+		adminpassword                = "password12"
+		members_memory_allocation_mb = 2048
+		members_disk_allocation_mb   = 10240
+	}
+
+	resource "ibm_database_backup" "backup" {
+		deployment_id = ibm_database.%[2]s.id
+	}
+	`, databaseResourceGroup, name)
+}
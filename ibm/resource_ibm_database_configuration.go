@@ -0,0 +1,351 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceIBMDatabaseConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseConfigurationCreate,
+		ReadContext:   resourceIBMDatabaseConfigurationRead,
+		UpdateContext: resourceIBMDatabaseConfigurationUpdate,
+		DeleteContext: resourceIBMDatabaseConfigurationDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID (CRN) of the database deployment",
+			},
+			"configuration": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+				StateFunc: func(v interface{}) string {
+					json, _ := normalizeJSONString(v)
+					return json
+				},
+				Description: "The settings of the database engine as a JSON object, ex: `{\"max_connections\": 200}`",
+			},
+			"configuration_schema": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The settings of the database engine that can be configured, as a JSON object",
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseConfigurationCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	deploymentID := d.Get("deployment_id").(string)
+	if diags := applyDatabaseConfiguration(context, d, meta, "create", nil, schema.TimeoutCreate); diags != nil {
+		return diags
+	}
+
+	d.SetId(deploymentID)
+
+	return resourceIBMDatabaseConfigurationRead(context, d, meta)
+}
+
+func resourceIBMDatabaseConfigurationRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	icdId := EscapeUrlParm(d.Id())
+	exists, err := getDatabaseDeploymentExists(icdClient, icdId)
+	if err != nil {
+		return diagFromErr(newAPIError("Error getting database", err, nil))
+	}
+	if !exists {
+		log.Printf("[WARN] Database %s not found, removing its configuration from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	configurationSchema, err := getDatabaseConfigurationSchema(client, icdId)
+	if err != nil {
+		return diagFromErr(newAPIError("Error getting database configuration schema", err, nil))
+	}
+	schemaJSON, err := json.Marshal(configurationSchema)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the configured settings are read back to detect the changes made outside
+	// of Terraform, the other settings of the deployment are not managed
+	configuration, err := expandDatabaseConfiguration(d.Get("configuration").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	live, ok, err := getDatabaseConfiguration(client, icdId)
+	if err != nil {
+		return diagFromErr(newAPIError("Error getting database configuration", err, nil))
+	}
+	if ok {
+		for k, v := range configuration {
+			if current, found := live[k]; found && fmt.Sprint(current) != fmt.Sprint(v) {
+				configuration[k] = current
+			}
+		}
+		configurationJSON, err := json.Marshal(configuration)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("configuration", string(configurationJSON))
+	} else {
+		log.Printf("[DEBUG] Database %s does not report its configuration, the configured settings are kept as they were applied", d.Id())
+	}
+
+	d.Set("deployment_id", d.Id())
+	d.Set("configuration_schema", string(schemaJSON))
+
+	return nil
+}
+
+func resourceIBMDatabaseConfigurationUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("configuration") {
+		old, _ := d.GetChange("configuration")
+		previous, err := expandDatabaseConfiguration(old.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if diags := applyDatabaseConfiguration(context, d, meta, "update", previous, schema.TimeoutUpdate); diags != nil {
+			return diags
+		}
+	}
+
+	return resourceIBMDatabaseConfigurationRead(context, d, meta)
+}
+
+func resourceIBMDatabaseConfigurationDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	icdId := EscapeUrlParm(deploymentID)
+	configuration, err := expandDatabaseConfiguration(d.Get("configuration").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_database_configuration delete"), databaseLockKey(deploymentID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	configurationSchema, err := getDatabaseConfigurationSchema(client, icdId)
+	if err != nil {
+		if isICDNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(newAPIError("Error getting database configuration schema", err, nil))
+	}
+	defaults := databaseConfigurationDefaults(configurationSchema, configuration, nil)
+	if len(defaults) != 0 {
+		task, err := updateDatabaseConfiguration(client, icdId, defaults)
+		if err != nil {
+			return diagFromErr(newAPIError("Error resetting database configuration", err, nil))
+		}
+		_, err = waitForDatabaseTaskComplete(task.Id, d, meta, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(
+				"[ERROR] Error waiting for database (%s) configuration reset task to complete: %s", icdId, err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// applyDatabaseConfiguration applies the configuration of d, and resets the
+// settings of previous it no longer sets to their defaults
+func applyDatabaseConfiguration(context context.Context, d *schema.ResourceData, meta interface{}, operation string, previous map[string]interface{}, timeout string) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	icdId := EscapeUrlParm(deploymentID)
+	configuration, err := expandDatabaseConfiguration(d.Get("configuration").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_database_configuration "+operation), databaseLockKey(deploymentID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	configurationSchema, err := getDatabaseConfigurationSchema(client, icdId)
+	if err != nil {
+		return diagFromErr(newAPIError("Error getting database configuration schema", err, nil))
+	}
+	if invalid := invalidDatabaseSettings(configurationSchema, configuration); len(invalid) != 0 {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Incorrect database settings: %s", strings.Join(invalid, ", ")),
+			Detail:        "The configuration_schema attribute lists the settings of the database that can be configured, with their type and range.",
+			AttributePath: attributePath("configuration"),
+		}}
+	}
+
+	settings := databaseConfigurationDefaults(configurationSchema, previous, configuration)
+	for k, v := range configuration {
+		settings[k] = v
+	}
+	task, err := updateDatabaseConfiguration(client, icdId, settings)
+	if err != nil {
+		return diagFromErr(newAPIError(fmt.Sprintf("Error updating database (%s) configuration", icdId), err, nil).withAttributes("configuration"))
+	}
+	_, err = waitForDatabaseTaskComplete(task.Id, d, meta, d.Timeout(timeout))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) configuration %s task to complete: %s", icdId, operation, err))
+	}
+	return nil
+}
+
+func expandDatabaseConfiguration(configuration string) (map[string]interface{}, error) {
+	settings := map[string]interface{}{}
+	if configuration == "" {
+		return settings, nil
+	}
+	if err := json.Unmarshal([]byte(configuration), &settings); err != nil {
+		return nil, fmt.Errorf("[ERROR] The database configuration must be a JSON object: %s", err)
+	}
+	return settings, nil
+}
+
+// invalidDatabaseSettings describes the settings of configuration that are
+// missing from the configuration schema, or whose value does not match the
+// type, range or choices of the schema, sorted by name
+func invalidDatabaseSettings(configurationSchema map[string]map[string]interface{}, configuration map[string]interface{}) []string {
+	var names []string
+	for k := range configuration {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var invalid []string
+	for _, k := range names {
+		setting, ok := configurationSchema[k]
+		if !ok {
+			invalid = append(invalid, fmt.Sprintf("%s is unknown", k))
+			continue
+		}
+		if problem := checkDatabaseSetting(setting, configuration[k]); problem != "" {
+			invalid = append(invalid, fmt.Sprintf("%s %s", k, problem))
+		}
+	}
+	return invalid
+}
+
+// checkDatabaseSetting returns what is wrong with the value v of a setting of
+// the configuration schema, or "" when it is correct
+func checkDatabaseSetting(setting map[string]interface{}, v interface{}) string {
+	settingType, _ := setting["type"].(string)
+	switch settingType {
+	case "integer", "number":
+		n, ok := databaseSettingNumber(v)
+		if !ok {
+			return "must be a number"
+		}
+		if settingType == "integer" && n != math.Trunc(n) {
+			return "must be an integer"
+		}
+		if minimum, ok := databaseSettingNumber(setting["minimum"]); ok && n < minimum {
+			return fmt.Sprintf("must be at least %v", minimum)
+		}
+		if maximum, ok := databaseSettingNumber(setting["maximum"]); ok && n > maximum {
+			return fmt.Sprintf("must be at most %v", maximum)
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return "must be a string"
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return "must be a boolean"
+		}
+	}
+	if choices, ok := setting["choices"].([]interface{}); ok && len(choices) != 0 {
+		for _, choice := range choices {
+			if fmt.Sprint(choice) == fmt.Sprint(v) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %v", choices)
+	}
+	return ""
+}
+
+// databaseSettingNumber returns the number of a setting value, the ICD client
+// decodes the numbers of the responses as json.Number
+func databaseSettingNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// databaseConfigurationDefaults returns the default values of the settings of
+// previous that are not in configuration. The settings without default are
+// left as they are.
+func databaseConfigurationDefaults(configurationSchema map[string]map[string]interface{}, previous, configuration map[string]interface{}) map[string]interface{} {
+	defaults := map[string]interface{}{}
+	for k := range previous {
+		if _, ok := configuration[k]; ok {
+			continue
+		}
+		if v, ok := configurationSchema[k]["default"]; ok {
+			defaults[k] = v
+		}
+	}
+	return defaults
+}
@@ -0,0 +1,68 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabaseConfiguration_Basic(t *testing.T) {
+	databaseResourceGroup := "default"
+	testName := fmt.Sprintf("tf-Pgress-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_configuration.configuration"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckIBMDatabaseConfigurationConfig(databaseResourceGroup, testName, `{"max_connection": 200}`),
+				ExpectError: regexp.MustCompile("Unknown database settings: max_connection"),
+			},
+			{
+				Config: testAccCheckIBMDatabaseConfigurationConfig(databaseResourceGroup, testName, `{"max_connections": 200}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "configuration", `{"max_connections":200}`),
+					resource.TestMatchResourceAttr(name, "configuration_schema", regexp.MustCompile("max_connections")),
+				),
+			},
+			{
+				Config: testAccCheckIBMDatabaseConfigurationConfig(databaseResourceGroup, testName, `{"deadlock_timeout": 5000}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "configuration", `{"deadlock_timeout":5000}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseConfigurationConfig(databaseResourceGroup, name, configuration string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		name = "%[1]s"
+	}
+
+	resource "ibm_database" "%[2]s" {
+		resource_group_id            = data.ibm_resource_group.test_acc.id
+		name                         = "%[2]s"
+		service                      = "databases-for-postgresql"
+		plan                         = "standard"
+		location                     = "us-south"
+		adminpassword                = "password12"
+		members_memory_allocation_mb = 2048
+		members_disk_allocation_mb   = 10240
+	}
+
+	resource "ibm_database_configuration" "configuration" {
+		deployment_id = ibm_database.%[2]s.id
+		configuration = jsonencode(%[3]s)
+	}
+	`, databaseResourceGroup, name, configuration)
+}
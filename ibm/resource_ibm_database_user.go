@@ -0,0 +1,220 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceIBMDatabaseUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseUserCreate,
+		ReadContext:   resourceIBMDatabaseUserRead,
+		UpdateContext: resourceIBMDatabaseUserUpdate,
+		DeleteContext: resourceIBMDatabaseUserDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID (CRN) of the database deployment",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "database",
+				ValidateFunc: validation.StringInSlice([]string{"database", "ops_manager", "read_only_replica"}, false),
+				Description:  "The type of the user: database, ops_manager or read_only_replica",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(5, 32),
+				Description:  "User name",
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(10, 32),
+				Description:  "User password",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The role of the user, ex: the ACL categories of a Redis user (`-@all +@read`) or the role of an ops_manager user (`group_read_only`)",
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseUserCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	icdId := EscapeUrlParm(deploymentID)
+	userType := d.Get("type").(string)
+	user := databaseUser{
+		UserName: d.Get("name").(string),
+		Password: d.Get("password").(string),
+		Role:     d.Get("role").(string),
+	}
+
+	unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_database_user create"), databaseLockKey(deploymentID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	task, err := createDatabaseUser(client, icdId, userType, user)
+	if err != nil {
+		return diagFromErr(newAPIError(fmt.Sprintf("Error creating database user (%s)", user.UserName), err, nil).withAttributes("name", "password", "role"))
+	}
+	_, err = waitForDatabaseTaskComplete(task.Id, d, meta, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) user (%s) create task to complete: %s", icdId, user.UserName, err))
+	}
+
+	d.SetId(databaseEntryID(deploymentID, userType, user.UserName))
+
+	return resourceIBMDatabaseUserRead(context, d, meta)
+}
+
+func resourceIBMDatabaseUserRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	deploymentID, parts, err := parseDatabaseEntryID(d.Id(), 2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	userType, userName := parts[0], parts[1]
+
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	icdId := EscapeUrlParm(deploymentID)
+	exists, err := getDatabaseDeploymentExists(icdClient, icdId)
+	if err == nil && exists {
+		exists, err = getDatabaseUserExists(client, icdId, userType, userName)
+	}
+	if err != nil {
+		return diagFromErr(newAPIError(fmt.Sprintf("Error getting database user (%s)", userName), err, nil))
+	}
+	if !exists {
+		log.Printf("[WARN] Database user %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("deployment_id", deploymentID)
+	d.Set("type", userType)
+	d.Set("name", userName)
+
+	return nil
+}
+
+func resourceIBMDatabaseUserUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("password") {
+		deploymentID := d.Get("deployment_id").(string)
+		icdId := EscapeUrlParm(deploymentID)
+		userName := d.Get("name").(string)
+
+		unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_database_user update"), databaseLockKey(deploymentID))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer unlock()
+
+		task, err := updateDatabaseUser(client, icdId, d.Get("type").(string), userName, databaseUser{
+			Password: d.Get("password").(string),
+		})
+		if err != nil {
+			return diagFromErr(newAPIError(fmt.Sprintf("Error updating database user (%s) password", userName), err, nil).withAttributes("password"))
+		}
+		_, err = waitForDatabaseTaskComplete(task.Id, d, meta, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(
+				"[ERROR] Error waiting for database (%s) user (%s) password update task to complete: %s", icdId, userName, err))
+		}
+	}
+
+	return resourceIBMDatabaseUserRead(context, d, meta)
+}
+
+func resourceIBMDatabaseUserDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	icdClient, err := meta.(ClientSession).ICDAPI()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	icdId := EscapeUrlParm(deploymentID)
+	userName := d.Get("name").(string)
+
+	unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_database_user delete"), databaseLockKey(deploymentID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	task, err := deleteDatabaseUser(client, icdId, d.Get("type").(string), userName)
+	if err != nil {
+		if isICDNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(newAPIError(fmt.Sprintf("Error deleting database user (%s)", userName), err, nil))
+	}
+	_, err = waitForDatabaseTaskComplete(task.Id, d, meta, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) user (%s) delete task to complete: %s", icdId, userName, err))
+	}
+
+	d.SetId("")
+	return nil
+}
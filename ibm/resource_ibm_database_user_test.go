@@ -0,0 +1,128 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMDatabaseUser_Basic(t *testing.T) {
+	databaseResourceGroup := "default"
+	testName := fmt.Sprintf("tf-Pgress-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_user.user"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseUserConfig(databaseResourceGroup, testName, "password12"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMDatabaseUserExists(name),
+					resource.TestCheckResourceAttr(name, "name", "user123"),
+					resource.TestCheckResourceAttr(name, "type", "database"),
+					resource.TestCheckResourceAttrPair(name, "deployment_id", "ibm_database."+testName, "id"),
+				),
+			},
+			{
+				Config: testAccCheckIBMDatabaseUserConfig(databaseResourceGroup, testName, "password34"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMDatabaseUserExists(name),
+					resource.TestCheckResourceAttr(name, "password", "password34"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseUserExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		exists, err := testAccGetIBMDatabaseUserExists(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Database user %s does not exist", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckIBMDatabaseUserDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_database_user" {
+			continue
+		}
+		exists, err := testAccGetIBMDatabaseUserExists(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("Database user %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccGetIBMDatabaseUserExists(id string) (bool, error) {
+	deploymentID, parts, err := parseDatabaseEntryID(id, 2)
+	if err != nil {
+		return false, err
+	}
+	icdClient, err := testAccProvider.Meta().(ClientSession).ICDAPI()
+	if err != nil {
+		return false, err
+	}
+	client, err := icdRequests(icdClient)
+	if err != nil {
+		return false, err
+	}
+	icdId := EscapeUrlParm(deploymentID)
+	exists, err := getDatabaseDeploymentExists(icdClient, icdId)
+	if err != nil || !exists {
+		return false, err
+	}
+	return getDatabaseUserExists(client, icdId, parts[0], parts[1])
+}
+
+func testAccCheckIBMDatabaseUserConfig(databaseResourceGroup, name, password string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		name = "%[1]s"
+	}
+
+	resource "ibm_database" "%[2]s" {
+		resource_group_id            = data.ibm_resource_group.test_acc.id
+		name                         = "%[2]s"
+		service                      = "databases-for-postgresql"
+		plan                         = "standard"
+		location                     = "us-south"
+		adminpassword                = "password12"
+		members_memory_allocation_mb = 2048
+		members_disk_allocation_mb   = 10240
+	}
+
+	resource "ibm_database_user" "user" {
+		deployment_id = ibm_database.%[2]s.id
+		name          = "user123"
+		password      = "%[3]s"
+	}
+	`, databaseResourceGroup, name, password)
+}
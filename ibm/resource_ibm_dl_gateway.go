@@ -80,7 +80,7 @@ func resourceIBMDLGateway() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -583,7 +583,7 @@ func resourceIBMdlGatewayCreate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(dlTags); ok || v != "" {
+	if _, ok := d.GetOk(dlTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(dlTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *gateway.Crn)
 		if err != nil {
@@ -32,7 +32,7 @@ func resourceIBMDLGatewayVC() *schema.Resource {
 		Delete:   resourceIBMdlGatewayVCDelete,
 		Exists:   resourceIBMdlGatewayVCExists,
 		Update:   resourceIBMdlGatewayVCUpdate,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "gateway_id", "virtual_connection_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
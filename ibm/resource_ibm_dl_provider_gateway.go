@@ -27,7 +27,7 @@ func resourceIBMDLProviderGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{},
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -221,7 +221,7 @@ func resourceIBMdlProviderGatewayCreate(d *schema.ResourceData, meta interface{}
 	log.Printf("[INFO] Created Direct Link Provider Gateway : %s", *gateway.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(dlTags); ok || v != "" {
+	if _, ok := d.GetOk(dlTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(dlTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *gateway.Crn)
 		if err != nil {
@@ -19,6 +19,9 @@ func resourceIBMDNSDomainRegistrationNameservers() *schema.Resource {
 		Read:   resourceIBMDNSDomainRegistrationNSRead,
 		Update: resourceIBMDNSDomainRegistrationNSUpdate,
 		Delete: resourceIBMDNSDomainRegistrationNSDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceIBMDNSDomainRegistrationNSImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"dns_registration_id": {
 				Type:        schema.TypeString,
@@ -180,6 +183,16 @@ func resourceIBMDNSDomainRegistrationNSRead(d *schema.ResourceData, meta interfa
 	return nil
 }
 
+// The name servers before the import are unknown, destroying an imported
+// resource keeps the current name servers.
+func resourceIBMDNSDomainRegistrationNSImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err != nil {
+		return nil, fmt.Errorf("Invalid ID %q, the ID must be the numeric ID of the domain registration", d.Id())
+	}
+	d.Set("dns_registration_id", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
 // No delete on IBM Cloud
 func resourceIBMDNSDomainRegistrationNSUpdate(d *schema.ResourceData, meta interface{}) error {
 	return nil
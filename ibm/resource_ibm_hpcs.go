@@ -44,7 +44,7 @@ func resourceIBMHPCS() *schema.Resource {
 				return immutableResourceCustomizeDiff([]string{"units", "failover_units", "location", "resource_group_id", "service"}, diff)
 			},
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -428,7 +428,7 @@ func resourceIBMHPCSCreate(context context.Context, d *schema.ResourceData, meta
 
 	// Update Tags for this Resource using Global Tagging APIs
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk("tags"); ok || v != "" {
+	if _, ok := d.GetOk("tags"); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange("tags")
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
@@ -20,7 +20,7 @@ func resourceIBMIAMDynamicRule() *schema.Resource {
 		Update:   resourceIBMIAMDynamicRuleUpdate,
 		Delete:   resourceIBMIAMDynamicRuleDelete,
 		Exists:   resourceIBMIAMDynamicRuleExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "access_group_id", "rule_id"),
 
 		Schema: map[string]*schema.Schema{
 			"access_group_id": {
@@ -22,7 +22,7 @@ func resourceIBMIAMAccessGroupMembers() *schema.Resource {
 		ReadContext:   resourceIBMIAMAccessGroupMembersRead,
 		UpdateContext: resourceIBMIAMAccessGroupMembersUpdate,
 		DeleteContext: resourceIBMIAMAccessGroupMembersDelete,
-		Importer:      &schema.ResourceImporter{},
+		Importer:      importIDParts("/", "access_group_id", "random_id"),
 
 		Schema: map[string]*schema.Schema{
 			"access_group_id": {
@@ -71,6 +71,12 @@ func resourceIBMIAMAccessGroupMembersCreate(context context.Context, d *schema.R
 
 	grpID := d.Get("access_group_id").(string)
 
+	unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_iam_access_group_members create"), accessGroupLockKey(grpID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	userDetails, err := meta.(ClientSession).BluemixUserDetails()
 	if err != nil {
 		return diag.FromErr(err)
@@ -194,6 +200,12 @@ func resourceIBMIAMAccessGroupMembersUpdate(context context.Context, d *schema.R
 
 	grpID := parts[0]
 
+	unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_iam_access_group_members update"), accessGroupLockKey(grpID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	userDetails, err := meta.(ClientSession).BluemixUserDetails()
 	if err != nil {
 		return diag.FromErr(err)
@@ -285,6 +297,12 @@ func resourceIBMIAMAccessGroupMembersDelete(context context.Context, d *schema.R
 
 	grpID := parts[0]
 
+	unlock, err := ibmLocks.Lock(context, lockHolder(d, "ibm_iam_access_group_members delete"), accessGroupLockKey(grpID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	userDetails, err := meta.(ClientSession).BluemixUserDetails()
 	if err != nil {
 		return diag.FromErr(err)
@@ -359,3 +377,9 @@ func getServiceID(id string, meta interface{}) (iamidentityv1.ServiceID, error)
 	}
 	return *serviceID, nil
 }
+
+// accessGroupLockKey returns the key of the lock serializing the changes of the
+// members of an access group
+func accessGroupLockKey(grpID string) string {
+	return "access_group_key_" + grpID
+}
@@ -426,7 +426,7 @@ func importAccessGroupPolicy(d *schema.ResourceData, meta interface{}) (interfac
 		return nil, nil, err
 	}
 
-	parts, err := idParts(d.Id())
+	parts, err := validateIDParts(d.Id(), "/", "access_group_id", "policy_id")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -494,7 +494,7 @@ func importServicePolicy(d *schema.ResourceData, meta interface{}) (interface{},
 	if err != nil {
 		return nil, nil, err
 	}
-	parts, err := idParts(d.Id())
+	parts, err := validateIDParts(d.Id(), "/", "service_id", "policy_id")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -22,6 +22,9 @@ func resourceIBMIAMUserPolicy() *schema.Resource {
 		Exists: resourceIBMIAMUserPolicyExists,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if _, err := validateIDParts(d.Id(), "/", "ibm_id", "policy_id"); err != nil {
+					return nil, err
+				}
 				resources, resourceAttributes, err := importServicePolicy(d, meta)
 				if err != nil {
 					return nil, fmt.Errorf("Error reading resource ID: %s", err)
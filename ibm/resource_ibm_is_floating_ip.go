@@ -11,8 +11,8 @@ import (
 	"time"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -33,12 +33,11 @@ const (
 
 func resourceIBMISFloatingIP() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMISFloatingIPCreate,
-		Read:     resourceIBMISFloatingIPRead,
-		Update:   resourceIBMISFloatingIPUpdate,
-		Delete:   resourceIBMISFloatingIPDelete,
-		Exists:   resourceIBMISFloatingIPExists,
-		Importer: &schema.ResourceImporter{},
+		CreateContext: resourceIBMISFloatingIPCreate,
+		ReadContext:   resourceIBMISFloatingIPRead,
+		UpdateContext: resourceIBMISFloatingIPUpdate,
+		DeleteContext: resourceIBMISFloatingIPDelete,
+		Importer:      &schema.ResourceImporter{},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -48,18 +47,18 @@ func resourceIBMISFloatingIP() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-					return resourceTagsCustomizeDiff(diff)
+					return resourceTagsCustomizeDiff(diff, v)
 				},
 			),
 			customdiff.Sequence(
-				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 					if diff.HasChange(isFloatingIPTarget) {
 						old, new := diff.GetChange(isFloatingIPTarget)
 						sess, err := vpcClient(v)
 						if err != nil {
 							return err
 						}
-						if checkIfZoneChanged(old.(string), new.(string), sess) {
+						if checkIfZoneChanged(ctx, old.(string), new.(string), sess) {
 							diff.ForceNew(isFloatingIPTarget)
 						}
 					}
@@ -188,18 +187,18 @@ func resourceIBMISFloatingIPValidator() *ResourceValidator {
 	return &ibmISFloatingIPResourceValidator
 }
 
-func resourceIBMISFloatingIPCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISFloatingIPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	name := d.Get(isFloatingIPName).(string)
-	err := fipCreate(d, meta, name)
+	err := fipCreate(ctx, d, meta, name)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
-	return resourceIBMISFloatingIPRead(d, meta)
+	return resourceIBMISFloatingIPRead(ctx, d, meta)
 }
 
-func fipCreate(d *schema.ResourceData, meta interface{}, name string) error {
+func fipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, name string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -238,18 +237,18 @@ func fipCreate(d *schema.ResourceData, meta interface{}, name string) error {
 		FloatingIPPrototype: floatingIPPrototype,
 	}
 
-	floatingip, response, err := sess.CreateFloatingIP(createFloatingIPOptions)
+	floatingip, response, err := sess.CreateFloatingIPWithContext(ctx, createFloatingIPOptions)
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Floating IP err %s\n%s", err, response)
+		return newAPIError("Error creating floating IP", err, response).withAttributes(isFloatingIPName, isFloatingIPZone, isFloatingIPTarget, isFloatingIPResourceGroup)
 	}
 	d.SetId(*floatingip.ID)
 	log.Printf("[INFO] Floating IP : %s[%s]", *floatingip.ID, *floatingip.Address)
-	_, err = isWaitForInstanceFloatingIP(sess, d.Id(), d)
+	_, err = isWaitForInstanceFloatingIP(ctx, sess, d.Id(), d, meta)
 	if err != nil {
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isFloatingIPTags); ok || v != "" {
+	if _, ok := d.GetOk(isFloatingIPTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isFloatingIPTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *floatingip.CRN)
 		if err != nil {
@@ -260,17 +259,17 @@ func fipCreate(d *schema.ResourceData, meta interface{}, name string) error {
 	return nil
 }
 
-func resourceIBMISFloatingIPRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISFloatingIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	id := d.Id()
-	err := fipGet(d, meta, id)
+	err := fipGet(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	return nil
 }
 
-func fipGet(d *schema.ResourceData, meta interface{}, id string) error {
+func fipGet(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -278,13 +277,13 @@ func fipGet(d *schema.ResourceData, meta interface{}, id string) error {
 	getFloatingIPOptions := &vpcv1.GetFloatingIPOptions{
 		ID: &id,
 	}
-	floatingip, response, err := sess.GetFloatingIP(getFloatingIPOptions)
+	floatingip, response, err := sess.GetFloatingIPWithContext(ctx, getFloatingIPOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Getting Floating IP (%s): %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error Getting Floating IP (%s)", id), err, response)
 
 	}
 	d.Set(isFloatingIPName, *floatingip.Name)
@@ -316,16 +315,16 @@ func fipGet(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func resourceIBMISFloatingIPUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	id := d.Id()
-	err := fipUpdate(d, meta, id)
+	err := fipUpdate(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	return resourceIBMISFloatingIPRead(d, meta)
+	return resourceIBMISFloatingIPRead(ctx, d, meta)
 }
 
-func fipUpdate(d *schema.ResourceData, meta interface{}, id string) error {
+func fipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -334,9 +333,9 @@ func fipUpdate(d *schema.ResourceData, meta interface{}, id string) error {
 		options := &vpcv1.GetFloatingIPOptions{
 			ID: &id,
 		}
-		fip, response, err := sess.GetFloatingIP(options)
+		fip, response, err := sess.GetFloatingIPWithContext(ctx, options)
 		if err != nil {
-			return fmt.Errorf("Error getting Floating IP: %s\n%s", err, response)
+			return newAPIError("Error getting Floating IP", err, response)
 		}
 		oldList, newList := d.GetChange(isFloatingIPTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *fip.CRN)
@@ -374,24 +373,24 @@ func fipUpdate(d *schema.ResourceData, meta interface{}, id string) error {
 		options.FloatingIPPatch = floatingIPPatch
 	}
 	if hasChanged {
-		_, response, err := sess.UpdateFloatingIP(options)
+		_, response, err := sess.UpdateFloatingIPWithContext(ctx, options)
 		if err != nil {
-			return fmt.Errorf("Error updating vpc Floating IP: %s\n%s", err, response)
+			return newAPIError("Error updating vpc Floating IP", err, response)
 		}
 	}
 	return nil
 }
 
-func resourceIBMISFloatingIPDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISFloatingIPDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	id := d.Id()
-	err := fipDelete(d, meta, id)
+	err := fipDelete(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	return nil
 }
 
-func fipDelete(d *schema.ResourceData, meta interface{}, id string) error {
+func fipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -399,23 +398,23 @@ func fipDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	getFloatingIpOptions := &vpcv1.GetFloatingIPOptions{
 		ID: &id,
 	}
-	_, response, err := sess.GetFloatingIP(getFloatingIpOptions)
+	_, response, err := sess.GetFloatingIPWithContext(ctx, getFloatingIpOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			return nil
 		}
 
-		return fmt.Errorf("Error Getting Floating IP (%s): %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error Getting Floating IP (%s)", id), err, response)
 	}
 
 	options := &vpcv1.DeleteFloatingIPOptions{
 		ID: &id,
 	}
-	response, err = sess.DeleteFloatingIP(options)
+	response, err = sess.DeleteFloatingIPWithContext(ctx, options)
 	if err != nil {
-		return fmt.Errorf("Error Deleting Floating IP : %s\n%s", err, response)
+		return newAPIError("Error Deleting Floating IP", err, response)
 	}
-	_, err = isWaitForFloatingIPDeleted(sess, id, d.Timeout(schema.TimeoutDelete))
+	_, err = isWaitForFloatingIPDeleted(ctx, sess, id, d.Timeout(schema.TimeoutDelete), meta)
 	if err != nil {
 		return err
 	}
@@ -423,96 +422,84 @@ func fipDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func resourceIBMISFloatingIPExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	id := d.Id()
-	exists, err := fipExists(d, meta, id)
-	return exists, err
-}
-
-func fipExists(d *schema.ResourceData, meta interface{}, id string) (bool, error) {
-	sess, err := vpcClient(meta)
-	if err != nil {
-		return false, err
-	}
-	getFloatingIpOptions := &vpcv1.GetFloatingIPOptions{
-		ID: &id,
-	}
-	_, response, err := sess.GetFloatingIP(getFloatingIpOptions)
-	if err != nil {
-		if response != nil && response.StatusCode == 404 {
-			return false, nil
-		}
-		return false, fmt.Errorf("Error getting floating IP: %s\n%s", err, response)
-	}
-	return true, nil
-}
-
-func isWaitForFloatingIPDeleted(fip *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
+func isWaitForFloatingIPDeleted(ctx context.Context, fip *vpcv1.VpcV1, id string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf("Waiting for FloatingIP (%s) to be deleted.", id)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{isFloatingIPPending, isFloatingIPDeleting},
-		Target:     []string{"", isFloatingIPDeleted},
-		Refresh:    isFloatingIPDeleteRefreshFunc(fip, id),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+	p := &poller{
+		Kind:    "ibm_is_floating_ip:deleted",
+		Name:    fmt.Sprintf("floating IP (%s)", id),
+		Pending: []string{isFloatingIPPending, isFloatingIPDeleting},
+		Target:  []string{"", isFloatingIPDeleted},
+		Refresh: isFloatingIPDeleteRefreshFunc(ctx, fip, id),
+		Timeout: timeout,
+		Delay:   10 * time.Second,
+		Policy:  pollingPolicy(meta),
 	}
 
-	return stateConf.WaitForState()
+	return p.WaitForStateContext(ctx)
 }
 
-func isFloatingIPDeleteRefreshFunc(fip *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func isFloatingIPDeleteRefreshFunc(ctx context.Context, fip *vpcv1.VpcV1, id string) pollRefreshFunc {
+	return func(etag string) (interface{}, string, string, error) {
 		log.Printf("[DEBUG] delete function here")
 		getfipoptions := &vpcv1.GetFloatingIPOptions{
-			ID: &id,
+			ID:      &id,
+			Headers: ifNoneMatchHeaders(etag),
+		}
+		FloatingIP, response, err := fip.GetFloatingIPWithContext(ctx, getfipoptions)
+		if isNotModified(response) {
+			return nil, "", etag, errNotModified
 		}
-		FloatingIP, response, err := fip.GetFloatingIP(getfipoptions)
 		if err != nil {
 			if response != nil && response.StatusCode == 404 {
-				return FloatingIP, isFloatingIPDeleted, nil
+				return FloatingIP, isFloatingIPDeleted, "", nil
 			}
-			return FloatingIP, "", fmt.Errorf("Error Getting Floating IP: %s\n%s", err, response)
+			return FloatingIP, "", "", newAPIError("Error Getting Floating IP", err, response)
 		}
-		return FloatingIP, isFloatingIPDeleting, err
+		return FloatingIP, isFloatingIPDeleting, responseETag(response), err
 	}
 }
 
-func isWaitForInstanceFloatingIP(floatingipC *vpcv1.VpcV1, id string, d *schema.ResourceData) (interface{}, error) {
+func isWaitForInstanceFloatingIP(ctx context.Context, floatingipC *vpcv1.VpcV1, id string, d *schema.ResourceData, meta interface{}) (interface{}, error) {
 	log.Printf("Waiting for floating IP (%s) to be available.", id)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{isFloatingIPPending},
-		Target:     []string{isFloatingIPAvailable, ""},
-		Refresh:    isInstanceFloatingIPRefreshFunc(floatingipC, id),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+	p := &poller{
+		Kind:    "ibm_is_floating_ip:available",
+		Name:    fmt.Sprintf("floating IP (%s)", id),
+		Pending: []string{isFloatingIPPending},
+		Target:  []string{isFloatingIPAvailable, ""},
+		Refresh: isInstanceFloatingIPRefreshFunc(ctx, floatingipC, id),
+		Timeout: d.Timeout(schema.TimeoutCreate),
+		Delay:   10 * time.Second,
+		Policy:  pollingPolicy(meta),
 	}
 
-	return stateConf.WaitForState()
+	return p.WaitForStateContext(ctx)
 }
 
-func isInstanceFloatingIPRefreshFunc(floatingipC *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func isInstanceFloatingIPRefreshFunc(ctx context.Context, floatingipC *vpcv1.VpcV1, id string) pollRefreshFunc {
+	return func(etag string) (interface{}, string, string, error) {
 		getfipoptions := &vpcv1.GetFloatingIPOptions{
-			ID: &id,
+			ID:      &id,
+			Headers: ifNoneMatchHeaders(etag),
+		}
+		instance, response, err := floatingipC.GetFloatingIPWithContext(ctx, getfipoptions)
+		if isNotModified(response) {
+			return nil, "", etag, errNotModified
 		}
-		instance, response, err := floatingipC.GetFloatingIP(getfipoptions)
 		if err != nil {
-			return nil, "", fmt.Errorf("Error Getting Floating IP for the instance: %s\n%s", err, response)
+			return nil, "", "", newAPIError("Error Getting Floating IP for the instance", err, response)
 		}
 
 		if *instance.Status == "available" {
-			return instance, isFloatingIPAvailable, nil
+			return instance, isFloatingIPAvailable, responseETag(response), nil
 		}
 
-		return instance, isFloatingIPPending, nil
+		return instance, isFloatingIPPending, responseETag(response), nil
 	}
 }
 
-func checkIfZoneChanged(oldNic, newNic string, floatingipC *vpcv1.VpcV1) bool {
+func checkIfZoneChanged(ctx context.Context, oldNic, newNic string, floatingipC *vpcv1.VpcV1) bool {
 	var oldZone, newZone string
 	listInstancesOptions := &vpcv1.ListInstancesOptions{}
 	start := ""
@@ -523,7 +510,7 @@ func checkIfZoneChanged(oldNic, newNic string, floatingipC *vpcv1.VpcV1) bool {
 			listInstancesOptions.Start = &start
 		}
 
-		instances, _, err := floatingipC.ListInstances(listInstancesOptions)
+		instances, _, err := floatingipC.ListInstancesWithContext(ctx, listInstancesOptions)
 		if err != nil {
 			return false
 		}
@@ -49,7 +49,7 @@ func resourceIBMISFlowLog() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -236,7 +236,7 @@ func resourceIBMISFlowLogCreate(d *schema.ResourceData, meta interface{}) error
 	log.Printf("Flow log collector : %s", *flowlogCollector.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isFlowLogTags); ok || v != "" {
+	if _, ok := d.GetOk(isFlowLogTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isFlowLogTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *flowlogCollector.CRN)
 		if err != nil {
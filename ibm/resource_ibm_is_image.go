@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -41,12 +42,11 @@ const (
 
 func resourceIBMISImage() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMISImageCreate,
-		Read:     resourceIBMISImageRead,
-		Update:   resourceIBMISImageUpdate,
-		Delete:   resourceIBMISImageDelete,
-		Exists:   resourceIBMISImageExists,
-		Importer: &schema.ResourceImporter{},
+		CreateContext: resourceIBMISImageCreate,
+		ReadContext:   resourceIBMISImageRead,
+		UpdateContext: resourceIBMISImageUpdate,
+		DeleteContext: resourceIBMISImageDelete,
+		Importer:      &schema.ResourceImporter{},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -56,7 +56,7 @@ func resourceIBMISImage() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -218,7 +218,7 @@ func resourceIBMISImageValidator() *ResourceValidator {
 	return &ibmISImageResourceValidator
 }
 
-func resourceIBMISImageCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISImageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	log.Printf("[DEBUG] Image create")
 	href := d.Get(isImageHref).(string)
@@ -227,21 +227,21 @@ func resourceIBMISImageCreate(d *schema.ResourceData, meta interface{}) error {
 	volume := d.Get(isImageVolume).(string)
 
 	if volume != "" {
-		err := imgCreateByVolume(d, meta, name, volume)
+		err := imgCreateByVolume(ctx, d, meta, name, volume)
 		if err != nil {
-			return err
+			return diagFromErr(err)
 		}
 	} else {
-		err := imgCreateByFile(d, meta, href, name, operatingSystem)
+		err := imgCreateByFile(ctx, d, meta, href, name, operatingSystem)
 		if err != nil {
-			return err
+			return diagFromErr(err)
 		}
 	}
 
-	return resourceIBMISImageRead(d, meta)
+	return resourceIBMISImageRead(ctx, d, meta)
 }
 
-func imgCreateByFile(d *schema.ResourceData, meta interface{}, href, name, operatingSystem string) error {
+func imgCreateByFile(ctx context.Context, d *schema.ResourceData, meta interface{}, href, name, operatingSystem string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -275,18 +275,18 @@ func imgCreateByFile(d *schema.ResourceData, meta interface{}, href, name, opera
 	options := &vpcv1.CreateImageOptions{
 		ImagePrototype: imagePrototype,
 	}
-	image, response, err := sess.CreateImage(options)
+	image, response, err := sess.CreateImageWithContext(ctx, options)
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Image creation err %s\n%s", err, response)
+		return newAPIError("Error creating Image", err, response).withAttributes(isImageName, "file="+isImageHref, isImageOperatingSystem, isImageEncryptionKey, isImageEncryptedDataKey, isImageResourceGroup)
 	}
 	d.SetId(*image.ID)
 	log.Printf("[INFO] Image ID : %s", *image.ID)
-	_, err = isWaitForImageAvailable(sess, d.Id(), d.Timeout(schema.TimeoutCreate))
+	_, err = isWaitForImageAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isImageTags); ok || v != "" {
+	if _, ok := d.GetOk(isImageTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isImageTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *image.CRN)
 		if err != nil {
@@ -296,7 +296,7 @@ func imgCreateByFile(d *schema.ResourceData, meta interface{}, href, name, opera
 	}
 	return nil
 }
-func imgCreateByVolume(d *schema.ResourceData, meta interface{}, name, volume string) error {
+func imgCreateByVolume(ctx context.Context, d *schema.ResourceData, meta interface{}, name, volume string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -311,9 +311,9 @@ func imgCreateByVolume(d *schema.ResourceData, meta interface{}, name, volume st
 	options := &vpcv1.GetVolumeOptions{
 		ID: &volume,
 	}
-	vol, response, err := sess.GetVolume(options)
+	vol, response, err := sess.GetVolumeWithContext(ctx, options)
 	if err != nil || vol == nil {
-		return fmt.Errorf("Error retrieving Volume (%s) details: %s\n%s", volume, err, response)
+		return newAPIError(fmt.Sprintf("Error retrieving Volume (%s) details", volume), err, response)
 	}
 	if vol.VolumeAttachments == nil {
 		return fmt.Errorf("Error creating Image because the specified source_volume %s is not attached to a virtual server instance ", volume)
@@ -323,9 +323,9 @@ func imgCreateByVolume(d *schema.ResourceData, meta interface{}, name, volume st
 	getinsOptions := &vpcv1.GetInstanceOptions{
 		ID: &insId,
 	}
-	instance, response, err := sess.GetInstance(getinsOptions)
+	instance, response, err := sess.GetInstanceWithContext(ctx, getinsOptions)
 	if err != nil || instance == nil {
-		return fmt.Errorf("Error retrieving Instance (%s) to which the source_volume (%s) is attached : %s\n%s", insId, volume, err, response)
+		return newAPIError(fmt.Sprintf("Error retrieving Instance (%s) to which the source_volume (%s) is attached", insId, volume), err, response)
 	}
 	if instance != nil && *instance.Status == "running" {
 		actiontype := "stop"
@@ -333,16 +333,16 @@ func imgCreateByVolume(d *schema.ResourceData, meta interface{}, name, volume st
 			InstanceID: &insId,
 			Type:       &actiontype,
 		}
-		_, response, err = sess.CreateInstanceAction(createinsactoptions)
+		_, response, err = sess.CreateInstanceActionWithContext(ctx, createinsactoptions)
 		if err != nil {
-			return fmt.Errorf("Error stopping Instance (%s) to which the source_volume (%s) is attached  : %s\n%s", insId, volume, err, response)
+			return newAPIError(fmt.Sprintf("Error stopping Instance (%s) to which the source_volume (%s) is attached", insId, volume), err, response)
 		}
-		_, err = isWaitForInstanceActionStop(sess, d.Timeout(schema.TimeoutCreate), insId, d)
+		_, err = isWaitForInstanceActionStop(ctx, sess, d.Timeout(schema.TimeoutCreate), insId, d)
 		if err != nil {
 			return err
 		}
 	} else if *instance.Status != "stopped" {
-		_, err = isWaitForInstanceActionStop(sess, d.Timeout(schema.TimeoutCreate), insId, d)
+		_, err = isWaitForInstanceActionStop(ctx, sess, d.Timeout(schema.TimeoutCreate), insId, d)
 		if err != nil {
 			return err
 		}
@@ -364,18 +364,18 @@ func imgCreateByVolume(d *schema.ResourceData, meta interface{}, name, volume st
 	imagOptions := &vpcv1.CreateImageOptions{
 		ImagePrototype: imagePrototype,
 	}
-	image, response, err := sess.CreateImage(imagOptions)
+	image, response, err := sess.CreateImageWithContext(ctx, imagOptions)
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Image creation err %s\n%s", err, response)
+		return newAPIError("Error creating Image", err, response).withAttributes(isImageName, isImageVolume, isImageEncryptionKey, isImageResourceGroup)
 	}
 	d.SetId(*image.ID)
 	log.Printf("[INFO] Image ID : %s", *image.ID)
-	_, err = isWaitForImageAvailable(sess, d.Id(), d.Timeout(schema.TimeoutCreate))
+	_, err = isWaitForImageAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isImageTags); ok || v != "" {
+	if _, ok := d.GetOk(isImageTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isImageTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *image.CRN)
 		if err != nil {
@@ -386,28 +386,28 @@ func imgCreateByVolume(d *schema.ResourceData, meta interface{}, name, volume st
 	return nil
 }
 
-func isWaitForImageAvailable(imageC *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
+func isWaitForImageAvailable(ctx context.Context, imageC *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
 	log.Printf("Waiting for image (%s) to be available.", id)
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"retry", isImageProvisioning},
 		Target:     []string{isImageProvisioningDone, ""},
-		Refresh:    isImageRefreshFunc(imageC, id),
+		Refresh:    isImageRefreshFunc(ctx, imageC, id),
 		Timeout:    timeout,
 		Delay:      10 * time.Second,
 		MinTimeout: 10 * time.Second,
 	}
 
-	return stateConf.WaitForState()
+	return stateConf.WaitForStateContext(ctx)
 }
-func isImageRefreshFunc(imageC *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
+func isImageRefreshFunc(ctx context.Context, imageC *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		getimgoptions := &vpcv1.GetImageOptions{
 			ID: &id,
 		}
-		image, response, err := imageC.GetImage(getimgoptions)
+		image, response, err := imageC.GetImageWithContext(ctx, getimgoptions)
 		if err != nil {
-			return nil, "", fmt.Errorf("Error Getting Image: %s\n%s", err, response)
+			return nil, "", newAPIError("Error Getting Image", err, response)
 		}
 
 		if *image.Status == "available" || *image.Status == "failed" {
@@ -418,7 +418,7 @@ func isImageRefreshFunc(imageC *vpcv1.VpcV1, id string) resource.StateRefreshFun
 	}
 }
 
-func resourceIBMISImageUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISImageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	id := d.Id()
 	name := ""
@@ -428,15 +428,15 @@ func resourceIBMISImageUpdate(d *schema.ResourceData, meta interface{}) error {
 		name = d.Get(isImageName).(string)
 		hasChanged = true
 	}
-	err := imgUpdate(d, meta, id, name, hasChanged)
+	err := imgUpdate(ctx, d, meta, id, name, hasChanged)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
-	return resourceIBMISImageRead(d, meta)
+	return resourceIBMISImageRead(ctx, d, meta)
 }
 
-func imgUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasChanged bool) error {
+func imgUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, id, name string, hasChanged bool) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -445,9 +445,9 @@ func imgUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 		options := &vpcv1.GetImageOptions{
 			ID: &id,
 		}
-		image, response, err := sess.GetImage(options)
+		image, response, err := sess.GetImageWithContext(ctx, options)
 		if err != nil {
-			return fmt.Errorf("Error getting Image IP: %s\n%s", err, response)
+			return newAPIError("Error getting Image IP", err, response)
 		}
 		oldList, newList := d.GetChange(isImageTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *image.CRN)
@@ -468,25 +468,25 @@ func imgUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 			return fmt.Errorf("Error calling asPatch for ImagePatch: %s", err)
 		}
 		options.ImagePatch = imagePatch
-		_, response, err := sess.UpdateImage(options)
+		_, response, err := sess.UpdateImageWithContext(ctx, options)
 		if err != nil {
-			return fmt.Errorf("Error on update of resource vpc Image: %s\n%s", err, response)
+			return newAPIError("Error on update of resource vpc Image", err, response)
 		}
 	}
 	return nil
 }
 
-func resourceIBMISImageRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	id := d.Id()
-	err := imgGet(d, meta, id)
+	err := imgGet(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	return nil
 }
 
-func imgGet(d *schema.ResourceData, meta interface{}, id string) error {
+func imgGet(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -494,13 +494,13 @@ func imgGet(d *schema.ResourceData, meta interface{}, id string) error {
 	options := &vpcv1.GetImageOptions{
 		ID: &id,
 	}
-	image, response, err := sess.GetImage(options)
+	image, response, err := sess.GetImageWithContext(ctx, options)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Getting Image (%s): %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error Getting Image (%s)", id), err, response)
 	}
 	// d.Set(isImageArchitecure, image.Architecture)
 	if image.MinimumProvisionedSize != nil {
@@ -554,17 +554,17 @@ func imgGet(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func resourceIBMISImageDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISImageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	id := d.Id()
-	err := imgDelete(d, meta, id)
+	err := imgDelete(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	return nil
 }
 
-func imgDelete(d *schema.ResourceData, meta interface{}, id string) error {
+func imgDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -572,22 +572,22 @@ func imgDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	getImageOptions := &vpcv1.GetImageOptions{
 		ID: &id,
 	}
-	_, response, err := sess.GetImage(getImageOptions)
+	_, response, err := sess.GetImageWithContext(ctx, getImageOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			return nil
 		}
-		return fmt.Errorf("Error Getting Image (%s): %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error Getting Image (%s)", id), err, response)
 	}
 
 	options := &vpcv1.DeleteImageOptions{
 		ID: &id,
 	}
-	response, err = sess.DeleteImage(options)
+	response, err = sess.DeleteImageWithContext(ctx, options)
 	if err != nil {
-		return fmt.Errorf("Error Deleting Image : %s\n%s", err, response)
+		return newAPIError("Error Deleting Image", err, response)
 	}
-	_, err = isWaitForImageDeleted(sess, id, d.Timeout(schema.TimeoutDelete))
+	_, err = isWaitForImageDeleted(ctx, sess, id, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return err
 	}
@@ -595,57 +595,34 @@ func imgDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func isWaitForImageDeleted(imageC *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
+func isWaitForImageDeleted(ctx context.Context, imageC *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
 	log.Printf("Waiting for image (%s) to be deleted.", id)
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"retry", isImageDeleting},
 		Target:     []string{"", isImageDeleted},
-		Refresh:    isImageDeleteRefreshFunc(imageC, id),
+		Refresh:    isImageDeleteRefreshFunc(ctx, imageC, id),
 		Timeout:    timeout,
 		Delay:      10 * time.Second,
 		MinTimeout: 10 * time.Second,
 	}
 
-	return stateConf.WaitForState()
+	return stateConf.WaitForStateContext(ctx)
 }
 
-func isImageDeleteRefreshFunc(imageC *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
+func isImageDeleteRefreshFunc(ctx context.Context, imageC *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		log.Printf("[DEBUG] delete function here")
 		getimgoptions := &vpcv1.GetImageOptions{
 			ID: &id,
 		}
-		image, response, err := imageC.GetImage(getimgoptions)
+		image, response, err := imageC.GetImageWithContext(ctx, getimgoptions)
 		if err != nil {
 			if response != nil && response.StatusCode == 404 {
 				return image, isImageDeleted, nil
 			}
-			return image, "", fmt.Errorf("Error Getting Image: %s\n%s", err, response)
+			return image, "", newAPIError("Error Getting Image", err, response)
 		}
 		return image, isImageDeleting, err
 	}
 }
-func resourceIBMISImageExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	id := d.Id()
-	exists, err := imgExists(d, meta, id)
-	return exists, err
-}
-
-func imgExists(d *schema.ResourceData, meta interface{}, id string) (bool, error) {
-	sess, err := vpcClient(meta)
-	if err != nil {
-		return false, err
-	}
-	options := &vpcv1.GetImageOptions{
-		ID: &id,
-	}
-	_, response, err := sess.GetImage(options)
-	if err != nil {
-		if response != nil && response.StatusCode == 404 {
-			return false, nil
-		}
-		return false, fmt.Errorf("Error getting Image: %s\n%s", err, response)
-	}
-	return true, nil
-}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -89,15 +90,38 @@ const (
 	isInstancePlacementTarget           = "placement_target"
 )
 
+// the fields of the instance prototype and their arguments, for the errors
+// of the create calls
+var isInstanceCreateAttributes = []string{
+	isInstanceName, isInstanceKeys, isInstanceProfile, isInstanceVPC, isInstanceZone,
+	isInstanceImage, isInstanceUserData, isInstanceResourceGroup,
+	isInstancePrimaryNetworkInterface, isInstanceNetworkInterfaces,
+	"boot_volume_attachment=" + isInstanceBootVolume,
+	"volume_attachments=" + isInstanceVolumes,
+	"source_template=" + isInstanceSourceTemplate,
+}
+
+const (
+	isInstanceForceRecoverySteps = "force_recovery_steps"
+
+	isInstanceRecoverySoftStop = "soft_stop"
+	isInstanceRecoveryHardStop = "hard_stop"
+	isInstanceRecoveryRestart  = "restart"
+)
+
+var isInstanceRecoveryStepValues = []string{isInstanceRecoverySoftStop, isInstanceRecoveryHardStop, isInstanceRecoveryRestart}
+
 func resourceIBMISInstance() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceIBMisInstanceCreate,
-		Read:   resourceIBMisInstanceRead,
-		Update: resourceIBMisInstanceUpdate,
-		Delete: resourceIBMisInstanceDelete,
-		Exists: resourceIBMisInstanceExists,
+	resource := &schema.Resource{
+		CreateContext: resourceIBMisInstanceCreate,
+		ReadContext:   resourceIBMisInstanceRead,
+		UpdateContext: resourceIBMisInstanceUpdate,
+		DeleteContext: resourceIBMisInstanceDelete,
+
+		SchemaVersion: 1,
+
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) (result []*schema.ResourceData, err error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) (result []*schema.ResourceData, err error) {
 				log.Printf("[INFO] Instance (%s) importing", d.Id())
 				id := d.Id()
 				instanceC, err := vpcClient(meta)
@@ -107,13 +131,13 @@ func resourceIBMISInstance() *schema.Resource {
 				getinsOptions := &vpcv1.GetInstanceOptions{
 					ID: &id,
 				}
-				instance, response, err := instanceC.GetInstance(getinsOptions)
+				instance, response, err := instanceC.GetInstanceWithContext(ctx, getinsOptions)
 				if err != nil {
 					if response != nil && response.StatusCode == 404 {
 						d.SetId("")
 						return nil, nil
 					}
-					return nil, fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
+					return nil, newAPIError("Error Getting Instance", err, response)
 				}
 				var volumes []string
 				volumes = make([]string, 0)
@@ -137,7 +161,7 @@ func resourceIBMISInstance() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -541,6 +565,17 @@ func resourceIBMISInstance() *schema.Resource {
 				Type:        schema.TypeInt,
 				Optional:    true,
 			},
+
+			isInstanceForceRecoverySteps: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				RequiredWith: []string{"force_recovery_time"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateAllowedStringValue(isInstanceRecoveryStepValues),
+				},
+				Description: "The recovery steps run in order, force_recovery_time minutes apart, while the instance does not start or stop",
+			},
 			isInstanceDisks: &schema.Schema{
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -587,6 +622,9 @@ func resourceIBMISInstance() *schema.Resource {
 			},
 		},
 	}
+	// boot_volume of version 0 is a list of one block like the current one
+	resource.StateUpgraders = stateUpgradersV0(resource, stateUpgradeKeep)
+	return resource
 }
 
 func resourceIBMISInstanceValidator() *ResourceValidator {
@@ -615,7 +653,7 @@ func resourceIBMISInstanceValidator() *ResourceValidator {
 	return &ibmISInstanceValidator
 }
 
-func instanceCreateByImage(d *schema.ResourceData, meta interface{}, profile, name, vpcID, zone, image string) error {
+func instanceCreateByImage(ctx context.Context, d *schema.ResourceData, meta interface{}, profile, name, vpcID, zone, image string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -796,23 +834,23 @@ func instanceCreateByImage(d *schema.ResourceData, meta interface{}, profile, na
 		InstancePrototype: instanceproto,
 	}
 
-	instance, response, err := sess.CreateInstance(options)
+	instance, response, err := sess.CreateInstanceWithContext(ctx, options)
 	if err != nil {
 		log.Printf("[DEBUG] Instance err %s\n%s", err, response)
-		return err
+		return newAPIError("Error creating instance", err, response).withAttributes(isInstanceCreateAttributes...)
 	}
 	d.SetId(*instance.ID)
 
 	log.Printf("[INFO] Instance : %s", *instance.ID)
 	d.Set(isInstanceStatus, instance.Status)
 
-	_, err = isWaitForInstanceAvailable(sess, d.Id(), d.Timeout(schema.TimeoutCreate), d)
+	_, err = isWaitForInstanceAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutCreate), d)
 	if err != nil {
 		return err
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isInstanceTags); ok || v != "" {
+	if _, ok := d.GetOk(isInstanceTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isInstanceTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
@@ -823,7 +861,7 @@ func instanceCreateByImage(d *schema.ResourceData, meta interface{}, profile, na
 	return nil
 }
 
-func instanceCreateByTemplate(d *schema.ResourceData, meta interface{}, profile, name, vpcID, zone, image, template string) error {
+func instanceCreateByTemplate(ctx context.Context, d *schema.ResourceData, meta interface{}, profile, name, vpcID, zone, image, template string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -996,23 +1034,23 @@ func instanceCreateByTemplate(d *schema.ResourceData, meta interface{}, profile,
 		InstancePrototype: instanceproto,
 	}
 
-	instance, response, err := sess.CreateInstance(options)
+	instance, response, err := sess.CreateInstanceWithContext(ctx, options)
 	if err != nil {
 		log.Printf("[DEBUG] Instance err %s\n%s", err, response)
-		return err
+		return newAPIError("Error creating instance", err, response).withAttributes(isInstanceCreateAttributes...)
 	}
 	d.SetId(*instance.ID)
 
 	log.Printf("[INFO] Instance : %s", *instance.ID)
 	d.Set(isInstanceStatus, instance.Status)
 
-	_, err = isWaitForInstanceAvailable(sess, d.Id(), d.Timeout(schema.TimeoutCreate), d)
+	_, err = isWaitForInstanceAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutCreate), d)
 	if err != nil {
 		return err
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isInstanceTags); ok || v != "" {
+	if _, ok := d.GetOk(isInstanceTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isInstanceTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
@@ -1023,7 +1061,7 @@ func instanceCreateByTemplate(d *schema.ResourceData, meta interface{}, profile,
 	return nil
 }
 
-func instanceCreateByVolume(d *schema.ResourceData, meta interface{}, profile, name, vpcID, zone string) error {
+func instanceCreateByVolume(ctx context.Context, d *schema.ResourceData, meta interface{}, profile, name, vpcID, zone string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -1191,23 +1229,23 @@ func instanceCreateByVolume(d *schema.ResourceData, meta interface{}, profile, n
 		InstancePrototype: instanceproto,
 	}
 
-	instance, response, err := sess.CreateInstance(options)
+	instance, response, err := sess.CreateInstanceWithContext(ctx, options)
 	if err != nil {
 		log.Printf("[DEBUG] Instance err %s\n%s", err, response)
-		return err
+		return newAPIError("Error creating instance", err, response).withAttributes(isInstanceCreateAttributes...)
 	}
 	d.SetId(*instance.ID)
 
 	log.Printf("[INFO] Instance : %s", *instance.ID)
 	d.Set(isInstanceStatus, instance.Status)
 
-	_, err = isWaitForInstanceAvailable(sess, d.Id(), d.Timeout(schema.TimeoutCreate), d)
+	_, err = isWaitForInstanceAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutCreate), d)
 	if err != nil {
 		return err
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isInstanceTags); ok || v != "" {
+	if _, ok := d.GetOk(isInstanceTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isInstanceTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
@@ -1218,7 +1256,7 @@ func instanceCreateByVolume(d *schema.ResourceData, meta interface{}, profile, n
 	return nil
 }
 
-func resourceIBMisInstanceCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMisInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	profile := d.Get(isInstanceProfile).(string)
 	name := d.Get(isInstanceName).(string)
@@ -1229,68 +1267,57 @@ func resourceIBMisInstanceCreate(d *schema.ResourceData, meta interface{}) error
 	template := d.Get(isInstanceSourceTemplate).(string)
 
 	if snapshot != "" {
-		err := instanceCreateByVolume(d, meta, profile, name, vpcID, zone)
+		err := instanceCreateByVolume(ctx, d, meta, profile, name, vpcID, zone)
 		if err != nil {
-			return err
+			return diagFromErr(err)
 		}
 	} else if template != "" {
-		err := instanceCreateByTemplate(d, meta, profile, name, vpcID, zone, image, template)
+		err := instanceCreateByTemplate(ctx, d, meta, profile, name, vpcID, zone, image, template)
 		if err != nil {
-			return err
+			return diagFromErr(err)
 		}
 	} else {
-		err := instanceCreateByImage(d, meta, profile, name, vpcID, zone, image)
+		err := instanceCreateByImage(ctx, d, meta, profile, name, vpcID, zone, image)
 		if err != nil {
-			return err
+			return diagFromErr(err)
 		}
 	}
 
-	return resourceIBMisInstanceUpdate(d, meta)
+	return resourceIBMisInstanceUpdate(ctx, d, meta)
 }
 
-func isWaitForInstanceAvailable(instanceC *vpcv1.VpcV1, id string, timeout time.Duration, d *schema.ResourceData) (interface{}, error) {
+func isWaitForInstanceAvailable(ctx context.Context, instanceC *vpcv1.VpcV1, id string, timeout time.Duration, d *schema.ResourceData) (interface{}, error) {
 	log.Printf("Waiting for instance (%s) to be available.", id)
 
-	communicator := make(chan interface{})
-
-	stateConf := &resource.StateChangeConf{
+	wait := &escalatingWait{
 		Pending:    []string{"retry", isInstanceProvisioning},
 		Target:     []string{isInstanceStatusRunning, "available", "failed", ""},
-		Refresh:    isInstanceRefreshFunc(instanceC, id, d, communicator),
+		Refresh:    isInstanceRefreshFunc(ctx, instanceC, id, d),
 		Timeout:    timeout,
 		Delay:      10 * time.Second,
 		MinTimeout: 10 * time.Second,
+		Steps:      isInstanceRecoverySteps(instanceC, id, d, []string{isInstanceRecoveryRestart}, isInstanceRecoveryRestart),
 	}
 
-	if v, ok := d.GetOk("force_recovery_time"); ok {
-		forceTimeout := v.(int)
-		go isRestartStartAction(instanceC, id, d, forceTimeout, communicator)
+	instance, step, err := wait.WaitForStateContext(ctx)
+	if step != "" {
+		log.Printf("[INFO] Instance (%s) is available after the %s recovery step", id, step)
 	}
-
-	return stateConf.WaitForState()
+	return instance, err
 }
 
-func isInstanceRefreshFunc(instanceC *vpcv1.VpcV1, id string, d *schema.ResourceData, communicator chan interface{}) resource.StateRefreshFunc {
+func isInstanceRefreshFunc(ctx context.Context, instanceC *vpcv1.VpcV1, id string, d *schema.ResourceData) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		getinsOptions := &vpcv1.GetInstanceOptions{
 			ID: &id,
 		}
-		instance, response, err := instanceC.GetInstance(getinsOptions)
+		instance, response, err := instanceC.GetInstanceWithContext(ctx, getinsOptions)
 		if err != nil {
-			return nil, "", fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
+			return nil, "", newAPIError("Error Getting Instance", err, response)
 		}
 		d.Set(isInstanceStatus, *instance.Status)
 
-		select {
-		case data := <-communicator:
-			return nil, "", data.(error)
-		default:
-			fmt.Println("no message sent")
-		}
-
 		if *instance.Status == "available" || *instance.Status == "failed" || *instance.Status == "running" {
-			// let know the isRestartStartAction() to stop
-			close(communicator)
 			// taint the instance if status is failed
 			if *instance.Status == "failed" {
 				return instance, *instance.Status, fmt.Errorf("Instance (%s) went into failed state during the operation \n [WARNING] Running terraform apply again will remove the tainted instance and attempt to create the instance again replacing the previous configuration", *instance.ID)
@@ -1302,56 +1329,89 @@ func isInstanceRefreshFunc(instanceC *vpcv1.VpcV1, id string, d *schema.Resource
 	}
 }
 
-func isRestartStartAction(instanceC *vpcv1.VpcV1, id string, d *schema.ResourceData, forceTimeout int, communicator chan interface{}) {
-	subticker := time.NewTicker(time.Duration(forceTimeout) * time.Minute)
-	//subticker := time.NewTicker(time.Duration(forceTimeout) * time.Second)
-	for {
-		select {
+// isInstanceRecoverySteps returns the force_recovery_steps of the instance d,
+// run force_recovery_time minutes apart while a wait does not complete, or
+// defaults when they are not set. Only the steps in allowed are kept, and
+// defaults are used when none of the configured steps is allowed. nil when
+// force_recovery_time is not set.
+func isInstanceRecoverySteps(instanceC *vpcv1.VpcV1, id string, d *schema.ResourceData, defaults []string, allowed ...string) []escalationStep {
+	v, ok := d.GetOk("force_recovery_time")
+	if !ok {
+		return nil
+	}
+	after := time.Duration(v.(int)) * time.Minute
 
-		case <-subticker.C:
-			log.Println("Instance is still in starting state, force retry by restarting the instance.")
-			actiontype := "stop"
-			createinsactoptions := &vpcv1.CreateInstanceActionOptions{
-				InstanceID: &id,
-				Type:       &actiontype,
-			}
-			_, response, err := instanceC.CreateInstanceAction(createinsactoptions)
-			if err != nil {
-				communicator <- fmt.Errorf("Error retrying instance action start: %s\n%s", err, response)
-				return
-			}
-			waitTimeout := time.Duration(1) * time.Minute
-			_, _ = isWaitForInstanceActionStop(instanceC, waitTimeout, id, d)
-			actiontype = "start"
-			createinsactoptions = &vpcv1.CreateInstanceActionOptions{
-				InstanceID: &id,
-				Type:       &actiontype,
-			}
-			_, response, err = instanceC.CreateInstanceAction(createinsactoptions)
-			if err != nil {
-				communicator <- fmt.Errorf("Error retrying instance action start: %s\n%s", err, response)
-				return
+	var names []string
+	if v, ok := d.GetOk(isInstanceForceRecoverySteps); ok {
+		for _, name := range expandStringList(v.([]interface{})) {
+			if stringInSlice(name, allowed) {
+				names = append(names, name)
 			}
-		case <-communicator:
-			// indicates refresh func is reached target and not proceed with the thread
-			subticker.Stop()
-			return
+		}
+	}
+	if len(names) == 0 {
+		names = defaults
+	}
+	var steps []escalationStep
+	for _, name := range names {
+		steps = append(steps, escalationStep{
+			Name:   name,
+			After:  after,
+			Action: isInstanceRecoveryAction(instanceC, id, name),
+		})
+	}
+	return steps
+}
 
+// isInstanceRecoveryAction returns the action of the recovery step: a stop,
+// a forced stop, or a forced stop followed by a start.
+func isInstanceRecoveryAction(instanceC *vpcv1.VpcV1, id, step string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		switch step {
+		case isInstanceRecoverySoftStop:
+			return isInstanceAction(ctx, instanceC, id, "stop", false)
+		case isInstanceRecoveryHardStop:
+			return isInstanceAction(ctx, instanceC, id, "stop", true)
 		}
+
+		log.Println("Instance is still in starting state, force retry by restarting the instance.")
+		if err := isInstanceAction(ctx, instanceC, id, "stop", true); err != nil {
+			return err
+		}
+		if _, err := isWaitForInstanceStopped(ctx, instanceC, id, 1*time.Minute, nil); err != nil {
+			log.Printf("[WARN] Instance (%s) is not stopped yet, starting it anyway: %s", id, err)
+		}
+		return isInstanceAction(ctx, instanceC, id, "start", false)
 	}
 }
-func resourceIBMisInstanceRead(d *schema.ResourceData, meta interface{}) error {
+
+func isInstanceAction(ctx context.Context, instanceC *vpcv1.VpcV1, id, actiontype string, force bool) error {
+	createinsactoptions := &vpcv1.CreateInstanceActionOptions{
+		InstanceID: &id,
+		Type:       &actiontype,
+	}
+	if force {
+		createinsactoptions.Force = &force
+	}
+	_, response, err := instanceC.CreateInstanceActionWithContext(ctx, createinsactoptions)
+	if err != nil {
+		return newAPIError(fmt.Sprintf("Error retrying instance action %s", actiontype), err, response)
+	}
+	return nil
+}
+
+func resourceIBMisInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	ID := d.Id()
 
-	err := instanceGet(d, meta, ID)
+	err := instanceGet(ctx, d, meta, ID)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	return nil
 }
 
-func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
+func instanceGet(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	instanceC, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -1359,13 +1419,13 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 	getinsOptions := &vpcv1.GetInstanceOptions{
 		ID: &id,
 	}
-	instance, response, err := instanceC.GetInstance(getinsOptions)
+	instance, response, err := instanceC.GetInstanceWithContext(ctx, getinsOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error getting Instance: %s\n%s", err, response)
+		return newAPIError("Error getting Instance", err, response)
 	}
 
 	d.Set(isInstanceName, *instance.Name)
@@ -1405,9 +1465,9 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 			InstanceID: &id,
 			ID:         instance.PrimaryNetworkInterface.ID,
 		}
-		insnic, response, err := instanceC.GetInstanceNetworkInterface(getnicoptions)
+		insnic, response, err := instanceC.GetInstanceNetworkInterfaceWithContext(ctx, getnicoptions)
 		if err != nil {
-			return fmt.Errorf("Error getting network interfaces attached to the instance %s\n%s", err, response)
+			return newAPIError("Error getting network interfaces attached to the instance", err, response)
 		}
 		currentPrimNic[isInstanceNicAllowIPSpoofing] = *insnic.AllowIPSpoofing
 		currentPrimNic[isInstanceNicSubnet] = *insnic.Subnet.ID
@@ -1435,9 +1495,9 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 					InstanceID: &id,
 					ID:         intfc.ID,
 				}
-				insnic, response, err := instanceC.GetInstanceNetworkInterface(getnicoptions)
+				insnic, response, err := instanceC.GetInstanceNetworkInterfaceWithContext(ctx, getnicoptions)
 				if err != nil {
-					return fmt.Errorf("Error getting network interfaces attached to the instance %s\n%s", err, response)
+					return newAPIError("Error getting network interfaces attached to the instance", err, response)
 				}
 				currentNic[isInstanceNicAllowIPSpoofing] = *insnic.AllowIPSpoofing
 				currentNic[isInstanceNicSubnet] = *insnic.Subnet.ID
@@ -1503,7 +1563,7 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 			options := &vpcv1.GetVolumeOptions{
 				ID: instance.BootVolumeAttachment.Volume.ID,
 			}
-			vol, response, err := instanceC.GetVolume(options)
+			vol, response, err := instanceC.GetVolumeWithContext(ctx, options)
 			if err != nil {
 				log.Printf("Error Getting Boot Volume (%s): %s\n%s", id, err, response)
 			}
@@ -1552,7 +1612,7 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
+func instanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	instanceC, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -1579,11 +1639,11 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 					},
 					DeleteVolumeOnInstanceDelete: &volautoDelete,
 				}
-				vol, _, err := instanceC.CreateInstanceVolumeAttachment(createvolattoptions)
+				vol, _, err := instanceC.CreateInstanceVolumeAttachmentWithContext(ctx, createvolattoptions)
 				if err != nil {
 					return fmt.Errorf("Error while attaching volume %q for instance %s: %q", add[i], d.Id(), err)
 				}
-				_, err = isWaitForInstanceVolumeAttached(instanceC, d, id, *vol.ID)
+				_, err = isWaitForInstanceVolumeAttached(ctx, instanceC, d, id, *vol.ID)
 				if err != nil {
 					return err
 				}
@@ -1595,7 +1655,7 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 				listvolattoptions := &vpcv1.ListInstanceVolumeAttachmentsOptions{
 					InstanceID: &id,
 				}
-				vols, _, err := instanceC.ListInstanceVolumeAttachments(listvolattoptions)
+				vols, _, err := instanceC.ListInstanceVolumeAttachmentsWithContext(ctx, listvolattoptions)
 				if err != nil {
 					return err
 				}
@@ -1605,11 +1665,11 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 							InstanceID: &id,
 							ID:         vol.ID,
 						}
-						_, err := instanceC.DeleteInstanceVolumeAttachment(delvolattoptions)
+						_, err := instanceC.DeleteInstanceVolumeAttachmentWithContext(ctx, delvolattoptions)
 						if err != nil {
 							return fmt.Errorf("Error while removing volume %q for instance %s: %q", remove[i], d.Id(), err)
 						}
-						_, err = isWaitForInstanceVolumeDetached(instanceC, d, d.Id(), *vol.ID)
+						_, err = isWaitForInstanceVolumeDetached(ctx, instanceC, d, d.Id(), *vol.ID)
 						if err != nil {
 							return err
 						}
@@ -1633,11 +1693,11 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 					SecurityGroupID: &add[i],
 					ID:              &networkID,
 				}
-				_, response, err := instanceC.AddSecurityGroupNetworkInterface(createsgnicoptions)
+				_, response, err := instanceC.AddSecurityGroupNetworkInterfaceWithContext(ctx, createsgnicoptions)
 				if err != nil {
-					return fmt.Errorf("Error while creating security group %q for primary network interface of instance %s\n%s: %q", add[i], d.Id(), err, response)
+					return newAPIError(fmt.Sprintf("Error while creating security group %q for primary network interface of instance %s", add[i], d.Id()), err, response)
 				}
-				_, err = isWaitForInstanceAvailable(instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
+				_, err = isWaitForInstanceAvailable(ctx, instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
 				if err != nil {
 					return err
 				}
@@ -1651,11 +1711,11 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 					SecurityGroupID: &remove[i],
 					ID:              &networkID,
 				}
-				response, err := instanceC.RemoveSecurityGroupNetworkInterface(deletesgnicoptions)
+				response, err := instanceC.RemoveSecurityGroupNetworkInterfaceWithContext(ctx, deletesgnicoptions)
 				if err != nil {
-					return fmt.Errorf("Error while removing security group %q for primary network interface of instance %s\n%s: %q", remove[i], d.Id(), err, response)
+					return newAPIError(fmt.Sprintf("Error while removing security group %q for primary network interface of instance %s", remove[i], d.Id()), err, response)
 				}
-				_, err = isWaitForInstanceAvailable(instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
+				_, err = isWaitForInstanceAvailable(ctx, instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
 				if err != nil {
 					return err
 				}
@@ -1682,11 +1742,11 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 		updatepnicfoptions.NetworkInterfacePatch = networkInterfacePatch
 
-		_, response, err := instanceC.UpdateInstanceNetworkInterface(updatepnicfoptions)
+		_, response, err := instanceC.UpdateInstanceNetworkInterfaceWithContext(ctx, updatepnicfoptions)
 		if err != nil {
-			return fmt.Errorf("Error while updating name %s for primary network interface of instance %s\n%s: %q", newName, d.Id(), err, response)
+			return newAPIError(fmt.Sprintf("Error while updating name %s for primary network interface of instance %s", newName, d.Id()), err, response)
 		}
-		_, err = isWaitForInstanceAvailable(instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
+		_, err = isWaitForInstanceAvailable(ctx, instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
 		if err != nil {
 			return err
 		}
@@ -1712,11 +1772,11 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 							SecurityGroupID: &add[i],
 							ID:              &networkID,
 						}
-						_, response, err := instanceC.AddSecurityGroupNetworkInterface(createsgnicoptions)
+						_, response, err := instanceC.AddSecurityGroupNetworkInterfaceWithContext(ctx, createsgnicoptions)
 						if err != nil {
-							return fmt.Errorf("Error while creating security group %q for network interface of instance %s\n%s: %q", add[i], d.Id(), err, response)
+							return newAPIError(fmt.Sprintf("Error while creating security group %q for network interface of instance %s", add[i], d.Id()), err, response)
 						}
-						_, err = isWaitForInstanceAvailable(instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
+						_, err = isWaitForInstanceAvailable(ctx, instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
 						if err != nil {
 							return err
 						}
@@ -1731,11 +1791,11 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 							SecurityGroupID: &remove[i],
 							ID:              &networkID,
 						}
-						response, err := instanceC.RemoveSecurityGroupNetworkInterface(deletesgnicoptions)
+						response, err := instanceC.RemoveSecurityGroupNetworkInterfaceWithContext(ctx, deletesgnicoptions)
 						if err != nil {
-							return fmt.Errorf("Error while removing security group %q for network interface of instance %s\n%s: %q", remove[i], d.Id(), err, response)
+							return newAPIError(fmt.Sprintf("Error while removing security group %q for network interface of instance %s", remove[i], d.Id()), err, response)
 						}
-						_, err = isWaitForInstanceAvailable(instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
+						_, err = isWaitForInstanceAvailable(ctx, instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
 						if err != nil {
 							return err
 						}
@@ -1764,9 +1824,9 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 				}
 				updatepnicfoptions.NetworkInterfacePatch = networkInterfacePatch
 
-				_, response, err := instanceC.UpdateInstanceNetworkInterface(updatepnicfoptions)
+				_, response, err := instanceC.UpdateInstanceNetworkInterfaceWithContext(ctx, updatepnicfoptions)
 				if err != nil {
-					return fmt.Errorf("Error while updating name %s for network interface of instance %s\n%s: %q", newName, d.Id(), err, response)
+					return newAPIError(fmt.Sprintf("Error while updating name %s for network interface of instance %s", newName, d.Id()), err, response)
 				}
 				if err != nil {
 					return err
@@ -1791,7 +1851,7 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 		updnetoptions.InstancePatch = instancePatch
 
-		_, _, err = instanceC.UpdateInstance(updnetoptions)
+		_, _, err = instanceC.UpdateInstanceWithContext(ctx, updnetoptions)
 		if err != nil {
 			return err
 		}
@@ -1802,13 +1862,13 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 		getinsOptions := &vpcv1.GetInstanceOptions{
 			ID: &id,
 		}
-		instance, response, err := instanceC.GetInstance(getinsOptions)
+		instance, response, err := instanceC.GetInstanceWithContext(ctx, getinsOptions)
 		if err != nil {
 			if response != nil && response.StatusCode == 404 {
 				d.SetId("")
 				return nil
 			}
-			return fmt.Errorf("Error Getting Instance (%s): %s\n%s", id, err, response)
+			return newAPIError(fmt.Sprintf("Error Getting Instance (%s)", id), err, response)
 		}
 
 		if instance != nil && *instance.Status == "running" {
@@ -1817,14 +1877,14 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 				InstanceID: &id,
 				Type:       &actiontype,
 			}
-			_, response, err = instanceC.CreateInstanceAction(createinsactoptions)
+			_, response, err = instanceC.CreateInstanceActionWithContext(ctx, createinsactoptions)
 			if err != nil {
 				if response != nil && response.StatusCode == 404 {
 					return nil
 				}
-				return fmt.Errorf("Error Creating Instance Action: %s\n%s", err, response)
+				return newAPIError("Error Creating Instance Action", err, response)
 			}
-			_, err = isWaitForInstanceActionStop(instanceC, d.Timeout(schema.TimeoutUpdate), id, d)
+			_, err = isWaitForInstanceActionStop(ctx, instanceC, d.Timeout(schema.TimeoutUpdate), id, d)
 			if err != nil {
 				return err
 			}
@@ -1847,9 +1907,9 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 		updnetoptions.InstancePatch = instancePatch
 
-		_, response, err = instanceC.UpdateInstance(updnetoptions)
+		_, response, err = instanceC.UpdateInstanceWithContext(ctx, updnetoptions)
 		if err != nil {
-			return fmt.Errorf("Error in UpdateInstancePatch: %s\n%s", err, response)
+			return newAPIError("Error in UpdateInstancePatch", err, response)
 		}
 
 		actiontype := "start"
@@ -1857,14 +1917,14 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 			InstanceID: &id,
 			Type:       &actiontype,
 		}
-		_, response, err = instanceC.CreateInstanceAction(createinsactoptions)
+		_, response, err = instanceC.CreateInstanceActionWithContext(ctx, createinsactoptions)
 		if err != nil {
 			if response != nil && response.StatusCode == 404 {
 				return nil
 			}
-			return fmt.Errorf("Error Creating Instance Action: %s\n%s", err, response)
+			return newAPIError("Error Creating Instance Action", err, response)
 		}
-		_, err = isWaitForInstanceAvailable(instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
+		_, err = isWaitForInstanceAvailable(ctx, instanceC, d.Id(), d.Timeout(schema.TimeoutUpdate), d)
 		if err != nil {
 			return err
 		}
@@ -1874,9 +1934,9 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 	getinsOptions := &vpcv1.GetInstanceOptions{
 		ID: &id,
 	}
-	instance, response, err := instanceC.GetInstance(getinsOptions)
+	instance, response, err := instanceC.GetInstanceWithContext(ctx, getinsOptions)
 	if err != nil {
-		return fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
+		return newAPIError("Error Getting Instance", err, response)
 	}
 	if d.HasChange(isInstanceTags) {
 		oldList, newList := d.GetChange(isInstanceTags)
@@ -1889,17 +1949,17 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceIBMisInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMisInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	err := instanceUpdate(d, meta)
+	err := instanceUpdate(ctx, d, meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
-	return resourceIBMisInstanceRead(d, meta)
+	return resourceIBMisInstanceRead(ctx, d, meta)
 }
 
-func instanceDelete(d *schema.ResourceData, meta interface{}, id string) error {
+func instanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	instanceC, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -1909,13 +1969,13 @@ func instanceDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	getinsOptions := &vpcv1.GetInstanceOptions{
 		ID: &id,
 	}
-	_, response, err := instanceC.GetInstance(getinsOptions)
+	_, response, err := instanceC.GetInstanceWithContext(ctx, getinsOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Getting Instance (%s): %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error Getting Instance (%s)", id), err, response)
 	}
 
 	bootvolid := ""
@@ -1926,23 +1986,23 @@ func instanceDelete(d *schema.ResourceData, meta interface{}, id string) error {
 			InstanceID: &id,
 			Type:       &actiontype,
 		}
-		_, response, err = instanceC.CreateInstanceAction(createinsactoptions)
+		_, response, err = instanceC.CreateInstanceActionWithContext(ctx, createinsactoptions)
 		if err != nil {
 			if response != nil && response.StatusCode == 404 {
 				return nil
 			}
-			return fmt.Errorf("Error Creating Instance Action: %s\n%s", err, response)
+			return newAPIError("Error Creating Instance Action", err, response)
 		}
-		_, err = isWaitForInstanceActionStop(instanceC, d.Timeout(schema.TimeoutDelete), id, d)
+		_, err = isWaitForInstanceActionStop(ctx, instanceC, d.Timeout(schema.TimeoutDelete), id, d)
 		if err != nil {
 			return err
 		}
 		listvolattoptions := &vpcv1.ListInstanceVolumeAttachmentsOptions{
 			InstanceID: &id,
 		}
-		vols, response, err := instanceC.ListInstanceVolumeAttachments(listvolattoptions)
+		vols, response, err := instanceC.ListInstanceVolumeAttachmentsWithContext(ctx, listvolattoptions)
 		if err != nil {
-			return fmt.Errorf("Error Listing volume attachments to the instance: %s\n%s", err, response)
+			return newAPIError("Error Listing volume attachments to the instance", err, response)
 		}
 		for _, vol := range vols.VolumeAttachments {
 			if *vol.Type == "data" {
@@ -1950,11 +2010,11 @@ func instanceDelete(d *schema.ResourceData, meta interface{}, id string) error {
 					InstanceID: &id,
 					ID:         vol.ID,
 				}
-				_, err := instanceC.DeleteInstanceVolumeAttachment(delvolattoptions)
+				_, err := instanceC.DeleteInstanceVolumeAttachmentWithContext(ctx, delvolattoptions)
 				if err != nil {
 					return fmt.Errorf("Error while removing volume Attachment %q for instance %s: %q", *vol.ID, d.Id(), err)
 				}
-				_, err = isWaitForInstanceVolumeDetached(instanceC, d, d.Id(), *vol.ID)
+				_, err = isWaitForInstanceVolumeDetached(ctx, instanceC, d, d.Id(), *vol.ID)
 				if err != nil {
 					return err
 				}
@@ -1967,17 +2027,17 @@ func instanceDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	deleteinstanceOptions := &vpcv1.DeleteInstanceOptions{
 		ID: &id,
 	}
-	_, err = instanceC.DeleteInstance(deleteinstanceOptions)
+	_, err = instanceC.DeleteInstanceWithContext(ctx, deleteinstanceOptions)
 	if err != nil {
 		return err
 	}
 	if cleanDelete {
-		_, err = isWaitForInstanceDelete(instanceC, d, d.Id())
+		_, err = isWaitForInstanceDelete(ctx, instanceC, d, d.Id())
 		if err != nil {
 			return err
 		}
 		if _, ok := d.GetOk(isInstanceBootVolume); ok {
-			_, err = isWaitForVolumeDeleted(instanceC, bootvolid, d.Timeout(schema.TimeoutDelete))
+			_, err = isWaitForVolumeDeleted(ctx, instanceC, bootvolid, d.Timeout(schema.TimeoutDelete), meta)
 			if err != nil {
 				return err
 			}
@@ -1986,46 +2046,19 @@ func instanceDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func resourceIBMisInstanceDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMisInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	id := d.Id()
-	err := instanceDelete(d, meta, id)
+	err := instanceDelete(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	d.SetId("")
 	return nil
 }
 
-func instanceExists(d *schema.ResourceData, meta interface{}, id string) (bool, error) {
-	instanceC, err := vpcClient(meta)
-	if err != nil {
-		return false, err
-	}
-	getinsOptions := &vpcv1.GetInstanceOptions{
-		ID: &id,
-	}
-	_, response, err := instanceC.GetInstance(getinsOptions)
-	if err != nil {
-		if response != nil && response.StatusCode == 404 {
-			return false, nil
-		}
-		return false, fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
-	}
-	return true, nil
-}
-
-func resourceIBMisInstanceExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-
-	id := d.Id()
-
-	exists, err := instanceExists(d, meta, id)
-	return exists, err
-
-}
-
-func isWaitForInstanceDelete(instanceC *vpcv1.VpcV1, d *schema.ResourceData, id string) (interface{}, error) {
+func isWaitForInstanceDelete(ctx context.Context, instanceC *vpcv1.VpcV1, d *schema.ResourceData, id string) (interface{}, error) {
 
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{isInstanceDeleting, isInstanceAvailable},
@@ -2034,12 +2067,12 @@ func isWaitForInstanceDelete(instanceC *vpcv1.VpcV1, d *schema.ResourceData, id
 			getinsoptions := &vpcv1.GetInstanceOptions{
 				ID: &id,
 			}
-			instance, response, err := instanceC.GetInstance(getinsoptions)
+			instance, response, err := instanceC.GetInstanceWithContext(ctx, getinsoptions)
 			if err != nil {
 				if response != nil && response.StatusCode == 404 {
 					return instance, isInstanceDeleteDone, nil
 				}
-				return nil, "", fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
+				return nil, "", newAPIError("Error Getting Instance", err, response)
 			}
 			if *instance.Status == isInstanceFailed {
 				return instance, *instance.Status, fmt.Errorf("The  instance %s failed to delete: %v", d.Id(), err)
@@ -2051,31 +2084,27 @@ func isWaitForInstanceDelete(instanceC *vpcv1.VpcV1, d *schema.ResourceData, id
 		MinTimeout: 10 * time.Second,
 	}
 
-	return stateConf.WaitForState()
+	return stateConf.WaitForStateContext(ctx)
 }
 
-func isWaitForInstanceActionStop(instanceC *vpcv1.VpcV1, timeout time.Duration, id string, d *schema.ResourceData) (interface{}, error) {
-	communicator := make(chan interface{})
-	stateConf := &resource.StateChangeConf{
+func isWaitForInstanceActionStop(ctx context.Context, instanceC *vpcv1.VpcV1, timeout time.Duration, id string, d *schema.ResourceData) (interface{}, error) {
+	steps := isInstanceRecoverySteps(instanceC, id, d, []string{isInstanceRecoveryHardStop}, isInstanceRecoverySoftStop, isInstanceRecoveryHardStop)
+	return isWaitForInstanceStopped(ctx, instanceC, id, timeout, steps)
+}
+
+func isWaitForInstanceStopped(ctx context.Context, instanceC *vpcv1.VpcV1, id string, timeout time.Duration, steps []escalationStep) (interface{}, error) {
+	wait := &escalatingWait{
 		Pending: []string{isInstanceStatusRunning, isInstanceStatusPending, isInstanceActionStatusStopping},
 		Target:  []string{isInstanceActionStatusStopped, isInstanceStatusFailed, ""},
 		Refresh: func() (interface{}, string, error) {
 			getinsoptions := &vpcv1.GetInstanceOptions{
 				ID: &id,
 			}
-			instance, response, err := instanceC.GetInstance(getinsoptions)
+			instance, response, err := instanceC.GetInstanceWithContext(ctx, getinsoptions)
 			if err != nil {
-				return nil, "", fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
-			}
-			select {
-			case data := <-communicator:
-				return nil, "", data.(error)
-			default:
-				fmt.Println("no message sent")
+				return nil, "", newAPIError("Error Getting Instance", err, response)
 			}
 			if *instance.Status == isInstanceStatusFailed {
-				// let know the isRestartStopAction() to stop
-				close(communicator)
 				return instance, *instance.Status, fmt.Errorf("The  instance %s failed to stop: %v", id, err)
 			}
 			return instance, *instance.Status, nil
@@ -2083,67 +2112,40 @@ func isWaitForInstanceActionStop(instanceC *vpcv1.VpcV1, timeout time.Duration,
 		Timeout:    timeout,
 		Delay:      10 * time.Second,
 		MinTimeout: 10 * time.Second,
+		Steps:      steps,
 	}
 
-	if v, ok := d.GetOk("force_recovery_time"); ok {
-		forceTimeout := v.(int)
-		go isRestartStopAction(instanceC, id, d, forceTimeout, communicator)
-	}
-
-	return stateConf.WaitForState()
-}
-
-func isRestartStopAction(instanceC *vpcv1.VpcV1, id string, d *schema.ResourceData, forceTimeout int, communicator chan interface{}) {
-	subticker := time.NewTicker(time.Duration(forceTimeout) * time.Minute)
-	//subticker := time.NewTicker(time.Duration(forceTimeout) * time.Second)
-	for {
-		select {
-
-		case <-subticker.C:
-			log.Println("Instance is still in stopping state, retrying to stop with -force")
-			actiontype := "stop"
-			createinsactoptions := &vpcv1.CreateInstanceActionOptions{
-				InstanceID: &id,
-				Type:       &actiontype,
-			}
-			_, response, err := instanceC.CreateInstanceAction(createinsactoptions)
-			if err != nil {
-				communicator <- fmt.Errorf("Error retrying instance action stop: %s\n%s", err, response)
-				return
-			}
-		case <-communicator:
-			// indicates refresh func is reached target and not proceed with the thread)
-			subticker.Stop()
-			return
-
-		}
+	instance, step, err := wait.WaitForStateContext(ctx)
+	if step != "" {
+		log.Printf("[INFO] Instance (%s) is stopped after the %s recovery step", id, step)
 	}
+	return instance, err
 }
 
-func isWaitForInstanceVolumeAttached(instanceC *vpcv1.VpcV1, d *schema.ResourceData, id, volID string) (interface{}, error) {
+func isWaitForInstanceVolumeAttached(ctx context.Context, instanceC *vpcv1.VpcV1, d *schema.ResourceData, id, volID string) (interface{}, error) {
 	log.Printf("Waiting for instance (%s) volume (%s) to be attached.", id, volID)
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{isInstanceVolumeAttaching},
 		Target:     []string{isInstanceVolumeAttached, ""},
-		Refresh:    isInstanceVolumeRefreshFunc(instanceC, id, volID),
+		Refresh:    isInstanceVolumeRefreshFunc(ctx, instanceC, id, volID),
 		Timeout:    d.Timeout(schema.TimeoutUpdate),
 		Delay:      10 * time.Second,
 		MinTimeout: 10 * time.Second,
 	}
 
-	return stateConf.WaitForState()
+	return stateConf.WaitForStateContext(ctx)
 }
 
-func isInstanceVolumeRefreshFunc(instanceC *vpcv1.VpcV1, id, volID string) resource.StateRefreshFunc {
+func isInstanceVolumeRefreshFunc(ctx context.Context, instanceC *vpcv1.VpcV1, id, volID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		getvolattoptions := &vpcv1.GetInstanceVolumeAttachmentOptions{
 			InstanceID: &id,
 			ID:         &volID,
 		}
-		vol, response, err := instanceC.GetInstanceVolumeAttachment(getvolattoptions)
+		vol, response, err := instanceC.GetInstanceVolumeAttachmentWithContext(ctx, getvolattoptions)
 		if err != nil {
-			return nil, "", fmt.Errorf("Error Attaching volume: %s\n%s", err, response)
+			return nil, "", newAPIError("Error Attaching volume", err, response)
 		}
 
 		if *vol.Status == isInstanceVolumeAttached {
@@ -2154,7 +2156,7 @@ func isInstanceVolumeRefreshFunc(instanceC *vpcv1.VpcV1, id, volID string) resou
 	}
 }
 
-func isWaitForInstanceVolumeDetached(instanceC *vpcv1.VpcV1, d *schema.ResourceData, id, volID string) (interface{}, error) {
+func isWaitForInstanceVolumeDetached(ctx context.Context, instanceC *vpcv1.VpcV1, d *schema.ResourceData, id, volID string) (interface{}, error) {
 
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{isInstanceVolumeAttached, isInstanceVolumeDetaching},
@@ -2164,12 +2166,12 @@ func isWaitForInstanceVolumeDetached(instanceC *vpcv1.VpcV1, d *schema.ResourceD
 				InstanceID: &id,
 				ID:         &volID,
 			}
-			vol, response, err := instanceC.GetInstanceVolumeAttachment(getvolattoptions)
+			vol, response, err := instanceC.GetInstanceVolumeAttachmentWithContext(ctx, getvolattoptions)
 			if err != nil {
 				if response != nil && response.StatusCode == 404 {
 					return vol, isInstanceDeleteDone, nil
 				}
-				return nil, "", fmt.Errorf("Error Detaching: %s\n%s", err, response)
+				return nil, "", newAPIError("Error Detaching", err, response)
 			}
 			if *vol.Status == isInstanceFailed {
 				return vol, *vol.Status, fmt.Errorf("The instance %s failed to detach volume %s: %v", d.Id(), volID, err)
@@ -2181,7 +2183,7 @@ func isWaitForInstanceVolumeDetached(instanceC *vpcv1.VpcV1, d *schema.ResourceD
 		MinTimeout: 10 * time.Second,
 	}
 
-	return stateConf.WaitForState()
+	return stateConf.WaitForStateContext(ctx)
 }
 
 func resourceIbmIsInstanceInstanceDiskToMap(instanceDisk vpcv1.InstanceDisk) map[string]interface{} {
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -24,20 +25,23 @@ const (
 	HEALTHY = "healthy"
 	// DELETING ...
 	DELETING = "deleting"
+
+	// the delay before the memberships of a group still scaling down to
+	// zero instances on delete are removed, with force_delete_memberships
+	isInstanceGroupDeleteMembershipsAfter = 10 * time.Minute
 )
 
 func resourceIBMISInstanceGroup() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMISInstanceGroupCreate,
-		Read:     resourceIBMISInstanceGroupRead,
-		Update:   resourceIBMISInstanceGroupUpdate,
-		Delete:   resourceIBMISInstanceGroupDelete,
-		Exists:   resourceIBMISInstanceGroupExists,
-		Importer: &schema.ResourceImporter{},
+		CreateContext: resourceIBMISInstanceGroupCreate,
+		ReadContext:   resourceIBMISInstanceGroupRead,
+		UpdateContext: resourceIBMISInstanceGroupUpdate,
+		DeleteContext: resourceIBMISInstanceGroupDelete,
+		Importer:      &schema.ResourceImporter{},
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -106,6 +110,13 @@ func resourceIBMISInstanceGroup() *schema.Resource {
 				Description:  "load balancer pool ID",
 			},
 
+			"force_delete_memberships": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the memberships of the instance group when it is still scaling down to zero instances 10 minutes after the start of its deletion",
+			},
+
 			"managers": {
 				Type:        schema.TypeList,
 				Elem:        &schema.Schema{Type: schema.TypeString},
@@ -183,7 +194,7 @@ func resourceIBMISInstanceGroupValidator() *ResourceValidator {
 	return &ibmISInstanceGroupResourceValidator
 }
 
-func resourceIBMISInstanceGroupCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	name := d.Get("name").(string)
 	instanceTemplate := d.Get("instance_template").(string)
@@ -192,7 +203,7 @@ func resourceIBMISInstanceGroupCreate(d *schema.ResourceData, meta interface{})
 
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	var subnetIDs []vpcv1.SubnetIdentityIntf
@@ -236,19 +247,19 @@ func resourceIBMISInstanceGroupCreate(d *schema.ResourceData, meta interface{})
 		instanceGroupOptions.ApplicationPort = &applicatioPort
 	}
 
-	instanceGroup, response, err := sess.CreateInstanceGroup(&instanceGroupOptions)
+	instanceGroup, response, err := sess.CreateInstanceGroupWithContext(ctx, &instanceGroupOptions)
 	if err != nil || instanceGroup == nil {
-		return fmt.Errorf("Error Creating InstanceGroup: %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error Creating InstanceGroup", err, response).withAttributes("name", "instance_template", "subnets", "membership_count=instance_count", "load_balancer", "load_balancer_pool", "resource_group", "application_port"))
 	}
 	d.SetId(*instanceGroup.ID)
 
-	_, healthError := waitForHealthyInstanceGroup(d.Id(), meta, d.Timeout(schema.TimeoutCreate))
+	_, healthError := waitForHealthyInstanceGroup(ctx, d.Id(), meta, d.Timeout(schema.TimeoutCreate))
 	if healthError != nil {
-		return healthError
+		return diagFromErr(healthError)
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk("tags"); ok || v != "" {
+	if _, ok := d.GetOk("tags"); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange("tags")
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instanceGroup.CRN)
 		if err != nil {
@@ -257,14 +268,14 @@ func resourceIBMISInstanceGroupCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	return resourceIBMISInstanceGroupRead(d, meta)
+	return resourceIBMISInstanceGroupRead(ctx, d, meta)
 
 }
 
-func resourceIBMISInstanceGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	var changed bool
@@ -274,9 +285,9 @@ func resourceIBMISInstanceGroupUpdate(d *schema.ResourceData, meta interface{})
 	if d.HasChange("tags") {
 		instanceGroupID := d.Id()
 		getInstanceGroupOptions := vpcv1.GetInstanceGroupOptions{ID: &instanceGroupID}
-		instanceGroup, response, err := sess.GetInstanceGroup(&getInstanceGroupOptions)
+		instanceGroup, response, err := sess.GetInstanceGroupWithContext(ctx, &getInstanceGroupOptions)
 		if err != nil || instanceGroup == nil {
-			return fmt.Errorf("Error getting instance group: %s\n%s", err, response)
+			return diagFromErr(newAPIError("Error getting instance group", err, response))
 		}
 		oldList, newList := d.GetChange("tags")
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instanceGroup.CRN)
@@ -333,38 +344,38 @@ func resourceIBMISInstanceGroupUpdate(d *schema.ResourceData, meta interface{})
 		instanceGroupUpdateOptions.ID = &instanceGroupID
 		instanceGroupPatch, err := instanceGroupPatchModel.AsPatch()
 		if err != nil {
-			return fmt.Errorf("Error calling asPatch for InstanceGroupPatch: %s", err)
+			return diagFromErr(fmt.Errorf("Error calling asPatch for InstanceGroupPatch: %s", err))
 		}
 		instanceGroupUpdateOptions.InstanceGroupPatch = instanceGroupPatch
-		_, response, err := sess.UpdateInstanceGroup(&instanceGroupUpdateOptions)
+		_, response, err := sess.UpdateInstanceGroupWithContext(ctx, &instanceGroupUpdateOptions)
 		if err != nil {
-			return fmt.Errorf("Error Updating InstanceGroup: %s\n%s", err, response)
+			return diagFromErr(newAPIError("Error Updating InstanceGroup", err, response))
 		}
 
 		// wait for instance group health update with update timeout configured.
-		_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutUpdate))
+		_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutUpdate))
 		if healthError != nil {
-			return healthError
+			return diagFromErr(healthError)
 		}
 	}
-	return resourceIBMISInstanceGroupRead(d, meta)
+	return resourceIBMISInstanceGroupRead(ctx, d, meta)
 }
 
-func resourceIBMISInstanceGroupRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	instanceGroupID := d.Id()
 	getInstanceGroupOptions := vpcv1.GetInstanceGroupOptions{ID: &instanceGroupID}
-	instanceGroup, response, err := sess.GetInstanceGroup(&getInstanceGroupOptions)
+	instanceGroup, response, err := sess.GetInstanceGroupWithContext(ctx, &getInstanceGroupOptions)
 	if err != nil || instanceGroup == nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Getting InstanceGroup: %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error Getting InstanceGroup", err, response))
 	}
 	d.Set("name", *instanceGroup.Name)
 	d.Set("instance_template", *instanceGroup.InstanceTemplate.ID)
@@ -401,21 +412,21 @@ func resourceIBMISInstanceGroupRead(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func getLBStatus(sess *vpcv1.VpcV1, lbId string) (string, error) {
+func getLBStatus(ctx context.Context, sess *vpcv1.VpcV1, lbId string) (string, error) {
 	getlboptions := &vpcv1.GetLoadBalancerOptions{
 		ID: &lbId,
 	}
-	lb, response, err := sess.GetLoadBalancer(getlboptions)
+	lb, response, err := sess.GetLoadBalancerWithContext(ctx, getlboptions)
 	if err != nil || lb == nil {
-		return "", fmt.Errorf("Error Getting Load Balancer : %s\n%s", err, response)
+		return "", newAPIError("Error Getting Load Balancer", err, response)
 	}
 	return *lb.ProvisioningStatus, nil
 }
 
-func resourceIBMISInstanceGroupDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	instanceGroupID := d.Id()
 
@@ -424,12 +435,12 @@ func resourceIBMISInstanceGroupDelete(d *schema.ResourceData, meta interface{})
 
 	// First, get the instance
 	igOpts := vpcv1.GetInstanceGroupOptions{ID: &instanceGroupID}
-	instanceGroup, response, err := sess.GetInstanceGroup(&igOpts)
+	instanceGroup, response, err := sess.GetInstanceGroupWithContext(ctx, &igOpts)
 	if err != nil || instanceGroup == nil {
 		if response != nil && response.StatusCode == 404 {
-			return fmt.Errorf("Instance Group with id:[%s] not found!!", instanceGroupID)
+			return diagFromErr(fmt.Errorf("Instance Group with id:[%s] not found!!", instanceGroupID))
 		}
-		return fmt.Errorf("Internal Error fetching info for instance group [%s]", instanceGroupID)
+		return diagFromErr(fmt.Errorf("Internal Error fetching info for instance group [%s]", instanceGroupID))
 	}
 	// Inorder to delete instance group, need to update membership count to 0
 	zeroMembers := int64(0)
@@ -439,18 +450,33 @@ func resourceIBMISInstanceGroupDelete(d *schema.ResourceData, meta interface{})
 	instanceGroupPatchModel.MembershipCount = &zeroMembers
 	instanceGroupPatch, err := instanceGroupPatchModel.AsPatch()
 	if err != nil {
-		return fmt.Errorf("Error calling asPatch for ImagePatch: %s", err)
+		return diagFromErr(fmt.Errorf("Error calling asPatch for ImagePatch: %s", err))
 	}
 
 	instanceGroupUpdateOptions.ID = &instanceGroupID
 	instanceGroupUpdateOptions.InstanceGroupPatch = instanceGroupPatch
-	_, response, err = sess.UpdateInstanceGroup(&instanceGroupUpdateOptions)
+	_, response, err = sess.UpdateInstanceGroupWithContext(ctx, &instanceGroupUpdateOptions)
 	if err != nil {
-		return fmt.Errorf("Error updating instanceGroup's instance count to 0 : %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error updating instanceGroup's instance count to 0", err, response))
+	}
+	var steps []escalationStep
+	if d.Get("force_delete_memberships").(bool) {
+		steps = append(steps, escalationStep{
+			Name:  "delete memberships",
+			After: isInstanceGroupDeleteMembershipsAfter,
+			Action: func(ctx context.Context) error {
+				options := &vpcv1.DeleteInstanceGroupMembershipsOptions{InstanceGroupID: &instanceGroupID}
+				response, err := sess.DeleteInstanceGroupMembershipsWithContext(ctx, options)
+				if err != nil {
+					return newAPIError("Error deleting the memberships of the instance group", err, response)
+				}
+				return nil
+			},
+		})
 	}
-	_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutUpdate))
+	_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutUpdate), steps...)
 	if healthError != nil {
-		return healthError
+		return diagFromErr(healthError)
 	}
 
 	// If there is any load balancer, please check if it is active
@@ -460,61 +486,46 @@ func resourceIBMISInstanceGroupDelete(d *schema.ResourceData, meta interface{})
 		loadBalancerID := strings.Split(loadBalancerPool, "/")[5]
 
 		// Now check if the load balancer is in active state or not
-		lbStatus, err := getLBStatus(sess, loadBalancerID)
+		lbStatus, err := getLBStatus(ctx, sess, loadBalancerID)
 		if err != nil {
-			return err
+			return diagFromErr(err)
 		}
 		if lbStatus != "active" {
 			log.Printf("Load Balancer [%s] is not active....Waiting it to be active!\n", loadBalancerID)
 			_, err := isWaitForLBAvailable(sess, loadBalancerID, d.Timeout(schema.TimeoutDelete))
 			if err != nil {
-				return err
+				return diagFromErr(err)
 			}
-			lbStatus, err = getLBStatus(sess, loadBalancerID)
+			lbStatus, err = getLBStatus(ctx, sess, loadBalancerID)
 			if err != nil {
-				return err
+				return diagFromErr(err)
 			}
 			if lbStatus != "active" {
-				return fmt.Errorf("LoadBalancer [%s] is not active yet! Current Load Balancer status is [%s]", loadBalancerID, lbStatus)
+				return diagFromErr(fmt.Errorf("LoadBalancer [%s] is not active yet! Current Load Balancer status is [%s]", loadBalancerID, lbStatus))
 			}
 		}
 	}
 
 	deleteInstanceGroupOptions := vpcv1.DeleteInstanceGroupOptions{ID: &instanceGroupID}
-	response, Err := sess.DeleteInstanceGroup(&deleteInstanceGroupOptions)
+	response, Err := sess.DeleteInstanceGroupWithContext(ctx, &deleteInstanceGroupOptions)
 	if Err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Deleting the InstanceGroup: %s\n%s", Err, response)
+		return diagFromErr(newAPIError("Error Deleting the InstanceGroup", Err, response))
 	}
 
-	_, deleteError := waitForInstanceGroupDelete(d, meta)
+	_, deleteError := waitForInstanceGroupDelete(ctx, d, meta)
 	if deleteError != nil {
-		return deleteError
+		return diagFromErr(deleteError)
 	}
 	return nil
 }
 
-func resourceIBMISInstanceGroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	sess, err := vpcClient(meta)
-	if err != nil {
-		return false, err
-	}
-	instanceGroupID := d.Id()
-	getInstanceGroupOptions := vpcv1.GetInstanceGroupOptions{ID: &instanceGroupID}
-	_, response, err := sess.GetInstanceGroup(&getInstanceGroupOptions)
-	if err != nil {
-		if response != nil && response.StatusCode == 404 {
-			return false, nil
-		}
-		return false, fmt.Errorf("Error Getting InstanceGroup: %s\n%s", err, response)
-	}
-	return true, nil
-}
-
-func waitForHealthyInstanceGroup(instanceGroupID string, meta interface{}, timeout time.Duration) (interface{}, error) {
+// waitForHealthyInstanceGroup waits for the instance group to be healthy,
+// running the recovery steps while it is scaling.
+func waitForHealthyInstanceGroup(ctx context.Context, instanceGroupID string, meta interface{}, timeout time.Duration, steps ...escalationStep) (interface{}, error) {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return nil, err
@@ -522,13 +533,13 @@ func waitForHealthyInstanceGroup(instanceGroupID string, meta interface{}, timeo
 
 	getInstanceGroupOptions := vpcv1.GetInstanceGroupOptions{ID: &instanceGroupID}
 
-	healthStateConf := &resource.StateChangeConf{
+	wait := &escalatingWait{
 		Pending: []string{SCALING},
 		Target:  []string{HEALTHY},
 		Refresh: func() (interface{}, string, error) {
-			instanceGroup, response, err := sess.GetInstanceGroup(&getInstanceGroupOptions)
+			instanceGroup, response, err := sess.GetInstanceGroupWithContext(ctx, &getInstanceGroupOptions)
 			if err != nil || instanceGroup == nil {
-				return nil, SCALING, fmt.Errorf("Error Getting InstanceGroup: %s\n%s", err, response)
+				return nil, SCALING, newAPIError("Error Getting InstanceGroup", err, response)
 			}
 			log.Println("Status : ", *instanceGroup.Status)
 
@@ -541,22 +552,36 @@ func waitForHealthyInstanceGroup(instanceGroupID string, meta interface{}, timeo
 		Delay:        20 * time.Second,
 		MinTimeout:   5 * time.Second,
 		PollInterval: 10 * time.Second,
+		Steps:        steps,
 	}
 
-	return healthStateConf.WaitForState()
-
+	instanceGroup, step, err := wait.WaitForStateContext(ctx)
+	if step != "" {
+		log.Printf("[INFO] Instance group (%s) is healthy after the %s recovery step", instanceGroupID, step)
+	}
+	return instanceGroup, err
 }
 
-func waitForInstanceGroupDelete(d *schema.ResourceData, meta interface{}) (interface{}, error) {
+func waitForInstanceGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (interface{}, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	instanceGroupID := d.Id()
+	getInstanceGroupOptions := vpcv1.GetInstanceGroupOptions{ID: &instanceGroupID}
+
 	healthStateConf := &resource.StateChangeConf{
 		Pending: []string{HEALTHY},
 		Target:  []string{DELETING},
 		Refresh: func() (interface{}, string, error) {
-			resp, err := resourceIBMISInstanceGroupExists(d, meta)
-			if resp {
-				return resp, HEALTHY, nil
+			_, response, err := sess.GetInstanceGroupWithContext(ctx, &getInstanceGroupOptions)
+			if err != nil {
+				if response != nil && response.StatusCode == 404 {
+					return false, DELETING, nil
+				}
+				return false, DELETING, newAPIError("Error Getting InstanceGroup", err, response)
 			}
-			return resp, DELETING, err
+			return true, HEALTHY, nil
 		},
 		Timeout:      d.Timeout(schema.TimeoutDelete),
 		Delay:        20 * time.Second,
@@ -564,6 +589,6 @@ func waitForInstanceGroupDelete(d *schema.ResourceData, meta interface{}) (inter
 		PollInterval: 10 * time.Second,
 	}
 
-	return healthStateConf.WaitForState()
+	return healthStateConf.WaitForStateContext(ctx)
 
 }
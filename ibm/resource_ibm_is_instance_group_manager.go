@@ -4,20 +4,22 @@
 package ibm
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceIBMISInstanceGroupManager() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMISInstanceGroupManagerCreate,
-		Read:     resourceIBMISInstanceGroupManagerRead,
-		Update:   resourceIBMISInstanceGroupManagerUpdate,
-		Delete:   resourceIBMISInstanceGroupManagerDelete,
-		Importer: &schema.ResourceImporter{},
+		CreateContext: resourceIBMISInstanceGroupManagerCreate,
+		ReadContext:   resourceIBMISInstanceGroupManagerRead,
+		UpdateContext: resourceIBMISInstanceGroupManagerUpdate,
+		DeleteContext: resourceIBMISInstanceGroupManagerDelete,
+		Importer:      importIDParts("/", "instance_group_id", "manager_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -177,14 +179,14 @@ func resourceIBMISInstanceGroupManagerValidator() *ResourceValidator {
 	return &ibmISInstanceGroupManagerResourceValidator
 }
 
-func resourceIBMISInstanceGroupManagerCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	instanceGroupID := d.Get("instance_group").(string)
 	managerType := d.Get("manager_type").(string)
 
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	if managerType == "scheduled" {
@@ -205,9 +207,9 @@ func resourceIBMISInstanceGroupManagerCreate(d *schema.ResourceData, meta interf
 			InstanceGroupID:               &instanceGroupID,
 			InstanceGroupManagerPrototype: &instanceGroupManagerPrototype,
 		}
-		instanceGroupManagerIntf, response, err := sess.CreateInstanceGroupManager(&createInstanceGroupManagerOptions)
+		instanceGroupManagerIntf, response, err := sess.CreateInstanceGroupManagerWithContext(ctx, &createInstanceGroupManagerOptions)
 		if err != nil || instanceGroupManagerIntf == nil {
-			return fmt.Errorf("Error creating InstanceGroup manager: %s\n%s", err, response)
+			return diagFromErr(newAPIError("Error creating InstanceGroup manager", err, response))
 		}
 		instanceGroupManager := instanceGroupManagerIntf.(*vpcv1.InstanceGroupManager)
 		d.SetId(fmt.Sprintf("%s/%s", instanceGroupID, *instanceGroupManager.ID))
@@ -252,14 +254,14 @@ func resourceIBMISInstanceGroupManagerCreate(d *schema.ResourceData, meta interf
 			InstanceGroupManagerPrototype: &instanceGroupManagerPrototype,
 		}
 
-		_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutCreate))
+		_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutCreate))
 		if healthError != nil {
-			return healthError
+			return diagFromErr(healthError)
 		}
 
-		instanceGroupManagerIntf, response, err := sess.CreateInstanceGroupManager(&createInstanceGroupManagerOptions)
+		instanceGroupManagerIntf, response, err := sess.CreateInstanceGroupManagerWithContext(ctx, &createInstanceGroupManagerOptions)
 		if err != nil || instanceGroupManagerIntf == nil {
-			return fmt.Errorf("Error creating InstanceGroup manager: %s\n%s", err, response)
+			return diagFromErr(newAPIError("Error creating InstanceGroup manager", err, response))
 		}
 		instanceGroupManager := instanceGroupManagerIntf.(*vpcv1.InstanceGroupManager)
 
@@ -267,14 +269,14 @@ func resourceIBMISInstanceGroupManagerCreate(d *schema.ResourceData, meta interf
 
 	}
 
-	return resourceIBMISInstanceGroupManagerRead(d, meta)
+	return resourceIBMISInstanceGroupManagerRead(ctx, d, meta)
 
 }
 
-func resourceIBMISInstanceGroupManagerUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	managerType := d.Get("manager_type").(string)
@@ -323,7 +325,7 @@ func resourceIBMISInstanceGroupManagerUpdate(d *schema.ResourceData, meta interf
 	if changed {
 		parts, err := idParts(d.Id())
 		if err != nil {
-			return err
+			return diagFromErr(err)
 		}
 		instanceGroupID := parts[0]
 		instanceGroupManagerID := parts[1]
@@ -331,32 +333,32 @@ func resourceIBMISInstanceGroupManagerUpdate(d *schema.ResourceData, meta interf
 		updateInstanceGroupManagerOptions.InstanceGroupID = &instanceGroupID
 		instanceGroupManagerPatch, err := instanceGroupManagerPatchModel.AsPatch()
 		if err != nil {
-			return fmt.Errorf("Error calling asPatch for InstanceGroupManagerPatch: %s", err)
+			return diagFromErr(fmt.Errorf("Error calling asPatch for InstanceGroupManagerPatch: %s", err))
 		}
 		updateInstanceGroupManagerOptions.InstanceGroupManagerPatch = instanceGroupManagerPatch
 
-		_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutUpdate))
+		_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutUpdate))
 		if healthError != nil {
-			return healthError
+			return diagFromErr(healthError)
 		}
 
-		_, response, err := sess.UpdateInstanceGroupManager(&updateInstanceGroupManagerOptions)
+		_, response, err := sess.UpdateInstanceGroupManagerWithContext(ctx, &updateInstanceGroupManagerOptions)
 		if err != nil {
-			return fmt.Errorf("Error updating InstanceGroup manager: %s\n%s", err, response)
+			return diagFromErr(newAPIError("Error updating InstanceGroup manager", err, response))
 		}
 	}
-	return resourceIBMISInstanceGroupManagerRead(d, meta)
+	return resourceIBMISInstanceGroupManagerRead(ctx, d, meta)
 }
 
-func resourceIBMISInstanceGroupManagerRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	parts, err := idParts(d.Id())
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	instanceGroupID := parts[0]
 	instanceGroupManagerID := parts[1]
@@ -365,13 +367,13 @@ func resourceIBMISInstanceGroupManagerRead(d *schema.ResourceData, meta interfac
 		ID:              &instanceGroupManagerID,
 		InstanceGroupID: &instanceGroupID,
 	}
-	instanceGroupManagerIntf, response, err := sess.GetInstanceGroupManager(&getInstanceGroupManagerOptions)
+	instanceGroupManagerIntf, response, err := sess.GetInstanceGroupManagerWithContext(ctx, &getInstanceGroupManagerOptions)
 	if err != nil || instanceGroupManagerIntf == nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Getting InstanceGroup Manager: %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error Getting InstanceGroup Manager", err, response))
 	}
 	instanceGroupManager := instanceGroupManagerIntf.(*vpcv1.InstanceGroupManager)
 
@@ -420,15 +422,15 @@ func resourceIBMISInstanceGroupManagerRead(d *schema.ResourceData, meta interfac
 	return nil
 }
 
-func resourceIBMISInstanceGroupManagerDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	parts, err := idParts(d.Id())
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	instanceGroupID := parts[0]
 	instanceGroupManagerID := parts[1]
@@ -438,18 +440,18 @@ func resourceIBMISInstanceGroupManagerDelete(d *schema.ResourceData, meta interf
 		InstanceGroupID: &instanceGroupID,
 	}
 
-	_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutDelete))
+	_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutDelete))
 	if healthError != nil {
-		return healthError
+		return diagFromErr(healthError)
 	}
 
-	response, err := sess.DeleteInstanceGroupManager(&deleteInstanceGroupManagerOptions)
+	response, err := sess.DeleteInstanceGroupManagerWithContext(ctx, &deleteInstanceGroupManagerOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Deleting the InstanceGroup Manager: %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error Deleting the InstanceGroup Manager", err, response))
 	}
 	return nil
 }
@@ -4,22 +4,23 @@
 package ibm
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceIBMISInstanceGroupManagerAction() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMISInstanceGroupManagerActionCreate,
-		Read:     resourceIBMISInstanceGroupManagerActionRead,
-		Update:   resourceIBMISInstanceGroupManagerActionUpdate,
-		Delete:   resourceIBMISInstanceGroupManagerActionDelete,
-		Exists:   resourceIBMISInstanceGroupManagerActionExists,
-		Importer: &schema.ResourceImporter{},
+		CreateContext: resourceIBMISInstanceGroupManagerActionCreate,
+		ReadContext:   resourceIBMISInstanceGroupManagerActionRead,
+		UpdateContext: resourceIBMISInstanceGroupManagerActionUpdate,
+		DeleteContext: resourceIBMISInstanceGroupManagerActionDelete,
+		Importer:      importIDParts("/", "instance_group_id", "manager_id", "action_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -204,13 +205,13 @@ func resourceIBMISInstanceGroupManagerActionValidator() *ResourceValidator {
 	return &ibmISInstanceGroupManagerResourceValidator
 }
 
-func resourceIBMISInstanceGroupManagerActionCreate(d *schema.ResourceData, meta interface{}) error { // CreateInstanceGroupManagerAction
+func resourceIBMISInstanceGroupManagerActionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics { // CreateInstanceGroupManagerAction
 	instanceGroupID := d.Get("instance_group").(string)
 	instancegroupmanagerscheduledID := d.Get("instance_group_manager").(string)
 
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	instanceGroupManagerActionOptions := vpcv1.CreateInstanceGroupManagerActionOptions{}
@@ -228,7 +229,7 @@ func resourceIBMISInstanceGroupManagerActionCreate(d *schema.ResourceData, meta
 		runat := v.(string)
 		datetime, err := strfmt.ParseDateTime(runat)
 		if err != nil {
-			return fmt.Errorf("error in converting run_at to datetime format %s", err)
+			return diagFromErr(fmt.Errorf("error in converting run_at to datetime format %s", err))
 		}
 		instanceGroupManagerActionPrototype.RunAt = &datetime
 	}
@@ -264,27 +265,27 @@ func resourceIBMISInstanceGroupManagerActionCreate(d *schema.ResourceData, meta
 
 	instanceGroupManagerActionOptions.InstanceGroupManagerActionPrototype = &instanceGroupManagerActionPrototype
 
-	_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutCreate))
+	_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutCreate))
 	if healthError != nil {
-		return healthError
+		return diagFromErr(healthError)
 	}
 
-	instanceGroupManagerActionIntf, response, err := sess.CreateInstanceGroupManagerAction(&instanceGroupManagerActionOptions)
+	instanceGroupManagerActionIntf, response, err := sess.CreateInstanceGroupManagerActionWithContext(ctx, &instanceGroupManagerActionOptions)
 	if err != nil || instanceGroupManagerActionIntf == nil {
-		return fmt.Errorf("error creating InstanceGroup manager Action: %s\n%s", err, response)
+		return diagFromErr(newAPIError("error creating InstanceGroup manager Action", err, response))
 	}
 	instanceGroupManagerAction := instanceGroupManagerActionIntf.(*vpcv1.InstanceGroupManagerAction)
 	d.SetId(fmt.Sprintf("%s/%s/%s", instanceGroupID, instancegroupmanagerscheduledID, *instanceGroupManagerAction.ID))
 
-	return resourceIBMISInstanceGroupManagerActionRead(d, meta)
+	return resourceIBMISInstanceGroupManagerActionRead(ctx, d, meta)
 
 }
 
-func resourceIBMISInstanceGroupManagerActionUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerActionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	var changed bool
@@ -306,7 +307,7 @@ func resourceIBMISInstanceGroupManagerActionUpdate(d *schema.ResourceData, meta
 		runat := d.Get("run_at").(string)
 		datetime, err := strfmt.ParseDateTime(runat)
 		if err != nil {
-			return fmt.Errorf("error in converting run_at to datetime format %s", err)
+			return diagFromErr(fmt.Errorf("error in converting run_at to datetime format %s", err))
 		}
 		instanceGroupManagerActionPatchModel.RunAt = &datetime
 		changed = true
@@ -339,7 +340,7 @@ func resourceIBMISInstanceGroupManagerActionUpdate(d *schema.ResourceData, meta
 
 		parts, err := idParts(d.Id())
 		if err != nil {
-			return err
+			return diagFromErr(err)
 		}
 
 		instanceGroupID := parts[0]
@@ -353,31 +354,31 @@ func resourceIBMISInstanceGroupManagerActionUpdate(d *schema.ResourceData, meta
 
 		instanceGroupManagerActionPatch, err := instanceGroupManagerActionPatchModel.AsPatch()
 		if err != nil {
-			return fmt.Errorf("error calling asPatch for instanceGroupManagerActionPatch: %s", err)
+			return diagFromErr(fmt.Errorf("error calling asPatch for instanceGroupManagerActionPatch: %s", err))
 		}
 		updateInstanceGroupManagerActionOptions.InstanceGroupManagerActionPatch = instanceGroupManagerActionPatch
 
-		_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutUpdate))
+		_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutUpdate))
 		if healthError != nil {
-			return healthError
+			return diagFromErr(healthError)
 		}
-		_, response, err := sess.UpdateInstanceGroupManagerAction(updateInstanceGroupManagerActionOptions)
+		_, response, err := sess.UpdateInstanceGroupManagerActionWithContext(ctx, updateInstanceGroupManagerActionOptions)
 		if err != nil {
-			return fmt.Errorf("error updating InstanceGroup manager action: %s\n%s", err, response)
+			return diagFromErr(newAPIError("error updating InstanceGroup manager action", err, response))
 		}
 	}
-	return resourceIBMISInstanceGroupManagerRead(d, meta)
+	return resourceIBMISInstanceGroupManagerActionRead(ctx, d, meta)
 }
 
-func resourceIBMISInstanceGroupManagerActionRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerActionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	parts, err := idParts(d.Id())
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	instanceGroupID := parts[0]
 	instancegroupmanagerscheduledID := parts[1]
@@ -389,61 +390,61 @@ func resourceIBMISInstanceGroupManagerActionRead(d *schema.ResourceData, meta in
 		ID:                     &instanceGroupManagerActionID,
 	}
 
-	instanceGroupManagerActionIntf, response, err := sess.GetInstanceGroupManagerAction(getInstanceGroupManagerActionOptions)
+	instanceGroupManagerActionIntf, response, err := sess.GetInstanceGroupManagerActionWithContext(ctx, getInstanceGroupManagerActionOptions)
 	if err != nil || instanceGroupManagerActionIntf == nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("error Getting InstanceGroup Manager Action: %s\n%s", err, response)
+		return diagFromErr(newAPIError("error Getting InstanceGroup Manager Action", err, response))
 	}
 	instanceGroupManagerAction := instanceGroupManagerActionIntf.(*vpcv1.InstanceGroupManagerAction)
 	if err = d.Set("auto_delete", *instanceGroupManagerAction.AutoDelete); err != nil {
-		return fmt.Errorf("error setting auto_delete: %s", err)
+		return diagFromErr(fmt.Errorf("error setting auto_delete: %s", err))
 	}
 
 	if err = d.Set("auto_delete_timeout", intValue(instanceGroupManagerAction.AutoDeleteTimeout)); err != nil {
-		return fmt.Errorf("error setting auto_delete_timeout: %s", err)
+		return diagFromErr(fmt.Errorf("error setting auto_delete_timeout: %s", err))
 	}
 	if err = d.Set("created_at", instanceGroupManagerAction.CreatedAt.String()); err != nil {
-		return fmt.Errorf("error setting created_at: %s", err)
+		return diagFromErr(fmt.Errorf("error setting created_at: %s", err))
 	}
 
 	if err = d.Set("action_id", *instanceGroupManagerAction.ID); err != nil {
-		return fmt.Errorf("error setting instance_group_manager_action : %s", err)
+		return diagFromErr(fmt.Errorf("error setting instance_group_manager_action : %s", err))
 	}
 
 	if err = d.Set("name", *instanceGroupManagerAction.Name); err != nil {
-		return fmt.Errorf("error setting name: %s", err)
+		return diagFromErr(fmt.Errorf("error setting name: %s", err))
 	}
 	if err = d.Set("resource_type", *instanceGroupManagerAction.ResourceType); err != nil {
-		return fmt.Errorf("error setting resource_type: %s", err)
+		return diagFromErr(fmt.Errorf("error setting resource_type: %s", err))
 	}
 	if err = d.Set("status", *instanceGroupManagerAction.Status); err != nil {
-		return fmt.Errorf("error setting status: %s", err)
+		return diagFromErr(fmt.Errorf("error setting status: %s", err))
 	}
 	if err = d.Set("updated_at", instanceGroupManagerAction.UpdatedAt.String()); err != nil {
-		return fmt.Errorf("error setting updated_at: %s", err)
+		return diagFromErr(fmt.Errorf("error setting updated_at: %s", err))
 	}
 	if err = d.Set("action_type", *instanceGroupManagerAction.ActionType); err != nil {
-		return fmt.Errorf("error setting action_type: %s", err)
+		return diagFromErr(fmt.Errorf("error setting action_type: %s", err))
 	}
 
 	if instanceGroupManagerAction.CronSpec != nil {
 		if err = d.Set("cron_spec", *instanceGroupManagerAction.CronSpec); err != nil {
-			return fmt.Errorf("error setting cron_spec: %s", err)
+			return diagFromErr(fmt.Errorf("error setting cron_spec: %s", err))
 		}
 	}
 
 	if instanceGroupManagerAction.LastAppliedAt != nil {
 		if err = d.Set("last_applied_at", instanceGroupManagerAction.LastAppliedAt.String()); err != nil {
-			return fmt.Errorf("error setting last_applied_at: %s", err)
+			return diagFromErr(fmt.Errorf("error setting last_applied_at: %s", err))
 		}
 	}
 
 	if instanceGroupManagerAction.NextRunAt != nil {
 		if err = d.Set("next_run_at", instanceGroupManagerAction.NextRunAt.String()); err != nil {
-			return fmt.Errorf("error setting next_run_at: %s", err)
+			return diagFromErr(fmt.Errorf("error setting next_run_at: %s", err))
 		}
 	}
 
@@ -468,15 +469,15 @@ func resourceIBMISInstanceGroupManagerActionRead(d *schema.ResourceData, meta in
 	return nil
 }
 
-func resourceIBMISInstanceGroupManagerActionDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerActionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	parts, err := idParts(d.Id())
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	instanceGroupID := parts[0]
 	instancegroupmanagerscheduledID := parts[1]
@@ -487,50 +488,18 @@ func resourceIBMISInstanceGroupManagerActionDelete(d *schema.ResourceData, meta
 	deleteInstanceGroupManagerActionOptions.InstanceGroupManagerID = &instancegroupmanagerscheduledID
 	deleteInstanceGroupManagerActionOptions.ID = &instanceGroupManagerActionID
 
-	_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutDelete))
+	_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutDelete))
 	if healthError != nil {
-		return healthError
+		return diagFromErr(healthError)
 	}
 
-	response, err := sess.DeleteInstanceGroupManagerAction(deleteInstanceGroupManagerActionOptions)
+	response, err := sess.DeleteInstanceGroupManagerActionWithContext(ctx, deleteInstanceGroupManagerActionOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("error Deleting the InstanceGroup Manager Action: %s\n%s", err, response)
+		return diagFromErr(newAPIError("error Deleting the InstanceGroup Manager Action", err, response))
 	}
 	return nil
 }
-
-func resourceIBMISInstanceGroupManagerActionExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-
-	sess, err := vpcClient(meta)
-	if err != nil {
-		return false, err
-	}
-
-	parts, err := idParts(d.Id())
-	if err != nil {
-		return false, err
-	}
-	instanceGroupID := parts[0]
-	instancegroupmanagerscheduledID := parts[1]
-	instanceGroupManagerActionID := parts[2]
-
-	getInstanceGroupManagerActionOptions := &vpcv1.GetInstanceGroupManagerActionOptions{
-		InstanceGroupID:        &instanceGroupID,
-		InstanceGroupManagerID: &instancegroupmanagerscheduledID,
-		ID:                     &instanceGroupManagerActionID,
-	}
-
-	_, response, err := sess.GetInstanceGroupManagerAction(getInstanceGroupManagerActionOptions)
-	if err != nil {
-		if response != nil && response.StatusCode == 404 {
-			return false, nil
-		}
-		return false, fmt.Errorf("error Getting InstanceGroup Manager Action: %s\n%s", err, response)
-	}
-
-	return true, nil
-}
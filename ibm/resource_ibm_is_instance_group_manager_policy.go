@@ -4,20 +4,21 @@
 package ibm
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceIBMISInstanceGroupManagerPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMISInstanceGroupManagerPolicyCreate,
-		Read:     resourceIBMISInstanceGroupManagerPolicyRead,
-		Update:   resourceIBMISInstanceGroupManagerPolicyUpdate,
-		Delete:   resourceIBMISInstanceGroupManagerPolicyDelete,
-		Exists:   resourceIBMISInstanceGroupManagerPolicyExists,
-		Importer: &schema.ResourceImporter{},
+		CreateContext: resourceIBMISInstanceGroupManagerPolicyCreate,
+		ReadContext:   resourceIBMISInstanceGroupManagerPolicyRead,
+		UpdateContext: resourceIBMISInstanceGroupManagerPolicyUpdate,
+		DeleteContext: resourceIBMISInstanceGroupManagerPolicyDelete,
+		Importer:      importIDParts("/", "instance_group_id", "manager_id", "policy_id"),
 
 		Schema: map[string]*schema.Schema{
 
@@ -102,13 +103,13 @@ func resourceIBMISInstanceGroupManagerPolicyValidator() *ResourceValidator {
 	return &ibmISInstanceGroupManagerPolicyResourceValidator
 }
 
-func resourceIBMISInstanceGroupManagerPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceGroupID := d.Get("instance_group").(string)
 	instanceGroupManagerID := d.Get("instance_group_manager").(string)
 
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	instanceGroupManagerPolicyPrototype := vpcv1.InstanceGroupManagerPolicyPrototype{}
@@ -130,30 +131,33 @@ func resourceIBMISInstanceGroupManagerPolicyCreate(d *schema.ResourceData, meta
 	}
 
 	isInsGrpKey := "Instance_Group_Key_" + instanceGroupID
-	ibmMutexKV.Lock(isInsGrpKey)
-	defer ibmMutexKV.Unlock(isInsGrpKey)
+	unlock, err := ibmLocks.Lock(ctx, lockHolder(d, "ibm_is_instance_group_manager_policy create"), isInsGrpKey)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	defer unlock()
 
-	_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutCreate))
+	_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutCreate))
 	if healthError != nil {
-		return healthError
+		return diagFromErr(healthError)
 	}
 
-	data, response, err := sess.CreateInstanceGroupManagerPolicy(&createInstanceGroupManagerPolicyOptions)
+	data, response, err := sess.CreateInstanceGroupManagerPolicyWithContext(ctx, &createInstanceGroupManagerPolicyOptions)
 	if err != nil || data == nil {
-		return fmt.Errorf("Error Creating InstanceGroup Manager Policy: %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error Creating InstanceGroup Manager Policy", err, response))
 	}
 	instanceGroupManagerPolicy := data.(*vpcv1.InstanceGroupManagerPolicy)
 
 	d.SetId(fmt.Sprintf("%s/%s/%s", instanceGroupID, instanceGroupManagerID, *instanceGroupManagerPolicy.ID))
 
-	return resourceIBMISInstanceGroupManagerPolicyRead(d, meta)
+	return resourceIBMISInstanceGroupManagerPolicyRead(ctx, d, meta)
 
 }
 
-func resourceIBMISInstanceGroupManagerPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	var changed bool
@@ -180,7 +184,7 @@ func resourceIBMISInstanceGroupManagerPolicyUpdate(d *schema.ResourceData, meta
 	if changed {
 		parts, err := idParts(d.Id())
 		if err != nil {
-			return err
+			return diagFromErr(err)
 		}
 		instanceGroupID := parts[0]
 		instanceGroupManagerID := parts[1]
@@ -191,31 +195,34 @@ func resourceIBMISInstanceGroupManagerPolicyUpdate(d *schema.ResourceData, meta
 		updateInstanceGroupManagerPolicyOptions.InstanceGroupManagerID = &instanceGroupManagerID
 
 		isInsGrpKey := "Instance_Group_Key_" + instanceGroupID
-		ibmMutexKV.Lock(isInsGrpKey)
-		defer ibmMutexKV.Unlock(isInsGrpKey)
+		unlock, err := ibmLocks.Lock(ctx, lockHolder(d, "ibm_is_instance_group_manager_policy update"), isInsGrpKey)
+		if err != nil {
+			return diagFromErr(err)
+		}
+		defer unlock()
 
-		_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutUpdate))
+		_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutUpdate))
 		if healthError != nil {
-			return healthError
+			return diagFromErr(healthError)
 		}
 
-		_, response, err := sess.UpdateInstanceGroupManagerPolicy(&updateInstanceGroupManagerPolicyOptions)
+		_, response, err := sess.UpdateInstanceGroupManagerPolicyWithContext(ctx, &updateInstanceGroupManagerPolicyOptions)
 		if err != nil {
-			return fmt.Errorf("Error Updating InstanceGroup Manager Policy: %s\n%s", err, response)
+			return diagFromErr(newAPIError("Error Updating InstanceGroup Manager Policy", err, response))
 		}
 	}
-	return resourceIBMISInstanceGroupManagerPolicyRead(d, meta)
+	return resourceIBMISInstanceGroupManagerPolicyRead(ctx, d, meta)
 }
 
-func resourceIBMISInstanceGroupManagerPolicyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	parts, err := idParts(d.Id())
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	instanceGroupID := parts[0]
 	instanceGroupManagerID := parts[1]
@@ -226,13 +233,13 @@ func resourceIBMISInstanceGroupManagerPolicyRead(d *schema.ResourceData, meta in
 		InstanceGroupID:        &instanceGroupID,
 		InstanceGroupManagerID: &instanceGroupManagerID,
 	}
-	data, response, err := sess.GetInstanceGroupManagerPolicy(&getInstanceGroupManagerPolicyOptions)
+	data, response, err := sess.GetInstanceGroupManagerPolicyWithContext(ctx, &getInstanceGroupManagerPolicyOptions)
 	if err != nil || data == nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Getting InstanceGroup Manager Policy: %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error Getting InstanceGroup Manager Policy", err, response))
 	}
 	instanceGroupManagerPolicy := data.(*vpcv1.InstanceGroupManagerPolicy)
 	d.Set("name", *instanceGroupManagerPolicy.Name)
@@ -246,14 +253,14 @@ func resourceIBMISInstanceGroupManagerPolicyRead(d *schema.ResourceData, meta in
 	return nil
 }
 
-func resourceIBMISInstanceGroupManagerPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISInstanceGroupManagerPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sess, err := vpcClient(meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	parts, err := idParts(d.Id())
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	instanceGroupID := parts[0]
 	instanceGroupManagerID := parts[1]
@@ -266,55 +273,24 @@ func resourceIBMISInstanceGroupManagerPolicyDelete(d *schema.ResourceData, meta
 	}
 
 	isInsGrpKey := "Instance_Group_Key_" + instanceGroupID
-	ibmMutexKV.Lock(isInsGrpKey)
-	defer ibmMutexKV.Unlock(isInsGrpKey)
+	unlock, err := ibmLocks.Lock(ctx, lockHolder(d, "ibm_is_instance_group_manager_policy delete"), isInsGrpKey)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	defer unlock()
 
-	_, healthError := waitForHealthyInstanceGroup(instanceGroupID, meta, d.Timeout(schema.TimeoutDelete))
+	_, healthError := waitForHealthyInstanceGroup(ctx, instanceGroupID, meta, d.Timeout(schema.TimeoutDelete))
 	if healthError != nil {
-		return healthError
+		return diagFromErr(healthError)
 	}
 
-	response, err := sess.DeleteInstanceGroupManagerPolicy(&deleteInstanceGroupManagerPolicyOptions)
+	response, err := sess.DeleteInstanceGroupManagerPolicyWithContext(ctx, &deleteInstanceGroupManagerPolicyOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Deleting the InstanceGroup Manager Policy: %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error Deleting the InstanceGroup Manager Policy", err, response))
 	}
 	return nil
 }
-
-func resourceIBMISInstanceGroupManagerPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	sess, err := vpcClient(meta)
-	if err != nil {
-		return false, err
-	}
-
-	parts, err := idParts(d.Id())
-	if err != nil {
-		return false, err
-	}
-
-	if len(parts) != 3 {
-		return false, fmt.Errorf("Incorrect ID %s: ID should be a combination of instanceGroupID/instanceGroupManagerID/instanceGroupManagerPolicyID", d.Id())
-	}
-	instanceGroupID := parts[0]
-	instanceGroupManagerID := parts[1]
-	instanceGroupManagerPolicyID := parts[2]
-
-	getInstanceGroupManagerPolicyOptions := vpcv1.GetInstanceGroupManagerPolicyOptions{
-		ID:                     &instanceGroupManagerPolicyID,
-		InstanceGroupManagerID: &instanceGroupManagerID,
-		InstanceGroupID:        &instanceGroupID,
-	}
-
-	_, response, err := sess.GetInstanceGroupManagerPolicy(&getInstanceGroupManagerPolicyOptions)
-	if err != nil {
-		if response != nil && response.StatusCode == 404 {
-			return false, nil
-		}
-		return false, fmt.Errorf("Error Getting InstanceGroup Manager Policy: %s\n%s", err, response)
-	}
-	return true, nil
-}
@@ -32,7 +32,7 @@ func resourceIBMISInstanceGroupMembership() *schema.Resource {
 		Read:     resourceIBMISInstanceGroupMembershipRead,
 		Update:   resourceIBMISInstanceGroupMembershipUpdate,
 		Delete:   resourceIBMISInstanceGroupMembershipDelete,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "instance_group_id", "membership_id"),
 
 		Schema: map[string]*schema.Schema{
 
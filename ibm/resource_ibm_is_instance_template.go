@@ -58,7 +58,7 @@ func resourceIBMISInstanceTemplate() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			customdiff.Sequence(
 				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-					return resourceTagsCustomizeDiff(diff)
+					return resourceTagsCustomizeDiff(diff, v)
 				},
 			),
 
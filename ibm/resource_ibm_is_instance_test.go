@@ -4,14 +4,22 @@
 package ibm
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	gohttp "net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -724,3 +732,105 @@ func testAccCheckIBMISInstanceVolumeUpdate(vpcname, subnetname, sshname, publicK
 	
 `, vpcname, subnetname, ISZoneName, ISCIDR, sshname, publicKey, volName, ISZoneName, name, isImage, instanceProfileName, ISZoneName)
 }
+
+func TestIsInstanceRecoverySteps(t *testing.T) {
+	cases := map[string]struct {
+		config   map[string]interface{}
+		defaults []string
+		allowed  []string
+		want     []string
+	}{
+		"no recovery": {
+			config:   map[string]interface{}{},
+			defaults: []string{isInstanceRecoveryRestart},
+			allowed:  isInstanceRecoveryStepValues,
+		},
+		"defaults": {
+			config:   map[string]interface{}{"force_recovery_time": 5},
+			defaults: []string{isInstanceRecoveryRestart},
+			allowed:  isInstanceRecoveryStepValues,
+			want:     []string{isInstanceRecoveryRestart},
+		},
+		"configured steps": {
+			config:   map[string]interface{}{"force_recovery_time": 5, isInstanceForceRecoverySteps: []interface{}{"soft_stop", "hard_stop", "restart"}},
+			defaults: []string{isInstanceRecoveryRestart},
+			allowed:  isInstanceRecoveryStepValues,
+			want:     []string{"soft_stop", "hard_stop", "restart"},
+		},
+		"steps of a start": {
+			config:   map[string]interface{}{"force_recovery_time": 5, isInstanceForceRecoverySteps: []interface{}{"soft_stop", "hard_stop", "restart"}},
+			defaults: []string{isInstanceRecoveryRestart},
+			allowed:  []string{isInstanceRecoveryRestart},
+			want:     []string{"restart"},
+		},
+		"no configured step of a start": {
+			config:   map[string]interface{}{"force_recovery_time": 5, isInstanceForceRecoverySteps: []interface{}{"soft_stop", "hard_stop"}},
+			defaults: []string{isInstanceRecoveryRestart},
+			allowed:  []string{isInstanceRecoveryRestart},
+			want:     []string{"restart"},
+		},
+		"steps of a stop": {
+			config:   map[string]interface{}{"force_recovery_time": 5, isInstanceForceRecoverySteps: []interface{}{"soft_stop", "hard_stop", "restart"}},
+			defaults: []string{isInstanceRecoveryHardStop},
+			allowed:  []string{isInstanceRecoverySoftStop, isInstanceRecoveryHardStop},
+			want:     []string{"soft_stop", "hard_stop"},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceIBMISInstance().Schema, c.config)
+			steps := isInstanceRecoverySteps(nil, "0717-1234", d, c.defaults, c.allowed...)
+			var got []string
+			for _, step := range steps {
+				got = append(got, step.Name)
+				if step.After != 5*time.Minute {
+					t.Errorf("Expected the %s step after 5 minutes, got %s", step.Name, step.After)
+				}
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Expected the steps %v, got %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestIsInstanceRecoveryAction(t *testing.T) {
+	var actions []map[string]interface{}
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == gohttp.MethodGet {
+			fmt.Fprint(w, `{"id": "0717-1234", "status": "stopped"}`)
+			return
+		}
+		var action map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&action)
+		actions = append(actions, action)
+		w.WriteHeader(gohttp.StatusCreated)
+		fmt.Fprint(w, `{"id": "action-1", "status": "pending"}`)
+	}))
+	defer server.Close()
+	sess, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string][]map[string]interface{}{
+		isInstanceRecoverySoftStop: {{"type": "stop"}},
+		isInstanceRecoveryHardStop: {{"type": "stop", "force": true}},
+		isInstanceRecoveryRestart:  {{"type": "stop", "force": true}, {"type": "start"}},
+	}
+	for step, want := range cases {
+		t.Run(step, func(t *testing.T) {
+			actions = nil
+			if err := isInstanceRecoveryAction(sess, "0717-1234", step)(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actions, want) {
+				t.Errorf("Expected the actions %v, got %v", want, actions)
+			}
+		})
+	}
+}
@@ -4,12 +4,14 @@
 package ibm
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -31,12 +33,11 @@ const (
 
 func resourceIBMISInstanceVolumeAttachment() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMisInstanceVolumeAttachmentCreate,
-		Read:     resourceIBMisInstanceVolumeAttachmentRead,
-		Update:   resourceIBMisInstanceVolumeAttachmentUpdate,
-		Delete:   resourceIBMisInstanceVolumeAttachmentDelete,
-		Exists:   resourceIBMisInstanceVolumeAttachmentExists,
-		Importer: &schema.ResourceImporter{},
+		CreateContext: resourceIBMisInstanceVolumeAttachmentCreate,
+		ReadContext:   resourceIBMisInstanceVolumeAttachmentRead,
+		UpdateContext: resourceIBMisInstanceVolumeAttachmentUpdate,
+		DeleteContext: resourceIBMisInstanceVolumeAttachmentDelete,
+		Importer:      &schema.ResourceImporter{},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -208,7 +209,7 @@ func resourceIBMISInstanceVolumeAttachmentValidator() *ResourceValidator {
 	return &ibmISInstanceVolumeAttachmentValidator
 }
 
-func instanceVolAttachmentCreate(d *schema.ResourceData, meta interface{}, instanceId string) error {
+func instanceVolAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, instanceId string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -252,7 +253,7 @@ func instanceVolAttachmentCreate(d *schema.ResourceData, meta interface{}, insta
 		}
 		var snapCapacity int64
 		if volSnapshotStr != "" {
-			snapshotGet, _, err := sess.GetSnapshot(&vpcv1.GetSnapshotOptions{
+			snapshotGet, _, err := sess.GetSnapshotWithContext(ctx, &vpcv1.GetSnapshotOptions{
 				ID: &volSnapshotStr,
 			})
 			if err != nil {
@@ -286,13 +287,13 @@ func instanceVolAttachmentCreate(d *schema.ResourceData, meta interface{}, insta
 		instanceVolAttproto.Name = &namestr
 	}
 
-	instanceVolAtt, response, err := sess.CreateInstanceVolumeAttachment(instanceVolAttproto)
+	instanceVolAtt, response, err := sess.CreateInstanceVolumeAttachmentWithContext(ctx, instanceVolAttproto)
 	if err != nil {
 		log.Printf("[DEBUG] Instance volume attachment create err %s\n%s", err, response)
 		return fmt.Errorf("Error while attaching volume for instance %s: %q", instanceId, err)
 	}
 	d.SetId(makeTerraformVolAttID(instanceId, *instanceVolAtt.ID))
-	_, err = isWaitForInstanceVolumeAttached(sess, d, instanceId, *instanceVolAtt.ID)
+	_, err = isWaitForInstanceVolumeAttached(ctx, sess, d, instanceId, *instanceVolAtt.ID)
 	if err != nil {
 		return err
 	}
@@ -300,28 +301,28 @@ func instanceVolAttachmentCreate(d *schema.ResourceData, meta interface{}, insta
 	return nil
 }
 
-func resourceIBMisInstanceVolumeAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMisInstanceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceId := d.Get(isInstanceId).(string)
-	err := instanceVolAttachmentCreate(d, meta, instanceId)
+	err := instanceVolAttachmentCreate(ctx, d, meta, instanceId)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	return resourceIBMisInstanceVolumeAttachmentRead(d, meta)
+	return resourceIBMisInstanceVolumeAttachmentRead(ctx, d, meta)
 }
 
-func resourceIBMisInstanceVolumeAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMisInstanceVolumeAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceID, id, err := parseVolAttTerraformID(d.Id())
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	err = instanceVolumeAttachmentGet(d, meta, instanceID, id)
+	err = instanceVolumeAttachmentGet(ctx, d, meta, instanceID, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	return nil
 }
 
-func instanceVolumeAttachmentGet(d *schema.ResourceData, meta interface{}, instanceId, id string) error {
+func instanceVolumeAttachmentGet(ctx context.Context, d *schema.ResourceData, meta interface{}, instanceId, id string) error {
 	instanceC, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -331,13 +332,13 @@ func instanceVolumeAttachmentGet(d *schema.ResourceData, meta interface{}, insta
 		InstanceID: &instanceId,
 		ID:         &id,
 	}
-	volumeAtt, response, err := instanceC.GetInstanceVolumeAttachment(getinsVolAttOptions)
+	volumeAtt, response, err := instanceC.GetInstanceVolumeAttachmentWithContext(ctx, getinsVolAttOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error getting Instance volume attachment : %s\n%s", err, response)
+		return newAPIError("Error getting Instance volume attachment", err, response)
 	}
 	d.Set(isInstanceId, instanceId)
 
@@ -363,7 +364,7 @@ func instanceVolumeAttachmentGet(d *schema.ResourceData, meta interface{}, insta
 	getVolOptions := &vpcv1.GetVolumeOptions{
 		ID: &volId,
 	}
-	volumeDetail, _, err := instanceC.GetVolume(getVolOptions)
+	volumeDetail, _, err := instanceC.GetVolumeWithContext(ctx, getVolOptions)
 	if err != nil || volumeDetail == nil {
 		return fmt.Errorf("Error while getting volume details of volume %s ", id)
 	}
@@ -381,7 +382,7 @@ func instanceVolumeAttachmentGet(d *schema.ResourceData, meta interface{}, insta
 	return nil
 }
 
-func instanceVolAttUpdate(d *schema.ResourceData, meta interface{}) error {
+func instanceVolAttUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	instanceC, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -414,7 +415,7 @@ func instanceVolAttUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 		updateInstanceVolAttOptions.VolumeAttachmentPatch = volAttPatchModelAsPatch
 
-		instanceVolAttUpdate, response, err := instanceC.UpdateInstanceVolumeAttachment(updateInstanceVolAttOptions)
+		instanceVolAttUpdate, response, err := instanceC.UpdateInstanceVolumeAttachmentWithContext(ctx, updateInstanceVolAttOptions)
 		if err != nil || instanceVolAttUpdate == nil {
 			log.Printf("[DEBUG] Instance volume attachment creation err %s\n%s", err, response)
 			return err
@@ -423,16 +424,16 @@ func instanceVolAttUpdate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceIBMisInstanceVolumeAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMisInstanceVolumeAttachmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	err := instanceVolAttUpdate(d, meta)
+	err := instanceVolAttUpdate(ctx, d, meta)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	return resourceIBMisInstanceVolumeAttachmentRead(d, meta)
+	return resourceIBMisInstanceVolumeAttachmentRead(ctx, d, meta)
 }
 
-func instanceVolAttDelete(d *schema.ResourceData, meta interface{}, instanceId, id, volId string, volDelete bool) error {
+func instanceVolAttDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, instanceId, id, volId string, volDelete bool) error {
 	instanceC, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -441,8 +442,8 @@ func instanceVolAttDelete(d *schema.ResourceData, meta interface{}, instanceId,
 		InstanceID: &instanceId,
 		ID:         &id,
 	}
-	_, err = instanceC.DeleteInstanceVolumeAttachment(deleteInstanceVolAttOptions)
-	_, err = isWaitForInstanceVolumeDetached(instanceC, d, instanceId, id)
+	_, err = instanceC.DeleteInstanceVolumeAttachmentWithContext(ctx, deleteInstanceVolAttOptions)
+	_, err = isWaitForInstanceVolumeDetached(ctx, instanceC, d, instanceId, id)
 	if err != nil {
 		return fmt.Errorf("Error while deleting volume attachment (%s) from instance (%s) : %q", id, instanceId, err)
 	}
@@ -450,11 +451,11 @@ func instanceVolAttDelete(d *schema.ResourceData, meta interface{}, instanceId,
 		deleteVolumeOptions := &vpcv1.DeleteVolumeOptions{
 			ID: &volId,
 		}
-		response, err := instanceC.DeleteVolume(deleteVolumeOptions)
+		response, err := instanceC.DeleteVolumeWithContext(ctx, deleteVolumeOptions)
 		if err != nil {
-			return fmt.Errorf("Error while deleting volume : %s\n%s", err, response)
+			return newAPIError("Error while deleting volume", err, response)
 		}
-		_, err = isWaitForVolumeDeleted(instanceC, volId, d.Timeout(schema.TimeoutDelete))
+		_, err = isWaitForVolumeDeleted(ctx, instanceC, volId, d.Timeout(schema.TimeoutDelete), meta)
 		if err != nil {
 			return err
 		}
@@ -462,10 +463,10 @@ func instanceVolAttDelete(d *schema.ResourceData, meta interface{}, instanceId,
 	return nil
 }
 
-func resourceIBMisInstanceVolumeAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMisInstanceVolumeAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceId, id, err := parseVolAttTerraformID(d.Id())
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	volDelete := false
 	if volDeleteOk, ok := d.GetOk(isInstanceVolumeDeleteOnAttachmentDelete); ok {
@@ -475,43 +476,14 @@ func resourceIBMisInstanceVolumeAttachmentDelete(d *schema.ResourceData, meta in
 	if volIdOk, ok := d.GetOk(isInstanceVolAttVol); ok {
 		volId = volIdOk.(string)
 	}
-	err = instanceVolAttDelete(d, meta, instanceId, id, volId, volDelete)
+	err = instanceVolAttDelete(ctx, d, meta, instanceId, id, volId, volDelete)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	d.SetId("")
 	return nil
 }
 
-func resourceIBMisInstanceVolumeAttachmentExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-
-	instanceId, id, err := parseVolAttTerraformID(d.Id())
-	if err != nil {
-		return false, err
-	}
-	exists, err := instanceVolAttExists(d, meta, instanceId, id)
-	return exists, err
-}
-
-func instanceVolAttExists(d *schema.ResourceData, meta interface{}, instanceId, id string) (bool, error) {
-	instanceC, err := vpcClient(meta)
-	if err != nil {
-		return false, err
-	}
-	getinsvolattOptions := &vpcv1.GetInstanceVolumeAttachmentOptions{
-		InstanceID: &instanceId,
-		ID:         &id,
-	}
-	_, response, err := instanceC.GetInstanceVolumeAttachment(getinsvolattOptions)
-	if err != nil {
-		if response != nil && response.StatusCode == 404 {
-			return false, nil
-		}
-		return false, fmt.Errorf("Error getting Instance volume attachment: %s\n%s", err, response)
-	}
-	return true, nil
-}
-
 func makeTerraformVolAttID(id1, id2 string) string {
 	// Include both instance id and volume attachment to create a unique Terraform id.  As a bonus,
 	// we can extract the instance id as needed for API calls such as READ.
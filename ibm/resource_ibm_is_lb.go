@@ -55,7 +55,7 @@ func resourceIBMISLB() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -331,7 +331,7 @@ func lbCreate(d *schema.ResourceData, meta interface{}, name, lbType, rg string,
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isLBTags); ok || v != "" {
+	if _, ok := d.GetOk(isLBTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isLBTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *lb.CRN)
 		if err != nil {
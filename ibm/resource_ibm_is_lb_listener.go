@@ -37,7 +37,7 @@ func resourceIBMISLBListener() *schema.Resource {
 		Update:   resourceIBMISLBListenerUpdate,
 		Delete:   resourceIBMISLBListenerDelete,
 		Exists:   resourceIBMISLBListenerExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "lb_id", "listener_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -176,10 +176,13 @@ func resourceIBMISLBListenerCreate(d *schema.ResourceData, meta interface{}) err
 	}
 
 	isLBKey := "load_balancer_key_" + lbID
-	ibmMutexKV.Lock(isLBKey)
-	defer ibmMutexKV.Unlock(isLBKey)
+	unlock, err := acquireLocks(d, schema.TimeoutCreate, "ibm_is_lb_listener create", []string{isLBKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	err := lbListenerCreate(d, meta, lbID, protocol, defPool, certificateCRN, port, connLimit, acceptProxyProtocol)
+	err = lbListenerCreate(d, meta, lbID, protocol, defPool, certificateCRN, port, connLimit, acceptProxyProtocol)
 	if err != nil {
 		return err
 	}
@@ -417,8 +420,11 @@ func lbListenerUpdate(d *schema.ResourceData, meta interface{}, lbID, lbListener
 		updateLoadBalancerListenerOptions.LoadBalancerListenerPatch = loadBalancerListenerPatch
 
 		isLBKey := "load_balancer_key_" + lbID
-		ibmMutexKV.Lock(isLBKey)
-		defer ibmMutexKV.Unlock(isLBKey)
+		unlock, err := acquireLocks(d, schema.TimeoutUpdate, "ibm_is_lb_listener update", []string{isLBKey}, nil)
+		if err != nil {
+			return err
+		}
+		defer unlock()
 
 		_, err = isWaitForLBAvailable(sess, lbID, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
@@ -456,8 +462,11 @@ func resourceIBMISLBListenerDelete(d *schema.ResourceData, meta interface{}) err
 	lbListenerID := parts[1]
 
 	isLBKey := "load_balancer_key_" + lbID
-	ibmMutexKV.Lock(isLBKey)
-	defer ibmMutexKV.Unlock(isLBKey)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_lb_listener delete", []string{isLBKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	err = lbListenerDelete(d, meta, lbID, lbListenerID)
 	if err != nil {
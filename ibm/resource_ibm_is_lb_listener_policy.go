@@ -49,7 +49,7 @@ func resourceIBMISLBListenerPolicy() *schema.Resource {
 		Update:   resourceIBMISLBListenerPolicyUpdate,
 		Delete:   resourceIBMISLBListenerPolicyDelete,
 		Exists:   resourceIBMISLBListenerPolicyExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "lb_id", "listener_id", "policy_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -57,6 +57,8 @@ func resourceIBMISLBListenerPolicy() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceValidatorCustomizeDiff("ibm_is_lb_listener_policy"),
+
 		Schema: map[string]*schema.Schema{
 
 			isLBListenerPolicyLBID: {
@@ -246,7 +248,21 @@ func resourceIBMISLBListenerPolicyValidator() *ResourceValidator {
 			Required:                   true,
 			AllowedValues:              action})
 
-	ibmISLBListenerPolicyResourceValidator := ResourceValidator{ResourceName: "ibm_is_lb_listener_policy", Schema: validateSchema}
+	// a redirect needs both the url and the status code of the response
+	rules := []ValidateRule{
+		{
+			RuleIdentifier: MutuallyRequired,
+			Identifiers:    []string{isLBListenerPolicyTargetURL, isLBListenerPolicyTargetHTTPStatusCode},
+		},
+		{
+			RuleIdentifier: ConditionalAllowedValues,
+			Identifiers:    []string{isLBListenerPolicyAction, isLBListenerPolicyTargetHTTPStatusCode},
+			When:           "redirect",
+			AllowedValues:  "301, 302, 303, 307, 308",
+		},
+	}
+
+	ibmISLBListenerPolicyResourceValidator := ResourceValidator{ResourceName: "ibm_is_lb_listener_policy", Schema: validateSchema, Rules: rules}
 	return &ibmISLBListenerPolicyResourceValidator
 }
 
@@ -405,8 +421,11 @@ func lbListenerPolicyCreate(d *schema.ResourceData, meta interface{}, lbID, list
 	}
 
 	isLBKey := "load_balancer_key_" + lbID
-	ibmMutexKV.Lock(isLBKey)
-	defer ibmMutexKV.Unlock(isLBKey)
+	unlock, err := acquireLocks(d, schema.TimeoutCreate, "ibm_is_lb_listener_policy create", []string{isLBKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	_, err = isWaitForLbAvailable(sess, lbID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
@@ -667,8 +686,11 @@ func lbListenerPolicyUpdate(d *schema.ResourceData, meta interface{}, lbID, list
 		}
 		updatePolicyOptions.LoadBalancerListenerPolicyPatch = loadBalancerListenerPolicyPatch
 		isLBKey := "load_balancer_key_" + lbID
-		ibmMutexKV.Lock(isLBKey)
-		defer ibmMutexKV.Unlock(isLBKey)
+		unlock, err := acquireLocks(d, schema.TimeoutUpdate, "ibm_is_lb_listener_policy update", []string{isLBKey}, nil)
+		if err != nil {
+			return err
+		}
+		defer unlock()
 
 		_, err = isWaitForLbAvailable(sess, lbID, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
@@ -701,8 +723,11 @@ func resourceIBMISLBListenerPolicyDelete(d *schema.ResourceData, meta interface{
 	policyID := parts[2]
 
 	isLBKey := "load_balancer_key_" + lbID
-	ibmMutexKV.Lock(isLBKey)
-	defer ibmMutexKV.Unlock(isLBKey)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_lb_listener_policy delete", []string{isLBKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	err = lbListenerPolicyDelete(d, meta, lbID, listenerID, policyID)
 	if err != nil {
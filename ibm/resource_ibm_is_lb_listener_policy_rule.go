@@ -41,7 +41,7 @@ func resourceIBMISLBListenerPolicyRule() *schema.Resource {
 		Update:   resourceIBMISLBListenerPolicyRuleUpdate,
 		Delete:   resourceIBMISLBListenerPolicyRuleDelete,
 		Exists:   resourceIBMISLBListenerPolicyRuleExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "lb_id", "listener_id", "policy_id", "rule_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -259,8 +259,11 @@ func lbListenerPolicyRuleCreate(d *schema.ResourceData, meta interface{}, lbID,
 	}
 
 	isLBKey := "load_balancer_key_" + lbID
-	ibmMutexKV.Lock(isLBKey)
-	defer ibmMutexKV.Unlock(isLBKey)
+	unlock, err := acquireLocks(d, schema.TimeoutCreate, "ibm_is_lb_listener_policy_rule create", []string{isLBKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	_, err = isWaitForLoadbalancerAvailable(sess, lbID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
@@ -496,8 +499,11 @@ func lbListenerPolicyRuleUpdate(d *schema.ResourceData, meta interface{}, lbID,
 		updatePolicyRuleOptions.LoadBalancerListenerPolicyRulePatch = loadBalancerListenerPolicyRulePatch
 
 		isLBKey := "load_balancer_key_" + lbID
-		ibmMutexKV.Lock(isLBKey)
-		defer ibmMutexKV.Unlock(isLBKey)
+		unlock, err := acquireLocks(d, schema.TimeoutUpdate, "ibm_is_lb_listener_policy_rule update", []string{isLBKey}, nil)
+		if err != nil {
+			return err
+		}
+		defer unlock()
 
 		_, err = isWaitForLoadbalancerAvailable(sess, lbID, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
@@ -532,8 +538,11 @@ func resourceIBMISLBListenerPolicyRuleDelete(d *schema.ResourceData, meta interf
 	ruleID := parts[3]
 
 	isLBKey := "load_balancer_key_" + lbID
-	ibmMutexKV.Lock(isLBKey)
-	defer ibmMutexKV.Unlock(isLBKey)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_lb_listener_policy_rule delete", []string{isLBKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	err = lbListenerPolicyRuleDelete(d, meta, lbID, listenerID, policyID, ruleID)
 	if err != nil {
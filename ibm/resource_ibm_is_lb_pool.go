@@ -48,7 +48,7 @@ func resourceIBMISLBPool() *schema.Resource {
 		Update:   resourceIBMISLBPoolUpdate,
 		Delete:   resourceIBMISLBPoolDelete,
 		Exists:   resourceIBMISLBPoolExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "lb_id", "pool_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -277,10 +277,13 @@ func resourceIBMISLBPoolCreate(d *schema.ResourceData, meta interface{}) error {
 		healthMonitorPort = int64(hmp.(int))
 	}
 	isLBKey := "load_balancer_key_" + lbID
-	ibmMutexKV.Lock(isLBKey)
-	defer ibmMutexKV.Unlock(isLBKey)
+	unlock, err := acquireLocks(d, schema.TimeoutCreate, "ibm_is_lb_pool create", []string{isLBKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	err := lbPoolCreate(d, meta, name, lbID, algorithm, protocol, healthType, spType, cName, healthMonitorURL, pProtocol, healthDelay, maxRetries, healthTimeOut, healthMonitorPort)
+	err = lbPoolCreate(d, meta, name, lbID, algorithm, protocol, healthType, spType, cName, healthMonitorURL, pProtocol, healthDelay, maxRetries, healthTimeOut, healthMonitorPort)
 	if err != nil {
 		return err
 	}
@@ -526,9 +529,12 @@ func lbPoolUpdate(d *schema.ResourceData, meta interface{}, lbID, lbPoolID strin
 		loadBalancerPoolPatchModel.Protocol = &protocol
 
 		isLBKey := "load_balancer_key_" + lbID
-		ibmMutexKV.Lock(isLBKey)
-		defer ibmMutexKV.Unlock(isLBKey)
-		_, err := isWaitForLBAvailable(sess, lbID, d.Timeout(schema.TimeoutUpdate))
+		unlock, err := acquireLocks(d, schema.TimeoutUpdate, "ibm_is_lb_pool update", []string{isLBKey}, nil)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		_, err = isWaitForLBAvailable(sess, lbID, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return fmt.Errorf(
 				"Error checking for load balancer (%s) is active: %s", lbID, err)
@@ -580,8 +586,11 @@ func resourceIBMISLBPoolDelete(d *schema.ResourceData, meta interface{}) error {
 	lbPoolID := parts[1]
 
 	isLBKey := "load_balancer_key_" + lbID
-	ibmMutexKV.Lock(isLBKey)
-	defer ibmMutexKV.Unlock(isLBKey)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_lb_pool delete", []string{isLBKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	err = lbPoolDelete(d, meta, lbID, lbPoolID)
 	if err != nil {
@@ -36,7 +36,7 @@ func resourceIBMISLBPoolMember() *schema.Resource {
 		Update:   resourceIBMISLBPoolMemberUpdate,
 		Delete:   resourceIBMISLBPoolMemberDelete,
 		Exists:   resourceIBMISLBPoolMemberExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "lb_id", "pool_id", "member_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -148,8 +148,11 @@ func resourceIBMISLBPoolMemberCreate(d *schema.ResourceData, meta interface{}) e
 		weight = int64(w.(int))
 	}
 	isLBKey := "load_balancer_key_" + lbID
-	ibmMutexKV.Lock(isLBKey)
-	defer ibmMutexKV.Unlock(isLBKey)
+	unlock, err := acquireLocks(d, schema.TimeoutCreate, "ibm_is_lb_pool_member create", []string{isLBKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	err = lbpMemberCreate(d, meta, lbID, lbPoolID, port64, weight)
 	if err != nil {
@@ -362,8 +365,11 @@ func lbpmemberUpdate(d *schema.ResourceData, meta interface{}, lbID, lbPoolID, l
 		weight := int64(d.Get(isLBPoolMemberWeight).(int))
 
 		isLBKey := "load_balancer_key_" + lbID
-		ibmMutexKV.Lock(isLBKey)
-		defer ibmMutexKV.Unlock(isLBKey)
+		unlock, err := acquireLocks(d, schema.TimeoutUpdate, "ibm_is_lb_pool_member update", []string{isLBKey}, nil)
+		if err != nil {
+			return err
+		}
+		defer unlock()
 
 		_, err = isWaitForLBPoolActive(sess, lbID, lbPoolID, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
@@ -449,8 +455,11 @@ func resourceIBMISLBPoolMemberDelete(d *schema.ResourceData, meta interface{}) e
 	lbPoolMemID := parts[2]
 
 	isLBKey := "load_balancer_key_" + lbID
-	ibmMutexKV.Lock(isLBKey)
-	defer ibmMutexKV.Unlock(isLBKey)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_lb_pool_member delete", []string{isLBKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	err = lbpmemberDelete(d, meta, lbID, lbPoolID, lbPoolMemID)
 	if err != nil {
@@ -38,7 +38,7 @@ func resourceIBMISNetworkACLRule() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -329,7 +329,13 @@ func resourceIBMISNetworkACLRuleValidator() *ResourceValidator {
 func resourceIBMISNetworkACLRuleCreate(d *schema.ResourceData, meta interface{}) error {
 	nwACLID := d.Get(isNwACLID).(string)
 
-	err := nwaclRuleCreate(d, meta, nwACLID)
+	unlock, err := acquireLocks(d, schema.TimeoutCreate, "ibm_is_network_acl_rule create", []string{networkACLLockKey(nwACLID)}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = nwaclRuleCreate(d, meta, nwACLID)
 	if err != nil {
 		return err
 	}
@@ -577,6 +583,15 @@ func nwaclRuleGet(d *schema.ResourceData, meta interface{}, nwACLID string, nwac
 func resourceIBMISNetworkACLRuleUpdate(d *schema.ResourceData, meta interface{}) error {
 	id := d.Id()
 	nwACLId, ruleId, err := parseNwACLTerraformID(id)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := acquireLocks(d, schema.TimeoutUpdate, "ibm_is_network_acl_rule update", []string{networkACLLockKey(nwACLId)}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	err = nwaclRuleUpdate(d, meta, ruleId, nwACLId)
 	if err != nil {
@@ -757,6 +772,12 @@ func resourceIBMISNetworkACLRuleDelete(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_network_acl_rule delete", []string{networkACLLockKey(nwACLID)}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	err = nwaclRuleDelete(d, meta, ruleId, nwACLID)
 	if err != nil {
 		return err
@@ -816,6 +837,12 @@ func nwaclRuleExists(d *schema.ResourceData, meta interface{}, id, nwACLId strin
 	return true, nil
 }
 
+// networkACLLockKey returns the key of the lock serializing the changes of the
+// rules of a network ACL, which are ordered by their before rule
+func networkACLLockKey(nwACLID string) string {
+	return "network_acl_key_" + nwACLID
+}
+
 func makeTerraformACLRuleID(id1, id2 string) string {
 	// Include both network acl id and rule id to create a unique Terraform id.  As a bonus,
 	// we can extract the network acl id as needed for API calls such as READ.
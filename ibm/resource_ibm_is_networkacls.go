@@ -61,7 +61,7 @@ func resourceIBMISNetworkACL() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -443,7 +443,7 @@ func nwaclCreate(d *schema.ResourceData, meta interface{}, name string) error {
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isNetworkACLTags); ok || v != "" {
+	if _, ok := d.GetOk(isNetworkACLTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isNetworkACLTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *nwacl.CRN)
 		if err != nil {
@@ -594,7 +594,13 @@ func resourceIBMISNetworkACLUpdate(d *schema.ResourceData, meta interface{}) err
 		hasChanged = true
 	}
 
-	err := nwaclUpdate(d, meta, id, name, hasChanged)
+	unlock, err := acquireLocks(d, schema.TimeoutUpdate, "ibm_is_network_acl update", []string{networkACLLockKey(id)}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = nwaclUpdate(d, meta, id, name, hasChanged)
 	if err != nil {
 		return err
 	}
@@ -653,7 +659,13 @@ func nwaclUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasC
 
 func resourceIBMISNetworkACLDelete(d *schema.ResourceData, meta interface{}) error {
 	id := d.Id()
-	err := nwaclDelete(d, meta, id)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_network_acl delete", []string{networkACLLockKey(id)}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = nwaclDelete(d, meta, id)
 	if err != nil {
 		return err
 	}
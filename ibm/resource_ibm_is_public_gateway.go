@@ -49,7 +49,7 @@ func resourceIBMISPublicGateway() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -220,7 +220,7 @@ func resourceIBMISPublicGatewayCreate(d *schema.ResourceData, meta interface{})
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isPublicGatewayTags); ok || v != "" {
+	if _, ok := d.GetOk(isPublicGatewayTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isPublicGatewayTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *publicgw.CRN)
 		if err != nil {
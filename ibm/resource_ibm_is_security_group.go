@@ -36,7 +36,7 @@ func resourceIBMISSecurityGroup() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -171,7 +171,7 @@ func resourceIBMISSecurityGroupCreate(d *schema.ResourceData, meta interface{})
 	}
 	d.SetId(*sg.ID)
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isSecurityGroupTags); ok || v != "" {
+	if _, ok := d.GetOk(isSecurityGroupTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isSecurityGroupTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *sg.CRN)
 		if err != nil {
@@ -365,6 +365,14 @@ func resourceIBMISSecurityGroupDelete(d *schema.ResourceData, meta interface{})
 	}
 	id := d.Id()
 
+	// wait for the changes of the rules of the group, and of the rules of
+	// other groups allowing this group as remote
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_security_group delete", []string{securityGroupLockKey(id)}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	getSecurityGroupOptions := &vpcv1.GetSecurityGroupOptions{
 		ID: &id,
 	}
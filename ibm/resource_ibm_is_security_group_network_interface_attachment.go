@@ -37,7 +37,7 @@ func resourceIBMISSecurityGroupNetworkInterfaceAttachment() *schema.Resource {
 		Read:     resourceIBMISSecurityGroupNetworkInterfaceAttachmentRead,
 		Delete:   resourceIBMISSecurityGroupNetworkInterfaceAttachmentDelete,
 		Exists:   resourceIBMISSecurityGroupNetworkInterfaceAttachmentExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "security_group_id", "network_interface_id"),
 
 		Schema: map[string]*schema.Schema{
 			isSGNICAGroupId: {
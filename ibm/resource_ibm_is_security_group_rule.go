@@ -234,9 +234,11 @@ func resourceIBMISSecurityGroupRuleCreate(d *schema.ResourceData, meta interface
 	if err != nil {
 		return err
 	}
-	isSecurityGroupRuleKey := "security_group_rule_key_" + parsed.secgrpID
-	ibmMutexKV.Lock(isSecurityGroupRuleKey)
-	defer ibmMutexKV.Unlock(isSecurityGroupRuleKey)
+	unlock, err := lockSecurityGroupRule(d, schema.TimeoutCreate, "ibm_is_security_group_rule create", parsed)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	options := &vpcv1.CreateSecurityGroupRuleOptions{
 		SecurityGroupID:            &parsed.secgrpID,
@@ -408,9 +410,11 @@ func resourceIBMISSecurityGroupRuleUpdate(d *schema.ResourceData, meta interface
 	if err != nil {
 		return err
 	}
-	isSecurityGroupRuleKey := "security_group_rule_key_" + parsed.secgrpID
-	ibmMutexKV.Lock(isSecurityGroupRuleKey)
-	defer ibmMutexKV.Unlock(isSecurityGroupRuleKey)
+	unlock, err := lockSecurityGroupRule(d, schema.TimeoutUpdate, "ibm_is_security_group_rule update", parsed)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	updateSecurityGroupRuleOptions := sgTemplate
 	_, response, err := sess.UpdateSecurityGroupRule(updateSecurityGroupRuleOptions)
@@ -430,9 +434,12 @@ func resourceIBMISSecurityGroupRuleDelete(d *schema.ResourceData, meta interface
 		return err
 	}
 
-	isSecurityGroupRuleKey := "security_group_rule_key_" + secgrpID
-	ibmMutexKV.Lock(isSecurityGroupRuleKey)
-	defer ibmMutexKV.Unlock(isSecurityGroupRuleKey)
+	isSecurityGroupRuleKey := securityGroupLockKey(secgrpID)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_security_group_rule delete", []string{isSecurityGroupRuleKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	getSecurityGroupRuleOptions := &vpcv1.GetSecurityGroupRuleOptions{
 		SecurityGroupID: &secgrpID,
@@ -495,6 +502,23 @@ func parseISTerraformID(s string) (string, string, error) {
 	return segments[0], segments[1], nil
 }
 
+// securityGroupLockKey returns the key of the lock serializing the changes of
+// the rules of a security group
+func securityGroupLockKey(secgrpID string) string {
+	return "security_group_rule_key_" + secgrpID
+}
+
+// lockSecurityGroupRule takes the lock of the security group of a rule, and a
+// read lock of its remote security group so that the remote group is not
+// deleted while the rule is changed.
+func lockSecurityGroupRule(d *schema.ResourceData, timeoutKey, operation string, parsed *parsedIBMISSecurityGroupRuleDictionary) (func(), error) {
+	var read []string
+	if parsed.remoteSecGrpID != "" {
+		read = append(read, securityGroupLockKey(parsed.remoteSecGrpID))
+	}
+	return acquireLocks(d, timeoutKey, operation, []string{securityGroupLockKey(parsed.secgrpID)}, read)
+}
+
 type parsedIBMISSecurityGroupRuleDictionary struct {
 	// After parsing, unused string fields are set to
 	// "" and unused int64 fields will be set to -1.
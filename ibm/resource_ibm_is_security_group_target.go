@@ -22,7 +22,7 @@ func resourceIBMISSecurityGroupTarget() *schema.Resource {
 		Read:     resourceIBMISSecurityGroupTargetRead,
 		Delete:   resourceIBMISSecurityGroupTargetDelete,
 		Exists:   resourceIBMISSecurityGroupTargetExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "security_group_id", "target_id"),
 
 		Schema: map[string]*schema.Schema{
 
@@ -35,7 +35,7 @@ func resourceIBMISSSHKey() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -181,7 +181,7 @@ func keyCreate(d *schema.ResourceData, meta interface{}, name, publickey string)
 	log.Printf("[INFO] Key : %s", *key.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isKeyTags); ok || v != "" {
+	if _, ok := d.GetOk(isKeyTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isKeyTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *key.CRN)
 		if err != nil {
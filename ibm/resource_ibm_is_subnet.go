@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -47,12 +48,11 @@ const (
 
 func resourceIBMISSubnet() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMISSubnetCreate,
-		Read:     resourceIBMISSubnetRead,
-		Update:   resourceIBMISSubnetUpdate,
-		Delete:   resourceIBMISSubnetDelete,
-		Exists:   resourceIBMISSubnetExists,
-		Importer: &schema.ResourceImporter{},
+		CreateContext: resourceIBMISSubnetCreate,
+		ReadContext:   resourceIBMISSubnetRead,
+		UpdateContext: resourceIBMISSubnetUpdate,
+		DeleteContext: resourceIBMISSubnetDelete,
+		Importer:      &schema.ResourceImporter{},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -61,7 +61,7 @@ func resourceIBMISSubnet() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -257,7 +257,7 @@ func resourceIBMISSubnetValidator() *ResourceValidator {
 	return &ibmISSubnetResourceValidator
 }
 
-func resourceIBMISSubnetCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISSubnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	name := d.Get(isSubnetName).(string)
 	vpc := d.Get(isSubnetVPC).(string)
@@ -274,15 +274,18 @@ func resourceIBMISSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 		ipv4addrcount64 = int64(ipv4addrcount)
 	}
 	if ipv4cidr == "" && ipv4addrcount == 0 {
-		return fmt.Errorf("%s or %s need to be provided", isSubnetIpv4CidrBlock, isSubnetTotalIpv4AddressCount)
+		return diag.FromErr(fmt.Errorf("%s or %s need to be provided", isSubnetIpv4CidrBlock, isSubnetTotalIpv4AddressCount))
 	}
 
 	if ipv4cidr != "" && ipv4addrcount != 0 {
-		return fmt.Errorf("only one of %s or %s needs to be provided", isSubnetIpv4CidrBlock, isSubnetTotalIpv4AddressCount)
+		return diag.FromErr(fmt.Errorf("only one of %s or %s needs to be provided", isSubnetIpv4CidrBlock, isSubnetTotalIpv4AddressCount))
 	}
 	isSubnetKey := "subnet_key_" + vpc + "_" + zone
-	ibmMutexKV.Lock(isSubnetKey)
-	defer ibmMutexKV.Unlock(isSubnetKey)
+	unlock, err := ibmLocks.Lock(ctx, lockHolder(d, "ibm_is_subnet create"), isSubnetKey)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	defer unlock()
 
 	acl := ""
 	if nwacl, ok := d.GetOk(isSubnetNetworkACL); ok {
@@ -300,15 +303,15 @@ func resourceIBMISSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 		rtID = rt.(string)
 	}
 
-	err := subnetCreate(d, meta, name, vpc, zone, ipv4cidr, acl, gw, rtID, ipv4addrcount64)
+	err = subnetCreate(ctx, d, meta, name, vpc, zone, ipv4cidr, acl, gw, rtID, ipv4addrcount64)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
-	return resourceIBMISSubnetRead(d, meta)
+	return resourceIBMISSubnetRead(ctx, d, meta)
 }
 
-func subnetCreate(d *schema.ResourceData, meta interface{}, name, vpc, zone, ipv4cidr, acl, gw, rtID string, ipv4addrcount64 int64) error {
+func subnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, name, vpc, zone, ipv4cidr, acl, gw, rtID string, ipv4addrcount64 int64) error {
 
 	sess, err := vpcClient(meta)
 	if err != nil {
@@ -357,19 +360,19 @@ func subnetCreate(d *schema.ResourceData, meta interface{}, name, vpc, zone, ipv
 	createSubnetOptions := &vpcv1.CreateSubnetOptions{
 		SubnetPrototype: subnetTemplate,
 	}
-	subnet, response, err := sess.CreateSubnet(createSubnetOptions)
+	subnet, response, err := sess.CreateSubnetWithContext(ctx, createSubnetOptions)
 	if err != nil {
 		log.Printf("[DEBUG] Subnet err %s\n%s", err, response)
-		return fmt.Errorf("Error while creating Subnet %s\n%v", err, response)
+		return newAPIError("Error while creating Subnet", err, response).withAttributes(isSubnetName, isSubnetVPC, isSubnetZone, isSubnetIpv4CidrBlock, isSubnetTotalIpv4AddressCount, isSubnetNetworkACL, isSubnetPublicGateway, isSubnetIPVersion, isSubnetRoutingTableID, isSubnetResourceGroup)
 	}
 	d.SetId(*subnet.ID)
 	log.Printf("[INFO] Subnet : %s", *subnet.ID)
-	_, err = isWaitForSubnetAvailable(sess, d.Id(), d.Timeout(schema.TimeoutCreate))
+	_, err = isWaitForSubnetAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutCreate), meta)
 	if err != nil {
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isSubnetTags); ok || v != "" {
+	if _, ok := d.GetOk(isSubnetTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isSubnetTags)
 		err = UpdateGlobalTagsUsingCRN(oldList, newList, meta, *subnet.CRN, "", isUserTagType)
 		if err != nil {
@@ -390,51 +393,57 @@ func subnetCreate(d *schema.ResourceData, meta interface{}, name, vpc, zone, ipv
 	return nil
 }
 
-func isWaitForSubnetAvailable(subnetC *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
+func isWaitForSubnetAvailable(ctx context.Context, subnetC *vpcv1.VpcV1, id string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf("Waiting for subnet (%s) to be available.", id)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"retry", isSubnetProvisioning},
-		Target:     []string{isSubnetProvisioningDone, ""},
-		Refresh:    isSubnetRefreshFunc(subnetC, id),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+	p := &poller{
+		Kind:    "ibm_is_subnet:available",
+		Name:    fmt.Sprintf("subnet (%s)", id),
+		Pending: []string{"retry", isSubnetProvisioning},
+		Target:  []string{isSubnetProvisioningDone, ""},
+		Refresh: isSubnetRefreshFunc(ctx, subnetC, id),
+		Timeout: timeout,
+		Delay:   10 * time.Second,
+		Policy:  pollingPolicy(meta),
 	}
 
-	return stateConf.WaitForState()
+	return p.WaitForStateContext(ctx)
 }
 
-func isSubnetRefreshFunc(subnetC *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func isSubnetRefreshFunc(ctx context.Context, subnetC *vpcv1.VpcV1, id string) pollRefreshFunc {
+	return func(etag string) (interface{}, string, string, error) {
 		getSubnetOptions := &vpcv1.GetSubnetOptions{
-			ID: &id,
+			ID:      &id,
+			Headers: ifNoneMatchHeaders(etag),
+		}
+		subnet, response, err := subnetC.GetSubnetWithContext(ctx, getSubnetOptions)
+		if isNotModified(response) {
+			return nil, "", etag, errNotModified
 		}
-		subnet, response, err := subnetC.GetSubnet(getSubnetOptions)
 		if err != nil {
-			return nil, "", fmt.Errorf("Error getting Subnet : %s\n%s", err, response)
+			return nil, "", "", newAPIError("Error getting Subnet", err, response)
 		}
 
 		if *subnet.Status == "available" || *subnet.Status == "failed" {
-			return subnet, isSubnetProvisioningDone, nil
+			return subnet, isSubnetProvisioningDone, responseETag(response), nil
 		}
 
-		return subnet, isSubnetProvisioning, nil
+		return subnet, isSubnetProvisioning, responseETag(response), nil
 	}
 }
 
-func resourceIBMISSubnetRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISSubnetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	id := d.Id()
 
-	err := subnetGet(d, meta, id)
+	err := subnetGet(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	return nil
 }
 
-func subnetGet(d *schema.ResourceData, meta interface{}, id string) error {
+func subnetGet(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -442,13 +451,13 @@ func subnetGet(d *schema.ResourceData, meta interface{}, id string) error {
 	getSubnetOptions := &vpcv1.GetSubnetOptions{
 		ID: &id,
 	}
-	subnet, response, err := sess.GetSubnet(getSubnetOptions)
+	subnet, response, err := sess.GetSubnetWithContext(ctx, getSubnetOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Getting Subnet (%s): %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error Getting Subnet (%s)", id), err, response)
 	}
 	d.Set(isSubnetName, *subnet.Name)
 	d.Set(isSubnetIPVersion, *subnet.IPVersion)
@@ -503,7 +512,7 @@ func subnetGet(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func resourceIBMISSubnetUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISSubnetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	id := d.Id()
 
 	if d.HasChange(isSubnetTags) {
@@ -524,15 +533,15 @@ func resourceIBMISSubnetUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	err := subnetUpdate(d, meta, id)
+	err := subnetUpdate(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
-	return resourceIBMISSubnetRead(d, meta)
+	return resourceIBMISSubnetRead(ctx, d, meta)
 }
 
-func subnetUpdate(d *schema.ResourceData, meta interface{}, id string) error {
+func subnetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -560,11 +569,11 @@ func subnetUpdate(d *schema.ResourceData, meta interface{}, id string) error {
 			unsetSubnetPublicGatewayOptions := &vpcv1.UnsetSubnetPublicGatewayOptions{
 				ID: &id,
 			}
-			response, err := sess.UnsetSubnetPublicGateway(unsetSubnetPublicGatewayOptions)
+			response, err := sess.UnsetSubnetPublicGatewayWithContext(ctx, unsetSubnetPublicGatewayOptions)
 			if err != nil {
-				return fmt.Errorf("Error Detaching the public gateway attached to the subnet : %s\n%s", err, response)
+				return newAPIError("Error Detaching the public gateway attached to the subnet", err, response)
 			}
-			_, err = isWaitForSubnetAvailable(sess, d.Id(), d.Timeout(schema.TimeoutUpdate))
+			_, err = isWaitForSubnetAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutUpdate), meta)
 			if err != nil {
 				return err
 			}
@@ -575,11 +584,11 @@ func subnetUpdate(d *schema.ResourceData, meta interface{}, id string) error {
 					ID: &gw,
 				},
 			}
-			_, response, err := sess.SetSubnetPublicGateway(setSubnetPublicGatewayOptions)
+			_, response, err := sess.SetSubnetPublicGatewayWithContext(ctx, setSubnetPublicGatewayOptions)
 			if err != nil {
-				return fmt.Errorf("Error Attaching public gateway to the subnet : %s\n%s", err, response)
+				return newAPIError("Error Attaching public gateway to the subnet", err, response)
 			}
-			_, err = isWaitForSubnetAvailable(sess, d.Id(), d.Timeout(schema.TimeoutUpdate))
+			_, err = isWaitForSubnetAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutUpdate), meta)
 			if err != nil {
 				return err
 			}
@@ -598,7 +607,7 @@ func subnetUpdate(d *schema.ResourceData, meta interface{}, id string) error {
 		setSubnetRoutingTableBindingOptions := sess.NewReplaceSubnetRoutingTableOptions(id, rt)
 		setSubnetRoutingTableBindingOptions.SetRoutingTableIdentity(rt)
 		setSubnetRoutingTableBindingOptions.SetID(id)
-		_, _, err = sess.ReplaceSubnetRoutingTable(setSubnetRoutingTableBindingOptions)
+		_, _, err = sess.ReplaceSubnetRoutingTableWithContext(ctx, setSubnetRoutingTableBindingOptions)
 		if err != nil {
 			log.Printf("SetSubnetRoutingTableBinding eroor: %s", err)
 			return err
@@ -611,27 +620,27 @@ func subnetUpdate(d *schema.ResourceData, meta interface{}, id string) error {
 		}
 		updateSubnetOptions.SubnetPatch = subnetPatch
 		updateSubnetOptions.ID = &id
-		_, response, err := sess.UpdateSubnet(updateSubnetOptions)
+		_, response, err := sess.UpdateSubnetWithContext(ctx, updateSubnetOptions)
 		if err != nil {
-			return fmt.Errorf("Error Updating Subnet : %s\n%s", err, response)
+			return newAPIError("Error Updating Subnet", err, response)
 		}
 	}
 	return nil
 }
 
-func resourceIBMISSubnetDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISSubnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	id := d.Id()
-	err := subnetDelete(d, meta, id)
+	err := subnetDelete(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	d.SetId("")
 	return nil
 }
 
-func subnetDelete(d *schema.ResourceData, meta interface{}, id string) error {
+func subnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -639,22 +648,22 @@ func subnetDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	getSubnetOptions := &vpcv1.GetSubnetOptions{
 		ID: &id,
 	}
-	subnet, response, err := sess.GetSubnet(getSubnetOptions)
+	subnet, response, err := sess.GetSubnetWithContext(ctx, getSubnetOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			return nil
 		}
-		return fmt.Errorf("Error Getting Subnet (%s): %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error Getting Subnet (%s)", id), err, response)
 	}
 	if subnet.PublicGateway != nil {
 		unsetSubnetPublicGatewayOptions := &vpcv1.UnsetSubnetPublicGatewayOptions{
 			ID: &id,
 		}
-		_, err = sess.UnsetSubnetPublicGateway(unsetSubnetPublicGatewayOptions)
+		_, err = sess.UnsetSubnetPublicGatewayWithContext(ctx, unsetSubnetPublicGatewayOptions)
 		if err != nil {
 			return err
 		}
-		_, err = isWaitForSubnetAvailable(sess, d.Id(), d.Timeout(schema.TimeoutDelete))
+		_, err = isWaitForSubnetAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutDelete), meta)
 		if err != nil {
 			return err
 		}
@@ -662,18 +671,18 @@ func subnetDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	deleteSubnetOptions := &vpcv1.DeleteSubnetOptions{
 		ID: &id,
 	}
-	response, err = sess.DeleteSubnet(deleteSubnetOptions)
+	response, err = sess.DeleteSubnetWithContext(ctx, deleteSubnetOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 409 {
-			_, err = isWaitForSubnetDeleteRetry(sess, d.Id(), d.Timeout(schema.TimeoutDelete))
+			_, err = isWaitForSubnetDeleteRetry(ctx, sess, d.Id(), d.Timeout(schema.TimeoutDelete))
 			if err != nil {
 				return fmt.Errorf("Error Deleting Subnet : %s", err)
 			}
 		} else {
-			return fmt.Errorf("Error Deleting Subnet : %s\n%s", err, response)
+			return newAPIError("Error Deleting Subnet", err, response)
 		}
 	}
-	_, err = isWaitForSubnetDeleted(sess, d.Id(), d.Timeout(schema.TimeoutDelete))
+	_, err = isWaitForSubnetDeleted(ctx, sess, d.Id(), d.Timeout(schema.TimeoutDelete), meta)
 	if err != nil {
 		return err
 	}
@@ -681,7 +690,7 @@ func subnetDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func isWaitForSubnetDeleteRetry(vpcClient *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
+func isWaitForSubnetDeleteRetry(ctx context.Context, vpcClient *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
 	log.Printf("Retrying subnet (%s) delete", id)
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{isSubnetInUse},
@@ -691,14 +700,14 @@ func isWaitForSubnetDeleteRetry(vpcClient *vpcv1.VpcV1, id string, timeout time.
 				ID: &id,
 			}
 			log.Printf("Retrying subnet (%s) delete", id)
-			response, err := vpcClient.DeleteSubnet(deleteSubnetOptions)
+			response, err := vpcClient.DeleteSubnetWithContext(ctx, deleteSubnetOptions)
 			if err != nil {
 				if response != nil && response.StatusCode == 409 {
 					return response, isSubnetInUse, nil
 				} else if response != nil && response.StatusCode == 404 {
 					return response, isSubnetDeleted, nil
 				}
-				return response, "", fmt.Errorf("Error deleting subnet: %s\n%s", err, response)
+				return response, "", newAPIError("Error deleting subnet", err, response)
 			}
 			return response, isSubnetDeleting, nil
 		},
@@ -706,64 +715,46 @@ func isWaitForSubnetDeleteRetry(vpcClient *vpcv1.VpcV1, id string, timeout time.
 		Delay:      10 * time.Second,
 		MinTimeout: 10 * time.Second,
 	}
-	return stateConf.WaitForState()
+	return stateConf.WaitForStateContext(ctx)
 }
 
-func isWaitForSubnetDeleted(subnetC *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
+func isWaitForSubnetDeleted(ctx context.Context, subnetC *vpcv1.VpcV1, id string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf("Waiting for subnet (%s) to be deleted.", id)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"retry", isSubnetDeleting},
-		Target:     []string{isSubnetDeleted, ""},
-		Refresh:    isSubnetDeleteRefreshFunc(subnetC, id),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+	p := &poller{
+		Kind:    "ibm_is_subnet:deleted",
+		Name:    fmt.Sprintf("subnet (%s)", id),
+		Pending: []string{"retry", isSubnetDeleting},
+		Target:  []string{isSubnetDeleted, ""},
+		Refresh: isSubnetDeleteRefreshFunc(ctx, subnetC, id),
+		Timeout: timeout,
+		Delay:   10 * time.Second,
+		Policy:  pollingPolicy(meta),
 	}
 
-	return stateConf.WaitForState()
+	return p.WaitForStateContext(ctx)
 }
 
-func isSubnetDeleteRefreshFunc(subnetC *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func isSubnetDeleteRefreshFunc(ctx context.Context, subnetC *vpcv1.VpcV1, id string) pollRefreshFunc {
+	return func(etag string) (interface{}, string, string, error) {
 		log.Printf("[DEBUG] delete function here")
 		getSubnetOptions := &vpcv1.GetSubnetOptions{
-			ID: &id,
+			ID:      &id,
+			Headers: ifNoneMatchHeaders(etag),
+		}
+		subnet, response, err := subnetC.GetSubnetWithContext(ctx, getSubnetOptions)
+		if isNotModified(response) {
+			return nil, "", etag, errNotModified
 		}
-		subnet, response, err := subnetC.GetSubnet(getSubnetOptions)
 		if err != nil {
 			if response != nil && response.StatusCode == 404 {
-				return subnet, isSubnetDeleted, nil
+				return subnet, isSubnetDeleted, "", nil
 			}
 			if response != nil && strings.Contains(err.Error(), "please detach all network interfaces from subnet before deleting it") {
-				return subnet, isSubnetDeleting, nil
+				return subnet, isSubnetDeleting, "", nil
 			}
-			return subnet, "", fmt.Errorf("The Subnet %s failed to delete: %s\n%s", id, err, response)
-		}
-		return subnet, isSubnetDeleting, err
-	}
-}
-
-func resourceIBMISSubnetExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	id := d.Id()
-	exists, err := subnetExists(d, meta, id)
-	return exists, err
-}
-
-func subnetExists(d *schema.ResourceData, meta interface{}, id string) (bool, error) {
-	sess, err := vpcClient(meta)
-	if err != nil {
-		return false, err
-	}
-	getsubnetOptions := &vpcv1.GetSubnetOptions{
-		ID: &id,
-	}
-	_, response, err := sess.GetSubnet(getsubnetOptions)
-	if err != nil {
-		if response != nil && response.StatusCode == 404 {
-			return false, nil
+			return subnet, "", "", newAPIError(fmt.Sprintf("The Subnet %s failed to delete", id), err, response)
 		}
-		return false, fmt.Errorf("Error getting Subnet: %s\n%s", err, response)
+		return subnet, isSubnetDeleting, responseETag(response), err
 	}
-	return true, nil
 }
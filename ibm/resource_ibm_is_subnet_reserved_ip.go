@@ -26,7 +26,7 @@ func resourceIBMISReservedIP() *schema.Resource {
 		Update:   resourceIBMISReservedIPUpdate,
 		Delete:   resourceIBMISReservedIPDelete,
 		Exists:   resourceIBMISReservedIPExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "subnet_id", "reserved_ip_id"),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
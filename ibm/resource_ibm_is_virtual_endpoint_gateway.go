@@ -50,7 +50,7 @@ func resourceIBMISEndpointGateway() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -259,7 +259,7 @@ func resourceIBMisVirtualEndpointGatewayCreate(d *schema.ResourceData, meta inte
 
 	d.SetId(*result.ID)
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVirtualEndpointGatewayTags); ok || v != "" {
+	if _, ok := d.GetOk(isVirtualEndpointGatewayTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isVirtualEndpointGatewayTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *result.CRN)
 		if err != nil {
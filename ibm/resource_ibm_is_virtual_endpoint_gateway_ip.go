@@ -32,7 +32,7 @@ func resourceIBMISEndpointGatewayIP() *schema.Resource {
 		Read:     resourceIBMisVirtualEndpointGatewayIPRead,
 		Delete:   resourceIBMisVirtualEndpointGatewayIPDelete,
 		Exists:   resourceIBMisVirtualEndpointGatewayIPExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "gateway_id", "ip_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
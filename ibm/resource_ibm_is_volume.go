@@ -11,8 +11,8 @@ import (
 	"time"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -40,12 +40,11 @@ const (
 
 func resourceIBMISVolume() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMISVolumeCreate,
-		Read:     resourceIBMISVolumeRead,
-		Update:   resourceIBMISVolumeUpdate,
-		Delete:   resourceIBMISVolumeDelete,
-		Exists:   resourceIBMISVolumeExists,
-		Importer: &schema.ResourceImporter{},
+		CreateContext: resourceIBMISVolumeCreate,
+		ReadContext:   resourceIBMISVolumeRead,
+		UpdateContext: resourceIBMISVolumeUpdate,
+		DeleteContext: resourceIBMISVolumeDelete,
+		Importer:      &schema.ResourceImporter{},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -54,7 +53,7 @@ func resourceIBMISVolume() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -220,7 +219,7 @@ func resourceIBMISVolumeValidator() *ResourceValidator {
 	return &ibmISVolumeResourceValidator
 }
 
-func resourceIBMISVolumeCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	volName := d.Get(isVolumeName).(string)
 	profile := d.Get(isVolumeProfileName).(string)
@@ -232,15 +231,15 @@ func resourceIBMISVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 		volCapacity = 100
 	}
 
-	err := volCreate(d, meta, volName, profile, zone, volCapacity)
+	err := volCreate(ctx, d, meta, volName, profile, zone, volCapacity)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
-	return resourceIBMISVolumeRead(d, meta)
+	return resourceIBMISVolumeRead(ctx, d, meta)
 }
 
-func volCreate(d *schema.ResourceData, meta interface{}, volName, profile, zone string, volCapacity int64) error {
+func volCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, volName, profile, zone string, volCapacity int64) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -278,18 +277,18 @@ func volCreate(d *schema.ResourceData, meta interface{}, volName, profile, zone
 		volTemplate.Iops = &iops
 	}
 
-	vol, response, err := sess.CreateVolume(options)
+	vol, response, err := sess.CreateVolumeWithContext(ctx, options)
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Create volume err %s\n%s", err, response)
+		return newAPIError("Error creating volume", err, response).withAttributes(isVolumeName, isVolumeProfileName, isVolumeZone, isVolumeEncryptionKey, isVolumeCapacity, isVolumeIops, isVolumeResourceGroup, isVolumeSourceSnapshot)
 	}
 	d.SetId(*vol.ID)
 	log.Printf("[INFO] Volume : %s", *vol.ID)
-	_, err = isWaitForVolumeAvailable(sess, d.Id(), d.Timeout(schema.TimeoutCreate))
+	_, err = isWaitForVolumeAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutCreate), meta)
 	if err != nil {
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVolumeTags); ok || v != "" {
+	if _, ok := d.GetOk(isVolumeTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isVolumeTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vol.CRN)
 		if err != nil {
@@ -300,17 +299,17 @@ func volCreate(d *schema.ResourceData, meta interface{}, volName, profile, zone
 	return nil
 }
 
-func resourceIBMISVolumeRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	id := d.Id()
-	err := volGet(d, meta, id)
+	err := volGet(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	return nil
 }
 
-func volGet(d *schema.ResourceData, meta interface{}, id string) error {
+func volGet(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -318,13 +317,13 @@ func volGet(d *schema.ResourceData, meta interface{}, id string) error {
 	options := &vpcv1.GetVolumeOptions{
 		ID: &id,
 	}
-	vol, response, err := sess.GetVolume(options)
+	vol, response, err := sess.GetVolumeWithContext(ctx, options)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error getting Volume (%s): %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error getting Volume (%s)", id), err, response)
 	}
 	d.SetId(*vol.ID)
 	d.Set(isVolumeName, *vol.Name)
@@ -374,7 +373,7 @@ func volGet(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func resourceIBMISVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	id := d.Id()
 	name := ""
@@ -390,29 +389,29 @@ func resourceIBMISVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 		hasChanged = true
 	}
 
-	err := volUpdate(d, meta, id, name, hasChanged, delete)
+	err := volUpdate(ctx, d, meta, id, name, hasChanged, delete)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	return resourceIBMISVolumeRead(d, meta)
+	return resourceIBMISVolumeRead(ctx, d, meta)
 }
 
-func volUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasChanged, delete bool) error {
+func volUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, id, name string, hasChanged, delete bool) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
 	}
 	if delete {
-		deleteAllSnapshots(sess, id)
+		deleteAllSnapshots(ctx, sess, id)
 	}
 
 	if d.HasChange(isVolumeTags) {
 		options := &vpcv1.GetVolumeOptions{
 			ID: &id,
 		}
-		vol, response, err := sess.GetVolume(options)
+		vol, response, err := sess.GetVolumeWithContext(ctx, options)
 		if err != nil {
-			return fmt.Errorf("Error getting Volume : %s\n%s", err, response)
+			return newAPIError("Error getting Volume", err, response)
 		}
 		oldList, newList := d.GetChange(isVolumeTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vol.CRN)
@@ -433,25 +432,25 @@ func volUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 			return fmt.Errorf("Error calling asPatch for VolumePatch: %s", err)
 		}
 		options.VolumePatch = volumePatch
-		_, response, err := sess.UpdateVolume(options)
+		_, response, err := sess.UpdateVolumeWithContext(ctx, options)
 		if err != nil {
-			return fmt.Errorf("Error updating vpc volume: %s\n%s", err, response)
+			return newAPIError("Error updating vpc volume", err, response)
 		}
 	}
 	return nil
 }
 
-func resourceIBMISVolumeDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	id := d.Id()
 
-	err := volDelete(d, meta, id)
+	err := volDelete(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	return nil
 }
 
-func volDelete(d *schema.ResourceData, meta interface{}, id string) error {
+func volDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -460,12 +459,12 @@ func volDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	getvoloptions := &vpcv1.GetVolumeOptions{
 		ID: &id,
 	}
-	volDetails, response, err := sess.GetVolume(getvoloptions)
+	volDetails, response, err := sess.GetVolumeWithContext(ctx, getvoloptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			return nil
 		}
-		return fmt.Errorf("Error getting Volume (%s): %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error getting Volume (%s)", id), err, response)
 	}
 
 	if volDetails.VolumeAttachments != nil {
@@ -474,11 +473,11 @@ func volDelete(d *schema.ResourceData, meta interface{}, id string) error {
 				InstanceID: volAtt.Instance.ID,
 				ID:         volAtt.ID,
 			}
-			_, err := sess.DeleteInstanceVolumeAttachment(deleteVolumeAttachment)
+			_, err := sess.DeleteInstanceVolumeAttachmentWithContext(ctx, deleteVolumeAttachment)
 			if err != nil {
 				return fmt.Errorf("Error while removing volume attachment %q for instance %s: %q", *volAtt.ID, *volAtt.Instance.ID, err)
 			}
-			_, err = isWaitForInstanceVolumeDetached(sess, d, d.Id(), *volAtt.ID)
+			_, err = isWaitForInstanceVolumeDetached(ctx, sess, d, d.Id(), *volAtt.ID)
 			if err != nil {
 				return err
 			}
@@ -489,11 +488,11 @@ func volDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	options := &vpcv1.DeleteVolumeOptions{
 		ID: &id,
 	}
-	response, err = sess.DeleteVolume(options)
+	response, err = sess.DeleteVolumeWithContext(ctx, options)
 	if err != nil {
-		return fmt.Errorf("Error deleting Volume : %s\n%s", err, response)
+		return newAPIError("Error deleting Volume", err, response)
 	}
-	_, err = isWaitForVolumeDeleted(sess, id, d.Timeout(schema.TimeoutDelete))
+	_, err = isWaitForVolumeDeleted(ctx, sess, id, d.Timeout(schema.TimeoutDelete), meta)
 	if err != nil {
 		return err
 	}
@@ -501,102 +500,88 @@ func volDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func isWaitForVolumeDeleted(vol *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
+func isWaitForVolumeDeleted(ctx context.Context, vol *vpcv1.VpcV1, id string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf("Waiting for  (%s) to be deleted.", id)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"retry", isVolumeDeleting},
-		Target:     []string{"done", ""},
-		Refresh:    isVolumeDeleteRefreshFunc(vol, id),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+	p := &poller{
+		Kind:    "ibm_is_volume:deleted",
+		Name:    fmt.Sprintf("volume (%s)", id),
+		Pending: []string{"retry", isVolumeDeleting},
+		Target:  []string{"done", ""},
+		Refresh: isVolumeDeleteRefreshFunc(ctx, vol, id),
+		Timeout: timeout,
+		Delay:   10 * time.Second,
+		Policy:  pollingPolicy(meta),
 	}
 
-	return stateConf.WaitForState()
+	return p.WaitForStateContext(ctx)
 }
 
-func isVolumeDeleteRefreshFunc(vol *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func isVolumeDeleteRefreshFunc(ctx context.Context, vol *vpcv1.VpcV1, id string) pollRefreshFunc {
+	return func(etag string) (interface{}, string, string, error) {
 		volgetoptions := &vpcv1.GetVolumeOptions{
-			ID: &id,
+			ID:      &id,
+			Headers: ifNoneMatchHeaders(etag),
+		}
+		vol, response, err := vol.GetVolumeWithContext(ctx, volgetoptions)
+		if isNotModified(response) {
+			return nil, "", etag, errNotModified
 		}
-		vol, response, err := vol.GetVolume(volgetoptions)
 		if err != nil {
 			if response != nil && response.StatusCode == 404 {
-				return vol, isVolumeDeleted, nil
+				return vol, isVolumeDeleted, "", nil
 			}
-			return vol, "", fmt.Errorf("Error getting Volume: %s\n%s", err, response)
+			return vol, "", "", newAPIError("Error getting Volume", err, response)
 		}
-		return vol, isVolumeDeleting, err
+		return vol, isVolumeDeleting, responseETag(response), err
 	}
 }
 
-func resourceIBMISVolumeExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-
-	id := d.Id()
-
-	exists, err := volExists(d, meta, id)
-	return exists, err
-}
-
-func volExists(d *schema.ResourceData, meta interface{}, id string) (bool, error) {
-	sess, err := vpcClient(meta)
-	if err != nil {
-		return false, err
-	}
-	options := &vpcv1.GetVolumeOptions{
-		ID: &id,
-	}
-	_, response, err := sess.GetVolume(options)
-	if err != nil {
-		if response != nil && response.StatusCode == 404 {
-			return false, nil
-		}
-		return false, fmt.Errorf("Error getting Volume: %s\n%s", err, response)
-	}
-	return true, nil
-}
-
-func isWaitForVolumeAvailable(client *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
+func isWaitForVolumeAvailable(ctx context.Context, client *vpcv1.VpcV1, id string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf("Waiting for Volume (%s) to be available.", id)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"retry", isVolumeProvisioning},
-		Target:     []string{isVolumeProvisioningDone, ""},
-		Refresh:    isVolumeRefreshFunc(client, id),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+	p := &poller{
+		Kind:    "ibm_is_volume:available",
+		Name:    fmt.Sprintf("volume (%s)", id),
+		Pending: []string{"retry", isVolumeProvisioning},
+		Target:  []string{isVolumeProvisioningDone, ""},
+		Refresh: isVolumeRefreshFunc(ctx, client, id),
+		Timeout: timeout,
+		Delay:   10 * time.Second,
+		Policy:  pollingPolicy(meta),
 	}
 
-	return stateConf.WaitForState()
+	return p.WaitForStateContext(ctx)
 }
 
-func isVolumeRefreshFunc(client *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func isVolumeRefreshFunc(ctx context.Context, client *vpcv1.VpcV1, id string) pollRefreshFunc {
+	return func(etag string) (interface{}, string, string, error) {
 		volgetoptions := &vpcv1.GetVolumeOptions{
-			ID: &id,
+			ID:      &id,
+			Headers: ifNoneMatchHeaders(etag),
+		}
+		vol, response, err := client.GetVolumeWithContext(ctx, volgetoptions)
+		if isNotModified(response) {
+			return nil, "", etag, errNotModified
 		}
-		vol, response, err := client.GetVolume(volgetoptions)
 		if err != nil {
-			return nil, "", fmt.Errorf("Error getting volume: %s\n%s", err, response)
+			return nil, "", "", newAPIError("Error getting volume", err, response)
 		}
 
 		if *vol.Status == "available" {
-			return vol, isVolumeProvisioningDone, nil
+			return vol, isVolumeProvisioningDone, responseETag(response), nil
 		}
 
-		return vol, isVolumeProvisioning, nil
+		return vol, isVolumeProvisioning, responseETag(response), nil
 	}
 }
 
-func deleteAllSnapshots(sess *vpcv1.VpcV1, id string) error {
+func deleteAllSnapshots(ctx context.Context, sess *vpcv1.VpcV1, id string) error {
 	delete_all_snapshots := new(vpcv1.DeleteSnapshotsOptions)
 	delete_all_snapshots.SourceVolumeID = &id
-	response, err := sess.DeleteSnapshots(delete_all_snapshots)
+	response, err := sess.DeleteSnapshotsWithContext(ctx, delete_all_snapshots)
 	if err != nil {
-		return fmt.Errorf("Error deleting snapshots from volume %s\n%s", err, response)
+		return newAPIError("Error deleting snapshots from volume", err, response)
 	}
 	return nil
 }
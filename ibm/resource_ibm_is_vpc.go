@@ -14,8 +14,8 @@ import (
 	"time"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/internal/hashcode"
@@ -61,12 +61,11 @@ const (
 
 func resourceIBMISVPC() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMISVPCCreate,
-		Read:     resourceIBMISVPCRead,
-		Update:   resourceIBMISVPCUpdate,
-		Delete:   resourceIBMISVPCDelete,
-		Exists:   resourceIBMISVPCExists,
-		Importer: &schema.ResourceImporter{},
+		CreateContext: resourceIBMISVPCCreate,
+		ReadContext:   resourceIBMISVPCRead,
+		UpdateContext: resourceIBMISVPCUpdate,
+		DeleteContext: resourceIBMISVPCDelete,
+		Importer:      &schema.ResourceImporter{},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -75,7 +74,7 @@ func resourceIBMISVPC() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -417,7 +416,7 @@ func resourceIBMISVPCValidator() *ResourceValidator {
 	return &ibmISVPCResourceValidator
 }
 
-func resourceIBMISVPCCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISVPCCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	log.Printf("[DEBUG] VPC create")
 	name := d.Get(isVPCName).(string)
@@ -435,14 +434,14 @@ func resourceIBMISVPCCreate(d *schema.ResourceData, meta interface{}) error {
 	if grp, ok := d.GetOk(isVPCResourceGroup); ok {
 		rg = grp.(string)
 	}
-	err := vpcCreate(d, meta, name, apm, rg, isClassic)
+	err := vpcCreate(ctx, d, meta, name, apm, rg, isClassic)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	return resourceIBMISVPCRead(d, meta)
+	return resourceIBMISVPCRead(ctx, d, meta)
 }
 
-func vpcCreate(d *schema.ResourceData, meta interface{}, name, apm, rg string, isClassic bool) error {
+func vpcCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, name, apm, rg string, isClassic bool) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -460,31 +459,31 @@ func vpcCreate(d *schema.ResourceData, meta interface{}, name, apm, rg string, i
 	}
 	options.ClassicAccess = &isClassic
 
-	vpc, response, err := sess.CreateVPC(options)
+	vpc, response, err := sess.CreateVPCWithContext(ctx, options)
 	if err != nil {
-		return fmt.Errorf("Error while creating VPC %s ", beautifyError(err, response))
+		return newAPIError("Error while creating VPC", err, response).withAttributes(isVPCName, isVPCAddressPrefixManagement, isVPCClassicAccess, isVPCResourceGroup)
 	}
 	d.SetId(*vpc.ID)
 
 	if defaultSGName, ok := d.GetOk(isVPCDefaultSecurityGroupName); ok {
-		sgNameUpdate(sess, *vpc.DefaultSecurityGroup.ID, defaultSGName.(string))
+		sgNameUpdate(ctx, sess, *vpc.DefaultSecurityGroup.ID, defaultSGName.(string))
 	}
 
 	if defaultRTName, ok := d.GetOk(isVPCDefaultRoutingTableName); ok {
-		rtNameUpdate(sess, *vpc.ID, *vpc.DefaultRoutingTable.ID, defaultRTName.(string))
+		rtNameUpdate(ctx, sess, *vpc.ID, *vpc.DefaultRoutingTable.ID, defaultRTName.(string))
 	}
 
 	if defaultACLName, ok := d.GetOk(isVPCDefaultNetworkACLName); ok {
-		nwaclNameUpdate(sess, *vpc.DefaultNetworkACL.ID, defaultACLName.(string))
+		nwaclNameUpdate(ctx, sess, *vpc.DefaultNetworkACL.ID, defaultACLName.(string))
 	}
 
 	log.Printf("[INFO] VPC : %s", *vpc.ID)
-	_, err = isWaitForVPCAvailable(sess, d.Id(), d.Timeout(schema.TimeoutCreate))
+	_, err = isWaitForVPCAvailable(ctx, sess, d.Id(), d.Timeout(schema.TimeoutCreate), meta)
 	if err != nil {
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVPCTags); ok || v != "" {
+	if _, ok := d.GetOk(isVPCTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isVPCTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpc.CRN)
 		if err != nil {
@@ -495,49 +494,55 @@ func vpcCreate(d *schema.ResourceData, meta interface{}, name, apm, rg string, i
 	return nil
 }
 
-func isWaitForVPCAvailable(vpc *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
+func isWaitForVPCAvailable(ctx context.Context, vpc *vpcv1.VpcV1, id string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf("Waiting for VPC (%s) to be available.", id)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{isVPCPending},
-		Target:     []string{isVPCAvailable, isVPCFailed},
-		Refresh:    isVPCRefreshFunc(vpc, id),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+	p := &poller{
+		Kind:    "ibm_is_vpc:available",
+		Name:    fmt.Sprintf("VPC (%s)", id),
+		Pending: []string{isVPCPending},
+		Target:  []string{isVPCAvailable, isVPCFailed},
+		Refresh: isVPCRefreshFunc(ctx, vpc, id),
+		Timeout: timeout,
+		Delay:   10 * time.Second,
+		Policy:  pollingPolicy(meta),
 	}
 
-	return stateConf.WaitForState()
+	return p.WaitForStateContext(ctx)
 }
 
-func isVPCRefreshFunc(vpc *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func isVPCRefreshFunc(ctx context.Context, vpc *vpcv1.VpcV1, id string) pollRefreshFunc {
+	return func(etag string) (interface{}, string, string, error) {
 		getvpcOptions := &vpcv1.GetVPCOptions{
-			ID: &id,
+			ID:      &id,
+			Headers: ifNoneMatchHeaders(etag),
+		}
+		vpc, response, err := vpc.GetVPCWithContext(ctx, getvpcOptions)
+		if isNotModified(response) {
+			return nil, "", etag, errNotModified
 		}
-		vpc, response, err := vpc.GetVPC(getvpcOptions)
 		if err != nil {
-			return nil, isVPCFailed, fmt.Errorf("Error getting VPC : %s\n%s", err, response)
+			return nil, isVPCFailed, "", newAPIError("Error getting VPC", err, response)
 		}
 
 		if *vpc.Status == isVPCAvailable || *vpc.Status == isVPCFailed {
-			return vpc, *vpc.Status, nil
+			return vpc, *vpc.Status, responseETag(response), nil
 		}
 
-		return vpc, isVPCPending, nil
+		return vpc, isVPCPending, responseETag(response), nil
 	}
 }
 
-func resourceIBMISVPCRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISVPCRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	id := d.Id()
-	err := vpcGet(d, meta, id)
+	err := vpcGet(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	return nil
 }
 
-func vpcGet(d *schema.ResourceData, meta interface{}, id string) error {
+func vpcGet(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -545,13 +550,13 @@ func vpcGet(d *schema.ResourceData, meta interface{}, id string) error {
 	getvpcOptions := &vpcv1.GetVPCOptions{
 		ID: &id,
 	}
-	vpc, response, err := sess.GetVPC(getvpcOptions)
+	vpc, response, err := sess.GetVPCWithContext(ctx, getvpcOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error getting VPC : %s\n%s", err, response)
+		return newAPIError("Error getting VPC", err, response)
 	}
 
 	d.Set(isVPCName, *vpc.Name)
@@ -615,9 +620,9 @@ func vpcGet(d *schema.ResourceData, meta interface{}, id string) error {
 		if start != "" {
 			options.Start = &start
 		}
-		s, response, err := sess.ListSubnets(options)
+		s, response, err := sess.ListSubnetsWithContext(ctx, options)
 		if err != nil {
-			return fmt.Errorf("Error Fetching subnets %s\n%s", err, response)
+			return newAPIError("Error Fetching subnets", err, response)
 		}
 		start = GetNext(s.Next)
 		allrecs = append(allrecs, s.Subnets...)
@@ -644,7 +649,7 @@ func vpcGet(d *schema.ResourceData, meta interface{}, id string) error {
 	//Set Security group list
 
 	listSgOptions := &vpcv1.ListSecurityGroupsOptions{}
-	sgs, _, err := sess.ListSecurityGroups(listSgOptions)
+	sgs, _, err := sess.ListSecurityGroupsWithContext(ctx, listSgOptions)
 	if err != nil {
 		return err
 	}
@@ -761,7 +766,7 @@ func vpcGet(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func resourceIBMISVPCUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISVPCUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	id := d.Id()
 
 	name := ""
@@ -771,14 +776,14 @@ func resourceIBMISVPCUpdate(d *schema.ResourceData, meta interface{}) error {
 		name = d.Get(isVPCName).(string)
 		hasChanged = true
 	}
-	err := vpcUpdate(d, meta, id, name, hasChanged)
+	err := vpcUpdate(ctx, d, meta, id, name, hasChanged)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	return resourceIBMISVPCRead(d, meta)
+	return resourceIBMISVPCRead(ctx, d, meta)
 }
 
-func vpcUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasChanged bool) error {
+func vpcUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, id, name string, hasChanged bool) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -787,9 +792,9 @@ func vpcUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 		getvpcOptions := &vpcv1.GetVPCOptions{
 			ID: &id,
 		}
-		vpc, response, err := sess.GetVPC(getvpcOptions)
+		vpc, response, err := sess.GetVPCWithContext(ctx, getvpcOptions)
 		if err != nil {
-			return fmt.Errorf("Error getting VPC : %s\n%s", err, response)
+			return newAPIError("Error getting VPC", err, response)
 		}
 		oldList, newList := d.GetChange(isVPCTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpc.CRN)
@@ -801,17 +806,17 @@ func vpcUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 
 	if d.HasChange(isVPCDefaultSecurityGroupName) {
 		if defaultSGName, ok := d.GetOk(isVPCDefaultSecurityGroupName); ok {
-			sgNameUpdate(sess, d.Get(isVPCDefaultSecurityGroup).(string), defaultSGName.(string))
+			sgNameUpdate(ctx, sess, d.Get(isVPCDefaultSecurityGroup).(string), defaultSGName.(string))
 		}
 	}
 	if d.HasChange(isVPCDefaultRoutingTableName) {
 		if defaultRTName, ok := d.GetOk(isVPCDefaultRoutingTableName); ok {
-			rtNameUpdate(sess, id, d.Get(isVPCDefaultRoutingTable).(string), defaultRTName.(string))
+			rtNameUpdate(ctx, sess, id, d.Get(isVPCDefaultRoutingTable).(string), defaultRTName.(string))
 		}
 	}
 	if d.HasChange(isVPCDefaultNetworkACLName) {
 		if defaultACLName, ok := d.GetOk(isVPCDefaultNetworkACLName); ok {
-			nwaclNameUpdate(sess, d.Get(isVPCDefaultNetworkACL).(string), defaultACLName.(string))
+			nwaclNameUpdate(ctx, sess, d.Get(isVPCDefaultNetworkACL).(string), defaultACLName.(string))
 		}
 	}
 
@@ -827,25 +832,25 @@ func vpcUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 			return fmt.Errorf("Error calling asPatch for VPCPatch: %s", err)
 		}
 		updateVpcOptions.VPCPatch = vpcPatch
-		_, response, err := sess.UpdateVPC(updateVpcOptions)
+		_, response, err := sess.UpdateVPCWithContext(ctx, updateVpcOptions)
 		if err != nil {
-			return fmt.Errorf("Error Updating VPC : %s\n%s", err, response)
+			return newAPIError("Error Updating VPC", err, response)
 		}
 	}
 	return nil
 }
 
-func resourceIBMISVPCDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIBMISVPCDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	id := d.Id()
-	err := vpcDelete(d, meta, id)
+	err := vpcDelete(ctx, d, meta, id)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	d.SetId("")
 	return nil
 }
 
-func vpcDelete(d *schema.ResourceData, meta interface{}, id string) error {
+func vpcDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, id string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
 		return err
@@ -854,23 +859,23 @@ func vpcDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	getVpcOptions := &vpcv1.GetVPCOptions{
 		ID: &id,
 	}
-	_, response, err := sess.GetVPC(getVpcOptions)
+	_, response, err := sess.GetVPCWithContext(ctx, getVpcOptions)
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error Getting VPC (%s): %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error Getting VPC (%s)", id), err, response)
 	}
 
 	deletevpcOptions := &vpcv1.DeleteVPCOptions{
 		ID: &id,
 	}
-	response, err = sess.DeleteVPC(deletevpcOptions)
+	response, err = sess.DeleteVPCWithContext(ctx, deletevpcOptions)
 	if err != nil {
-		return fmt.Errorf("Error Deleting VPC : %s\n%s", err, response)
+		return newAPIError("Error Deleting VPC", err, response)
 	}
-	_, err = isWaitForVPCDeleted(sess, id, d.Timeout(schema.TimeoutDelete))
+	_, err = isWaitForVPCDeleted(ctx, sess, id, d.Timeout(schema.TimeoutDelete), meta)
 	if err != nil {
 		return err
 	}
@@ -878,61 +883,43 @@ func vpcDelete(d *schema.ResourceData, meta interface{}, id string) error {
 	return nil
 }
 
-func isWaitForVPCDeleted(vpc *vpcv1.VpcV1, id string, timeout time.Duration) (interface{}, error) {
+func isWaitForVPCDeleted(ctx context.Context, vpc *vpcv1.VpcV1, id string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf("Waiting for VPC (%s) to be deleted.", id)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"retry", isVPCDeleting},
-		Target:     []string{isVPCDeleted, isVPCFailed},
-		Refresh:    isVPCDeleteRefreshFunc(vpc, id),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+	p := &poller{
+		Kind:    "ibm_is_vpc:deleted",
+		Name:    fmt.Sprintf("VPC (%s)", id),
+		Pending: []string{"retry", isVPCDeleting},
+		Target:  []string{isVPCDeleted, isVPCFailed},
+		Refresh: isVPCDeleteRefreshFunc(ctx, vpc, id),
+		Timeout: timeout,
+		Delay:   10 * time.Second,
+		Policy:  pollingPolicy(meta),
 	}
 
-	return stateConf.WaitForState()
+	return p.WaitForStateContext(ctx)
 }
 
-func isVPCDeleteRefreshFunc(vpc *vpcv1.VpcV1, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func isVPCDeleteRefreshFunc(ctx context.Context, vpc *vpcv1.VpcV1, id string) pollRefreshFunc {
+	return func(etag string) (interface{}, string, string, error) {
 		log.Printf("[DEBUG] delete function here")
 		getvpcOptions := &vpcv1.GetVPCOptions{
-			ID: &id,
+			ID:      &id,
+			Headers: ifNoneMatchHeaders(etag),
+		}
+		vpc, response, err := vpc.GetVPCWithContext(ctx, getvpcOptions)
+		if isNotModified(response) {
+			return nil, "", etag, errNotModified
 		}
-		vpc, response, err := vpc.GetVPC(getvpcOptions)
 		if err != nil {
 			if response != nil && response.StatusCode == 404 {
-				return vpc, isVPCDeleted, nil
+				return vpc, isVPCDeleted, "", nil
 			}
-			return nil, isVPCFailed, fmt.Errorf("The VPC %s failed to delete: %s\n%s", id, err, response)
+			return nil, isVPCFailed, "", newAPIError(fmt.Sprintf("The VPC %s failed to delete", id), err, response)
 		}
 
-		return vpc, isVPCDeleting, nil
-	}
-}
-
-func resourceIBMISVPCExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	id := d.Id()
-	exists, err := vpcExists(d, meta, id)
-	return exists, err
-}
-
-func vpcExists(d *schema.ResourceData, meta interface{}, id string) (bool, error) {
-	sess, err := vpcClient(meta)
-	if err != nil {
-		return false, err
-	}
-	getvpcOptions := &vpcv1.GetVPCOptions{
-		ID: &id,
-	}
-	_, response, err := sess.GetVPC(getvpcOptions)
-	if err != nil {
-		if response != nil && response.StatusCode == 404 {
-			return false, nil
-		}
-		return false, fmt.Errorf("Error getting VPC: %s\n%s", err, response)
+		return vpc, isVPCDeleting, responseETag(response), nil
 	}
-	return true, nil
 }
 
 func resourceIBMVPCHash(v interface{}) int {
@@ -942,7 +929,7 @@ func resourceIBMVPCHash(v interface{}) int {
 	return hashcode.String(buf.String())
 }
 
-func nwaclNameUpdate(sess *vpcv1.VpcV1, id, name string) error {
+func nwaclNameUpdate(ctx context.Context, sess *vpcv1.VpcV1, id, name string) error {
 	updateNetworkACLOptions := &vpcv1.UpdateNetworkACLOptions{
 		ID: &id,
 	}
@@ -954,14 +941,14 @@ func nwaclNameUpdate(sess *vpcv1.VpcV1, id, name string) error {
 		return fmt.Errorf("Error calling asPatch for NetworkACLPatch: %s", err)
 	}
 	updateNetworkACLOptions.NetworkACLPatch = networkACLPatch
-	_, response, err := sess.UpdateNetworkACL(updateNetworkACLOptions)
+	_, response, err := sess.UpdateNetworkACLWithContext(ctx, updateNetworkACLOptions)
 	if err != nil {
-		return fmt.Errorf("Error Updating Network ACL(%s) name : %s\n%s", id, err, response)
+		return newAPIError(fmt.Sprintf("Error Updating Network ACL(%s) name", id), err, response)
 	}
 	return nil
 }
 
-func sgNameUpdate(sess *vpcv1.VpcV1, id, name string) error {
+func sgNameUpdate(ctx context.Context, sess *vpcv1.VpcV1, id, name string) error {
 	updateSecurityGroupOptions := &vpcv1.UpdateSecurityGroupOptions{
 		ID: &id,
 	}
@@ -973,14 +960,14 @@ func sgNameUpdate(sess *vpcv1.VpcV1, id, name string) error {
 		return fmt.Errorf("Error calling asPatch for SecurityGroupPatch: %s", err)
 	}
 	updateSecurityGroupOptions.SecurityGroupPatch = securityGroupPatch
-	_, response, err := sess.UpdateSecurityGroup(updateSecurityGroupOptions)
+	_, response, err := sess.UpdateSecurityGroupWithContext(ctx, updateSecurityGroupOptions)
 	if err != nil {
-		return fmt.Errorf("Error Updating Security Group name : %s\n%s", err, response)
+		return newAPIError("Error Updating Security Group name", err, response)
 	}
 	return nil
 }
 
-func rtNameUpdate(sess *vpcv1.VpcV1, vpcID, id, name string) error {
+func rtNameUpdate(ctx context.Context, sess *vpcv1.VpcV1, vpcID, id, name string) error {
 	updateVpcRoutingTableOptions := new(vpcv1.UpdateVPCRoutingTableOptions)
 	updateVpcRoutingTableOptions.VPCID = &vpcID
 	updateVpcRoutingTableOptions.ID = &id
@@ -991,9 +978,9 @@ func rtNameUpdate(sess *vpcv1.VpcV1, vpcID, id, name string) error {
 		return fmt.Errorf("Error calling asPatch for RoutingTablePatchModel: %s", asPatchErr)
 	}
 	updateVpcRoutingTableOptions.RoutingTablePatch = routingTablePatchModelAsPatch
-	_, response, err := sess.UpdateVPCRoutingTable(updateVpcRoutingTableOptions)
+	_, response, err := sess.UpdateVPCRoutingTableWithContext(ctx, updateVpcRoutingTableOptions)
 	if err != nil {
-		return fmt.Errorf("Error Updating Routing table name %s\n%s", err, response)
+		return newAPIError("Error Updating Routing table name", err, response)
 	}
 	return nil
 }
@@ -26,7 +26,7 @@ func resourceIBMISVpcAddressPrefix() *schema.Resource {
 		Update:   resourceIBMISVpcAddressPrefixUpdate,
 		Delete:   resourceIBMISVpcAddressPrefixDelete,
 		Exists:   resourceIBMISVpcAddressPrefixExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "vpc_id", "address_prefix_id"),
 
 		Schema: map[string]*schema.Schema{
 			isVPCAddressPrefixPrefixName: {
@@ -122,10 +122,13 @@ func resourceIBMISVpcAddressPrefixCreate(d *schema.ResourceData, meta interface{
 	}
 
 	isVPCAddressPrefixKey := "vpc_address_prefix_key_" + vpcID
-	ibmMutexKV.Lock(isVPCAddressPrefixKey)
-	defer ibmMutexKV.Unlock(isVPCAddressPrefixKey)
+	unlock, err := acquireLocks(d, schema.TimeoutCreate, "ibm_is_vpc_address_prefix create", []string{isVPCAddressPrefixKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	err := vpcAddressPrefixCreate(d, meta, prefixName, zoneName, cidr, vpcID, isDefault)
+	err = vpcAddressPrefixCreate(d, meta, prefixName, zoneName, cidr, vpcID, isDefault)
 	if err != nil {
 		return err
 	}
@@ -224,8 +227,11 @@ func resourceIBMISVpcAddressPrefixUpdate(d *schema.ResourceData, meta interface{
 	addrPrefixID := parts[1]
 
 	isVPCAddressPrefixKey := "vpc_address_prefix_key_" + vpcID
-	ibmMutexKV.Lock(isVPCAddressPrefixKey)
-	defer ibmMutexKV.Unlock(isVPCAddressPrefixKey)
+	unlock, err := acquireLocks(d, schema.TimeoutUpdate, "ibm_is_vpc_address_prefix update", []string{isVPCAddressPrefixKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	if d.HasChange(isVPCAddressPrefixPrefixName) {
 		name = d.Get(isVPCAddressPrefixPrefixName).(string)
@@ -284,8 +290,11 @@ func resourceIBMISVpcAddressPrefixDelete(d *schema.ResourceData, meta interface{
 	addrPrefixID := parts[1]
 
 	isVPCAddressPrefixKey := "vpc_address_prefix_key_" + vpcID
-	ibmMutexKV.Lock(isVPCAddressPrefixKey)
-	defer ibmMutexKV.Unlock(isVPCAddressPrefixKey)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_is_vpc_address_prefix delete", []string{isVPCAddressPrefixKey}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	error := vpcAddressPrefixDelete(d, meta, vpcID, addrPrefixID)
 	if error != nil {
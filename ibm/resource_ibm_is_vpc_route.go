@@ -37,7 +37,7 @@ func resourceIBMISVpcRoute() *schema.Resource {
 		Update:   resourceIBMISVpcRouteUpdate,
 		Delete:   resourceIBMISVpcRouteDelete,
 		Exists:   resourceIBMISVpcRouteExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "vpc_id", "route_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
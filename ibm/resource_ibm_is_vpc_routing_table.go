@@ -41,7 +41,7 @@ func resourceIBMISVPCRoutingTable() *schema.Resource {
 		Update:   resourceIBMISVPCRoutingTableUpdate,
 		Delete:   resourceIBMISVPCRoutingTableDelete,
 		Exists:   resourceIBMISVPCRoutingTableExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "vpc_id", "routing_table_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -31,7 +31,7 @@ func resourceIBMISVPCRoutingTableRoute() *schema.Resource {
 		Update:   resourceIBMISVPCRoutingTableRouteUpdate,
 		Delete:   resourceIBMISVPCRoutingTableRouteDelete,
 		Exists:   resourceIBMISVPCRoutingTableRouteExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "vpc_id", "routing_table_id", "route_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -39,6 +39,8 @@ func resourceIBMISVPCRoutingTableRoute() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceValidatorCustomizeDiff("ibm_is_vpc_routing_table_route"),
+
 		Schema: map[string]*schema.Schema{
 			rtID: {
 				Type:        schema.TypeString,
@@ -138,7 +140,17 @@ func resourceIBMISVPCRoutingTableRouteValidator() *ResourceValidator {
 			Required:                   false,
 			AllowedValues:              actionAllowedValues})
 
-	ibmVPCRoutingTableRouteValidator := ResourceValidator{ResourceName: "ibm_is_vpc_routing_table_route", Schema: validateSchema}
+	// only the routes delivering packets have a next hop
+	rules := []ValidateRule{
+		{
+			RuleIdentifier: ConditionalAllowedValues,
+			Identifiers:    []string{rAction, rNextHop},
+			When:           "delegate, delegate_vpc, drop",
+			AllowedValues:  "0.0.0.0",
+		},
+	}
+
+	ibmVPCRoutingTableRouteValidator := ResourceValidator{ResourceName: "ibm_is_vpc_routing_table_route", Schema: validateSchema, Rules: rules}
 	return &ibmVPCRoutingTableRouteValidator
 }
 
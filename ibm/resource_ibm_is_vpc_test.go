@@ -4,10 +4,15 @@
 package ibm
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	gohttp "net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -189,3 +194,29 @@ func testAccCheckIBMISVPCSgConfig(vpcname string, sgname string) string {
 `, vpcname, sgname)
 
 }
+
+func TestIsWaitForVPCAvailableCancel(t *testing.T) {
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "r006-1234", "status": "pending"}`)
+	}))
+	defer server.Close()
+	sess, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = isWaitForVPCAvailable(ctx, sess, "r006-1234", 10*time.Minute, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected the wait to be cancelled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the wait to stop when cancelled, took %s", elapsed)
+	}
+}
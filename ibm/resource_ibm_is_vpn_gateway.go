@@ -51,7 +51,7 @@ func resourceIBMISVPNGateway() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -285,7 +285,7 @@ func vpngwCreate(d *schema.ResourceData, meta interface{}, name, subnetID, mode
 	log.Printf("[INFO] VPNGateway : %s", *vpnGateway.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVPNGatewayTags); ok || v != "" {
+	if _, ok := d.GetOk(isVPNGatewayTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(isVPNGatewayTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpnGateway.CRN)
 		if err != nil {
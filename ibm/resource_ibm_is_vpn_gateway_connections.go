@@ -46,7 +46,7 @@ func resourceIBMISVPNGatewayConnection() *schema.Resource {
 		Update:   resourceIBMISVPNGatewayConnectionUpdate,
 		Delete:   resourceIBMISVPNGatewayConnectionDelete,
 		Exists:   resourceIBMISVPNGatewayConnectionExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "vpn_gateway_id", "connection_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -15,7 +15,7 @@ func resourceIBMKmskeyAlias() *schema.Resource {
 		Create:   resourceIBMKmsKeyAliasCreate,
 		Delete:   resourceIBMKmsKeyAliasDelete,
 		Read:     resourceIBMKmsKeyAliasRead,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts(":alias:", "alias", "key_crn"),
 
 		Schema: map[string]*schema.Schema{
 			"instance_id": {
@@ -18,7 +18,7 @@ func resourceIBMKmskeyRings() *schema.Resource {
 		Create:   resourceIBMKmsKeyRingCreate,
 		Delete:   resourceIBMKmsKeyRingDelete,
 		Read:     resourceIBMKmsKeyRingRead,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts(":keyRing:", "key_ring_id", "instance_crn"),
 
 		Schema: map[string]*schema.Schema{
 			"instance_id": {
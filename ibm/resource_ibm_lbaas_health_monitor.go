@@ -18,7 +18,7 @@ func resourceIBMLbaasHealthMonitor() *schema.Resource {
 		Read:     resourceIBMLbaasHealthMonitorRead,
 		Delete:   resourceIBMLbaasHealthMonitorDelete,
 		Update:   resourceIBMLbaasHealthMonitorUpdate,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "lbaas_id", "monitor_id"),
 
 		Schema: map[string]*schema.Schema{
 
@@ -23,6 +23,9 @@ func resourceIBMNetworkInterfaceSGAttachment() *schema.Resource {
 		Read:   resourceIBMNetworkInterfaceSGAttachmentRead,
 		Delete: resourceIBMNetworkInterfaceSGAttachmentDelete,
 		Exists: resourceIBMNetworkInterfaceSGAttachmentExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceIBMNetworkInterfaceSGAttachmentImport,
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(15 * time.Minute),
 		},
@@ -52,8 +55,11 @@ func resourceIBMNetworkInterfaceSGAttachment() *schema.Resource {
 
 func resourceIBMNetworkInterfaceSGAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
 	mk := "network_interface_sg_attachment_" + strconv.Itoa(d.Get("network_interface_id").(int))
-	ibmMutexKV.Lock(mk)
-	defer ibmMutexKV.Unlock(mk)
+	unlock, err := acquireLocks(d, schema.TimeoutCreate, "ibm_network_interface_sg_attachment create", []string{mk}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	sess := meta.(ClientSession).SoftLayerSession()
 	service := services.GetNetworkSecurityGroupService(sess)
@@ -61,7 +67,7 @@ func resourceIBMNetworkInterfaceSGAttachmentCreate(d *schema.ResourceData, meta
 
 	sgID := d.Get("security_group_id").(int)
 	interfaceID := d.Get("network_interface_id").(int)
-	_, err := WaitForVSAvailable(d, meta, d.Timeout(schema.TimeoutCreate))
+	_, err = WaitForVSAvailable(d, meta, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return err
 	}
@@ -131,8 +137,11 @@ func resourceIBMNetworkInterfaceSGAttachmentRead(d *schema.ResourceData, meta in
 
 func resourceIBMNetworkInterfaceSGAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
 	mk := "network_interface_sg_attachment_" + strconv.Itoa(d.Get("network_interface_id").(int))
-	ibmMutexKV.Lock(mk)
-	defer ibmMutexKV.Unlock(mk)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_network_interface_sg_attachment delete", []string{mk}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	sess := meta.(ClientSession).SoftLayerSession()
 	service := services.GetNetworkSecurityGroupService(sess)
 	sgID, interfaceID, err := decomposeNetworkSGAttachmentID(d.Id())
@@ -173,6 +182,16 @@ func resourceIBMNetworkInterfaceSGAttachmentExists(d *schema.ResourceData, meta
 	return false, fmt.Errorf("No association found between security group %d and network interface %d", sgID, interfaceID)
 }
 
+func resourceIBMNetworkInterfaceSGAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	sgID, interfaceID, err := decomposeNetworkSGAttachmentID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("security_group_id", sgID)
+	d.Set("network_interface_id", interfaceID)
+	return []*schema.ResourceData{d}, nil
+}
+
 func decomposeNetworkSGAttachmentID(attachmentID string) (sgID, interfaceID int, err error) {
 	ids := strings.Split(attachmentID, "_")
 	if len(ids) != 2 {
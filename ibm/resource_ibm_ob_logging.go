@@ -36,7 +36,7 @@ func resourceIBMObLogging() *schema.Resource {
 		Read:     resourceIBMLoggingRead,
 		Update:   resourceIBMLoggingUpdate,
 		Delete:   resourceIBMLoggingDelete,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cluster", "instance_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
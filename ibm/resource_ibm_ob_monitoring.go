@@ -36,7 +36,7 @@ func resourceIBMObMonitoring() *schema.Resource {
 		Read:     resourceIBMMonitoringRead,
 		Update:   resourceIBMMonitoringUpdate,
 		Delete:   resourceIBMMonitoringDelete,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cluster", "instance_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -23,7 +23,7 @@ func resourceIBMPIImage() *schema.Resource {
 		Update:   resourceIBMPIImageUpdate,
 		Delete:   resourceIBMPIImageDelete,
 		Exists:   resourceIBMPIImageExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cloud_instance_id", "image_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -38,7 +38,7 @@ func resourceIBMPIInstance() *schema.Resource {
 		Update:   resourceIBMPIInstanceUpdate,
 		Delete:   resourceIBMPIInstanceDelete,
 		Exists:   resourceIBMPIInstanceExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cloud_instance_id", "instance_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(120 * time.Minute),
@@ -22,7 +22,7 @@ func resourceIBMPIKey() *schema.Resource {
 		Update:   resourceIBMPIKeyUpdate,
 		Delete:   resourceIBMPIKeyDelete,
 		Exists:   resourceIBMPIKeyExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cloud_instance_id", "key_name"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -25,13 +25,15 @@ func resourceIBMPINetwork() *schema.Resource {
 		Update: resourceIBMPINetworkUpdate,
 		Delete: resourceIBMPINetworkDelete,
 		//Exists:   resourceIBMPINetworkExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cloud_instance_id", "network_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(60 * time.Minute),
 		},
 
+		CustomizeDiff: resourceValidatorCustomizeDiff("ibm_pi_network"),
+
 		Schema: map[string]*schema.Schema{
 
 			helpers.PINetworkType: {
@@ -88,6 +90,28 @@ func resourceIBMPINetwork() *schema.Resource {
 	}
 }
 
+func resourceIBMPINetworkValidator() *ResourceValidator {
+
+	validateSchema := make([]ValidateSchema, 0)
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 helpers.PINetworkDNS,
+			ValidateFunctionIdentifier: ValidateIP,
+			Type:                       TypeString,
+			Optional:                   true,
+			Elements:                   true})
+
+	rules := []ValidateRule{
+		{
+			RuleIdentifier: CIDRWithinCIDR,
+			Identifiers:    []string{helpers.PINetworkGateway, helpers.PINetworkCidr},
+		},
+	}
+
+	ibmPINetworkResourceValidator := ResourceValidator{ResourceName: "ibm_pi_network", Schema: validateSchema, Rules: rules}
+	return &ibmPINetworkResourceValidator
+}
+
 func resourceIBMPINetworkCreate(d *schema.ResourceData, meta interface{}) error {
 	sess, err := meta.(ClientSession).IBMPISession()
 	if err != nil {
@@ -24,7 +24,7 @@ func resourceIBMPINetworkPort() *schema.Resource {
 		Update: resourceIBMPINetworkPortUpdate,
 		Delete: resourceIBMPINetworkPortDelete,
 		//Exists:   resourceIBMPINetworkExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cloud_instance_id", "port_id", "network_name"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -23,7 +23,7 @@ func resourceIBMPINetworkPortAttach() *schema.Resource {
 		Update: resourceIBMPINetworkPortAttachUpdate,
 		Delete: resourceIBMPINetworkPortAttachDelete,
 		//Exists:   resourceIBMPINetworkExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cloud_instance_id", "port_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
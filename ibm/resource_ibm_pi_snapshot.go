@@ -25,7 +25,7 @@ func resourceIBMPISnapshot() *schema.Resource {
 		Update:   resourceIBMPISnapshotUpdate,
 		Delete:   resourceIBMPISnapshotDelete,
 		Exists:   resourceIBMPISnapshotExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cloud_instance_id", "snapshot_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -33,7 +33,7 @@ func resourceIBMPIVolume() *schema.Resource {
 		Update:   resourceIBMPIVolumeUpdate,
 		Delete:   resourceIBMPIVolumeDelete,
 		Exists:   resourceIBMPIVolumeExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cloud_instance_id", "volume_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
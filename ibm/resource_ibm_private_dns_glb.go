@@ -39,7 +39,7 @@ func resourceIBMPrivateDNSGLB() *schema.Resource {
 		Update:   resourceIBMPrivateDNSGLBUpdate,
 		Delete:   resourceIBMPrivateDNSGLBDelete,
 		Exists:   resourceIBMPrivateDNSGLBExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "instance_id", "zone_id", "glb_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -41,7 +41,7 @@ func resourceIBMPrivateDNSGLBMonitor() *schema.Resource {
 		Update:   resourceIBMPrivateDNSGLBMonitorUpdate,
 		Delete:   resourceIBMPrivateDNSGLBMonitorDelete,
 		Exists:   resourceIBMPrivateDNSGLBMonitorExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "instance_id", "monitor_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
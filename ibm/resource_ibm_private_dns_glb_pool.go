@@ -48,7 +48,7 @@ func resourceIBMPrivateDNSGLBPool() *schema.Resource {
 		Update:   resourceIBMPrivateDNSGLBPoolUpdate,
 		Delete:   resourceIBMPrivateDNSGLBPoolDelete,
 		Exists:   resourceIBMPrivateDNSGLBPoolExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "instance_id", "pool_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -31,7 +31,7 @@ func resourceIBMPrivateDNSPermittedNetwork() *schema.Resource {
 		Read:     resourceIBMPrivateDNSPermittedNetworkRead,
 		Delete:   resourceIBMPrivateDNSPermittedNetworkDelete,
 		Exists:   resourceIBMPrivateDNSPermittedNetworkExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "instance_id", "zone_id", "permitted_network_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -107,8 +107,11 @@ func resourceIBMPrivateDNSPermittedNetworkCreate(d *schema.ResourceData, meta in
 	vpcCRN := d.Get(pdnsVpcCRN).(string)
 	nwType := d.Get(pdnsNetworkType).(string)
 	mk := "private_dns_permitted_network_" + instanceID + zoneID
-	ibmMutexKV.Lock(mk)
-	defer ibmMutexKV.Unlock(mk)
+	unlock, err := acquireLocks(d, schema.TimeoutCreate, "ibm_private_dns_permitted_network create", []string{mk}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	createPermittedNetworkOptions := sess.NewCreatePermittedNetworkOptions(instanceID, zoneID)
 	permittedNetworkCrn, err := sess.NewPermittedNetworkVpc(vpcCRN)
@@ -162,8 +165,11 @@ func resourceIBMPrivateDNSPermittedNetworkDelete(d *schema.ResourceData, meta in
 
 	idSet := strings.Split(d.Id(), "/")
 	mk := "private_dns_permitted_network_" + idSet[0] + idSet[1]
-	ibmMutexKV.Lock(mk)
-	defer ibmMutexKV.Unlock(mk)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_private_dns_permitted_network delete", []string{mk}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	deletePermittedNetworkOptions := sess.NewDeletePermittedNetworkOptions(idSet[0], idSet[1], idSet[2])
 	_, response, err := sess.DeletePermittedNetwork(deletePermittedNetworkOptions)
 
@@ -187,8 +193,11 @@ func resourceIBMPrivateDNSPermittedNetworkExists(d *schema.ResourceData, meta in
 	}
 
 	mk := "private_dns_permitted_network_" + idSet[0] + idSet[1]
-	ibmMutexKV.Lock(mk)
-	defer ibmMutexKV.Unlock(mk)
+	unlock, err := acquireLocks(d, schema.TimeoutRead, "ibm_private_dns_permitted_network exists", nil, []string{mk})
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
 	getPermittedNetworkOptions := sess.NewGetPermittedNetworkOptions(idSet[0], idSet[1], idSet[2])
 	_, response, err := sess.GetPermittedNetwork(getPermittedNetworkOptions)
 	if err != nil {
@@ -42,7 +42,7 @@ func resourceIBMPrivateDNSResourceRecord() *schema.Resource {
 		Update:   resourceIBMPrivateDNSResourceRecordUpdate,
 		Delete:   resourceIBMPrivateDNSResourceRecordDelete,
 		Exists:   resourceIBMPrivateDNSResourceRecordExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "instance_id", "zone_id", "record_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -286,8 +286,11 @@ func resourceIBMPrivateDNSResourceRecordCreate(d *schema.ResourceData, meta inte
 		createResourceRecordOptions.SetProtocol(protocol)
 	}
 	mk := "private_dns_resource_record_" + instanceID + zoneID
-	ibmMutexKV.Lock(mk)
-	defer ibmMutexKV.Unlock(mk)
+	unlock, err := acquireLocks(d, schema.TimeoutCreate, "ibm_private_dns_resource_record create", []string{mk}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	response, detail, err := sess.CreateResourceRecord(createResourceRecordOptions)
 	if err != nil {
 		return fmt.Errorf("Error creating pdns resource record:%s\n%s", err, detail)
@@ -376,8 +379,11 @@ func resourceIBMPrivateDNSResourceRecordUpdate(d *schema.ResourceData, meta inte
 	}
 
 	mk := "private_dns_resource_record_" + idSet[0] + idSet[1]
-	ibmMutexKV.Lock(mk)
-	defer ibmMutexKV.Unlock(mk)
+	unlock, err := acquireLocks(d, schema.TimeoutUpdate, "ibm_private_dns_resource_record update", []string{mk}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	updateResourceRecordOptions := sess.NewUpdateResourceRecordOptions(idSet[0], idSet[1], idSet[2])
 
@@ -484,8 +490,11 @@ func resourceIBMPrivateDNSResourceRecordDelete(d *schema.ResourceData, meta inte
 
 	deleteResourceRecordOptions := sess.NewDeleteResourceRecordOptions(idSet[0], idSet[1], idSet[2])
 	mk := "private_dns_resource_record_" + idSet[0] + idSet[1]
-	ibmMutexKV.Lock(mk)
-	defer ibmMutexKV.Unlock(mk)
+	unlock, err := acquireLocks(d, schema.TimeoutDelete, "ibm_private_dns_resource_record delete", []string{mk}, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	response, err := sess.DeleteResourceRecord(deleteResourceRecordOptions)
 	if err != nil {
 		return fmt.Errorf("Error deleting pdns resource record:%s\n%s", err, response)
@@ -507,8 +516,11 @@ func resourceIBMPrivateDNSResourceRecordExists(d *schema.ResourceData, meta inte
 	}
 	getResourceRecordOptions := sess.NewGetResourceRecordOptions(idSet[0], idSet[1], idSet[2])
 	mk := "private_dns_resource_record_" + idSet[0] + idSet[1]
-	ibmMutexKV.Lock(mk)
-	defer ibmMutexKV.Unlock(mk)
+	unlock, err := acquireLocks(d, schema.TimeoutRead, "ibm_private_dns_resource_record exists", nil, []string{mk})
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
 	_, response, err := sess.GetResourceRecord(getResourceRecordOptions)
 
 	if err != nil {
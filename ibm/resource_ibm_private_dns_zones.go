@@ -29,7 +29,7 @@ func resourceIBMPrivateDNSZone() *schema.Resource {
 		Update:   resourceIBMPrivateDNSZoneUpdate,
 		Delete:   resourceIBMPrivateDNSZoneDelete,
 		Exists:   resourceIBMPrivateDNSZoneExists,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "instance_id", "zone_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
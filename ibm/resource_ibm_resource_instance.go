@@ -47,7 +47,7 @@ func resourceIBMResourceInstance() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -468,7 +468,7 @@ func resourceIBMResourceInstanceCreate(d *schema.ResourceData, meta interface{})
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk("tags"); ok || v != "" {
+	if _, ok := d.GetOk("tags"); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange("tags")
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
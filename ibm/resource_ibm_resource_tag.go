@@ -37,7 +37,7 @@ func resourceIBMResourceTag() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -128,11 +128,12 @@ func resourceIBMResourceTagCreate(d *schema.ResourceData, meta interface{}) erro
 	resources = append(resources, r)
 
 	var add []string
-	if v, ok := d.GetOk(tags); ok {
-		tags := v.(*schema.Set)
-		for _, t := range tags.List() {
-			add = append(add, fmt.Sprint(t))
-		}
+	tagSet := d.Get(tags).(*schema.Set)
+	if tType := d.Get(tagType).(string); tType == "" || tType == "user" {
+		tagSet = withDefaultTags(tagSet, meta)
+	}
+	for _, t := range tagSet.List() {
+		add = append(add, fmt.Sprint(t))
 	}
 
 	schematicTags := os.Getenv("IC_ENV_TAGS")
@@ -43,7 +43,7 @@ func resourceIBMSatelliteCluster() *schema.Resource {
 				return immutableResourceCustomizeDiff([]string{"name", "location", "resource_group_id"}, diff)
 			},
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -394,7 +394,7 @@ func resourceIBMSatelliteClusterCreate(d *schema.ResourceData, meta interface{})
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk("tags"); ok || v != "" {
+	if _, ok := d.GetOk("tags"); ok || v != "" || hasDefaultTags(meta) {
 		getSatClusterOptions := &kubernetesserviceapiv1.GetClusterOptions{
 			Cluster: ptrToString(*instance.ID),
 		}
@@ -633,7 +633,7 @@ func resourceIBMSatelliteClusterUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if d.HasChange("tags") || v != "" {
+	if d.HasChange("tags") || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange("tags")
 		getSatClusterOptions := &kubernetesserviceapiv1.GetClusterOptions{
 			Cluster:            &clusterID,
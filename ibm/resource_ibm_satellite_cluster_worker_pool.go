@@ -24,7 +24,7 @@ func resourceIBMSatelliteClusterWorkerPool() *schema.Resource {
 		Read:     resourceIBMSatelliteClusterWorkerPoolRead,
 		Update:   resourceIBMSatelliteClusterWorkerPoolUpdate,
 		Delete:   resourceIBMSatelliteClusterWorkerPoolDelete,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "cluster", "worker_pool_id"),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(120 * time.Minute),
 			Delete: schema.DefaultTimeout(90 * time.Minute),
@@ -37,7 +37,7 @@ func resourceIBMSatelliteHost() *schema.Resource {
 		Read:     resourceIBMSatelliteHostRead,
 		Update:   resourceIBMSatelliteHostUpdate,
 		Delete:   resourceIBMSatelliteHostDelete,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "location", "host_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(75 * time.Minute),
@@ -37,7 +37,7 @@ func resourceIBMSatelliteLocation() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
 				return immutableResourceCustomizeDiff([]string{satLocation, sateLocZone, "resource_group_id"}, diff)
@@ -260,7 +260,7 @@ func resourceIBMSatelliteLocationCreate(d *schema.ResourceData, meta interface{}
 	log.Printf("[INFO] Created satellite location : %s", satLocation)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk("tags"); ok || v != "" {
+	if _, ok := d.GetOk("tags"); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange("tags")
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.Crn)
 		if err != nil {
@@ -345,7 +345,7 @@ func resourceIBMSatelliteLocationUpdate(d *schema.ResourceData, meta interface{}
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if d.HasChange("tags") || v != "" {
+	if d.HasChange("tags") || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange("tags")
 		getSatLocOptions := &kubernetesserviceapiv1.GetSatelliteLocationOptions{
 			Controller: &ID,
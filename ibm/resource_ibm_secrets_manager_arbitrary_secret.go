@@ -0,0 +1,160 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
+)
+
+func resourceIBMSecretsManagerArbitrarySecret() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMSecretsManagerArbitrarySecretCreate,
+		ReadContext:   resourceIBMSecretsManagerArbitrarySecretRead,
+		UpdateContext: resourceIBMSecretsManagerArbitrarySecretUpdate,
+		DeleteContext: resourceIBMSecretsManagerArbitrarySecretDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Schema: secretsManagerSecretSchema(map[string]*schema.Schema{
+			"payload": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The arbitrary secret data. A change of the payload rotates the secret.",
+			},
+			"expiration_date": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentSecretsManagerDate,
+				Description:      "The date the secret material expires. The date format follows RFC 3339.",
+			},
+		}),
+	}
+}
+
+func resourceIBMSecretsManagerArbitrarySecretCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := d.Get("instance_id").(string)
+	secretsManagerClient, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secret := &secretsmanagerv1.SecretResourceArbitrarySecretResource{
+		Name:    core.StringPtr(d.Get("name").(string)),
+		Payload: core.StringPtr(d.Get("payload").(string)),
+		Labels:  expandStringList(d.Get("labels").([]interface{})),
+	}
+	if v, ok := d.GetOk("description"); ok {
+		secret.Description = core.StringPtr(v.(string))
+	}
+	if v, ok := d.GetOk("secret_group_id"); ok {
+		secret.SecretGroupID = core.StringPtr(v.(string))
+	}
+	if v, ok := d.GetOk("expiration_date"); ok {
+		expirationDate, err := core.ParseDateTime(v.(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Error parsing expiration_date: %s", err))
+		}
+		secret.ExpirationDate = &expirationDate
+	}
+
+	result, response, err := secretsManagerClient.CreateSecretWithContext(context, &secretsmanagerv1.CreateSecretOptions{
+		SecretType: core.StringPtr(secretsmanagerv1.CreateSecretOptionsSecretTypeArbitraryConst),
+		Metadata:   secretsManagerCollectionMetadata(secretsmanagerv1.CollectionMetadataCollectionTypeApplicationVndIBMSecretsManagerSecretJSONConst),
+		Resources:  []secretsmanagerv1.SecretResourceIntf{secret},
+	})
+	if err != nil {
+		log.Printf("[DEBUG] CreateSecretWithContext failed %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error creating the arbitrary secret", err, response).withAttributes("name", "description", "secret_group_id", "labels", "expiration_date"))
+	}
+	created, err := secretsManagerSecretResourceOf(result.Resources)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(secretsManagerResourceID(instanceID, *created.ID))
+
+	return resourceIBMSecretsManagerArbitrarySecretRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerArbitrarySecretRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, instanceID, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secret, err := getSecretsManagerSecret(context, secretsManagerClient, secretsmanagerv1.GetSecretOptionsSecretTypeArbitraryConst, secretID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if secret == nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err = setSecretsManagerSecret(d, instanceID, secret); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("payload", secretsManagerSecretData(secret.SecretData)["payload"]); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting payload: %s", err))
+	}
+	if err = d.Set("expiration_date", dateTimeToString(secret.ExpirationDate)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting expiration_date: %s", err))
+	}
+
+	return nil
+}
+
+func resourceIBMSecretsManagerArbitrarySecretUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, _, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = updateSecretsManagerSecretMetadata(context, secretsManagerClient, d, secretsmanagerv1.UpdateSecretMetadataOptionsSecretTypeArbitraryConst, secretID, true)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if d.HasChange("payload") {
+		_, response, err := secretsManagerClient.UpdateSecretWithContext(context, &secretsmanagerv1.UpdateSecretOptions{
+			SecretType: core.StringPtr(secretsmanagerv1.UpdateSecretOptionsSecretTypeArbitraryConst),
+			ID:         core.StringPtr(secretID),
+			Action:     core.StringPtr(secretsmanagerv1.UpdateSecretOptionsActionRotateConst),
+			SecretActionOneOf: &secretsmanagerv1.SecretActionOneOfRotateArbitrarySecretBody{
+				Payload: core.StringPtr(d.Get("payload").(string)),
+			},
+		})
+		if err != nil {
+			log.Printf("[DEBUG] UpdateSecretWithContext failed %s\n%s", err, response)
+			return diagFromErr(newAPIError("Error rotating the arbitrary secret", err, response))
+		}
+	}
+
+	return resourceIBMSecretsManagerArbitrarySecretRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerArbitrarySecretDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, _, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = deleteSecretsManagerSecret(context, secretsManagerClient, secretsmanagerv1.DeleteSecretOptionsSecretTypeArbitraryConst, secretID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
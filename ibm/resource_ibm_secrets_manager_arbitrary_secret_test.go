@@ -0,0 +1,69 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMSecretsManagerArbitrarySecretBasic(t *testing.T) {
+	name := fmt.Sprintf("tf-arbitrary-secret-%d", acctest.RandIntRange(10, 100))
+	nameUpdate := fmt.Sprintf("tf-arbitrary-secret-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSecretsManager(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMSecretsManagerSecretDestroy("ibm_secrets_manager_arbitrary_secret"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerArbitrarySecretConfigBasic(name, "first payload"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMSecretsManagerSecretExists("ibm_secrets_manager_arbitrary_secret.secret"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_arbitrary_secret.secret", "name", name),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_arbitrary_secret.secret", "payload", "first payload"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_arbitrary_secret.secret", "labels.#", "2"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_arbitrary_secret.secret", "versions_total", "1"),
+					resource.TestCheckResourceAttrPair("ibm_secrets_manager_arbitrary_secret.secret", "secret_group_id", "ibm_secrets_manager_secret_group.secret_group", "secret_group_id"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerArbitrarySecretConfigBasic(nameUpdate, "second payload"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_secrets_manager_arbitrary_secret.secret", "name", nameUpdate),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_arbitrary_secret.secret", "payload", "second payload"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_arbitrary_secret.secret", "versions_total", "2"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "ibm_secrets_manager_arbitrary_secret.secret",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMSecretsManagerArbitrarySecretConfigBasic(name, payload string) string {
+	return fmt.Sprintf(`
+
+		resource "ibm_secrets_manager_secret_group" "secret_group" {
+			instance_id = "%[1]s"
+			name        = "%[2]s-group"
+		}
+
+		resource "ibm_secrets_manager_arbitrary_secret" "secret" {
+			instance_id     = "%[1]s"
+			secret_group_id = ibm_secrets_manager_secret_group.secret_group.secret_group_id
+			name            = "%[2]s"
+			description     = "Arbitrary secret of the tests"
+			labels          = ["terraform", "test"]
+			payload         = "%[3]s"
+			expiration_date = "2030-01-01T00:00:00Z"
+		}
+	`, secretsManagerInstanceID, name, payload)
+}
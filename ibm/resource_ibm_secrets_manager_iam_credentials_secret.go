@@ -0,0 +1,188 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
+)
+
+func resourceIBMSecretsManagerIAMCredentialsSecret() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMSecretsManagerIAMCredentialsSecretCreate,
+		ReadContext:   resourceIBMSecretsManagerIAMCredentialsSecretRead,
+		UpdateContext: resourceIBMSecretsManagerIAMCredentialsSecretUpdate,
+		DeleteContext: resourceIBMSecretsManagerIAMCredentialsSecretDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Schema: secretsManagerSecretSchema(map[string]*schema.Schema{
+			"ttl": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9]+[smhd]?$`), "must be a number of seconds or a number followed by s, m, h or d"),
+				Description:  "The time-to-live (TTL) to assign to the generated API keys, in seconds (ex: `3600`) or with a unit (ex: `1h`, `60m`). The minimum is 1 minute and the maximum is 90 days.",
+			},
+			"access_groups": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"service_id"},
+				Description:   "The access groups that define the capabilities of the service ID and API key that are generated for the secret.",
+				Elem:          &schema.Schema{Type: schema.TypeString},
+			},
+			"service_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"access_groups"},
+				Description:   "The service ID under which the API key is created. If you omit this argument, a service ID is created with the access of the access_groups.",
+			},
+			"reuse_api_key": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Set to `true` to reuse the service ID and API key for future read operations.",
+			},
+			"api_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API key that is generated for the secret.",
+			},
+		}),
+	}
+}
+
+func resourceIBMSecretsManagerIAMCredentialsSecretCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := d.Get("instance_id").(string)
+	secretsManagerClient, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secret := &secretsmanagerv1.SecretResourceIamSecretResource{
+		Name:        core.StringPtr(d.Get("name").(string)),
+		TTL:         d.Get("ttl").(string),
+		Labels:      expandStringList(d.Get("labels").([]interface{})),
+		ReuseAPIKey: core.BoolPtr(d.Get("reuse_api_key").(bool)),
+	}
+	if v, ok := d.GetOk("access_groups"); ok {
+		secret.AccessGroups = expandStringList(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("service_id"); ok {
+		secret.ServiceID = core.StringPtr(v.(string))
+	}
+	if v, ok := d.GetOk("description"); ok {
+		secret.Description = core.StringPtr(v.(string))
+	}
+	if v, ok := d.GetOk("secret_group_id"); ok {
+		secret.SecretGroupID = core.StringPtr(v.(string))
+	}
+
+	result, response, err := secretsManagerClient.CreateSecretWithContext(context, &secretsmanagerv1.CreateSecretOptions{
+		SecretType: core.StringPtr(secretsmanagerv1.CreateSecretOptionsSecretTypeIamCredentialsConst),
+		Metadata:   secretsManagerCollectionMetadata(secretsmanagerv1.CollectionMetadataCollectionTypeApplicationVndIBMSecretsManagerSecretJSONConst),
+		Resources:  []secretsmanagerv1.SecretResourceIntf{secret},
+	})
+	if err != nil {
+		log.Printf("[DEBUG] CreateSecretWithContext failed %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error creating the IAM credentials secret", err, response).withAttributes("name", "description", "secret_group_id", "labels", "ttl", "access_groups", "service_id"))
+	}
+	created, err := secretsManagerSecretResourceOf(result.Resources)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(secretsManagerResourceID(instanceID, *created.ID))
+
+	return resourceIBMSecretsManagerIAMCredentialsSecretRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerIAMCredentialsSecretRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, instanceID, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secret, err := getSecretsManagerSecret(context, secretsManagerClient, secretsmanagerv1.GetSecretOptionsSecretTypeIamCredentialsConst, secretID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if secret == nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err = setSecretsManagerSecret(d, instanceID, secret); err != nil {
+		return diag.FromErr(err)
+	}
+	// The API returns the TTL in seconds, keep the configured value when
+	// they are the same duration
+	if ttl := secretsManagerTTLString(secret.TTL); ttl != "" && !secretsManagerTTLEqual(d.Get("ttl").(string), ttl) {
+		if err = d.Set("ttl", ttl); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting ttl: %s", err))
+		}
+	}
+	if err = d.Set("access_groups", secret.AccessGroups); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting access_groups: %s", err))
+	}
+	if err = d.Set("service_id", secret.ServiceID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting service_id: %s", err))
+	}
+	if secret.ReuseAPIKey != nil {
+		if err = d.Set("reuse_api_key", *secret.ReuseAPIKey); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting reuse_api_key: %s", err))
+		}
+	}
+	apiKey := secret.APIKey
+	if v, ok := secretsManagerSecretData(secret.SecretData)["api_key"].(string); ok {
+		apiKey = &v
+	}
+	if err = d.Set("api_key", apiKey); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting api_key: %s", err))
+	}
+
+	return nil
+}
+
+func resourceIBMSecretsManagerIAMCredentialsSecretUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, _, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = updateSecretsManagerSecretMetadata(context, secretsManagerClient, d, secretsmanagerv1.UpdateSecretMetadataOptionsSecretTypeIamCredentialsConst, secretID, false)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return resourceIBMSecretsManagerIAMCredentialsSecretRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerIAMCredentialsSecretDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, _, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = deleteSecretsManagerSecret(context, secretsManagerClient, secretsmanagerv1.DeleteSecretOptionsSecretTypeIamCredentialsConst, secretID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
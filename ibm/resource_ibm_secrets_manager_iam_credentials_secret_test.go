@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMSecretsManagerIAMCredentialsSecretBasic(t *testing.T) {
+	name := fmt.Sprintf("tf-iam-credentials-%d", acctest.RandIntRange(10, 100))
+	nameUpdate := fmt.Sprintf("tf-iam-credentials-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSecretsManager(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMSecretsManagerSecretDestroy("ibm_secrets_manager_iam_credentials_secret"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerIAMCredentialsSecretConfigBasic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMSecretsManagerSecretExists("ibm_secrets_manager_iam_credentials_secret.secret"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_iam_credentials_secret.secret", "name", name),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_iam_credentials_secret.secret", "ttl", "1h"),
+					resource.TestCheckResourceAttrSet("ibm_secrets_manager_iam_credentials_secret.secret", "service_id"),
+					resource.TestCheckResourceAttrSet("ibm_secrets_manager_iam_credentials_secret.secret", "api_key"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerIAMCredentialsSecretConfigBasic(nameUpdate),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_secrets_manager_iam_credentials_secret.secret", "name", nameUpdate),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMSecretsManagerIAMCredentialsSecretConfigBasic(name string) string {
+	return fmt.Sprintf(`
+
+		resource "ibm_iam_access_group" "access_group" {
+			name = "%[2]s-group"
+		}
+
+		resource "ibm_secrets_manager_iam_credentials_secret" "secret" {
+			instance_id   = "%[1]s"
+			name          = "%[2]s"
+			ttl           = "1h"
+			access_groups = [ibm_iam_access_group.access_group.id]
+			reuse_api_key = true
+		}
+	`, secretsManagerInstanceID, name)
+}
@@ -0,0 +1,226 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
+)
+
+func resourceIBMSecretsManagerImportedCert() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMSecretsManagerImportedCertCreate,
+		ReadContext:   resourceIBMSecretsManagerImportedCertRead,
+		UpdateContext: resourceIBMSecretsManagerImportedCertUpdate,
+		DeleteContext: resourceIBMSecretsManagerImportedCertDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Schema: secretsManagerSecretSchema(map[string]*schema.Schema{
+			"certificate": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentPEM,
+				Description:      "The PEM encoded certificate to import. A change of the certificate, private key or intermediate certificate rotates the secret.",
+			},
+			"private_key": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressEquivalentPEM,
+				Description:      "The PEM encoded private key of the certificate.",
+			},
+			"intermediate": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentPEM,
+				Description:      "The PEM encoded intermediate certificate of the certificate.",
+			},
+			"common_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The fully qualified domain name or host domain name of the certificate.",
+			},
+			"alt_names": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The alternative names of the certificate.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"issuer": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distinguished name that identifies the entity that signed and issued the certificate.",
+			},
+			"serial_number": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique serial number that was assigned to the certificate by the issuing certificate authority.",
+			},
+			"algorithm": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The identifier for the cryptographic algorithm used by the issuing certificate authority to sign the certificate.",
+			},
+			"key_algorithm": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The identifier for the cryptographic algorithm used to generate the public key that is associated with the certificate.",
+			},
+			"expiration_date": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the certificate expires. The date format follows RFC 3339.",
+			},
+			"intermediate_included": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the certificate was imported with an associated intermediate certificate.",
+			},
+			"private_key_included": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the certificate was imported with an associated private key.",
+			},
+			"validity": secretsManagerCertificateValiditySchema(),
+		}),
+	}
+}
+
+func resourceIBMSecretsManagerImportedCertCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := d.Get("instance_id").(string)
+	secretsManagerClient, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secret := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"certificate": d.Get("certificate").(string),
+		"labels":      expandStringList(d.Get("labels").([]interface{})),
+	}
+	for _, k := range []string{"description", "secret_group_id", "private_key", "intermediate"} {
+		if v, ok := d.GetOk(k); ok {
+			secret[k] = v.(string)
+		}
+	}
+	body := secretsManagerSecretRequestBody(secretsmanagerv1.CollectionMetadataCollectionTypeApplicationVndIBMSecretsManagerSecretJSONConst, secret)
+
+	result, response, err := secretsManagerRequest(context, secretsManagerClient, core.POST, "/api/v1/secrets/{secret_type}", map[string]string{
+		"secret_type": secretsManagerSecretTypeImportedCert,
+	}, nil, body)
+	if err != nil {
+		log.Printf("[DEBUG] Create imported certificate failed %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error importing the certificate", err, response).withAttributes("name", "description", "secret_group_id", "labels", "certificate", "private_key", "intermediate"))
+	}
+	created, err := secretsManagerSecretResource(result)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, ok := created["id"].(string)
+	if !ok {
+		return diag.FromErr(fmt.Errorf("Error importing the certificate: the response has no secret ID"))
+	}
+
+	d.SetId(secretsManagerResourceID(instanceID, id))
+
+	return resourceIBMSecretsManagerImportedCertRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerImportedCertRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, instanceID, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secret, err := getSecretsManagerRawSecret(context, secretsManagerClient, secretsManagerSecretTypeImportedCert, secretID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if secret == nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err = setSecretsManagerRawSecret(d, instanceID, secret); err != nil {
+		return diag.FromErr(err)
+	}
+	secretData := secretsManagerSecretData(secret["secret_data"])
+	values := map[string]interface{}{
+		"certificate":           secretData["certificate"],
+		"private_key":           secretData["private_key"],
+		"intermediate":          secretData["intermediate"],
+		"common_name":           secret["common_name"],
+		"alt_names":             flattenSecretsManagerAltNames(secret["alt_names"]),
+		"issuer":                secret["issuer"],
+		"serial_number":         secret["serial_number"],
+		"algorithm":             secret["algorithm"],
+		"key_algorithm":         secret["key_algorithm"],
+		"expiration_date":       secret["expiration_date"],
+		"intermediate_included": secret["intermediate_included"],
+		"private_key_included":  secret["private_key_included"],
+		"validity":              flattenSecretsManagerCertificateValidity(secret["validity"]),
+	}
+	for k, v := range values {
+		if err = d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting %s: %s", k, err))
+		}
+	}
+
+	return nil
+}
+
+func resourceIBMSecretsManagerImportedCertUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, _, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = updateSecretsManagerSecretMetadata(context, secretsManagerClient, d, secretsManagerSecretTypeImportedCert, secretID, false)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if d.HasChanges("certificate", "private_key", "intermediate") {
+		body := map[string]interface{}{
+			"certificate": d.Get("certificate").(string),
+		}
+		for _, k := range []string{"private_key", "intermediate"} {
+			if v, ok := d.GetOk(k); ok {
+				body[k] = v.(string)
+			}
+		}
+		_, response, err := secretsManagerRequest(context, secretsManagerClient, core.POST, "/api/v1/secrets/{secret_type}/{id}", map[string]string{
+			"secret_type": secretsManagerSecretTypeImportedCert,
+			"id":          secretID,
+		}, map[string]string{"action": secretsmanagerv1.UpdateSecretOptionsActionRotateConst}, body)
+		if err != nil {
+			log.Printf("[DEBUG] Rotate imported certificate failed %s\n%s", err, response)
+			return diagFromErr(newAPIError("Error rotating the imported certificate", err, response).withAttributes("certificate", "private_key", "intermediate"))
+		}
+	}
+
+	return resourceIBMSecretsManagerImportedCertRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerImportedCertDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, _, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = deleteSecretsManagerSecret(context, secretsManagerClient, secretsManagerSecretTypeImportedCert, secretID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
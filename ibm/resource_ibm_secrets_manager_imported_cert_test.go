@@ -0,0 +1,95 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMSecretsManagerImportedCertBasic(t *testing.T) {
+	name := fmt.Sprintf("tf-imported-cert-%d", acctest.RandIntRange(10, 100))
+	cert, key := testAccSelfSignedCertificate(t, "tf-test-1.example.com")
+	certUpdate, keyUpdate := testAccSelfSignedCertificate(t, "tf-test-2.example.com")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSecretsManager(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMSecretsManagerSecretDestroy("ibm_secrets_manager_imported_cert"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerImportedCertConfigBasic(name, cert, key),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMSecretsManagerSecretExists("ibm_secrets_manager_imported_cert.cert"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_imported_cert.cert", "name", name),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_imported_cert.cert", "common_name", "tf-test-1.example.com"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_imported_cert.cert", "private_key_included", "true"),
+					resource.TestCheckResourceAttrSet("ibm_secrets_manager_imported_cert.cert", "serial_number"),
+					resource.TestCheckResourceAttrSet("ibm_secrets_manager_imported_cert.cert", "validity.0.not_after"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerImportedCertConfigBasic(name, certUpdate, keyUpdate),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_secrets_manager_imported_cert.cert", "common_name", "tf-test-2.example.com"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_imported_cert.cert", "versions_total", "2"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "ibm_secrets_manager_imported_cert.cert",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMSecretsManagerImportedCertConfigBasic(name, cert, key string) string {
+	return fmt.Sprintf(`
+
+		resource "ibm_secrets_manager_imported_cert" "cert" {
+			instance_id = "%s"
+			name        = "%s"
+			certificate = <<EOT
+%sEOT
+			private_key = <<EOT
+%sEOT
+		}
+	`, secretsManagerInstanceID, name, cert, key)
+}
+
+// testAccSelfSignedCertificate returns a PEM encoded self-signed certificate
+// of commonName and its private key
+func testAccSelfSignedCertificate(t *testing.T, commonName string) (string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return string(cert), string(privateKey)
+}
@@ -0,0 +1,358 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
+)
+
+const (
+	secretsManagerPublicCertPreActivation = "pre_activation"
+	secretsManagerPublicCertActive        = "active"
+)
+
+func resourceIBMSecretsManagerPublicCert() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMSecretsManagerPublicCertCreate,
+		ReadContext:   resourceIBMSecretsManagerPublicCertRead,
+		UpdateContext: resourceIBMSecretsManagerPublicCertUpdate,
+		DeleteContext: resourceIBMSecretsManagerPublicCertDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: secretsManagerSecretSchema(map[string]*schema.Schema{
+			"common_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The fully qualified domain name or host domain name for the certificate.",
+			},
+			"alt_names": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The alternative names that are defined for the certificate.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"key_algorithm": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "RSA2048",
+				ValidateFunc: validation.StringInSlice([]string{"RSA2048", "RSA4096", "ECDSA256", "ECDSA384"}, false),
+				Description:  "The identifier for the cryptographic algorithm used to generate the public and private keys of the certificate: `RSA2048`, `RSA4096`, `ECDSA256` or `ECDSA384`.",
+			},
+			"ca": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the certificate authority configuration of the instance.",
+			},
+			"dns": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the DNS provider configuration of the instance.",
+			},
+			"bundle_certs": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Determines whether your issued certificate is bundled with intermediate certificates.",
+			},
+			"rotation": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "The policy of the automatic rotation of the certificate.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auto_rotate": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Determines whether Secrets Manager rotates the certificate automatically before it expires.",
+						},
+						"rotate_keys": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Determines whether Secrets Manager rotates the private key of the certificate when it rotates the certificate.",
+						},
+					},
+				},
+			},
+			"certificate": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The PEM encoded issued certificate.",
+			},
+			"private_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The PEM encoded private key of the certificate.",
+			},
+			"intermediate": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The PEM encoded intermediate certificate of the certificate.",
+			},
+			"issuer": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distinguished name that identifies the entity that signed and issued the certificate.",
+			},
+			"serial_number": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique serial number that was assigned to the certificate by the issuing certificate authority.",
+			},
+			"algorithm": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The identifier for the cryptographic algorithm used by the issuing certificate authority to sign the certificate.",
+			},
+			"expiration_date": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the certificate expires. The date format follows RFC 3339.",
+			},
+			"validity": secretsManagerCertificateValiditySchema(),
+		}),
+	}
+}
+
+func resourceIBMSecretsManagerPublicCertCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := d.Get("instance_id").(string)
+	secretsManagerClient, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secret := map[string]interface{}{
+		"name":          d.Get("name").(string),
+		"labels":        expandStringList(d.Get("labels").([]interface{})),
+		"common_name":   d.Get("common_name").(string),
+		"alt_names":     expandStringList(d.Get("alt_names").([]interface{})),
+		"key_algorithm": d.Get("key_algorithm").(string),
+		"ca":            d.Get("ca").(string),
+		"dns":           d.Get("dns").(string),
+		"bundle_certs":  d.Get("bundle_certs").(bool),
+	}
+	for _, k := range []string{"description", "secret_group_id"} {
+		if v, ok := d.GetOk(k); ok {
+			secret[k] = v.(string)
+		}
+	}
+	if rotation := expandSecretsManagerPublicCertRotation(d); rotation != nil {
+		secret["rotation"] = rotation
+	}
+	body := secretsManagerSecretRequestBody(secretsmanagerv1.CollectionMetadataCollectionTypeApplicationVndIBMSecretsManagerSecretJSONConst, secret)
+
+	result, response, err := secretsManagerRequest(context, secretsManagerClient, core.POST, "/api/v1/secrets/{secret_type}", map[string]string{
+		"secret_type": secretsManagerSecretTypePublicCert,
+	}, nil, body)
+	if err != nil {
+		log.Printf("[DEBUG] Order public certificate failed %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error ordering the public certificate", err, response).withAttributes("name", "description", "secret_group_id", "labels", "common_name", "alt_names", "key_algorithm", "ca", "dns"))
+	}
+	created, err := secretsManagerSecretResource(result)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, ok := created["id"].(string)
+	if !ok {
+		return diag.FromErr(fmt.Errorf("Error ordering the public certificate: the response has no secret ID"))
+	}
+
+	d.SetId(secretsManagerResourceID(instanceID, id))
+
+	_, err = waitForSecretsManagerPublicCert(context, secretsManagerClient, id, d.Timeout(schema.TimeoutCreate), meta)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("Error waiting for the public certificate (%s) to be issued: %s", d.Id(), err))
+	}
+
+	return resourceIBMSecretsManagerPublicCertRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerPublicCertRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, instanceID, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secret, err := getSecretsManagerRawSecret(context, secretsManagerClient, secretsManagerSecretTypePublicCert, secretID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if secret == nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err = setSecretsManagerRawSecret(d, instanceID, secret); err != nil {
+		return diag.FromErr(err)
+	}
+	secretData := secretsManagerSecretData(secret["secret_data"])
+	values := map[string]interface{}{
+		"common_name":     secret["common_name"],
+		"alt_names":       flattenSecretsManagerAltNames(secret["alt_names"]),
+		"key_algorithm":   secret["key_algorithm"],
+		"certificate":     secretData["certificate"],
+		"private_key":     secretData["private_key"],
+		"intermediate":    secretData["intermediate"],
+		"issuer":          secret["issuer"],
+		"serial_number":   secret["serial_number"],
+		"algorithm":       secret["algorithm"],
+		"expiration_date": secret["expiration_date"],
+		"validity":        flattenSecretsManagerCertificateValidity(secret["validity"]),
+		"rotation":        flattenSecretsManagerPublicCertRotation(secret["rotation"]),
+	}
+	for _, k := range []string{"ca", "dns", "bundle_certs"} {
+		if v, ok := secret[k]; ok {
+			values[k] = v
+		}
+	}
+	for k, v := range values {
+		if err = d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting %s: %s", k, err))
+		}
+	}
+
+	return nil
+}
+
+func resourceIBMSecretsManagerPublicCertUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, _, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = updateSecretsManagerSecretMetadata(context, secretsManagerClient, d, secretsManagerSecretTypePublicCert, secretID, false)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if d.HasChange("rotation") {
+		if rotation := expandSecretsManagerPublicCertRotation(d); rotation != nil {
+			policy := map[string]interface{}{
+				"type":     secretsmanagerv1.SecretPolicyRotationTypeApplicationVndIBMSecretsManagerSecretPolicyJSONConst,
+				"rotation": rotation,
+			}
+			body := secretsManagerSecretRequestBody(secretsmanagerv1.CollectionMetadataCollectionTypeApplicationVndIBMSecretsManagerSecretPolicyJSONConst, policy)
+			_, response, err := secretsManagerRequest(context, secretsManagerClient, core.PUT, "/api/v1/secrets/{secret_type}/{id}/policies", map[string]string{
+				"secret_type": secretsManagerSecretTypePublicCert,
+				"id":          secretID,
+			}, map[string]string{"policy": secretsmanagerv1.PutPolicyOptionsPolicyRotationConst}, body)
+			if err != nil {
+				log.Printf("[DEBUG] Put public certificate rotation policy failed %s\n%s", err, response)
+				return diagFromErr(newAPIError("Error setting the rotation policy of the public certificate", err, response).withAttributes("auto_rotate=rotation.0.auto_rotate", "rotate_keys=rotation.0.rotate_keys"))
+			}
+		}
+	}
+
+	return resourceIBMSecretsManagerPublicCertRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerPublicCertDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, _, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = deleteSecretsManagerSecret(context, secretsManagerClient, secretsManagerSecretTypePublicCert, secretID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// waitForSecretsManagerPublicCert waits for the certificate authority to
+// issue the certificate, the secret is in the pre-activation state (0) until
+// then
+func waitForSecretsManagerPublicCert(ctx context.Context, client *secretsmanagerv1.SecretsManagerV1, secretID string, timeout time.Duration, meta interface{}) (interface{}, error) {
+	p := &poller{
+		Kind:    "ibm_secrets_manager_public_cert:active",
+		Name:    fmt.Sprintf("public certificate (%s)", secretID),
+		Pending: []string{secretsManagerPublicCertPreActivation},
+		Target:  []string{secretsManagerPublicCertActive},
+		Refresh: pollStateRefresh(func() (interface{}, string, error) {
+			secret, err := getSecretsManagerRawSecret(ctx, client, secretsManagerSecretTypePublicCert, secretID)
+			if err != nil {
+				return nil, "", err
+			}
+			if secret == nil {
+				return nil, "", fmt.Errorf("The public certificate %s does not exist", secretID)
+			}
+			state, err := secretsManagerPublicCertState(secret)
+			return secret, state, err
+		}),
+		Timeout: timeout,
+		Delay:   10 * time.Second,
+		Policy:  pollingPolicy(meta),
+	}
+	return p.WaitForStateContext(ctx)
+}
+
+// secretsManagerPublicCertState returns the state of the order of a public
+// certificate, an error when the order failed
+func secretsManagerPublicCertState(secret map[string]interface{}) (string, error) {
+	if issuance, ok := secret["issuance_info"].(map[string]interface{}); ok {
+		if message, ok := issuance["error_message"].(string); ok && message != "" {
+			return "", fmt.Errorf("The order of the certificate failed: %s (%v)", message, issuance["error_code"])
+		}
+	}
+	state, _ := secret["state"].(float64)
+	switch state {
+	case 0:
+		return secretsManagerPublicCertPreActivation, nil
+	case 1:
+		return secretsManagerPublicCertActive, nil
+	}
+	description, _ := secret["state_description"].(string)
+	return description, nil
+}
+
+func expandSecretsManagerPublicCertRotation(d *schema.ResourceData) map[string]interface{} {
+	rotation, ok := d.GetOk("rotation")
+	if !ok || len(rotation.([]interface{})) == 0 || rotation.([]interface{})[0] == nil {
+		return nil
+	}
+	r := rotation.([]interface{})[0].(map[string]interface{})
+	return map[string]interface{}{
+		"auto_rotate": r["auto_rotate"].(bool),
+		"rotate_keys": r["rotate_keys"].(bool),
+	}
+}
+
+func flattenSecretsManagerPublicCertRotation(rotation interface{}) []map[string]interface{} {
+	r, ok := rotation.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return []map[string]interface{}{
+		{
+			"auto_rotate": r["auto_rotate"],
+			"rotate_keys": r["rotate_keys"],
+		},
+	}
+}
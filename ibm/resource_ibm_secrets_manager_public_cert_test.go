@@ -0,0 +1,60 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMSecretsManagerPublicCertBasic(t *testing.T) {
+	name := fmt.Sprintf("tf-public-cert-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSecretsManagerPublicCert(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMSecretsManagerSecretDestroy("ibm_secrets_manager_public_cert"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerPublicCertConfigBasic(name, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMSecretsManagerSecretExists("ibm_secrets_manager_public_cert.cert"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_public_cert.cert", "name", name),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_public_cert.cert", "common_name", secretsManagerPublicCertCommonName),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_public_cert.cert", "state", "1"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_public_cert.cert", "rotation.0.auto_rotate", "false"),
+					resource.TestCheckResourceAttrSet("ibm_secrets_manager_public_cert.cert", "certificate"),
+					resource.TestCheckResourceAttrSet("ibm_secrets_manager_public_cert.cert", "private_key"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerPublicCertConfigBasic(name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_secrets_manager_public_cert.cert", "rotation.0.auto_rotate", "true"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_public_cert.cert", "rotation.0.rotate_keys", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMSecretsManagerPublicCertConfigBasic(name string, autoRotate bool) string {
+	return fmt.Sprintf(`
+
+		resource "ibm_secrets_manager_public_cert" "cert" {
+			instance_id = "%s"
+			name        = "%s"
+			common_name = "%s"
+			ca          = "%s"
+			dns         = "%s"
+			rotation {
+				auto_rotate = %t
+				rotate_keys = %t
+			}
+		}
+	`, secretsManagerInstanceID, name, secretsManagerPublicCertCommonName, secretsManagerPublicCertCA, secretsManagerPublicCertDNS, autoRotate, autoRotate)
+}
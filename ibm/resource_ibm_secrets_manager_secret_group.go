@@ -0,0 +1,223 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
+)
+
+func resourceIBMSecretsManagerSecretGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMSecretsManagerSecretGroupCreate,
+		ReadContext:   resourceIBMSecretsManagerSecretGroupRead,
+		UpdateContext: resourceIBMSecretsManagerSecretGroupUpdate,
+		DeleteContext: resourceIBMSecretsManagerSecretGroupDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The GUID of the Secrets Manager instance.",
+			},
+			"endpoint_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "public",
+				ValidateFunc: validation.StringInSlice([]string{"public", "private"}, false),
+				Description:  "The endpoint of the Secrets Manager instance, `public` or `private`.",
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: InvokeValidator("ibm_secrets_manager_secret_group", "name"),
+				Description:  "A human-readable name to assign to your secret group.",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An extended description of your secret group.",
+			},
+			"secret_group_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The v4 UUID that uniquely identifies the secret group.",
+			},
+			"creation_date": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the secret group was created. The date format follows RFC 3339.",
+			},
+			"last_update_date": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Updates when the metadata of the secret group is modified. The date format follows RFC 3339.",
+			},
+		},
+	}
+}
+
+func resourceIBMSecretsManagerSecretGroupValidator() *ResourceValidator {
+	validateSchema := make([]ValidateSchema, 1)
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 "name",
+			ValidateFunctionIdentifier: ValidateRegexpLen,
+			Type:                       TypeString,
+			Required:                   true,
+			Regexp:                     `^[A-Za-z0-9][A-Za-z0-9]*(?:_*-*\.*[A-Za-z0-9]+)*$`,
+			MinValueLength:             2,
+			MaxValueLength:             64,
+		})
+
+	resourceValidator := ResourceValidator{ResourceName: "ibm_secrets_manager_secret_group", Schema: validateSchema}
+	return &resourceValidator
+}
+
+func resourceIBMSecretsManagerSecretGroupCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := d.Get("instance_id").(string)
+	secretsManagerClient, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secretGroup := secretsmanagerv1.SecretGroupResource{
+		Name: core.StringPtr(d.Get("name").(string)),
+	}
+	if v, ok := d.GetOk("description"); ok {
+		secretGroup.Description = core.StringPtr(v.(string))
+	}
+	createSecretGroupOptions := &secretsmanagerv1.CreateSecretGroupOptions{
+		Metadata:  secretsManagerCollectionMetadata(secretsmanagerv1.CollectionMetadataCollectionTypeApplicationVndIBMSecretsManagerSecretGroupJSONConst),
+		Resources: []secretsmanagerv1.SecretGroupResource{secretGroup},
+	}
+
+	result, response, err := secretsManagerClient.CreateSecretGroupWithContext(context, createSecretGroupOptions)
+	if err != nil {
+		log.Printf("[DEBUG] CreateSecretGroupWithContext failed %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error creating the secret group", err, response).withAttributes("name"))
+	}
+	if len(result.Resources) == 0 || result.Resources[0].ID == nil {
+		return diag.FromErr(fmt.Errorf("Error creating the secret group: the response has no secret group"))
+	}
+
+	d.SetId(secretsManagerResourceID(instanceID, *result.Resources[0].ID))
+
+	return resourceIBMSecretsManagerSecretGroupRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerSecretGroupRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID, secretGroupID, err := parseSecretsManagerSecretID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	secretsManagerClient, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	result, response, err := secretsManagerClient.GetSecretGroupWithContext(context, &secretsmanagerv1.GetSecretGroupOptions{
+		ID: core.StringPtr(secretGroupID),
+	})
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] GetSecretGroupWithContext failed %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error getting the secret group", err, response))
+	}
+	if len(result.Resources) == 0 {
+		d.SetId("")
+		return nil
+	}
+	secretGroup := result.Resources[0]
+
+	if err = d.Set("instance_id", instanceID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting instance_id: %s", err))
+	}
+	if err = d.Set("endpoint_type", secretsManagerEndpointType(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting endpoint_type: %s", err))
+	}
+	if err = d.Set("secret_group_id", secretGroup.ID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting secret_group_id: %s", err))
+	}
+	if err = d.Set("name", secretGroup.Name); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting name: %s", err))
+	}
+	if err = d.Set("description", secretGroup.Description); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting description: %s", err))
+	}
+	if err = d.Set("creation_date", dateTimeToString(secretGroup.CreationDate)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting creation_date: %s", err))
+	}
+	if err = d.Set("last_update_date", dateTimeToString(secretGroup.LastUpdateDate)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting last_update_date: %s", err))
+	}
+
+	return nil
+}
+
+func resourceIBMSecretsManagerSecretGroupUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID, secretGroupID, err := parseSecretsManagerSecretID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	secretsManagerClient, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if d.HasChanges("name", "description") {
+		updateSecretGroupMetadataOptions := &secretsmanagerv1.UpdateSecretGroupMetadataOptions{
+			ID:       core.StringPtr(secretGroupID),
+			Metadata: secretsManagerCollectionMetadata(secretsmanagerv1.CollectionMetadataCollectionTypeApplicationVndIBMSecretsManagerSecretGroupJSONConst),
+			Resources: []secretsmanagerv1.SecretGroupMetadataUpdatable{
+				{
+					Name:        core.StringPtr(d.Get("name").(string)),
+					Description: core.StringPtr(d.Get("description").(string)),
+				},
+			},
+		}
+		_, response, err := secretsManagerClient.UpdateSecretGroupMetadataWithContext(context, updateSecretGroupMetadataOptions)
+		if err != nil {
+			log.Printf("[DEBUG] UpdateSecretGroupMetadataWithContext failed %s\n%s", err, response)
+			return diagFromErr(newAPIError("Error updating the secret group", err, response).withAttributes("name", "description"))
+		}
+	}
+
+	return resourceIBMSecretsManagerSecretGroupRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerSecretGroupDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID, secretGroupID, err := parseSecretsManagerSecretID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	secretsManagerClient, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	response, err := secretsManagerClient.DeleteSecretGroupWithContext(context, &secretsmanagerv1.DeleteSecretGroupOptions{
+		ID: core.StringPtr(secretGroupID),
+	})
+	if err != nil && (response == nil || response.StatusCode != 404) {
+		log.Printf("[DEBUG] DeleteSecretGroupWithContext failed %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error deleting the secret group", err, response))
+	}
+
+	d.SetId("")
+	return nil
+}
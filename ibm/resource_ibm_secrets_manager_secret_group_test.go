@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
+)
+
+func TestAccIBMSecretsManagerSecretGroupBasic(t *testing.T) {
+	name := fmt.Sprintf("tf-secret-group-%d", acctest.RandIntRange(10, 100))
+	nameUpdate := fmt.Sprintf("tf-secret-group-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSecretsManager(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMSecretsManagerSecretGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerSecretGroupConfigBasic(name, "Secrets of the tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMSecretsManagerSecretGroupExists("ibm_secrets_manager_secret_group.secret_group"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_secret_group.secret_group", "name", name),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_secret_group.secret_group", "description", "Secrets of the tests"),
+					resource.TestCheckResourceAttrSet("ibm_secrets_manager_secret_group.secret_group", "secret_group_id"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerSecretGroupConfigBasic(nameUpdate, "Secrets of the updated tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_secrets_manager_secret_group.secret_group", "name", nameUpdate),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_secret_group.secret_group", "description", "Secrets of the updated tests"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "ibm_secrets_manager_secret_group.secret_group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMSecretsManagerSecretGroupConfigBasic(name, description string) string {
+	return fmt.Sprintf(`
+
+		resource "ibm_secrets_manager_secret_group" "secret_group" {
+			instance_id = "%s"
+			name        = "%s"
+			description = "%s"
+		}
+	`, secretsManagerInstanceID, name, description)
+}
+
+func testAccCheckIBMSecretsManagerSecretGroupExists(n string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		_, response, err := testAccGetIBMSecretsManagerSecretGroup(rs)
+		if err != nil {
+			return fmt.Errorf("Error getting the secret group %s: %s\n%s", rs.Primary.ID, err, response)
+		}
+		return nil
+	}
+}
+
+func testAccCheckIBMSecretsManagerSecretGroupDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_secrets_manager_secret_group" {
+			continue
+		}
+
+		_, response, err := testAccGetIBMSecretsManagerSecretGroup(rs)
+		if err == nil {
+			return fmt.Errorf("Secret group %s still exists", rs.Primary.ID)
+		}
+		if response == nil || response.StatusCode != 404 {
+			return fmt.Errorf("Error checking for the secret group %s: %s", rs.Primary.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccGetIBMSecretsManagerSecretGroup(rs *terraform.ResourceState) (*secretsmanagerv1.SecretGroupDef, *core.DetailedResponse, error) {
+	instanceID, secretGroupID, err := parseSecretsManagerSecretID(rs.Primary.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := secretsManagerInstanceClient(testAccProvider.Meta(), instanceID, "public")
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.GetSecretGroup(&secretsmanagerv1.GetSecretGroupOptions{
+		ID: core.StringPtr(secretGroupID),
+	})
+}
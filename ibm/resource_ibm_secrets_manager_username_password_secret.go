@@ -0,0 +1,288 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
+)
+
+func resourceIBMSecretsManagerUsernamePasswordSecret() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMSecretsManagerUsernamePasswordSecretCreate,
+		ReadContext:   resourceIBMSecretsManagerUsernamePasswordSecretRead,
+		UpdateContext: resourceIBMSecretsManagerUsernamePasswordSecretUpdate,
+		DeleteContext: resourceIBMSecretsManagerUsernamePasswordSecretDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Schema: secretsManagerSecretSchema(map[string]*schema.Schema{
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The username to assign to this secret.",
+			},
+			"password": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressRotatedSecretsManagerPassword,
+				Description:      "The password to assign to this secret. If you omit this argument, Secrets Manager generates a new random password. A change of the password rotates the secret, unless the secret has a rotation policy.",
+			},
+			"expiration_date": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentSecretsManagerDate,
+				Description:      "The date the secret material expires. The date format follows RFC 3339.",
+			},
+			"rotation": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "The policy of the automatic rotation of the password. Removing the block keeps the policy of the secret.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"interval": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The length of the secret rotation time interval.",
+						},
+						"unit": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{secretsmanagerv1.SecretPolicyRotationRotationUnitDayConst, secretsmanagerv1.SecretPolicyRotationRotationUnitMonthConst}, false),
+							Description:  "The units for the secret rotation time interval, `day` or `month`.",
+						},
+					},
+				},
+			},
+			"next_rotation_date": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date that the secret is scheduled for automatic rotation. The date format follows RFC 3339.",
+			},
+		}),
+	}
+}
+
+func resourceIBMSecretsManagerUsernamePasswordSecretCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := d.Get("instance_id").(string)
+	secretsManagerClient, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secret := &secretsmanagerv1.SecretResourceUsernamePasswordSecretResource{
+		Name:     core.StringPtr(d.Get("name").(string)),
+		Username: core.StringPtr(d.Get("username").(string)),
+		Labels:   expandStringList(d.Get("labels").([]interface{})),
+	}
+	if v, ok := d.GetOk("password"); ok {
+		secret.Password = core.StringPtr(v.(string))
+	}
+	if v, ok := d.GetOk("description"); ok {
+		secret.Description = core.StringPtr(v.(string))
+	}
+	if v, ok := d.GetOk("secret_group_id"); ok {
+		secret.SecretGroupID = core.StringPtr(v.(string))
+	}
+	if v, ok := d.GetOk("expiration_date"); ok {
+		expirationDate, err := core.ParseDateTime(v.(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Error parsing expiration_date: %s", err))
+		}
+		secret.ExpirationDate = &expirationDate
+	}
+
+	result, response, err := secretsManagerClient.CreateSecretWithContext(context, &secretsmanagerv1.CreateSecretOptions{
+		SecretType: core.StringPtr(secretsmanagerv1.CreateSecretOptionsSecretTypeUsernamePasswordConst),
+		Metadata:   secretsManagerCollectionMetadata(secretsmanagerv1.CollectionMetadataCollectionTypeApplicationVndIBMSecretsManagerSecretJSONConst),
+		Resources:  []secretsmanagerv1.SecretResourceIntf{secret},
+	})
+	if err != nil {
+		log.Printf("[DEBUG] CreateSecretWithContext failed %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error creating the username password secret", err, response).withAttributes("name", "description", "secret_group_id", "labels", "username", "password", "expiration_date"))
+	}
+	created, err := secretsManagerSecretResourceOf(result.Resources)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(secretsManagerResourceID(instanceID, *created.ID))
+
+	if _, ok := d.GetOk("rotation"); ok {
+		if err = putSecretsManagerRotationPolicy(context, secretsManagerClient, d, *created.ID); err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	return resourceIBMSecretsManagerUsernamePasswordSecretRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerUsernamePasswordSecretRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, instanceID, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	secret, err := getSecretsManagerSecret(context, secretsManagerClient, secretsmanagerv1.GetSecretOptionsSecretTypeUsernamePasswordConst, secretID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if secret == nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err = setSecretsManagerSecret(d, instanceID, secret); err != nil {
+		return diag.FromErr(err)
+	}
+	secretData := secretsManagerSecretData(secret.SecretData)
+	username := secret.Username
+	if v, ok := secretData["username"].(string); ok {
+		username = &v
+	}
+	if err = d.Set("username", username); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting username: %s", err))
+	}
+	if err = d.Set("password", secretData["password"]); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting password: %s", err))
+	}
+	if err = d.Set("expiration_date", dateTimeToString(secret.ExpirationDate)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting expiration_date: %s", err))
+	}
+	if err = d.Set("next_rotation_date", dateTimeToString(secret.NextRotationDate)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting next_rotation_date: %s", err))
+	}
+
+	policy, response, err := secretsManagerClient.GetPolicyWithContext(context, &secretsmanagerv1.GetPolicyOptions{
+		SecretType: core.StringPtr(secretsmanagerv1.GetPolicyOptionsSecretTypeUsernamePasswordConst),
+		ID:         core.StringPtr(secretID),
+		Policy:     core.StringPtr(secretsmanagerv1.GetPolicyOptionsPolicyRotationConst),
+	})
+	if err != nil {
+		log.Printf("[DEBUG] GetPolicyWithContext failed %s\n%s", err, response)
+		return diagFromErr(newAPIError("Error getting the rotation policy of the secret", err, response))
+	}
+	if err = d.Set("rotation", flattenSecretsManagerRotationPolicy(policy)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting rotation: %s", err))
+	}
+
+	return nil
+}
+
+func resourceIBMSecretsManagerUsernamePasswordSecretUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, _, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = updateSecretsManagerSecretMetadata(context, secretsManagerClient, d, secretsmanagerv1.UpdateSecretMetadataOptionsSecretTypeUsernamePasswordConst, secretID, true)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if d.HasChange("password") {
+		_, response, err := secretsManagerClient.UpdateSecretWithContext(context, &secretsmanagerv1.UpdateSecretOptions{
+			SecretType: core.StringPtr(secretsmanagerv1.UpdateSecretOptionsSecretTypeUsernamePasswordConst),
+			ID:         core.StringPtr(secretID),
+			Action:     core.StringPtr(secretsmanagerv1.UpdateSecretOptionsActionRotateConst),
+			SecretActionOneOf: &secretsmanagerv1.SecretActionOneOfRotateUsernamePasswordSecretBody{
+				Password: core.StringPtr(d.Get("password").(string)),
+			},
+		})
+		if err != nil {
+			log.Printf("[DEBUG] UpdateSecretWithContext failed %s\n%s", err, response)
+			return diagFromErr(newAPIError("Error rotating the username password secret", err, response))
+		}
+	}
+
+	if d.HasChange("rotation") {
+		if _, ok := d.GetOk("rotation"); ok {
+			if err = putSecretsManagerRotationPolicy(context, secretsManagerClient, d, secretID); err != nil {
+				return diagFromErr(err)
+			}
+		}
+	}
+
+	return resourceIBMSecretsManagerUsernamePasswordSecretRead(context, d, meta)
+}
+
+func resourceIBMSecretsManagerUsernamePasswordSecretDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	secretsManagerClient, _, secretID, err := secretsManagerSecretClient(d, meta)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = deleteSecretsManagerSecret(context, secretsManagerClient, secretsmanagerv1.DeleteSecretOptionsSecretTypeUsernamePasswordConst, secretID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// suppressRotatedSecretsManagerPassword ignores the changes of the password of
+// a secret with a rotation policy, the automatic rotations replace the
+// configured password. The password is still set on create.
+func suppressRotatedSecretsManagerPassword(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && len(d.Get("rotation").([]interface{})) > 0
+}
+
+// putSecretsManagerRotationPolicy sets the rotation policy of a username
+// password secret from the rotation block
+func putSecretsManagerRotationPolicy(ctx context.Context, client *secretsmanagerv1.SecretsManagerV1, d *schema.ResourceData, secretID string) error {
+	rotation := d.Get("rotation").([]interface{})[0].(map[string]interface{})
+	_, response, err := client.PutPolicyWithContext(ctx, &secretsmanagerv1.PutPolicyOptions{
+		SecretType: core.StringPtr(secretsmanagerv1.PutPolicyOptionsSecretTypeUsernamePasswordConst),
+		ID:         core.StringPtr(secretID),
+		Policy:     core.StringPtr(secretsmanagerv1.PutPolicyOptionsPolicyRotationConst),
+		Metadata:   secretsManagerCollectionMetadata(secretsmanagerv1.CollectionMetadataCollectionTypeApplicationVndIBMSecretsManagerSecretPolicyJSONConst),
+		Resources: []secretsmanagerv1.SecretPolicyRotation{
+			{
+				Type: core.StringPtr(secretsmanagerv1.SecretPolicyRotationTypeApplicationVndIBMSecretsManagerSecretPolicyJSONConst),
+				Rotation: &secretsmanagerv1.SecretPolicyRotationRotation{
+					Interval: core.Int64Ptr(int64(rotation["interval"].(int))),
+					Unit:     core.StringPtr(rotation["unit"].(string)),
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("[DEBUG] PutPolicyWithContext failed %s\n%s", err, response)
+		return newAPIError("Error setting the rotation policy of the secret", err, response).withAttributes("interval=rotation.0.interval", "unit=rotation.0.unit")
+	}
+	return nil
+}
+
+func flattenSecretsManagerRotationPolicy(policy secretsmanagerv1.GetSecretPoliciesOneOfIntf) []map[string]interface{} {
+	policies, ok := policy.(*secretsmanagerv1.GetSecretPoliciesOneOf)
+	if !ok {
+		return nil
+	}
+	for _, p := range policies.Resources {
+		if p.Rotation != nil && p.Rotation.Interval != nil && p.Rotation.Unit != nil {
+			return []map[string]interface{}{
+				{
+					"interval": *p.Rotation.Interval,
+					"unit":     *p.Rotation.Unit,
+				},
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMSecretsManagerUsernamePasswordSecretBasic(t *testing.T) {
+	name := fmt.Sprintf("tf-username-password-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSecretsManager(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMSecretsManagerSecretDestroy("ibm_secrets_manager_username_password_secret"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerUsernamePasswordSecretConfigBasic(name, "Passw0rd-1", 30),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMSecretsManagerSecretExists("ibm_secrets_manager_username_password_secret.secret"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_username_password_secret.secret", "name", name),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_username_password_secret.secret", "username", "tf-user"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_username_password_secret.secret", "password", "Passw0rd-1"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_username_password_secret.secret", "rotation.0.interval", "30"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_username_password_secret.secret", "rotation.0.unit", "day"),
+					resource.TestCheckResourceAttrSet("ibm_secrets_manager_username_password_secret.secret", "next_rotation_date"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIBMSecretsManagerUsernamePasswordSecretConfigBasic(name, "Passw0rd-2", 60),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_secrets_manager_username_password_secret.secret", "password", "Passw0rd-2"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_username_password_secret.secret", "rotation.0.interval", "60"),
+					resource.TestCheckResourceAttr("ibm_secrets_manager_username_password_secret.secret", "versions_total", "2"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "ibm_secrets_manager_username_password_secret.secret",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMSecretsManagerUsernamePasswordSecretConfigBasic(name, password string, interval int) string {
+	return fmt.Sprintf(`
+
+		resource "ibm_secrets_manager_username_password_secret" "secret" {
+			instance_id = "%s"
+			name        = "%s"
+			username    = "tf-user"
+			password    = "%s"
+			rotation {
+				interval = %d
+				unit     = "day"
+			}
+		}
+	`, secretsManagerInstanceID, name, password, interval)
+}
@@ -52,7 +52,7 @@ func resourceIBMTransitGateway() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -218,7 +218,7 @@ func resourceIBMTransitGatewayCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(tgGatewayTags); ok || v != "" {
+	if _, ok := d.GetOk(tgGatewayTags); ok || v != "" || hasDefaultTags(meta) {
 		oldList, newList := d.GetChange(tgGatewayTags)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *tgw.Crn)
 		if err != nil {
@@ -46,7 +46,7 @@ func resourceIBMTransitGatewayConnection() *schema.Resource {
 		Delete:   resourceIBMTransitGatewayConnectionDelete,
 		Exists:   resourceIBMTransitGatewayConnectionExists,
 		Update:   resourceIBMTransitGatewayConnectionUpdate,
-		Importer: &schema.ResourceImporter{},
+		Importer: importIDParts("/", "gateway_id", "connection_id"),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -0,0 +1,236 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/version"
+)
+
+// schemaExportFormatVersion is the version of the format of the exported
+// schema, increased on incompatible changes.
+const schemaExportFormatVersion = "1.0"
+
+// ProviderSchemaExport is the machine readable description of the provider:
+// the schemas of the provider, resources and data sources, along with the
+// constraints of their ResourceValidator.
+type ProviderSchemaExport struct {
+	FormatVersion     string                     `json:"format_version"`
+	ProviderVersion   string                     `json:"provider_version"`
+	Provider          exportedSchema             `json:"provider"`
+	ResourceSchemas   map[string]*exportedSchema `json:"resource_schemas"`
+	DataSourceSchemas map[string]*exportedSchema `json:"data_source_schemas"`
+}
+
+type exportedSchema struct {
+	Version    int                 `json:"version"`
+	Block      *exportedBlock      `json:"block"`
+	Validators []exportedValidator `json:"validators,omitempty"`
+	Rules      []exportedRule      `json:"rules,omitempty"`
+}
+
+type exportedBlock struct {
+	Attributes map[string]*exportedAttribute   `json:"attributes,omitempty"`
+	BlockTypes map[string]*exportedNestedBlock `json:"block_types,omitempty"`
+	Deprecated string                          `json:"deprecated,omitempty"`
+}
+
+type exportedAttribute struct {
+	Type          interface{} `json:"type"`
+	Description   string      `json:"description,omitempty"`
+	Required      bool        `json:"required,omitempty"`
+	Optional      bool        `json:"optional,omitempty"`
+	Computed      bool        `json:"computed,omitempty"`
+	Sensitive     bool        `json:"sensitive,omitempty"`
+	ForceNew      bool        `json:"force_new,omitempty"`
+	Default       interface{} `json:"default,omitempty"`
+	Deprecated    string      `json:"deprecated,omitempty"`
+	ConflictsWith []string    `json:"conflicts_with,omitempty"`
+	ExactlyOneOf  []string    `json:"exactly_one_of,omitempty"`
+	AtLeastOneOf  []string    `json:"at_least_one_of,omitempty"`
+	RequiredWith  []string    `json:"required_with,omitempty"`
+	MinItems      int         `json:"min_items,omitempty"`
+	MaxItems      int         `json:"max_items,omitempty"`
+}
+
+type exportedNestedBlock struct {
+	NestingMode string         `json:"nesting_mode"`
+	Block       *exportedBlock `json:"block"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Optional    bool           `json:"optional,omitempty"`
+	Computed    bool           `json:"computed,omitempty"`
+	ForceNew    bool           `json:"force_new,omitempty"`
+	MinItems    int            `json:"min_items,omitempty"`
+	MaxItems    int            `json:"max_items,omitempty"`
+}
+
+// exportedValidator is a ValidateSchema of the validator catalog.
+type exportedValidator struct {
+	Identifier    string      `json:"identifier"`
+	Type          string      `json:"type"`
+	Function      string      `json:"function"`
+	MinValue      string      `json:"min_value,omitempty"`
+	MaxValue      string      `json:"max_value,omitempty"`
+	AllowedValues []string    `json:"allowed_values,omitempty"`
+	Matches       string      `json:"matches,omitempty"`
+	Regexp        string      `json:"regexp,omitempty"`
+	MinLength     int         `json:"min_length,omitempty"`
+	MaxLength     int         `json:"max_length,omitempty"`
+	Nullable      bool        `json:"nullable,omitempty"`
+	Required      bool        `json:"required,omitempty"`
+	Optional      bool        `json:"optional,omitempty"`
+	Default       interface{} `json:"default,omitempty"`
+	ForceNew      bool        `json:"force_new,omitempty"`
+	Elements      bool        `json:"elements,omitempty"`
+}
+
+// exportedRule is a ValidateRule of the validator catalog.
+type exportedRule struct {
+	Rule          string   `json:"rule"`
+	Identifiers   []string `json:"identifiers"`
+	When          []string `json:"when,omitempty"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+}
+
+// ExportSchema writes the schemas of the provider, resources and data sources
+// with their validator catalog to w, as indented JSON.
+func ExportSchema(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(providerSchemaExport(Provider(), Validator()))
+}
+
+func providerSchemaExport(p *schema.Provider, validators ValidatorDict) *ProviderSchemaExport {
+	export := &ProviderSchemaExport{
+		FormatVersion:     schemaExportFormatVersion,
+		ProviderVersion:   version.Version,
+		Provider:          exportedSchema{Block: exportBlock(p.Schema)},
+		ResourceSchemas:   make(map[string]*exportedSchema, len(p.ResourcesMap)),
+		DataSourceSchemas: make(map[string]*exportedSchema, len(p.DataSourcesMap)),
+	}
+	for name, r := range p.ResourcesMap {
+		export.ResourceSchemas[name] = exportResource(r, validators.ResourceValidatorDictionary[name])
+	}
+	for name, r := range p.DataSourcesMap {
+		export.DataSourceSchemas[name] = exportResource(r, validators.DataSourceValidatorDictionary[name])
+	}
+	return export
+}
+
+func exportResource(r *schema.Resource, validator *ResourceValidator) *exportedSchema {
+	s := &exportedSchema{
+		Version: r.SchemaVersion,
+		Block:   exportBlock(r.Schema),
+	}
+	s.Block.Deprecated = r.DeprecationMessage
+	if validator != nil {
+		for _, v := range validator.Schema {
+			s.Validators = append(s.Validators, exportValidator(v))
+		}
+		for _, rule := range validator.Rules {
+			s.Rules = append(s.Rules, exportedRule{
+				Rule:          rule.RuleIdentifier.String(),
+				Identifiers:   rule.Identifiers,
+				When:          splitValues(rule.When),
+				AllowedValues: splitValues(rule.AllowedValues),
+			})
+		}
+	}
+	return s
+}
+
+func exportBlock(schemaMap map[string]*schema.Schema) *exportedBlock {
+	b := &exportedBlock{
+		Attributes: map[string]*exportedAttribute{},
+		BlockTypes: map[string]*exportedNestedBlock{},
+	}
+	for k, s := range schemaMap {
+		if elem, ok := s.Elem.(*schema.Resource); ok && s.Type != schema.TypeMap {
+			nestingMode := "list"
+			if s.Type == schema.TypeSet {
+				nestingMode = "set"
+			}
+			b.BlockTypes[k] = &exportedNestedBlock{
+				NestingMode: nestingMode,
+				Block:       exportBlock(elem.Schema),
+				Description: s.Description,
+				Required:    s.Required,
+				Optional:    s.Optional,
+				Computed:    s.Computed,
+				ForceNew:    s.ForceNew,
+				MinItems:    s.MinItems,
+				MaxItems:    s.MaxItems,
+			}
+			continue
+		}
+		b.Attributes[k] = &exportedAttribute{
+			Type:          exportType(s),
+			Description:   s.Description,
+			Required:      s.Required,
+			Optional:      s.Optional,
+			Computed:      s.Computed,
+			Sensitive:     s.Sensitive,
+			ForceNew:      s.ForceNew,
+			Default:       s.Default,
+			Deprecated:    s.Deprecated,
+			ConflictsWith: s.ConflictsWith,
+			ExactlyOneOf:  s.ExactlyOneOf,
+			AtLeastOneOf:  s.AtLeastOneOf,
+			RequiredWith:  s.RequiredWith,
+			MinItems:      s.MinItems,
+			MaxItems:      s.MaxItems,
+		}
+	}
+	return b
+}
+
+// exportType returns the type of the attribute s in the notation of the
+// Terraform JSON schemas: "string", ["list", "string"]...
+func exportType(s *schema.Schema) interface{} {
+	switch s.Type {
+	case schema.TypeBool:
+		return "bool"
+	case schema.TypeInt, schema.TypeFloat:
+		return "number"
+	case schema.TypeString:
+		return "string"
+	}
+
+	kind := map[schema.ValueType]string{
+		schema.TypeList: "list",
+		schema.TypeSet:  "set",
+		schema.TypeMap:  "map",
+	}[s.Type]
+	if elem, ok := s.Elem.(*schema.Schema); ok {
+		return []interface{}{kind, exportType(elem)}
+	}
+	// maps and lists without element schema hold strings
+	return []interface{}{kind, "string"}
+}
+
+func exportValidator(v ValidateSchema) exportedValidator {
+	return exportedValidator{
+		Identifier:    v.Identifier,
+		Type:          v.Type.String(),
+		Function:      v.ValidateFunctionIdentifier.String(),
+		MinValue:      v.MinValue,
+		MaxValue:      v.MaxValue,
+		Matches:       v.Matches,
+		Regexp:        v.Regexp,
+		MinLength:     v.MinValueLength,
+		MaxLength:     v.MaxValueLength,
+		Nullable:      v.Nullable,
+		Required:      v.Required,
+		Optional:      v.Optional,
+		Default:       v.Default,
+		ForceNew:      v.ForceNew,
+		Elements:      v.Elements,
+		AllowedValues: splitValues(v.AllowedValues),
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExportBlock(t *testing.T) {
+	b := exportBlock(map[string]*schema.Schema{
+		"name":   {Type: schema.TypeString, Required: true, ForceNew: true, Description: "Name"},
+		"port":   {Type: schema.TypeInt, Optional: true, Default: 22},
+		"labels": {Type: schema.TypeMap, Optional: true},
+		"tags":   {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"rule": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"days": {Type: schema.TypeInt, Required: true, ConflictsWith: []string{"rule.0.date"}},
+				},
+			},
+		},
+	})
+
+	b2, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"attributes":{` +
+		`"labels":{"type":["map","string"],"optional":true},` +
+		`"name":{"type":"string","description":"Name","required":true,"force_new":true},` +
+		`"port":{"type":"number","optional":true,"default":22},` +
+		`"tags":{"type":["set","string"],"optional":true}},` +
+		`"block_types":{"rule":{"nesting_mode":"list","block":{"attributes":{` +
+		`"days":{"type":"number","required":true,"conflicts_with":["rule.0.date"]}}},"optional":true,"max_items":1}}}`
+	if string(b2) != want {
+		t.Errorf("Unexpected block:\n%s\nwant:\n%s", b2, want)
+	}
+}
+
+func TestExportValidator(t *testing.T) {
+	got := exportValidator(ValidateSchema{
+		Identifier:                 "type",
+		ValidateFunctionIdentifier: ValidateAllowedStringValue,
+		Type:                       TypeString,
+		AllowedValues:              "GLACIER, ACCELERATED",
+		Required:                   true,
+	})
+	want := exportedValidator{
+		Identifier:    "type",
+		Type:          "TypeString",
+		Function:      "ValidateAllowedStringValue",
+		AllowedValues: []string{"GLACIER", "ACCELERATED"},
+		Required:      true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestFunctionIdentifierString(t *testing.T) {
+	names := map[FunctionIdentifier]string{
+		IntBetween:                 "IntBetween",
+		ValidateIPorCIDR:           "ValidateIPorCIDR",
+		ValidateCIDRAddress:        "ValidateCIDRAddress",
+		ValidateAllowedIntValue:    "ValidateAllowedIntValue",
+		ValidateOverlappingAddress: "ValidateOverlappingAddress",
+	}
+	for f, want := range names {
+		if got := f.String(); got != want {
+			t.Errorf("Expected %s for %d, got %s", want, f, got)
+		}
+	}
+}
+
+func TestExportSchema(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportSchema(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var export ProviderSchemaExport
+	if err := json.Unmarshal(buf.Bytes(), &export); err != nil {
+		t.Fatal(err)
+	}
+
+	p := Provider()
+	if len(export.ResourceSchemas) != len(p.ResourcesMap) || len(export.DataSourceSchemas) != len(p.DataSourcesMap) {
+		t.Errorf("Expected %d resources and %d data sources, got %d and %d", len(p.ResourcesMap), len(p.DataSourcesMap), len(export.ResourceSchemas), len(export.DataSourceSchemas))
+	}
+	if _, ok := export.Provider.Block.Attributes["ibmcloud_api_key"]; !ok {
+		t.Error("Expected the provider arguments")
+	}
+	vpc := export.ResourceSchemas["ibm_is_vpc"]
+	if vpc == nil || vpc.Block.Attributes["name"] == nil || len(vpc.Validators) == 0 {
+		t.Fatalf("Expected the schema and validators of ibm_is_vpc, got %+v", vpc)
+	}
+	if export.ResourceSchemas["ibm_is_instance"].Version != 1 {
+		t.Errorf("Expected the schema version of ibm_is_instance")
+	}
+}
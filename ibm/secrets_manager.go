@@ -0,0 +1,548 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Secret types without a model in the Secrets Manager SDK of the provider,
+// managed with raw requests, see secretsManagerRequest
+const (
+	secretsManagerSecretTypeImportedCert = "imported_cert"
+	secretsManagerSecretTypePublicCert   = "public_cert"
+)
+
+// secretsManagerEndpoints caches the endpoint of the instances by instance ID
+// and endpoint type
+var secretsManagerEndpoints sync.Map
+
+// secretsManagerInstanceClient returns a client of the Secrets Manager
+// instance. The client is a copy of the client of the session, so that the
+// resources of several instances can be managed at once.
+func secretsManagerInstanceClient(meta interface{}, instanceID, endpointType string) (*secretsmanagerv1.SecretsManagerV1, error) {
+	secretsManagerClient, err := meta.(ClientSession).SecretsManagerV1()
+	if err != nil {
+		return nil, err
+	}
+	url, err := secretsManagerInstanceURL(meta, instanceID, endpointType)
+	if err != nil {
+		return nil, err
+	}
+	client := secretsManagerClient.Clone()
+	if err := client.SetServiceURL(meta.(ClientSession).Endpoint("IBMCLOUD_SECRETS_MANAGER_API_ENDPOINT", url)); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// secretsManagerSecretClient returns the client of the instance of a secret
+// resource along with the instance ID and the secret ID.
+func secretsManagerSecretClient(d *schema.ResourceData, meta interface{}) (*secretsmanagerv1.SecretsManagerV1, string, string, error) {
+	instanceID, secretID, err := parseSecretsManagerSecretID(d.Id())
+	if err != nil {
+		return nil, "", "", err
+	}
+	client, err := secretsManagerInstanceClient(meta, instanceID, d.Get("endpoint_type").(string))
+	if err != nil {
+		return nil, "", "", err
+	}
+	return client, instanceID, secretID, nil
+}
+
+// secretsManagerInstanceURL returns the endpoint of the Secrets Manager
+// instance in the region of the instance.
+func secretsManagerInstanceURL(meta interface{}, instanceID, endpointType string) (string, error) {
+	key := instanceID + "/" + endpointType
+	if url, ok := secretsManagerEndpoints.Load(key); ok {
+		return url.(string), nil
+	}
+
+	rContollerClient, err := meta.(ClientSession).ResourceControllerAPIV2()
+	if err != nil {
+		return "", err
+	}
+	instanceData, err := rContollerClient.ResourceServiceInstanceV2().GetInstance(instanceID)
+	if err != nil {
+		return "", newAPIError(fmt.Sprintf("Error getting the Secrets Manager instance %s", instanceID), err, nil)
+	}
+	crnData := strings.Split(instanceData.Crn.String(), ":")
+	if len(crnData) < 6 || crnData[4] != "secrets-manager" {
+		return "", fmt.Errorf("Invalid or unsupported service Instance %s: %s is not a Secrets Manager instance", instanceID, instanceData.Crn.String())
+	}
+
+	url := secretsManagerEndpointURL(instanceID, crnData[5], endpointType)
+	secretsManagerEndpoints.Store(key, url)
+	return url, nil
+}
+
+func secretsManagerEndpointURL(instanceID, region, endpointType string) string {
+	if endpointType == "private" {
+		return fmt.Sprintf("https://%s.private.%s.secrets-manager.appdomain.cloud", instanceID, region)
+	}
+	return fmt.Sprintf("https://%s.%s.secrets-manager.appdomain.cloud", instanceID, region)
+}
+
+// secretsManagerRequest sends a request to the Secrets Manager API and
+// returns the decoded JSON response. It is used for the secret types and
+// actions without a model in the SDK, ex: the imported certificates.
+func secretsManagerRequest(ctx context.Context, client *secretsmanagerv1.SecretsManagerV1, method, path string, pathParams, query map[string]string, body interface{}) (map[string]interface{}, *core.DetailedResponse, error) {
+	builder := core.NewRequestBuilder(method)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = client.GetEnableGzipCompression()
+	if _, err := builder.ResolveRequestURL(client.Service.Options.URL, path, pathParams); err != nil {
+		return nil, nil, err
+	}
+	builder.AddHeader("Accept", "application/json")
+	for name, value := range query {
+		builder.AddQuery(name, value)
+	}
+	if body != nil {
+		builder.AddHeader("Content-Type", "application/json")
+		if _, err := builder.SetBodyContentJSON(body); err != nil {
+			return nil, nil, err
+		}
+	}
+	request, err := builder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result map[string]interface{}
+	response, err := client.Service.Request(request, &result)
+	return result, response, err
+}
+
+// secretsManagerSecretResource returns the first resource of a raw response
+func secretsManagerSecretResource(result map[string]interface{}) (map[string]interface{}, error) {
+	if resources, ok := result["resources"].([]interface{}); ok && len(resources) > 0 {
+		if resource, ok := resources[0].(map[string]interface{}); ok {
+			return resource, nil
+		}
+	}
+	return nil, fmt.Errorf("The Secrets Manager response has no resource")
+}
+
+// getSecretsManagerRawSecret returns a secret of a type without a model in the
+// SDK, nil when it does not exist
+func getSecretsManagerRawSecret(ctx context.Context, client *secretsmanagerv1.SecretsManagerV1, secretType, secretID string) (map[string]interface{}, error) {
+	result, response, err := secretsManagerRequest(ctx, client, core.GET, "/api/v1/secrets/{secret_type}/{id}", map[string]string{
+		"secret_type": secretType,
+		"id":          secretID,
+	}, nil, nil)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, newAPIError("Error getting the secret", err, response)
+	}
+	return secretsManagerSecretResource(result)
+}
+
+// secretsManagerSecretRequestBody returns the body of a request with the
+// collection of a single resource
+func secretsManagerSecretRequestBody(collectionType string, resource interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"collection_type":  collectionType,
+			"collection_total": 1,
+		},
+		"resources": []interface{}{resource},
+	}
+}
+
+func secretsManagerCollectionMetadata(collectionType string) *secretsmanagerv1.CollectionMetadata {
+	return &secretsmanagerv1.CollectionMetadata{
+		CollectionType:  core.StringPtr(collectionType),
+		CollectionTotal: core.Int64Ptr(1),
+	}
+}
+
+// secretsManagerResourceID returns the ID of a secret group or of a secret:
+// <instance_id>/<id>
+func secretsManagerResourceID(instanceID, id string) string {
+	return fmt.Sprintf("%s/%s", instanceID, id)
+}
+
+// parseSecretsManagerSecretID returns the instance ID and the secret ID of a
+// resource ID.
+func parseSecretsManagerSecretID(id string) (string, string, error) {
+	parts, err := idParts(id)
+	if err != nil {
+		return "", "", err
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid ID %q, expected <instance_id>/<secret_id>", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// secretsManagerSecretSchema returns the schema of the arguments and
+// attributes shared by the secrets, merged with the schema of a secret type.
+func secretsManagerSecretSchema(typeSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"instance_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The GUID of the Secrets Manager instance.",
+		},
+		"endpoint_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "public",
+			ValidateFunc: validation.StringInSlice([]string{"public", "private"}, false),
+			Description:  "The endpoint of the Secrets Manager instance, `public` or `private`.",
+		},
+		"secret_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The v4 UUID that uniquely identifies the secret.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "A human-readable alias to assign to your secret.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "An extended description of your secret.",
+		},
+		"secret_group_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "The v4 UUID that uniquely identifies the secret group to assign to this secret. If you omit this parameter, your secret is assigned to the `default` secret group.",
+		},
+		"labels": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Labels that you can use to filter for secrets in your instance.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"state": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The secret state based on NIST SP 800-57. States are integers and correspond to the Pre-activation = 0, Active = 1,  Suspended = 2, Deactivated = 3, and Destroyed = 5 values.",
+		},
+		"state_description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "A text representation of the secret state.",
+		},
+		"crn": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The Cloud Resource Name (CRN) that uniquely identifies the secret.",
+		},
+		"creation_date": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the secret was created. The date format follows RFC 3339.",
+		},
+		"created_by": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The unique identifier for the entity that created the secret.",
+		},
+		"last_update_date": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Updates when the actual secret is modified. The date format follows RFC 3339.",
+		},
+		"versions_total": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of versions of the secret.",
+		},
+	}
+	for k, v := range typeSchema {
+		s[k] = v
+	}
+	return s
+}
+
+// setSecretsManagerSecret sets the attributes shared by the secrets
+func setSecretsManagerSecret(d *schema.ResourceData, instanceID string, secret *secretsmanagerv1.SecretResource) error {
+	values := map[string]interface{}{
+		"instance_id":       instanceID,
+		"endpoint_type":     secretsManagerEndpointType(d),
+		"secret_id":         secret.ID,
+		"name":              secret.Name,
+		"description":       secret.Description,
+		"secret_group_id":   secret.SecretGroupID,
+		"labels":            secret.Labels,
+		"state":             secret.State,
+		"state_description": secret.StateDescription,
+		"crn":               secret.CRN,
+		"creation_date":     dateTimeToString(secret.CreationDate),
+		"created_by":        secret.CreatedBy,
+		"last_update_date":  dateTimeToString(secret.LastUpdateDate),
+		"versions_total":    len(secret.Versions),
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("Error setting %s: %s", k, err)
+		}
+	}
+	return nil
+}
+
+// setSecretsManagerRawSecret sets the attributes shared by the secrets from a
+// raw response
+func setSecretsManagerRawSecret(d *schema.ResourceData, instanceID string, secret map[string]interface{}) error {
+	versions, _ := secret["versions"].([]interface{})
+	total := len(versions)
+	if t, ok := secret["versions_total"].(float64); ok {
+		total = int(t)
+	}
+	values := map[string]interface{}{
+		"instance_id":       instanceID,
+		"endpoint_type":     secretsManagerEndpointType(d),
+		"secret_id":         secret["id"],
+		"name":              secret["name"],
+		"description":       secret["description"],
+		"secret_group_id":   secret["secret_group_id"],
+		"labels":            secret["labels"],
+		"state":             secret["state"],
+		"state_description": secret["state_description"],
+		"crn":               secret["crn"],
+		"creation_date":     secret["creation_date"],
+		"created_by":        secret["created_by"],
+		"last_update_date":  secret["last_update_date"],
+		"versions_total":    total,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("Error setting %s: %s", k, err)
+		}
+	}
+	return nil
+}
+
+// secretsManagerEndpointType returns the endpoint type of a resource, public
+// for the imported resources
+func secretsManagerEndpointType(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("endpoint_type"); ok {
+		return v.(string)
+	}
+	return "public"
+}
+
+// suppressEquivalentSecretsManagerDate suppresses the diff of two dates at
+// the same instant, the API returns the dates with milliseconds
+func suppressEquivalentSecretsManagerDate(k, old, new string, d *schema.ResourceData) bool {
+	o, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	n, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return o.Equal(n)
+}
+
+// suppressEquivalentPEM suppresses the diff of two PEM blocks differing by
+// the surrounding white space
+func suppressEquivalentPEM(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+func secretsManagerCertificateValiditySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The date the certificate validity period begins and ends.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"not_before": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The date the certificate validity period begins.",
+				},
+				"not_after": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The date the certificate validity period ends.",
+				},
+			},
+		},
+	}
+}
+
+func flattenSecretsManagerCertificateValidity(validity interface{}) []map[string]interface{} {
+	v, ok := validity.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return []map[string]interface{}{
+		{
+			"not_before": v["not_before"],
+			"not_after":  v["not_after"],
+		},
+	}
+}
+
+// flattenSecretsManagerAltNames returns the alternative names of a
+// certificate, a list or a comma separated string depending on the secret
+// type
+func flattenSecretsManagerAltNames(altNames interface{}) []string {
+	switch v := altNames.(type) {
+	case []interface{}:
+		names := make([]string, 0, len(v))
+		for _, name := range v {
+			if name, ok := name.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	case string:
+		if v == "" {
+			return nil
+		}
+		names := strings.Split(v, ",")
+		for i := range names {
+			names[i] = strings.TrimSpace(names[i])
+		}
+		return names
+	}
+	return nil
+}
+
+// secretsManagerTTLSeconds returns the seconds of a TTL given in seconds or
+// with a unit: s, m, h or d
+func secretsManagerTTLSeconds(ttl string) (int64, bool) {
+	units := map[byte]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400}
+	unit := int64(1)
+	if n := len(ttl); n > 0 {
+		if u, ok := units[ttl[n-1]]; ok {
+			unit = u
+			ttl = ttl[:n-1]
+		}
+	}
+	seconds, err := strconv.ParseInt(ttl, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return seconds * unit, true
+}
+
+// secretsManagerTTLString returns the TTL of a secret, a JSON number or
+// string
+func secretsManagerTTLString(ttl interface{}) string {
+	switch v := ttl.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	}
+	return fmt.Sprint(ttl)
+}
+
+// secretsManagerTTLEqual returns whether two TTLs are the same duration
+func secretsManagerTTLEqual(a, b string) bool {
+	x, ok := secretsManagerTTLSeconds(a)
+	if !ok {
+		return false
+	}
+	y, ok := secretsManagerTTLSeconds(b)
+	return ok && x == y
+}
+
+// updateSecretsManagerSecretMetadata updates the name, description, labels
+// and, for the secret types with an expiration_date argument, the expiration
+// date of a secret
+func updateSecretsManagerSecretMetadata(ctx context.Context, client *secretsmanagerv1.SecretsManagerV1, d *schema.ResourceData, secretType, secretID string, expires bool) error {
+	attributes := []string{"name", "description", "labels"}
+	if expires {
+		attributes = append(attributes, "expiration_date")
+	}
+	if !d.HasChanges(attributes...) {
+		return nil
+	}
+	metadata := secretsmanagerv1.SecretMetadata{
+		Name:        core.StringPtr(d.Get("name").(string)),
+		Description: core.StringPtr(d.Get("description").(string)),
+		Labels:      expandStringList(d.Get("labels").([]interface{})),
+	}
+	if v, ok := d.GetOk("expiration_date"); expires && ok {
+		expirationDate, err := core.ParseDateTime(v.(string))
+		if err != nil {
+			return fmt.Errorf("Error parsing expiration_date: %s", err)
+		}
+		metadata.ExpirationDate = &expirationDate
+	}
+	_, response, err := client.UpdateSecretMetadataWithContext(ctx, &secretsmanagerv1.UpdateSecretMetadataOptions{
+		SecretType: core.StringPtr(secretType),
+		ID:         core.StringPtr(secretID),
+		Metadata:   secretsManagerCollectionMetadata(secretsmanagerv1.CollectionMetadataCollectionTypeApplicationVndIBMSecretsManagerSecretJSONConst),
+		Resources:  []secretsmanagerv1.SecretMetadata{metadata},
+	})
+	if err != nil {
+		return newAPIError("Error updating the metadata of the secret", err, response).withAttributes(attributes...)
+	}
+	return nil
+}
+
+// deleteSecretsManagerSecret deletes a secret, a secret already deleted is
+// not an error
+func deleteSecretsManagerSecret(ctx context.Context, client *secretsmanagerv1.SecretsManagerV1, secretType, secretID string) error {
+	response, err := client.DeleteSecretWithContext(ctx, &secretsmanagerv1.DeleteSecretOptions{
+		SecretType: core.StringPtr(secretType),
+		ID:         core.StringPtr(secretID),
+	})
+	if err != nil && (response == nil || response.StatusCode != 404) {
+		return newAPIError("Error deleting the secret", err, response)
+	}
+	return nil
+}
+
+// secretsManagerSecretResourceOf returns the secret of a GetSecret or
+// CreateSecret response
+func secretsManagerSecretResourceOf(resources []secretsmanagerv1.SecretResourceIntf) (*secretsmanagerv1.SecretResource, error) {
+	for _, r := range resources {
+		if secret, ok := r.(*secretsmanagerv1.SecretResource); ok {
+			return secret, nil
+		}
+	}
+	return nil, fmt.Errorf("The Secrets Manager response has no secret")
+}
+
+// getSecretsManagerSecret returns a secret, nil when it does not exist
+func getSecretsManagerSecret(ctx context.Context, client *secretsmanagerv1.SecretsManagerV1, secretType, secretID string) (*secretsmanagerv1.SecretResource, error) {
+	getSecret, response, err := client.GetSecretWithContext(ctx, &secretsmanagerv1.GetSecretOptions{
+		SecretType: core.StringPtr(secretType),
+		ID:         core.StringPtr(secretID),
+	})
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, newAPIError("Error getting the secret", err, response)
+	}
+	return secretsManagerSecretResourceOf(getSecret.Resources)
+}
+
+// secretsManagerSecretData returns the secret data of a secret as a map
+func secretsManagerSecretData(secretData interface{}) map[string]interface{} {
+	if m, ok := secretData.(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{}
+}
@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// State migrations
+//
+// A resource whose schema changes in a way its existing state can't be read
+// with bumps its SchemaVersion and appends to its StateUpgraders an upgrader
+// from the previous version. Terraform runs the upgraders of the versions
+// between the version recorded in the state and the SchemaVersion in order,
+// on the raw JSON state, before reading the state with the current schema.
+// The attributes that are no longer in the schema are dropped from the
+// upgraded state by the SDK, so the upgraders only have to move or reshape
+// the values that are kept.
+
+// stateUpgradersV0 returns the upgraders of a resource at SchemaVersion 1,
+// upgrading the state of version 0 with upgrade. The state of version 0 is
+// read with the type of the current schema: the attributes kept since version
+// 0 have the same types, they were lists of blocks already, and the
+// attributes added since are null in the older states. A resource whose
+// attribute types change must declare the type of its previous schema instead.
+func stateUpgradersV0(resource *schema.Resource, upgrade schema.StateUpgradeFunc) []schema.StateUpgrader {
+	return []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resource.CoreConfigSchema().ImpliedType(),
+			Upgrade: upgrade,
+		},
+	}
+}
+
+// stateUpgradeKeep is the upgrade of the resources whose state of version 0
+// is read as is with the current schema.
+func stateUpgradeKeep(_ context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	return rawState, nil
+}
+
+// stateInt returns the integer value of the attribute key of rawState, as
+// decoded from the JSON state.
+func stateInt(rawState map[string]interface{}, key string) (int, bool) {
+	switch v := rawState[key].(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	case json.Number:
+		i, err := v.Int64()
+		return int(i), err == nil
+	case string:
+		i, err := strconv.Atoi(v)
+		return i, err == nil
+	}
+	return 0, false
+}
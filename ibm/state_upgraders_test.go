@@ -0,0 +1,220 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testUpgradeState upgrades the raw JSON state of version 0 of resource and
+// checks the upgraded state can be read with the current schema.
+func testUpgradeState(t *testing.T, resource *schema.Resource, rawJSON string) map[string]interface{} {
+	t.Helper()
+	if resource.SchemaVersion != 1 || len(resource.StateUpgraders) != 1 {
+		t.Fatalf("Expected a state upgrader from version 0, got version %d with %d upgraders", resource.SchemaVersion, len(resource.StateUpgraders))
+	}
+	var rawState map[string]interface{}
+	if err := json.Unmarshal([]byte(rawJSON), &rawState); err != nil {
+		t.Fatal(err)
+	}
+	upgraded, err := resource.StateUpgraders[0].Upgrade(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(upgraded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctyjson.Unmarshal(b, resource.CoreConfigSchema().ImpliedType()); err != nil {
+		t.Fatalf("Error reading the upgraded state %s: %s", b, err)
+	}
+	return upgraded
+}
+
+// testUpgradeStateSDK upgrades the raw state of version 0 of the resource
+// name like Terraform does, through the SDK, and returns the upgraded state.
+func testUpgradeStateSDK(t *testing.T, name string, resource *schema.Resource, rawState *tfprotov5.RawState) map[string]interface{} {
+	t.Helper()
+	server := schema.NewGRPCProviderServer(&schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{name: resource},
+	})
+	resp, err := server.UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: name,
+		Version:  0,
+		RawState: rawState,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("Error upgrading the state: %s: %s", d.Summary, d.Detail)
+	}
+
+	ty := resource.CoreConfigSchema().ImpliedType()
+	val, err := msgpack.Unmarshal(resp.UpgradedState.MsgPack, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ctyjson.Marshal(val, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state map[string]interface{}
+	if err := json.Unmarshal(b, &state); err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+func TestResourceIBMISInstanceStateUpgradeV0SDK(t *testing.T) {
+	cases := map[string]struct {
+		rawState *tfprotov5.RawState
+		want     []interface{}
+	}{
+		"flatmap": {
+			&tfprotov5.RawState{Flatmap: map[string]string{
+				"id":                       "0717-1234",
+				"name":                     "vsi",
+				"boot_volume.#":            "1",
+				"boot_volume.0.name":       "boot",
+				"boot_volume.0.encryption": "crn:v1:key",
+				"boot_volume.0.size":       "100",
+				"boot_volume.0.iops":       "3000",
+				"boot_volume.0.profile":    "general-purpose",
+			}},
+			[]interface{}{map[string]interface{}{"name": "boot", "encryption": "crn:v1:key", "size": float64(100), "iops": float64(3000), "profile": "general-purpose", "snapshot": nil}},
+		},
+		"flatmap without boot volume": {
+			&tfprotov5.RawState{Flatmap: map[string]string{"id": "0717-1234", "name": "vsi", "boot_volume.#": "0"}},
+			[]interface{}{},
+		},
+		"json": {
+			&tfprotov5.RawState{JSON: []byte(`{"id": "0717-1234", "name": "vsi", "boot_volume": [{"name": "boot", "size": 100}]}`)},
+			[]interface{}{map[string]interface{}{"name": "boot", "size": float64(100), "encryption": nil, "iops": nil, "profile": nil, "snapshot": nil}},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			state := testUpgradeStateSDK(t, "ibm_is_instance", resourceIBMISInstance(), c.rawState)
+			if state["name"] != "vsi" {
+				t.Errorf("Expected the name to be kept, got %v", state["name"])
+			}
+			if !reflect.DeepEqual(state["boot_volume"], c.want) {
+				t.Errorf("Expected boot_volume %v, got %v", c.want, state["boot_volume"])
+			}
+		})
+	}
+}
+
+func TestResourceIBMCOSBucketStateUpgradeV0SDK(t *testing.T) {
+	state := testUpgradeStateSDK(t, "ibm_cos_bucket", resourceIBMCOSBucket(), &tfprotov5.RawState{Flatmap: map[string]string{
+		"id":                         "crn:v1:bluemix:public:cloud-object-storage:global:a/1234:5678::bucket:b1",
+		"bucket_name":                "b1",
+		"archive_rule.#":             "1",
+		"archive_rule.0.rule_id":     "a1",
+		"archive_rule.0.enable":      "true",
+		"archive_rule.0.days":        "30",
+		"archive_rule.0.type":        "GLACIER",
+		"expire_rule.#":              "2",
+		"expire_rule.0.rule_id":      "e1",
+		"expire_rule.0.enable":       "true",
+		"expire_rule.0.days":         "60",
+		"expire_rule.0.prefix":       "logs/",
+		"expire_rule.1.rule_id":      "e2",
+		"expire_rule.1.enable":       "false",
+		"expire_rule.1.days":         "90",
+		"expire_rule.1.prefix":       "",
+		"object_versioning.#":        "1",
+		"object_versioning.0.enable": "true",
+		"retention_rule.#":           "0",
+	}})
+
+	want := map[string]interface{}{
+		"archive_rule": []interface{}{
+			map[string]interface{}{"rule_id": "a1", "enable": true, "days": float64(30), "type": "GLACIER"},
+		},
+		"expire_rule": []interface{}{
+			map[string]interface{}{"rule_id": "e1", "enable": true, "days": float64(60), "prefix": "logs/"},
+			map[string]interface{}{"rule_id": "e2", "enable": false, "days": float64(90), "prefix": ""},
+		},
+		"object_versioning": []interface{}{map[string]interface{}{"enable": true}},
+	}
+	for k, v := range want {
+		if !reflect.DeepEqual(state[k], v) {
+			t.Errorf("Expected %s %v, got %v", k, v, state[k])
+		}
+	}
+	for _, k := range []string{"retention_rule", "activity_tracking", "metrics_monitoring"} {
+		if blocks, _ := state[k].([]interface{}); len(blocks) != 0 {
+			t.Errorf("Expected no %s, got %v", k, state[k])
+		}
+	}
+}
+
+func TestResourceIBMContainerClusterStateUpgradeV0(t *testing.T) {
+	cases := map[string]struct {
+		rawJSON string
+		want    interface{}
+	}{
+		"worker_num only":   {`{"id": "c1", "name": "cluster", "worker_num": 3}`, 3},
+		"unset pool size":   {`{"id": "c1", "name": "cluster", "worker_num": 3, "default_pool_size": 0}`, 3},
+		"both":              {`{"id": "c1", "name": "cluster", "worker_num": 3, "default_pool_size": 2}`, float64(2)},
+		"no worker_num":     {`{"id": "c1", "name": "cluster", "worker_num": 0, "default_pool_size": 2}`, float64(2)},
+		"string worker_num": {`{"id": "c1", "name": "cluster", "worker_num": "4"}`, 4},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			state := testUpgradeState(t, resourceIBMContainerCluster(), c.rawJSON)
+			if state["default_pool_size"] != c.want {
+				t.Errorf("Expected default_pool_size %v, got %v", c.want, state["default_pool_size"])
+			}
+		})
+	}
+}
+
+func TestResourceIBMCOSBucketStateUpgradeV0(t *testing.T) {
+	rawJSON := `{
+		"id": "crn:v1:bluemix:public:cloud-object-storage:global:a/1234:5678::bucket:b1",
+		"bucket_name": "b1",
+		"archive_rule": [{"rule_id": "a1", "enable": true, "days": 30, "type": "GLACIER"}],
+		"expire_rule": [],
+		"activity_tracking": null
+	}`
+	state := testUpgradeState(t, resourceIBMCOSBucket(), rawJSON)
+	var want map[string]interface{}
+	if err := json.Unmarshal([]byte(rawJSON), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(state, want) {
+		t.Errorf("Expected the state to be kept, got %v", state)
+	}
+}
+
+func TestStateInt(t *testing.T) {
+	rawState := map[string]interface{}{
+		"float":  float64(3),
+		"number": json.Number("4"),
+		"string": "5",
+		"bad":    "five",
+	}
+	for k, want := range map[string]int{"float": 3, "number": 4, "string": 5} {
+		if got, ok := stateInt(rawState, k); !ok || got != want {
+			t.Errorf("Expected %d for %s, got %d", want, k, got)
+		}
+	}
+	for _, k := range []string{"bad", "missing"} {
+		if _, ok := stateInt(rawState, k); ok {
+			t.Errorf("Expected no integer for %s", k)
+		}
+	}
+}
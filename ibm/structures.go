@@ -1182,6 +1182,48 @@ func cfIdParts(id string) ([]string, error) {
 	return parts, nil
 }
 
+// validateIDParts splits the composite id with sepIdParts and checks that it
+// has a non empty value for each of the named parts.
+func validateIDParts(id string, separator string, names ...string) ([]string, error) {
+	var reason string
+	parts, err := sepIdParts(id, separator)
+	switch {
+	case err != nil:
+		reason = fmt.Sprintf("it does not contain %s", separator)
+	case len(parts) != len(names):
+		reason = fmt.Sprintf("it has %d parts instead of %d", len(parts), len(names))
+	default:
+		for i, part := range parts {
+			if part == "" {
+				reason = fmt.Sprintf("%s is empty", names[i])
+				break
+			}
+		}
+	}
+	if reason != "" {
+		format := make([]string, len(names))
+		for i, name := range names {
+			format[i] = "<" + name + ">"
+		}
+		return nil, fmt.Errorf("Invalid ID %q, %s. The ID must have the format %s", id, reason, strings.Join(format, separator))
+	}
+	return parts, nil
+}
+
+// importIDParts returns the importer of the resources whose ID is made of the
+// named parts joined by separator. Malformed IDs are rejected with the format
+// the ID must have.
+func importIDParts(separator string, names ...string) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			if _, err := validateIDParts(d.Id(), separator, names...); err != nil {
+				return nil, err
+			}
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
 // getCustomAttributes will return all attributes which are not system defined
 func getCustomAttributes(r iampolicymanagementv1.PolicyResource) []iampolicymanagementv1.ResourceAttribute {
 	attributes := []iampolicymanagementv1.ResourceAttribute{}
@@ -1822,6 +1864,9 @@ func UpdateGlobalTagsUsingCRN(oldList, newList interface{}, meta interface{}, re
 	}
 	olds := oldList.(*schema.Set)
 	news := newList.(*schema.Set)
+	if strings.TrimSpace(tagType) == "" || tagType == "user" {
+		news = withDefaultTags(news, meta)
+	}
 	removeInt := olds.Difference(news).List()
 	addInt := news.Difference(olds).List()
 	add := make([]string, len(addInt))
@@ -1918,7 +1963,7 @@ func UpdateTagsUsingCRN(oldList, newList interface{}, meta interface{}, resource
 		newList = new(schema.Set)
 	}
 	olds := oldList.(*schema.Set)
-	news := newList.(*schema.Set)
+	news := withDefaultTags(newList.(*schema.Set), meta)
 	removeInt := olds.Difference(news).List()
 	addInt := news.Difference(olds).List()
 	add := make([]string, len(addInt))
@@ -1979,7 +2024,7 @@ func flattenSSLCiphers(ciphers []datatypes.Network_LBaaS_SSLCipher) *schema.Set
 	return newStringSet(schema.HashString, c)
 }
 
-func resourceTagsCustomizeDiff(diff *schema.ResourceDiff) error {
+func resourceTagsCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
 
 	if diff.Id() != "" && diff.HasChange("tags") {
 		o, n := diff.GetChange("tags")
@@ -1995,7 +2040,63 @@ func resourceTagsCustomizeDiff(diff *schema.ResourceDiff) error {
 			}
 		}
 	}
-	return nil
+	return resourceDefaultTagsCustomizeDiff(diff, meta)
+}
+
+// resourceDefaultTagsCustomizeDiff plans the tags of the resource with the
+// default tags of the provider, which are attached on top of the configured
+// tags. The tags read back from the resource then match the plan, so the
+// default tags only show in the plan when they change.
+func resourceDefaultTagsCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if !hasDefaultTags(meta) || !diff.NewValueKnown("tags") {
+		return nil
+	}
+	newSet, ok := diff.Get("tags").(*schema.Set)
+	if !ok {
+		// the resource has no tags
+		return nil
+	}
+	desired := withDefaultTags(newSet, meta)
+	if v := os.Getenv("IC_ENV_TAGS"); v != "" {
+		for _, t := range strings.Split(v, ",") {
+			desired.Add(t)
+		}
+	}
+	if newSet.Equal(desired) {
+		return nil
+	}
+	return diff.SetNew("tags", desired)
+}
+
+// expandDefaultTags returns the tags of the provider default_tags block.
+func expandDefaultTags(l []interface{}) []string {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	return expandStringList(l[0].(map[string]interface{})["tags"].(*schema.Set).List())
+}
+
+// hasDefaultTags returns whether the provider has default tags.
+func hasDefaultTags(meta interface{}) bool {
+	sess, ok := meta.(ClientSession)
+	return ok && len(sess.DefaultTags()) > 0
+}
+
+// withDefaultTags returns the tags merged with the default tags of the
+// provider, the tags the resource must have.
+func withDefaultTags(tags *schema.Set, meta interface{}) *schema.Set {
+	if !hasDefaultTags(meta) {
+		return tags
+	}
+	f := tags.F
+	if f == nil {
+		f = resourceIBMVPCHash
+	}
+	merged := schema.NewSet(f, tags.List())
+	for _, t := range meta.(ClientSession).DefaultTags() {
+		merged.Add(t)
+	}
+	return merged
 }
 
 func resourceIBMISLBPoolCookieValidate(diff *schema.ResourceDiff) error {
@@ -0,0 +1,178 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testDefaultTagsSession(tags ...string) *clientSession {
+	return &clientSession{config: &Config{DefaultTags: tags}}
+}
+
+func TestWithDefaultTags(t *testing.T) {
+	tags := newStringSet(resourceIBMVPCHash, []string{"env:dev", "cost-center:1234"})
+
+	if got := withDefaultTags(tags, testDefaultTagsSession()); got != tags {
+		t.Error("Expected the tags to be unchanged without default tags")
+	}
+
+	got := withDefaultTags(tags, testDefaultTagsSession("cost-center:1234", "team:platform"))
+	want := newStringSet(resourceIBMVPCHash, []string{"env:dev", "cost-center:1234", "team:platform"})
+	if !got.Equal(want) {
+		t.Errorf("Expected %v, got %v", want.List(), got.List())
+	}
+	if tags.Len() != 2 {
+		t.Error("Expected the configured tags not to be modified")
+	}
+
+	if got := withDefaultTags(new(schema.Set), testDefaultTagsSession("team:platform")); got.Len() != 1 {
+		t.Errorf("Expected the default tags for a resource without tags, got %v", got.List())
+	}
+}
+
+func TestResourceTagsCustomizeDiffDefaultTags(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceIBMVPCHash,
+			},
+		},
+		CustomizeDiff: func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+			return resourceTagsCustomizeDiff(diff, v)
+		},
+	}
+	state := &terraform.InstanceState{
+		ID: "crn:v1:bluemix:public:is:us-south:a/1234::vpc:r006-1234",
+		Attributes: map[string]string{
+			"id":                                   "crn:v1:bluemix:public:is:us-south:a/1234::vpc:r006-1234",
+			"tags.#":                               "2",
+			"tags." + testHash("env:dev"):          "env:dev",
+			"tags." + testHash("cost-center:1234"): "cost-center:1234",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"tags": []interface{}{"env:dev"},
+	})
+
+	cases := map[string]struct {
+		meta    interface{}
+		planned []string
+	}{
+		"no default tags":       {testDefaultTagsSession(), []string{"env:dev"}},
+		"attached default tags": {testDefaultTagsSession("cost-center:1234"), nil},
+		"changed default tags":  {testDefaultTagsSession("cost-center:5678"), []string{"env:dev", "cost-center:5678"}},
+	}
+	for name, c := range cases {
+		diff, err := r.SimpleDiff(context.Background(), state, config, c.meta)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if c.planned == nil {
+			if diff != nil && len(diff.Attributes) > 0 {
+				t.Errorf("%s: expected no diff, got %v", name, diff)
+			}
+			continue
+		}
+		for _, tag := range c.planned {
+			if attr, ok := diff.Attributes["tags."+testHash(tag)]; !ok || attr.New != tag {
+				t.Errorf("%s: expected tag %s to be planned, got %v", name, tag, diff)
+			}
+		}
+		if attr, ok := diff.Attributes["tags."+testHash("cost-center:1234")]; !ok || !attr.NewRemoved {
+			t.Errorf("%s: expected the previous default tag to be removed, got %v", name, diff)
+		}
+	}
+
+	noTags := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Optional: true},
+		},
+		CustomizeDiff: r.CustomizeDiff,
+	}
+	_, err := noTags.SimpleDiff(context.Background(), &terraform.InstanceState{ID: "1234"},
+		terraform.NewResourceConfigRaw(map[string]interface{}{"name": "foo"}), testDefaultTagsSession("cost-center:1234"))
+	if err != nil {
+		t.Errorf("Unexpected error for a resource without tags: %s", err)
+	}
+}
+
+func testHash(tag string) string {
+	return fmt.Sprint(resourceIBMVPCHash(tag))
+}
+
+func TestValidateIDParts(t *testing.T) {
+	parts, err := validateIDParts("r006-lb/r006-pool/r006-member", "/", "lb_id", "pool_id", "member_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 3 || parts[2] != "r006-member" {
+		t.Errorf("Unexpected parts %v", parts)
+	}
+
+	cases := map[string]string{
+		"r006-lb":                     "it does not contain /",
+		"r006-lb/r006-pool":           "it has 2 parts instead of 3",
+		"r006-lb//r006-member":        "pool_id is empty",
+		"r006-lb/r006-pool/m/extra":   "it has 4 parts instead of 3",
+		"r006-lb/r006-pool/r006-m/":   "it has 4 parts instead of 3",
+		"/r006-pool/r006-pool-member": "lb_id is empty",
+	}
+	for id, reason := range cases {
+		_, err := validateIDParts(id, "/", "lb_id", "pool_id", "member_id")
+		if err == nil || !strings.Contains(err.Error(), reason) || !strings.Contains(err.Error(), "<lb_id>/<pool_id>/<member_id>") {
+			t.Errorf("Expected %q for ID %s, got %v", reason, id, err)
+		}
+	}
+}
+
+func TestImportIDParts(t *testing.T) {
+	r := &schema.Resource{
+		Schema:   map[string]*schema.Schema{"name": {Type: schema.TypeString, Optional: true}},
+		Importer: importIDParts(":alias:", "alias", "key_crn"),
+	}
+	d := r.Data(nil)
+	d.SetId("my-alias:alias:crn:v1:bluemix:public:kms:us-south:a/1234:5678:key:abcd")
+	if imported, err := r.Importer.State(d, nil); err != nil || len(imported) != 1 {
+		t.Errorf("Expected the ID to be imported, got %v", err)
+	}
+	d.SetId("crn:v1:bluemix:public:kms:us-south:a/1234:5678:key:abcd")
+	if _, err := r.Importer.State(d, nil); err == nil || !strings.Contains(err.Error(), "<alias>:alias:<key_crn>") {
+		t.Errorf("Expected an error with the ID format, got %v", err)
+	}
+}
+
+func TestResourceIBMCOSBucketImport(t *testing.T) {
+	crn := "crn:v1:bluemix:public:cloud-object-storage:global:a/4ea1882a2d3401ed1e459979941966ea:31fa970d-51d0-4b05-893e-251cba75a7b3"
+	cases := map[string]string{
+		crn + ":bucket:mybucket:meta:crl:eu:public":   "",
+		crn + ":bucket:mybucket:meta:rl:us-south":     "",
+		crn + ":bucket:mybucket":                      "does not contain :meta:",
+		crn + ":mybucket:meta:rl:us-south":            "is not the CRN of a bucket",
+		crn + ":bucket:mybucket:meta:rl":              "must have the format",
+		crn + ":bucket:mybucket:meta:global:us-south": "the bucket type must be ssl, rl or crl",
+	}
+	r := resourceIBMCOSBucket()
+	for id, reason := range cases {
+		d := r.Data(nil)
+		d.SetId(id)
+		_, err := r.Importer.State(d, nil)
+		if reason == "" && err != nil {
+			t.Errorf("Unexpected error for ID %s: %s", id, err)
+		}
+		if reason != "" && (err == nil || !strings.Contains(err.Error(), reason)) {
+			t.Errorf("Expected %q for ID %s, got %v", reason, id, err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	gohttp "net/http"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+)
+
+// httpClientSetter is implemented by the go-sdk-core BaseService of every
+// generated IBM Cloud SDK client (v4 and v5).
+type httpClientSetter interface {
+	SetHTTPClient(*gohttp.Client)
+}
+
+// wrapTransport layers the provider's HTTP middleware over base. Every client
+// built in Config.ClientSession sends its requests through the result, so
+// behaviour added here applies to the whole provider. The token renewal is the
+// outermost layer so every other layer sees the token that is sent, the
+// recorder is next so replayed requests are not rate limited and the logger
+// the innermost so it times the requests sent over the network.
+func (c *Config) wrapTransport(base gohttp.RoundTripper) gohttp.RoundTripper {
+	if c.HTTPLogging {
+		base = &loggingTransport{next: base}
+	}
+	if c.limiter != nil {
+		base = &rateLimitTransport{limiter: c.limiter, next: base}
+	}
+	if cassetteMode() != "" {
+		base = &recorderTransport{cassette: c.cassette, next: base}
+	}
+	if c.tokenSource != nil || c.Assume != nil {
+		base = &tokenSourceTransport{config: c, next: base}
+	}
+	return base
+}
+
+// enableRetries is the provider's replacement for BaseService.EnableRetries:
+// it configures the same retrying client as go-sdk-core, driven by the
+// provider retry policy, and routes the requests through wrapTransport.
+func (c *Config) enableRetries(service httpClientSetter) {
+	policy := c.retryPolicy()
+	client := core.NewRetryableHTTPClient()
+	client.RetryMax = policy.MaxRetries
+	if policy.MinBackoff > 0 {
+		client.RetryWaitMin = policy.MinBackoff
+	}
+	if policy.MaxBackoff > 0 {
+		client.RetryWaitMax = policy.MaxBackoff
+	}
+	client.CheckRetry = policy.checkRetry
+	client.Backoff = policy.backoff
+	client.RequestLogHook = logRetryAttempt
+	client.HTTPClient.Transport = c.wrapTransport(client.HTTPClient.Transport)
+	service.SetHTTPClient(client.StandardClient())
+}
+
+// retryTransport applies the provider retry policy to the clients that do not
+// use go-sdk-core: the bluemix-go, SoftLayer and Power sessions, which are
+// configured not to retry themselves.
+func (c *Config) retryTransport(base gohttp.RoundTripper) gohttp.RoundTripper {
+	return &retryingTransport{policy: c.retryPolicy(), next: base}
+}
+
+type retryingTransport struct {
+	policy *RetryPolicy
+	next   gohttp.RoundTripper
+}
+
+func (t *retryingTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.policy.MaxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+		if retry, _ := t.policy.checkRetry(req.Context(), resp, err); !retry {
+			return resp, err
+		}
+		wait := t.policy.backoff(t.policy.MinBackoff, t.policy.MaxBackoff, attempt, resp)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Printf("[DEBUG] Retrying %s %s in %s", req.Method, redactURL(req.URL), wait)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.Body != nil {
+			if attemptReq.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		logRetryAttempt(nil, attemptReq, attempt+1)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	gohttp "net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// bodies larger than this are truncated in the logs, only this much of a body
+// is read ahead of its reader
+const maxLoggedBodySize = 64 * 1024
+
+var (
+	// headers whose values are never logged
+	redactedHeaders = []string{"Authorization", "X-Auth-Token", "X-Auth-Refresh-Token", "X-Auth-User-Token", "Refresh-Token", "Cookie", "Set-Cookie"}
+	// response headers holding the ID IBM Cloud services assign to a request
+	requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Transaction-Id", "X-Global-Transaction-Id"}
+)
+
+type retryAttemptKey struct{}
+
+// loggingTransport logs every request and its response as a JSON document at
+// the DEBUG level. Credentials are redacted from the headers, the URL and the
+// bodies.
+type loggingTransport struct {
+	next gohttp.RoundTripper
+}
+
+// httpLogEntry is the JSON document logged for a request
+type httpLogEntry struct {
+	Method          string        `json:"method"`
+	URL             string        `json:"url"`
+	Status          int           `json:"status,omitempty"`
+	LatencyMs       int64         `json:"latency_ms"`
+	RequestID       string        `json:"request_id,omitempty"`
+	Retry           int           `json:"retry"`
+	RequestHeaders  gohttp.Header `json:"request_headers,omitempty"`
+	RequestBody     string        `json:"request_body,omitempty"`
+	ResponseHeaders gohttp.Header `json:"response_headers,omitempty"`
+	ResponseBody    string        `json:"response_body,omitempty"`
+	Error           string        `json:"error,omitempty"`
+}
+
+func (t *loggingTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	entry := httpLogEntry{
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+	if retry, ok := req.Context().Value(retryAttemptKey{}).(int); ok {
+		entry.Retry = retry
+	}
+	body, err := loggedBody(&req.Body, req.Header, req.ContentLength)
+	if err != nil {
+		return nil, err
+	}
+	entry.RequestBody = body
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	entry.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		entry.Error = err.Error()
+		logHTTP(entry)
+		return resp, err
+	}
+
+	entry.Status = resp.StatusCode
+	entry.ResponseHeaders = redactHeaders(resp.Header)
+	for _, h := range requestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			entry.RequestID = id
+			break
+		}
+	}
+	if body, err := loggedBody(&resp.Body, resp.Header, resp.ContentLength); err == nil {
+		entry.ResponseBody = body
+	} else {
+		entry.Error = err.Error()
+	}
+	logHTTP(entry)
+	return resp, nil
+}
+
+func logHTTP(entry httpLogEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[WARN] Error logging HTTP request %s %s: %s", entry.Method, entry.URL, err)
+		return
+	}
+	log.Printf("[DEBUG] HTTP %s", data)
+}
+
+// logRetryAttempt is the retryablehttp.RequestLogHook passing the retry attempt
+// of a request to the logging transport.
+func logRetryAttempt(_ retryablehttp.Logger, req *gohttp.Request, attempt int) {
+	*req = *req.WithContext(context.WithValue(req.Context(), retryAttemptKey{}, attempt))
+}
+
+func redactHeaders(header gohttp.Header) gohttp.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	redacted := header.Clone()
+	for _, h := range redactedHeaders {
+		if redacted.Get(h) != "" {
+			redacted.Set(h, redactedValue)
+		}
+	}
+	return redacted
+}
+
+func redactURL(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User(redacted.User.Username())
+	}
+	return scrubSecrets(redacted.String())
+}
+
+// loggedBody returns the body to log, the text bodies with their secrets
+// scrubbed. At most maxLoggedBodySize bytes are read ahead, the body is
+// restored with the bytes read followed by the rest of the stream.
+func loggedBody(body *io.ReadCloser, header gohttp.Header, length int64) (string, error) {
+	if *body == nil || *body == gohttp.NoBody {
+		return "", nil
+	}
+	contentType := header.Get("Content-Type")
+	if contentType != "" && !strings.Contains(contentType, "json") && !strings.Contains(contentType, "text") &&
+		!strings.Contains(contentType, "xml") && !strings.Contains(contentType, "form") {
+		if length < 0 {
+			return fmt.Sprintf("<body of %s>", contentType), nil
+		}
+		return fmt.Sprintf("<%d bytes of %s>", length, contentType), nil
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(*body, maxLoggedBodySize+1))
+	*body = &readAheadBody{Reader: io.MultiReader(bytes.NewReader(data), *body), Closer: *body}
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxLoggedBodySize {
+		return fmt.Sprintf("%s... <truncated>", scrubSecrets(string(data[:maxLoggedBodySize]))), nil
+	}
+	return scrubSecrets(string(data)), nil
+}
+
+// readAheadBody is a body whose first bytes were read ahead for the logs
+type readAheadBody struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,171 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"log"
+	"math"
+	gohttp "net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// RateLimit configures the client side rate limiter of the provider. A rate of
+// zero means no limit.
+type RateLimit struct {
+	// RequestsPerSecond limits the requests of the whole provider
+	RequestsPerSecond float64
+	// ServiceRequestsPerSecond limits the requests sent to each API host
+	ServiceRequestsPerSecond float64
+	// Burst is the number of requests that can be sent at once, at least 1
+	Burst int
+	// Services overrides ServiceRequestsPerSecond for the API hosts matching a key,
+	// either the host itself or a domain it belongs to
+	Services map[string]float64
+}
+
+// tokenBucket is a token bucket refilled at rate tokens per second. Requests
+// reserve a token up front and wait until the bucket has refilled it, so the
+// waiters are served in order.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	b := math.Max(float64(burst), 1)
+	return &tokenBucket{rate: rate, burst: b, tokens: b}
+}
+
+// reserve takes a token and returns how long to wait before it is available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token reserved by a request that is not sent, so the
+// requests after it do not wait for it.
+func (b *tokenBucket) cancel() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// rateLimiter holds the provider wide bucket and a bucket per API host.
+type rateLimiter struct {
+	config   *RateLimit
+	global   *tokenBucket
+	mu       sync.Mutex
+	services map[string]*tokenBucket
+}
+
+func newRateLimiter(config *RateLimit) *rateLimiter {
+	if config == nil {
+		return nil
+	}
+	return &rateLimiter{
+		config:   config,
+		global:   newTokenBucket(config.RequestsPerSecond, config.Burst),
+		services: map[string]*tokenBucket{},
+	}
+}
+
+// serviceBucket returns the bucket of host, creating it on first use.
+func (l *rateLimiter) serviceBucket(host string) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.services[host]; ok {
+		return b
+	}
+	rate := l.config.ServiceRequestsPerSecond
+	match := ""
+	for key, r := range l.config.Services {
+		if (host == key || strings.HasSuffix(host, "."+key)) && len(key) > len(match) {
+			match, rate = key, r
+		}
+	}
+	b := newTokenBucket(rate, l.config.Burst)
+	l.services[host] = b
+	return b
+}
+
+// wait blocks until req may be sent or its context is done.
+func (l *rateLimiter) wait(req *gohttp.Request) error {
+	now := time.Now()
+	service := l.serviceBucket(req.URL.Hostname())
+	delay := l.global.reserve(now)
+	if d := service.reserve(now); d > delay {
+		delay = d
+	}
+	if delay <= 0 {
+		return nil
+	}
+	log.Printf("[DEBUG] Rate limit: delaying %s %s by %s", req.Method, req.URL.Host, delay)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		l.global.cancel()
+		service.cancel()
+		return req.Context().Err()
+	}
+}
+
+// rateLimitTransport delays the requests that exceed the rate limits.
+type rateLimitTransport struct {
+	limiter *rateLimiter
+	next    gohttp.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	if err := t.limiter.wait(req); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// expandRateLimit builds the RateLimit from the provider rate_limit block.
+func expandRateLimit(l []interface{}) *RateLimit {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	limit := l[0].(map[string]interface{})
+	rateLimit := &RateLimit{
+		RequestsPerSecond:        limit["requests_per_second"].(float64),
+		ServiceRequestsPerSecond: limit["service_requests_per_second"].(float64),
+		Burst:                    limit["burst"].(int),
+		Services:                 map[string]float64{},
+	}
+	for _, s := range limit["service"].(*schema.Set).List() {
+		service := s.(map[string]interface{})
+		rateLimit.Services[service["host"].(string)] = service["requests_per_second"].(float64)
+	}
+	return rateLimit
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	gohttp "net/http"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	b := newTokenBucket(2, 2)
+	now := time.Now()
+	expected := []time.Duration{0, 0, 500 * time.Millisecond, time.Second}
+	for i, want := range expected {
+		if got := b.reserve(now); got != want {
+			t.Errorf("Reservation %d: expected %s, got %s", i, want, got)
+		}
+	}
+	// after 2s the tokens owed are repaid and the bucket is full again
+	if got := b.reserve(now.Add(2 * time.Second)); got != 0 {
+		t.Errorf("Expected a token after refill, got wait %s", got)
+	}
+
+	if b := newTokenBucket(0, 5); b != nil || b.reserve(now) != 0 {
+		t.Error("Expected no limit for a zero rate")
+	}
+}
+
+func TestRateLimiterServiceBuckets(t *testing.T) {
+	l := newRateLimiter(&RateLimit{
+		ServiceRequestsPerSecond: 10,
+		Services: map[string]float64{
+			"cloud.ibm.com":      5,
+			"iaas.cloud.ibm.com": 1,
+		},
+	})
+	cases := map[string]float64{
+		"us-south.iaas.cloud.ibm.com": 1,
+		"api.cis.cloud.ibm.com":       5,
+		"api.softlayer.com":           10,
+	}
+	for host, rate := range cases {
+		if b := l.serviceBucket(host); b.rate != rate {
+			t.Errorf("%s: expected %v requests per second, got %v", host, rate, b.rate)
+		}
+	}
+	if l.serviceBucket("api.softlayer.com") != l.serviceBucket("api.softlayer.com") {
+		t.Error("Expected the bucket of a host to be reused")
+	}
+}
+
+func TestRateLimitTransportHonorsContext(t *testing.T) {
+	config := &Config{limiter: newRateLimiter(&RateLimit{RequestsPerSecond: 0.1})}
+	calls := 0
+	transport := config.wrapTransport(roundTripFunc(func(req *gohttp.Request) (*gohttp.Response, error) {
+		calls++
+		return &gohttp.Response{StatusCode: gohttp.StatusOK, Body: gohttp.NoBody}, nil
+	}))
+
+	req, _ := gohttp.NewRequest("GET", "https://iam.cloud.ibm.com/identity/keys", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := transport.RoundTrip(req.WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Errorf("Expected the rate limited request to time out, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 request to be sent, got %d", calls)
+	}
+	// the token of the cancelled request is returned, the next request only
+	// waits for the token of the first one
+	if d := config.limiter.global.reserve(time.Now()); d > 10*time.Second {
+		t.Errorf("Expected the cancelled token to be returned, got wait %s", d)
+	}
+}
+
+func TestTokenBucketCancel(t *testing.T) {
+	b := newTokenBucket(1, 1)
+	now := time.Now()
+	b.reserve(now)
+	if got := b.reserve(now); got != time.Second {
+		t.Fatalf("Expected a wait of 1s, got %s", got)
+	}
+	b.cancel()
+	if got := b.reserve(now); got != time.Second {
+		t.Errorf("Expected the cancelled token to be reserved again, got wait %s", got)
+	}
+	// a cancel does not fill the bucket over its burst
+	b.cancel()
+	b.cancel()
+	b.cancel()
+	if b.tokens != 1 {
+		t.Errorf("Expected at most %v tokens, got %v", b.burst, b.tokens)
+	}
+}
+
+type roundTripFunc func(*gohttp.Request) (*gohttp.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	return f(req)
+}
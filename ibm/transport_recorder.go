@@ -0,0 +1,256 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	gohttp "net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// The cassette recorder captures the HTTP interactions of the provider so the
+// acceptance tests can be replayed offline. It is enabled by setting
+// IBMCLOUD_CASSETTE_MODE to "record" or "replay"; cassettes are read from and
+// written to IBMCLOUD_CASSETTE_DIR (test-fixtures/cassettes by default). The
+// cassette of a provider is set on its Config.
+const (
+	cassetteModeRecord = "record"
+	cassetteModeReplay = "replay"
+
+	defaultCassetteDir = "test-fixtures/cassettes"
+	redactedValue      = "REDACTED"
+)
+
+var (
+	// secrets in JSON bodies, form bodies and query strings
+	secretJSONFieldRegexp = regexp.MustCompile(`"(apikey|api_key|password|passphrase|refresh_token|uaa_refresh_token|private_key|secret|payload)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+	secretFormFieldRegexp = regexp.MustCompile(`(^|[?&])(apikey|password|refresh_token|passcode)=[^&]*`)
+	// the signature of a JWT, the claims are kept so the account details can still be read on replay
+	jwtSignatureRegexp = regexp.MustCompile(`(eyJ[A-Za-z0-9_-]*\.eyJ[A-Za-z0-9_-]*)\.[A-Za-z0-9_-]+`)
+	// query parameters that change from run to run and are ignored when matching
+	volatileQueryParams = []string{"version"}
+	// response headers that are not written to the cassette
+	droppedResponseHeaders = []string{"Set-Cookie", "Authorization"}
+)
+
+// cassetteMode returns the recorder mode selected through IBMCLOUD_CASSETTE_MODE.
+func cassetteMode() string {
+	return strings.ToLower(os.Getenv("IBMCLOUD_CASSETTE_MODE"))
+}
+
+type cassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int           `json:"status_code"`
+	Header     gohttp.Header `json:"header,omitempty"`
+	Body       string        `json:"body,omitempty"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+
+	replayed bool
+}
+
+type cassette struct {
+	Interactions []*cassetteInteraction `json:"interactions"`
+
+	mu   sync.Mutex
+	mode string
+	path string
+}
+
+// startCassette returns the cassette name, read from disk when replaying, for
+// the recorder transports of a Config to record to or replay from.
+func startCassette(name string) (*cassette, error) {
+	mode := cassetteMode()
+	if mode != cassetteModeRecord && mode != cassetteModeReplay {
+		return nil, fmt.Errorf("IBMCLOUD_CASSETTE_MODE must be %q or %q, got %q", cassetteModeRecord, cassetteModeReplay, mode)
+	}
+	dir := envFallBack([]string{"IBMCLOUD_CASSETTE_DIR"}, defaultCassetteDir)
+	c := &cassette{
+		mode: mode,
+		path: filepath.Join(dir, strings.Replace(name, "/", "_", -1)+".json"),
+	}
+	if mode == cassetteModeReplay {
+		data, err := ioutil.ReadFile(c.path)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading cassette %s: %s", c.path, err)
+		}
+		if err := json.Unmarshal(data, c); err != nil {
+			return nil, fmt.Errorf("Error parsing cassette %s: %s", c.path, err)
+		}
+	}
+	return c, nil
+}
+
+// stopCassette writes the cassette to disk when recording.
+func stopCassette(c *cassette) error {
+	if c.mode != cassetteModeRecord {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, data, 0644)
+}
+
+// recorderTransport records requests to, or replays them from, its cassette.
+type recorderTransport struct {
+	cassette *cassette
+	next     gohttp.RoundTripper
+}
+
+func (t *recorderTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	c := t.cassette
+	if c == nil {
+		if cassetteMode() == cassetteModeReplay {
+			return nil, fmt.Errorf("No cassette loaded to replay %s %s", req.Method, req.URL)
+		}
+		return t.next.RoundTrip(req)
+	}
+
+	reqBody, err := readAndRestoreBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	recorded := cassetteRequest{
+		Method: req.Method,
+		URL:    scrubSecrets(req.URL.String()),
+		Body:   scrubSecrets(reqBody),
+	}
+
+	if c.mode == cassetteModeReplay {
+		interaction := c.match(recorded)
+		if interaction == nil {
+			return nil, fmt.Errorf("No interaction recorded in %s for %s %s", c.path, req.Method, recorded.URL)
+		}
+		return &gohttp.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, gohttp.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readAndRestoreBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	header := resp.Header.Clone()
+	for _, h := range droppedResponseHeaders {
+		header.Del(h)
+	}
+	c.record(&cassetteInteraction{
+		Request: recorded,
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       scrubSecrets(respBody),
+		},
+	})
+	return resp, nil
+}
+
+func (c *cassette) record(interaction *cassetteInteraction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, interaction)
+}
+
+// match returns the first interaction not replayed yet with the method and URL
+// of req, preferring one whose body is identical. Bodies are only a preference
+// as they often contain names randomised by the tests.
+func (c *cassette) match(req cassetteRequest) *cassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := matchKey(req.URL)
+	var candidate *cassetteInteraction
+	for _, interaction := range c.Interactions {
+		if interaction.replayed || interaction.Request.Method != req.Method || matchKey(interaction.Request.URL) != key {
+			continue
+		}
+		if interaction.Request.Body == req.Body {
+			candidate = interaction
+			break
+		}
+		if candidate == nil {
+			candidate = interaction
+		}
+	}
+	if candidate != nil {
+		candidate.replayed = true
+	}
+	return candidate
+}
+
+// matchKey normalises rawURL for matching by dropping the query parameters
+// that change between runs.
+func matchKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	for _, p := range volatileQueryParams {
+		query.Del(p)
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// scrubSecrets masks credentials and token signatures in s.
+func scrubSecrets(s string) string {
+	if s == "" {
+		return s
+	}
+	s = jwtSignatureRegexp.ReplaceAllString(s, "$1."+redactedValue)
+	s = secretJSONFieldRegexp.ReplaceAllString(s, `"$1":"`+redactedValue+`"`)
+	s = secretFormFieldRegexp.ReplaceAllString(s, "$1$2="+redactedValue)
+	return s
+}
+
+func readAndRestoreBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == gohttp.NoBody {
+		return "", nil
+	}
+	data, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		log.Printf("[WARN] Error reading body for cassette: %s", err)
+		return "", err
+	}
+	*body = ioutil.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
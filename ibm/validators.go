@@ -1045,6 +1045,7 @@ const (
 	ValidateJSONParam
 	ValidateBindedPackageName
 	ValidateOverlappingAddress
+	ValidateIP
 )
 
 // MarshalText implements the encoding.TextMarshaler interface.
@@ -1056,7 +1057,7 @@ func (f FunctionIdentifier) MarshalText() ([]byte, error) {
 
 // Use stringer tool to generate this later.
 func (i FunctionIdentifier) String() string {
-	return [...]string{"IntBetween", "IntAtLeast", "IntAtMost", "ValidateAllowedStringValue", "StringLenBetween", "ValidateIPorCIDR", "ValidateAllowedIntValue", "ValidateRegexpLen", "ValidateRegexp", "ValidateNoZeroValues", "ValidateJSONString", "ValidateJSONParam", "ValidateBindedPackageName", "ValidateOverlappingAddress"}[i]
+	return [...]string{"IntBetween", "IntAtLeast", "IntAtMost", "ValidateAllowedStringValue", "StringLenBetween", "ValidateIPorCIDR", "ValidateCIDRAddress", "ValidateAllowedIntValue", "ValidateRegexpLen", "ValidateRegexp", "ValidateNoZeroValues", "ValidateJSONString", "ValidateJSONParam", "ValidateBindedPackageName", "ValidateOverlappingAddress", "ValidateIP"}[i]
 }
 
 // ValueType -- Copied from Terraform for now. You can refer to Terraform ValueType directly.
@@ -1132,6 +1133,11 @@ type ValidateSchema struct {
 	Required bool
 	Default  interface{}
 	ForceNew bool
+
+	// Validate the elements of a list, set or map parameter, or the parameter
+	// of each block of a list or set, at plan time.
+	// Ex: dns (each address), rules.port_min (the port_min of each rule)
+	Elements bool
 }
 
 type ResourceValidator struct {
@@ -1141,6 +1147,9 @@ type ResourceValidator struct {
 
 	// Array of validator objects. Each object refers to one parameter in the resource provider.
 	Schema []ValidateSchema
+
+	// Constraints between parameters, checked at plan time by resourceValidatorCustomizeDiff.
+	Rules []ValidateRule
 }
 
 type ValidatorDict struct {
@@ -1245,6 +1254,8 @@ func invokeValidatorInternal(schema ValidateSchema) schema.SchemaValidateFunc {
 		return validateBindedPackageName()
 	case ValidateOverlappingAddress:
 		return validateOverlappingAddress()
+	case ValidateIP:
+		return validateIP
 
 	default:
 		return nil
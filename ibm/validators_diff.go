@@ -0,0 +1,225 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// RuleIdentifier is an enum of the constraints between parameters
+type RuleIdentifier int
+
+const (
+	// All the parameters are set or none of them is.
+	MutuallyRequired RuleIdentifier = iota
+	// When the first parameter has one of the When values, the second one
+	// must have one of the AllowedValues.
+	ConditionalAllowedValues
+	// The address or CIDR of the first parameter is within the CIDR of the
+	// second one.
+	CIDRWithinCIDR
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r RuleIdentifier) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r RuleIdentifier) String() string {
+	return [...]string{"MutuallyRequired", "ConditionalAllowedValues", "CIDRWithinCIDR"}[r]
+}
+
+// ValidateRule is a constraint between parameters of a resource.
+type ValidateRule struct {
+	RuleIdentifier RuleIdentifier
+
+	// The parameters of the rule, in the order the rule refers to them.
+	// Ex: target_url, target_http_status_code
+	Identifiers []string
+
+	// ConditionalAllowedValues: comma separated values of the first parameter
+	// the rule applies to.
+	When string
+
+	// ConditionalAllowedValues: comma separated values allowed for the second
+	// parameter.
+	AllowedValues string
+}
+
+// resourceValidatorCustomizeDiff validates the elements of the parameters and
+// the rules of the ResourceValidator of resourceName at plan time, so the
+// errors are reported before any resource is changed.
+func resourceValidatorCustomizeDiff(resourceName string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		return validateResourceDiff(diff, validatorDict.ResourceValidatorDictionary[resourceName])
+	}
+}
+
+func validateResourceDiff(diff *schema.ResourceDiff, validator *ResourceValidator) error {
+	if validator == nil {
+		return nil
+	}
+
+	var msgs []string
+	for _, vs := range validator.Schema {
+		if !vs.Elements {
+			continue
+		}
+		validate := invokeValidatorInternal(vs)
+		if validate == nil {
+			continue
+		}
+		for _, e := range diffElements(diff, vs.Identifier) {
+			_, errs := validate(e.value, e.key)
+			for _, err := range errs {
+				msgs = append(msgs, err.Error())
+			}
+		}
+	}
+	for _, rule := range validator.Rules {
+		if err := rule.validate(diff); err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "\n"))
+	}
+	return nil
+}
+
+// diffElement is a value to validate and its key
+type diffElement struct {
+	key   string
+	value interface{}
+}
+
+// diffElements returns the known, set elements the validator of identifier
+// applies to: the elements of a list, set or map, or the parameter of each
+// block of a list or set when identifier has the form block.parameter.
+func diffElements(diff *schema.ResourceDiff, identifier string) []diffElement {
+	parts := strings.Split(identifier, ".")
+	if !diff.NewValueKnown(parts[0]) {
+		return nil
+	}
+	var elements []diffElement
+	var walk func(v interface{}, key string, parts []string)
+	walk = func(v interface{}, key string, parts []string) {
+		if len(parts) == 0 {
+			switch t := v.(type) {
+			case map[string]interface{}:
+				keys := make([]string, 0, len(t))
+				for k := range t {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				for _, k := range keys {
+					elements = append(elements, diffElement{key + "." + k, t[k]})
+				}
+			case *schema.Set, []interface{}:
+				for i, item := range listValue(v) {
+					elements = append(elements, diffElement{key + "." + strconv.Itoa(i), item})
+				}
+			default:
+				elements = append(elements, diffElement{key, v})
+			}
+			return
+		}
+		for i, item := range listValue(v) {
+			if block, ok := item.(map[string]interface{}); ok {
+				walk(block[parts[0]], fmt.Sprintf("%s.%d.%s", key, i, parts[0]), parts[1:])
+			}
+		}
+	}
+	walk(diff.Get(parts[0]), parts[0], parts[1:])
+
+	set := elements[:0]
+	for _, e := range elements {
+		if !isZeroValue(e.value) {
+			set = append(set, e)
+		}
+	}
+	return set
+}
+
+func (rule ValidateRule) validate(diff *schema.ResourceDiff) error {
+	for _, id := range rule.Identifiers {
+		if !diff.NewValueKnown(id) {
+			return nil
+		}
+	}
+
+	switch rule.RuleIdentifier {
+	case MutuallyRequired:
+		var set, unset []string
+		for _, id := range rule.Identifiers {
+			if _, ok := diff.GetOk(id); ok {
+				set = append(set, id)
+			} else {
+				unset = append(unset, id)
+			}
+		}
+		if len(set) > 0 && len(unset) > 0 {
+			return fmt.Errorf("%q must be set when %q is set", unset[0], set[0])
+		}
+	case ConditionalAllowedValues:
+		condition, target := rule.Identifiers[0], rule.Identifiers[1]
+		when := fmt.Sprint(diff.Get(condition))
+		value, ok := diff.GetOk(target)
+		if !ok || !stringInSlice(when, splitValues(rule.When)) {
+			return nil
+		}
+		allowed := splitValues(rule.AllowedValues)
+		if !stringInSlice(fmt.Sprint(value), allowed) {
+			return fmt.Errorf("%q must be one of %v when %q is %q, got %v", target, allowed, condition, when, value)
+		}
+	case CIDRWithinCIDR:
+		inner, outer := rule.Identifiers[0], rule.Identifiers[1]
+		address, okInner := diff.GetOk(inner)
+		cidr, okOuter := diff.GetOk(outer)
+		if !okInner || !okOuter {
+			return nil
+		}
+		_, network, err := net.ParseCIDR(cidr.(string))
+		if err != nil {
+			// invalid CIDRs are reported by the validator of the parameter
+			return nil
+		}
+		if !cidrWithin(address.(string), network) {
+			return fmt.Errorf("%q (%s) must be within %q (%s)", inner, address, outer, cidr)
+		}
+	}
+	return nil
+}
+
+// cidrWithin returns whether the address or CIDR s is within network. Invalid
+// addresses are reported by the validator of the parameter.
+func cidrWithin(s string, network *net.IPNet) bool {
+	if _, inner, err := net.ParseCIDR(s); err == nil {
+		innerOnes, _ := inner.Mask.Size()
+		outerOnes, _ := network.Mask.Size()
+		return network.Contains(inner.IP) && innerOnes >= outerOnes
+	}
+	ip := net.ParseIP(s)
+	return ip == nil || network.Contains(ip)
+}
+
+func splitValues(values string) []string {
+	if values == "" {
+		return nil
+	}
+	split := strings.Split(values, ",")
+	for i, v := range split {
+		split[i] = strings.TrimSpace(v)
+	}
+	return split
+}
@@ -0,0 +1,167 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// unknown value of the raw configurations
+const testUnknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func testValidatedResource(validator *ResourceValidator) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"action":      {Type: schema.TypeString, Optional: true},
+			"status_code": {Type: schema.TypeInt, Optional: true},
+			"url":         {Type: schema.TypeString, Optional: true},
+			"cidr":        {Type: schema.TypeString, Optional: true},
+			"gateway":     {Type: schema.TypeString, Optional: true},
+			"dns":         {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"labels":      {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"rules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"port": {Type: schema.TypeInt, Optional: true},
+					},
+				},
+			},
+		},
+		CustomizeDiff: func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			return validateResourceDiff(diff, validator)
+		},
+	}
+}
+
+func TestValidateResourceDiff(t *testing.T) {
+	validator := &ResourceValidator{
+		ResourceName: "ibm_test",
+		Schema: []ValidateSchema{
+			{Identifier: "dns", ValidateFunctionIdentifier: ValidateIP, Type: TypeString, Elements: true},
+			{Identifier: "labels", ValidateFunctionIdentifier: StringLenBetween, Type: TypeString, MinValueLength: 1, MaxValueLength: 5, Elements: true},
+			{Identifier: "rules.port", ValidateFunctionIdentifier: IntBetween, Type: TypeInt, MinValue: "1", MaxValue: "65535", Elements: true},
+			// validated by its ValidateFunc
+			{Identifier: "action", ValidateFunctionIdentifier: ValidateAllowedStringValue, Type: TypeString, AllowedValues: "forward"},
+		},
+		Rules: []ValidateRule{
+			{RuleIdentifier: MutuallyRequired, Identifiers: []string{"url", "status_code"}},
+			{RuleIdentifier: ConditionalAllowedValues, Identifiers: []string{"action", "status_code"}, When: "redirect", AllowedValues: "301, 302"},
+			{RuleIdentifier: CIDRWithinCIDR, Identifiers: []string{"gateway", "cidr"}},
+		},
+	}
+	resource := testValidatedResource(validator)
+
+	cases := map[string]struct {
+		config map[string]interface{}
+		errs   []string
+	}{
+		"valid": {
+			config: map[string]interface{}{
+				"action":      "redirect",
+				"url":         "https://example.com",
+				"status_code": 301,
+				"cidr":        "192.168.0.0/24",
+				"gateway":     "192.168.0.1",
+				"dns":         []interface{}{"9.9.9.9", "127.0.0.1"},
+				"labels":      map[string]interface{}{"env": "dev"},
+				"rules":       []interface{}{map[string]interface{}{"port": 443}, map[string]interface{}{}},
+			},
+		},
+		"empty": {
+			config: map[string]interface{}{},
+		},
+		"invalid elements": {
+			config: map[string]interface{}{
+				"dns":    []interface{}{"9.9.9"},
+				"labels": map[string]interface{}{"a": "short", "b": "too long"},
+				"rules":  []interface{}{map[string]interface{}{"port": 80}, map[string]interface{}{"port": 70000}},
+				"action": "unchecked",
+			},
+			errs: []string{`"dns.0" must be a valid ip address`, `labels.b to be in the range (1 - 5)`, `rules.1.port to be in the range (1 - 65535)`},
+		},
+		"missing url": {
+			config: map[string]interface{}{"status_code": 301},
+			errs:   []string{`"url" must be set when "status_code" is set`},
+		},
+		"status code not allowed": {
+			config: map[string]interface{}{"action": "redirect", "status_code": 200, "url": "https://example.com"},
+			errs:   []string{`"status_code" must be one of [301 302] when "action" is "redirect", got 200`},
+		},
+		"status code of another action": {
+			config: map[string]interface{}{"action": "forward", "status_code": 200, "url": "https://example.com"},
+		},
+		"gateway outside cidr": {
+			config: map[string]interface{}{"cidr": "192.168.0.0/24", "gateway": "192.168.1.1"},
+			errs:   []string{`"gateway" (192.168.1.1) must be within "cidr" (192.168.0.0/24)`},
+		},
+		"larger cidr": {
+			config: map[string]interface{}{"cidr": "192.168.0.0/24", "gateway": "192.168.0.0/16"},
+			errs:   []string{`"gateway" (192.168.0.0/16) must be within "cidr"`},
+		},
+		"smaller cidr": {
+			config: map[string]interface{}{"cidr": "192.168.0.0/16", "gateway": "192.168.4.0/24"},
+		},
+		"unknown values": {
+			config: map[string]interface{}{
+				"cidr":        testUnknownValue,
+				"gateway":     "192.168.1.1",
+				"status_code": 301,
+				"url":         testUnknownValue,
+				"dns":         testUnknownValue,
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := resource.SimpleDiff(context.Background(), &terraform.InstanceState{}, terraform.NewResourceConfigRaw(c.config), nil)
+			if len(c.errs) == 0 {
+				if err != nil {
+					t.Errorf("Expected no error, got %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected the errors %v", c.errs)
+			}
+			for _, want := range c.errs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected the error %s, got %s", want, err)
+				}
+			}
+			if n := len(strings.Split(err.Error(), "\n")); n != len(c.errs) {
+				t.Errorf("Expected %d errors, got %s", len(c.errs), err)
+			}
+		})
+	}
+}
+
+func TestResourceValidatorRules(t *testing.T) {
+	validators := Validator()
+	for name, validator := range validators.ResourceValidatorDictionary {
+		var resource *schema.Resource
+		if validator != nil && len(validator.Rules) > 0 {
+			resource = Provider().ResourcesMap[name]
+		}
+		if resource == nil {
+			continue
+		}
+		if resource.CustomizeDiff == nil {
+			t.Errorf("%s has validation rules but no CustomizeDiff", name)
+		}
+		for _, rule := range validator.Rules {
+			for _, id := range rule.Identifiers {
+				if _, ok := resource.Schema[id]; !ok {
+					t.Errorf("Unknown parameter %s in the rules of %s", id, name)
+				}
+			}
+		}
+	}
+}
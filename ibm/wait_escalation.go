@@ -0,0 +1,130 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// escalationStep is a recovery action of an escalatingWait, ex: a hard stop
+// of a hung instance.
+type escalationStep struct {
+	Name string
+
+	// The step runs when the resource is still pending After the previous
+	// step, or the start of the wait for the first step.
+	After time.Duration
+
+	Action func(ctx context.Context) error
+}
+
+// escalatingWait waits for a target state like resource.StateChangeConf and
+// runs its Steps in order while the resource stays in a Pending state. Once
+// all the steps ran, the last step runs again every After. The steps run in
+// the refresh loop, so nothing outlives the wait: a step in progress is
+// cancelled when the wait returns, times out or is cancelled.
+type escalatingWait struct {
+	Pending      []string
+	Target       []string
+	Refresh      resource.StateRefreshFunc
+	Timeout      time.Duration
+	Delay        time.Duration
+	MinTimeout   time.Duration
+	PollInterval time.Duration
+	Steps        []escalationStep
+
+	// now returns the current time, time.Now when nil
+	now func() time.Time
+}
+
+// WaitForStateContext waits for a target state and returns the result of the
+// last refresh along with the name of the last step run before the target was
+// reached, "" when no step was needed.
+func (w *escalatingWait) WaitForStateContext(ctx context.Context) (interface{}, string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	now := w.now
+	if now == nil {
+		now = time.Now
+	}
+
+	var (
+		mu   sync.Mutex
+		ran  []string
+		last = now()
+	)
+	stateConf := &resource.StateChangeConf{
+		Pending:      w.Pending,
+		Target:       w.Target,
+		Timeout:      w.Timeout,
+		Delay:        w.Delay,
+		MinTimeout:   w.MinTimeout,
+		PollInterval: w.PollInterval,
+		Refresh: func() (interface{}, string, error) {
+			result, state, err := w.Refresh()
+			if err != nil || !stringInSlice(state, w.Pending) {
+				return result, state, err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(w.Steps) == 0 {
+				return result, state, nil
+			}
+			index := len(ran)
+			if index >= len(w.Steps) {
+				index = len(w.Steps) - 1
+			}
+			step := w.Steps[index]
+			if now().Sub(last) < step.After {
+				return result, state, nil
+			}
+			log.Printf("[INFO] Still %s after %s, running the %s recovery step", state, step.After, step.Name)
+			if err := step.Action(ctx); err != nil {
+				return nil, "", fmt.Errorf("Error running the %s recovery step: %w", step.Name, err)
+			}
+			ran = append(ran, step.Name)
+			last = now()
+			return result, state, nil
+		},
+	}
+
+	result, err := stateConf.WaitForStateContext(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err != nil && len(ran) > 0 {
+		return result, "", fmt.Errorf("%w (recovery steps run: %s)", err, escalationStepsSummary(ran))
+	}
+	if len(ran) > 0 {
+		return result, ran[len(ran)-1], err
+	}
+	return result, "", err
+}
+
+// escalationStepsSummary lists the steps run, ex: "soft stop, hard stop x3"
+func escalationStepsSummary(ran []string) string {
+	var summary []string
+	for i := 0; i < len(ran); {
+		n := 1
+		for i+n < len(ran) && ran[i+n] == ran[i] {
+			n++
+		}
+		if n > 1 {
+			summary = append(summary, fmt.Sprintf("%s x%d", ran[i], n))
+		} else {
+			summary = append(summary, ran[i])
+		}
+		i += n
+	}
+	return strings.Join(summary, ", ")
+}
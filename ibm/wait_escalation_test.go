@@ -0,0 +1,170 @@
+// Copyright IBM Corp. 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testEscalatingWait returns a wait whose resource stays pending until resolve
+// returns true, with a clock advancing a minute on each refresh.
+func testEscalatingWait(resolve func(ran []string) bool, steps ...string) (*escalatingWait, *[]string) {
+	var (
+		mu  sync.Mutex
+		ran []string
+		now = time.Now()
+	)
+	w := &escalatingWait{
+		Pending:      []string{"pending"},
+		Target:       []string{"done"},
+		Timeout:      5 * time.Second,
+		PollInterval: time.Millisecond,
+		Refresh: func() (interface{}, string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			now = now.Add(time.Minute)
+			if resolve(ran) {
+				return "result", "done", nil
+			}
+			return "result", "pending", nil
+		},
+		now: func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		},
+	}
+	for i, name := range steps {
+		name := name
+		w.Steps = append(w.Steps, escalationStep{
+			Name:  name,
+			After: time.Duration(i+2) * time.Minute,
+			Action: func(ctx context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				ran = append(ran, name)
+				return nil
+			},
+		})
+	}
+	return w, &ran
+}
+
+func TestEscalatingWait(t *testing.T) {
+	cases := map[string]struct {
+		resolve  func(ran []string) bool
+		wantStep string
+		wantRan  []string
+	}{
+		"no step needed": {
+			resolve: func(ran []string) bool { return true },
+		},
+		"resolved by the second step": {
+			resolve:  func(ran []string) bool { return len(ran) == 2 },
+			wantStep: "hard stop",
+			wantRan:  []string{"soft stop", "hard stop"},
+		},
+		"resolved by the last step": {
+			resolve:  func(ran []string) bool { return len(ran) == 3 },
+			wantStep: "restart",
+			wantRan:  []string{"soft stop", "hard stop", "restart"},
+		},
+		"resolved by a repeat of the last step": {
+			resolve:  func(ran []string) bool { return len(ran) == 5 },
+			wantStep: "restart",
+			wantRan:  []string{"soft stop", "hard stop", "restart", "restart", "restart"},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			w, ran := testEscalatingWait(c.resolve, "soft stop", "hard stop", "restart")
+			result, step, err := w.WaitForStateContext(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != "result" || step != c.wantStep {
+				t.Errorf("Expected the result and step %q, got %v and %q", c.wantStep, result, step)
+			}
+			if !reflect.DeepEqual(*ran, c.wantRan) {
+				t.Errorf("Expected the steps %v, got %v", c.wantRan, *ran)
+			}
+		})
+	}
+}
+
+func TestEscalatingWaitTimeout(t *testing.T) {
+	w, ran := testEscalatingWait(func(ran []string) bool { return false }, "soft stop", "hard stop")
+	w.Timeout = 200 * time.Millisecond
+	_, step, err := w.WaitForStateContext(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "recovery steps run: soft stop, hard stop x") {
+		t.Errorf("Expected a timeout reporting the steps, got %v", err)
+	}
+	if step != "" || len(*ran) < 3 {
+		t.Errorf("Expected the last step to repeat, got %v", *ran)
+	}
+	for i, name := range *ran {
+		if want := map[bool]string{true: "soft stop", false: "hard stop"}[i == 0]; name != want {
+			t.Errorf("Expected the step %d to be %s, got %v", i, want, *ran)
+			break
+		}
+	}
+}
+
+func TestEscalationStepsSummary(t *testing.T) {
+	cases := []struct {
+		ran  []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"restart"}, "restart"},
+		{[]string{"soft stop", "hard stop", "hard stop"}, "soft stop, hard stop x2"},
+		{[]string{"restart", "restart", "restart"}, "restart x3"},
+	}
+	for _, c := range cases {
+		if got := escalationStepsSummary(c.ran); got != c.want {
+			t.Errorf("Expected %q for %v, got %q", c.want, c.ran, got)
+		}
+	}
+}
+
+func TestEscalatingWaitStepError(t *testing.T) {
+	stepErr := errors.New("Forbidden")
+	w, _ := testEscalatingWait(func(ran []string) bool { return false }, "soft stop")
+	w.Steps[0].Action = func(ctx context.Context) error { return stepErr }
+	_, _, err := w.WaitForStateContext(context.Background())
+	if !errors.Is(err, stepErr) || !strings.Contains(err.Error(), "soft stop recovery step") {
+		t.Errorf("Expected the error of the step, got %v", err)
+	}
+}
+
+func TestEscalatingWaitCancel(t *testing.T) {
+	w, _ := testEscalatingWait(func(ran []string) bool { return false }, "restart")
+	cancelled := make(chan struct{})
+	w.Steps[0].Action = func(ctx context.Context) error {
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, _, err := w.WaitForStateContext(ctx); err == nil {
+		t.Error("Expected the wait to be cancelled")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the wait to stop when cancelled, took %s", elapsed)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Expected the step in progress to be cancelled")
+	}
+}
@@ -1,7 +1,12 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm"
 	"github.com/IBM-Cloud/terraform-provider-ibm/version"
@@ -9,8 +14,82 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		generate(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		exportSchema(os.Args[2:])
+		return
+	}
 	log.Println("IBM Cloud Provider version", version.Version, version.VersionPrerelease, version.GitCommit)
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: ibm.Provider,
 	})
 }
+
+// generate writes the configuration of the existing resources of the account,
+// authenticated with the provider environment variables.
+func generate(args []string) {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	output := flags.String("output", ".", "Directory the .tf files are written to")
+	region := flags.String("region", "", "Region of the resources, defaults to the IC_REGION environment variable or us-south")
+	resources := flags.String("resources", "", fmt.Sprintf("Comma separated resource types to generate, from %s, defaults to all", strings.Join(ibm.GenerateResourceTypes(), ", ")))
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s generate [options]\n\n", os.Args[0])
+		fmt.Fprintln(flags.Output(), "Writes the Terraform configuration and the import blocks of the existing resources of an account.")
+		fmt.Fprintln(flags.Output(), "The provider environment variables, such as IC_API_KEY, select the account.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	opts := ibm.GenerateOptions{
+		OutputDir: *output,
+		Region:    *region,
+	}
+	if *resources != "" {
+		opts.ResourceTypes = strings.Split(*resources, ",")
+	}
+	if err := ibm.Generate(context.Background(), opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// exportSchema writes the schemas of the provider, resources and data sources
+// with their validator catalog as JSON.
+func exportSchema(args []string) {
+	flags := flag.NewFlagSet("schema", flag.ExitOnError)
+	output := flags.String("output", "", "File the schema is written to, defaults to the standard output")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s schema [options]\n\n", os.Args[0])
+		fmt.Fprintln(flags.Output(), "Writes the schemas of the provider, resources and data sources with their validation constraints as JSON.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *output == "" {
+		if err := ibm.ExportSchema(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	err = ibm.ExportSchema(f)
+	// os.Exit skips the deferred calls, the file is closed before, and a
+	// failed close is a failed write
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}